@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
 
@@ -103,9 +104,17 @@ func CreateRebaseArgParser() *argparser.ArgParser {
 		return errors.New("rebase takes at most one positional argument.")
 	}
 	ap.SupportsString(EmptyParam, "", "empty", "How to handle commits that are not empty to start, but which become empty after rebasing. Valid values are: drop (default) or keep")
-	ap.SupportsFlag(AbortParam, "", "Abort an interactive rebase and return the working set to the pre-rebase state")
-	ap.SupportsFlag(ContinueFlag, "", "Continue an interactive rebase after adjusting the rebase plan")
+	ap.SupportsFlag(AbortParam, "", "Abort a rebase and return the working set to the pre-rebase state")
+	ap.SupportsFlag(ContinueFlag, "", "Continue a rebase after adjusting the rebase plan or resolving conflicts")
 	ap.SupportsFlag(InteractiveFlag, "i", "Start an interactive rebase")
+	ap.SupportsString(StrategyParam, "", "strategy", "How to automatically resolve data conflicts. Valid values are: ours, to keep the rows from the upstream commits, or theirs, to keep the rows from the commits being rebased")
+	return ap
+}
+
+func CreateBisectArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("bisect")
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"subcommand", "One of start, bad, good, skip, reset or run."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"rev", "The commits to mark, or the predicate query to run for the run subcommand."})
 	return ap
 }
 
@@ -139,8 +148,12 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use.")
 	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file.")
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use.")
+	ap.SupportsString(dbfactory.S3EndpointParam, "", "url", "Endpoint of the S3-compatible object store for s3:// remotes.")
+	ap.SupportsString(dbfactory.AzureEndpointParam, "", "url", "Blob service endpoint for az:// remotes.")
 	ap.SupportsString(UserFlag, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(SingleBranchFlag, "", "Clone only the history leading to the tip of a single branch, either specified by --branch or the remote's HEAD (default).")
+	ap.SupportsStringList(TablesFlag, "", "table", "Clone the rows of only the given tables. The rows of other tables are fetched from the remote when they are first read. Later fetches from the remote use the same filter.")
+	ap.SupportsStringList(ExcludeTablesFlag, "", "table", "Clone the rows of all tables but the given tables, which are fetched from the remote when they are first read. Later fetches from the remote use the same filter.")
 	return ap
 }
 
@@ -151,6 +164,13 @@ func CreateResetArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateStashArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("stash", 2)
+	ap.SupportsFlag(IncludeUntrackedFlag, "u", "Untracked tables are also stashed.")
+	ap.SupportsFlag(AllFlag, "a", "All tables are stashed, including untracked and ignored tables.")
+	return ap
+}
+
 func CreateRemoteArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("remote")
 	return ap
@@ -188,6 +208,8 @@ func CreateFetchArgParser() *argparser.ArgParser {
 	ap.SupportsString(UserFlag, "", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(PruneFlag, "p", "After fetching, remove any remote-tracking references that don't exist on the remote.")
 	ap.SupportsFlag(SilentFlag, "", "Suppress progress information.")
+	ap.SupportsStringList(TablesFlag, "", "table", "Fetch the rows of only the given tables. The rows of other tables are fetched when they are first read. Overrides the filter the remote was cloned with.")
+	ap.SupportsStringList(ExcludeTablesFlag, "", "table", "Fetch the rows of all tables but the given tables, which are fetched when they are first read. Overrides the filter the remote was cloned with.")
 	return ap
 }
 
@@ -261,6 +283,8 @@ func CreateBackupArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	ap.SupportsString(dbfactory.S3EndpointParam, "", "url", "Endpoint of the S3-compatible object store for s3:// backups")
+	ap.SupportsString(dbfactory.AzureEndpointParam, "", "url", "Blob service endpoint for az:// backups")
 	return ap
 }
 
@@ -331,6 +355,8 @@ func CreateGlobalArgParser(name string) *argparser.ArgParser {
 
 var awsParams = []string{dbfactory.AWSRegionParam, dbfactory.AWSCredsTypeParam, dbfactory.AWSCredsFileParam, dbfactory.AWSCredsProfile}
 var ossParams = []string{dbfactory.OSSCredsFileParam, dbfactory.OSSCredsProfile}
+var s3Params = append([]string{dbfactory.S3EndpointParam}, awsParams...)
+var azureParams = []string{dbfactory.AzureEndpointParam}
 
 func ProcessBackupArgs(apr *argparser.ArgParseResults, scheme, backupUrl string) (map[string]string, error) {
 	params := map[string]string{}
@@ -341,12 +367,46 @@ func ProcessBackupArgs(apr *argparser.ArgParseResults, scheme, backupUrl string)
 		err = AddAWSParams(backupUrl, apr, params)
 	case dbfactory.OSSScheme:
 		err = AddOSSParams(backupUrl, apr, params)
+	case dbfactory.S3Scheme:
+		err = AddS3Params(backupUrl, apr, params)
+	case dbfactory.AzureScheme:
+		err = AddAzureParams(backupUrl, apr, params)
 	default:
 		err = VerifyNoAwsParams(apr)
 	}
 	return params, err
 }
 
+// ParseTableFilter returns the filter given by the --tables or --exclude-tables flags of a clone or fetch
+func ParseTableFilter(apr *argparser.ArgParseResults) (doltdb.TableFilter, error) {
+	var filter doltdb.TableFilter
+	if apr.Contains(TablesFlag) && apr.Contains(ExcludeTablesFlag) {
+		return filter, fmt.Errorf("--%s and --%s cannot be used together", TablesFlag, ExcludeTablesFlag)
+	}
+
+	for _, fl := range []string{TablesFlag, ExcludeTablesFlag} {
+		list, ok := apr.GetValueList(fl)
+		if !ok {
+			continue
+		}
+		var tables []string
+		for _, t := range list {
+			if t = strings.TrimSpace(t); t != "" {
+				tables = append(tables, t)
+			}
+		}
+		if len(tables) == 0 {
+			return filter, fmt.Errorf("--%s requires at least one table", fl)
+		}
+		if fl == TablesFlag {
+			filter.Tables = tables
+		} else {
+			filter.ExcludeTables = tables
+		}
+	}
+	return filter, nil
+}
+
 func AddAWSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[string]string) error {
 	isAWS := strings.HasPrefix(remoteUrl, "aws")
 
@@ -387,6 +447,46 @@ func AddOSSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[s
 	return nil
 }
 
+func AddS3Params(remoteUrl string, apr *argparser.ArgParseResults, params map[string]string) error {
+	isS3 := strings.HasPrefix(remoteUrl, "s3")
+
+	if !isS3 {
+		for _, p := range s3Params {
+			if _, ok := apr.GetValue(p); ok {
+				return fmt.Errorf("%s param is only valid for s3 cloud remotes in the format s3://s3-bucket/database", p)
+			}
+		}
+	}
+
+	for _, p := range s3Params {
+		if val, ok := apr.GetValue(p); ok {
+			params[p] = val
+		}
+	}
+
+	return nil
+}
+
+func AddAzureParams(remoteUrl string, apr *argparser.ArgParseResults, params map[string]string) error {
+	isAzure := strings.HasPrefix(remoteUrl, "az")
+
+	if !isAzure {
+		for _, p := range azureParams {
+			if _, ok := apr.GetValue(p); ok {
+				return fmt.Errorf("%s param is only valid for azure cloud remotes in the format az://account/container/database", p)
+			}
+		}
+	}
+
+	for _, p := range azureParams {
+		if val, ok := apr.GetValue(p); ok {
+			params[p] = val
+		}
+	}
+
+	return nil
+}
+
 func VerifyNoAwsParams(apr *argparser.ArgParseResults) error {
 	if awsParams := apr.GetValues(awsParams...); len(awsParams) > 0 {
 		awsParamKeys := make([]string, 0, len(awsParams))
@@ -36,10 +36,12 @@ const (
 	DepthFlag            = "depth"
 	DryRunFlag           = "dry-run"
 	EmptyParam           = "empty"
+	ExcludeTablesFlag    = "exclude-tables"
 	ForceFlag            = "force"
 	GraphFlag            = "graph"
 	HardResetParam       = "hard"
 	HostFlag             = "host"
+	IncludeUntrackedFlag = "include-untracked"
 	InteractiveFlag      = "interactive"
 	ListFlag             = "list"
 	MergesFlag           = "merges"
@@ -76,6 +78,7 @@ const (
 	SquashParam          = "squash"
 	StagedFlag           = "staged"
 	StatFlag             = "stat"
+	StrategyParam        = "strategy"
 	SystemFlag           = "system"
 	TablesFlag           = "tables"
 	TheirsFlag           = "theirs"
@@ -0,0 +1,158 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/patch"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var amDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Apply patch files as commits.`,
+	LongDesc: `Applies the patches in the patch files written by {{.EmphasisLeft}}dolt format-patch{{.EmphasisRight}}, and commits the changes of each with the author, date and message of the commit the patch was made from. If no patch files are given, patches are read from STDIN. This requires your working set to be clean.
+
+Each patch is merged into the current HEAD. Rows the patch changes that have been changed differently on the current branch, since the commit the patch was made from, are reported as conflicts. When a patch has conflicts, the patches after it are not applied. Resolve the conflicts and commit the result with {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}, or use {{.EmphasisLeft}}dolt merge --abort{{.EmphasisRight}} to undo the patch.
+`,
+	Synopsis: []string{
+		`[{{.LessThan}}patch-file{{.GreaterThan}}...]`,
+	},
+}
+
+type AmCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd AmCmd) Name() string {
+	return "am"
+}
+
+// Description returns a description of the command
+func (cmd AmCmd) Description() string {
+	return amDocs.ShortDesc
+}
+
+func (cmd AmCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(amDocs, ap)
+}
+
+func (cmd AmCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"patch-file", "Patch files to apply. If none are given, patches are read from STDIN."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd AmCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, amDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	patches, verr := readPatchFiles(dEnv.FS, apr.Args)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if ws.MergeActive() {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: a merge is in progress, resolve it before applying patches").Build(), usage)
+	}
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	clean, err := diff.WorkingSetContainsOnlyIgnoredTables(ctx, roots)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if !clean {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: your local changes would be overwritten by the patches, commit or stash them first").Build(), usage)
+	}
+
+	for _, p := range patches {
+		cli.Println("Applying:", p.Subject())
+
+		result, err := applyPatch(ctx, dEnv, p, true)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to apply patch %s", p.Commit.String()).AddCause(err).Build(), usage)
+		}
+		if result.HasMergeArtifacts() {
+			printPatchConflicts(p, result)
+			return 1
+		}
+
+		if err = commitPatch(ctx, dEnv, p); err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to commit patch %s", p.Commit.String()).AddCause(err).Build(), usage)
+		}
+	}
+
+	return 0
+}
+
+// commitPatch commits the staged changes of the patch |p| with the author, date and message of the patch.
+func commitPatch(ctx context.Context, dEnv *env.DoltEnv, p *patch.Patch) error {
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return err
+	}
+	prevHash, err := ws.HashOf()
+	if err != nil {
+		return err
+	}
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return err
+	}
+
+	pendingCommit, err := actions.GetCommitStaged(ctx, roots, ws, nil, dEnv.DoltDB, actions.CommitStagedProps{
+		Message:    p.Message,
+		Date:       p.Date,
+		AllowEmpty: len(p.Tables) == 0,
+		Name:       p.Name,
+		Email:      p.Email,
+	})
+	if actions.IsNothingStaged(err) {
+		return errors.New("no changes, the patch has already been applied")
+	} else if err != nil {
+		return err
+	}
+
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef()
+	if err != nil {
+		return err
+	}
+	_, err = dEnv.DoltDB.CommitWithWorkingSet(
+		ctx,
+		headRef,
+		ws.Ref(),
+		pendingCommit,
+		ws.WithStagedRoot(pendingCommit.Roots.Staged).WithWorkingRoot(pendingCommit.Roots.Working).ClearMerge(),
+		prevHash,
+		doltdb.TodoWorkingSetMeta(),
+		nil,
+	)
+	return err
+}
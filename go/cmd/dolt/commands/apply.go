@@ -0,0 +1,222 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/patch"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var applyDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Apply patch files to the working set.`,
+	LongDesc: `Applies the changes of the patches in the patch files written by {{.EmphasisLeft}}dolt format-patch{{.EmphasisRight}} to the working set, without committing them. If no patch files are given, patches are read from STDIN.
+
+Each patch is merged into the working set. Rows the patch changes that have been changed differently in the working set, since the commit the patch was made from, are reported as conflicts. When a patch has conflicts, the patches after it are not applied. Resolve the conflicts and commit the result with {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}, or use {{.EmphasisLeft}}dolt merge --abort{{.EmphasisRight}} to undo the patch.
+
+Use {{.EmphasisLeft}}dolt am{{.EmphasisRight}} to apply patches as commits.
+`,
+	Synopsis: []string{
+		`[{{.LessThan}}patch-file{{.GreaterThan}}...]`,
+	},
+}
+
+type ApplyCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ApplyCmd) Name() string {
+	return "apply"
+}
+
+// Description returns a description of the command
+func (cmd ApplyCmd) Description() string {
+	return applyDocs.ShortDesc
+}
+
+func (cmd ApplyCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(applyDocs, ap)
+}
+
+func (cmd ApplyCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"patch-file", "Patch files to apply. If none are given, patches are read from STDIN."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd ApplyCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, applyDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	patches, verr := readPatchFiles(dEnv.FS, apr.Args)
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if ws.MergeActive() {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: a merge is in progress, resolve it before applying patches").Build(), usage)
+	}
+
+	for _, p := range patches {
+		result, err := applyPatch(ctx, dEnv, p, false)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to apply patch %s", p.Commit.String()).AddCause(err).Build(), usage)
+		}
+		if result.HasMergeArtifacts() {
+			printPatchConflicts(p, result)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// readPatchFiles reads the patches in the files at |paths|, or from STDIN if there are none.
+func readPatchFiles(fs filesys.ReadableFS, paths []string) ([]*patch.Patch, errhand.VerboseError) {
+	if len(paths) == 0 {
+		patches, err := patch.Read(cli.InStream)
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to read patches from STDIN").AddCause(err).Build()
+		}
+		return patches, nil
+	}
+
+	var patches []*patch.Patch
+	for _, path := range paths {
+		rd, err := fs.OpenForRead(path)
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to open %s", path).AddCause(err).Build()
+		}
+		ps, err := patch.Read(rd)
+		rd.Close()
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to read %s", path).AddCause(err).Build()
+		}
+		patches = append(patches, ps...)
+	}
+	return patches, nil
+}
+
+// applyPatch merges the changes of |p| into the working set of |dEnv|. If |stage| is true, the tables changed by the
+// patch that don't have conflicts are staged. If the merge has conflicts, they are recorded in the working set as a
+// cherry-pick of the patch, which is concluded by committing the resolved tables or aborting the merge.
+func applyPatch(ctx context.Context, dEnv *env.DoltEnv, p *patch.Patch, stage bool) (*merge.Result, error) {
+	ws, err := dEnv.WorkingSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head, err := dEnv.HeadCommit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlCtx, _, err := rebaseSqlEngine(ctx, dEnv, ws.WorkingRoot())
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	opts := editor.Options{Deaf: dEnv.BulkDbEaFactory(), Tempdir: tmpDir}
+
+	result, theirCommit, err := patch.Apply(sqlCtx, dEnv.DoltDB, head, ws.WorkingRoot(), p, patchStatementRunner(dEnv), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := dEnv.Roots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roots.Working = result.Root
+	if stage {
+		var tables []doltdb.TableName
+		for name, stats := range result.Stats {
+			if !stats.HasArtifacts() {
+				tables = append(tables, doltdb.TableName{Name: name})
+			}
+		}
+		if roots, err = actions.StageTables(ctx, roots, tables, true); err != nil {
+			return nil, err
+		}
+	}
+
+	// the merge state records the working root from before the patch, which aborting the merge restores
+	if result.HasMergeArtifacts() {
+		ws = ws.StartCherryPick(theirCommit, fmt.Sprintf("patch %s", p.Commit.String()))
+	}
+	ws = ws.WithWorkingRoot(roots.Working).WithStagedRoot(roots.Staged)
+	if err = dEnv.UpdateWorkingSet(ctx, ws); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// patchStatementRunner returns a patch.StatementRunner that runs statements with foreign key checks disabled, so
+// that rows can be changed in any order.
+func patchStatementRunner(dEnv *env.DoltEnv) patch.StatementRunner {
+	return func(ctx context.Context, root doltdb.RootValue, stmts []string) (doltdb.RootValue, error) {
+		sqlCtx, eng, err := rebaseSqlEngine(ctx, dEnv, root)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = runDrainedQuery(sqlCtx, eng, "SET foreign_key_checks = 0"); err != nil {
+			return nil, err
+		}
+		for _, stmt := range stmts {
+			if err = runDrainedQuery(sqlCtx, eng, stmt); err != nil {
+				return nil, fmt.Errorf("%s: %w", stmt, err)
+			}
+		}
+
+		ws, err := dsess.DSessFromSess(sqlCtx.Session).WorkingSet(sqlCtx, filterDbName)
+		if err != nil {
+			return nil, err
+		}
+		return ws.WorkingRoot(), nil
+	}
+}
+
+func printPatchConflicts(p *patch.Patch, result *merge.Result) {
+	cli.PrintErrf("error: patch %s did not apply cleanly: %s\n", p.Commit.String(), p.Subject())
+	for name, stats := range result.Stats {
+		if stats.HasArtifacts() {
+			cli.PrintErrf("CONFLICT: %s\n", name)
+		}
+	}
+	for _, conflict := range result.SchemaConflicts {
+		cli.PrintErrf("CONFLICT (schema): %s\n", conflict.TableName.Name)
+	}
+	cli.PrintErrln("Resolve the conflicts and commit the result with 'dolt commit', or undo the patch with 'dolt merge --abort'.")
+}
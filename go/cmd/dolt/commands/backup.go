@@ -37,7 +37,7 @@ var backupDocs = cli.CommandDocumentationContent{
 
 {{.EmphasisLeft}}add{{.EmphasisRight}}
 Adds a backup named {{.LessThan}}name{{.GreaterThan}} for the database at {{.LessThan}}url{{.GreaterThan}}.
-The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, gs, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}backups.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
+The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, s3, az, gs, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}backups.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
 The URL address must be unique to existing remotes and backups.
 
 AWS cloud backup urls should be of the form {{.EmphasisLeft}}aws://[dynamo-table:s3-bucket]/database{{.EmphasisRight}}. You may configure your aws cloud backup using the optional parameters {{.EmphasisLeft}}aws-region{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-type{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-file{{.EmphasisRight}}.
@@ -49,6 +49,10 @@ aws-creds-type specifies the means by which credentials should be retrieved in o
 	file: Uses the credentials file specified by the parameter aws-creds-file
 
 	
+S3 backup urls of the form {{.EmphasisLeft}}s3://s3-bucket/database{{.EmphasisRight}} store the whole database, including its manifest, in the bucket and need no dynamo table. They accept the same aws parameters, and the optional parameter {{.EmphasisLeft}}s3-endpoint{{.EmphasisRight}} (or the environment variable DOLT_S3_ENDPOINT) points them at an S3-compatible object store such as MinIO or Ceph. The store must support conditional writes.
+
+Azure backup urls should be of the form {{.EmphasisLeft}}az://storage-account/container/database{{.EmphasisRight}}. Credentials are read from the environment variables AZURE_STORAGE_CONNECTION_STRING, AZURE_STORAGE_KEY or AZURE_STORAGE_SAS_TOKEN, falling back to the default Azure credential chain (managed identity, Azure CLI login, etc). The optional parameter {{.EmphasisLeft}}az-endpoint{{.EmphasisRight}} (or the environment variable DOLT_AZURE_ENDPOINT) sets the blob service endpoint, for example to use the Azurite emulator.
+
 GCP backup urls should be of the form gs://gcs-bucket/database and will use the credentials setup using the gcloud command line available from Google.
 
 The local filesystem can be used as a backup by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_scheme
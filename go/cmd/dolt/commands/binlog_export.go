@@ -0,0 +1,91 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var binlogExportDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Export the binary log files written by a sql-server.",
+	LongDesc: `Copies the binary log files that {{.EmphasisLeft}}dolt sql-server{{.EmphasisRight}} writes when binary logging is enabled with {{.EmphasisLeft}}@@log_bin{{.EmphasisRight}} into {{.LessThan}}directory{{.GreaterThan}}, along with a {{.EmphasisLeft}}binlog.index{{.EmphasisRight}} file listing them.
+
+The exported files use MySQL's binary log file format, so they can be read with standard MySQL tools, for example: {{.EmphasisLeft}}mysqlbinlog binlog-main.000001{{.EmphasisRight}}. Only complete events are exported, so it is safe to run this command while the server is running.
+
+This command must be run from the directory the sql-server serves databases from.`,
+	Synopsis: []string{
+		"{{.LessThan}}directory{{.GreaterThan}}",
+	},
+}
+
+type BinlogExportCmd struct{}
+
+var _ cli.Command = BinlogExportCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BinlogExportCmd) Name() string {
+	return "binlog-export"
+}
+
+// Description returns a description of the command
+func (cmd BinlogExportCmd) Description() string {
+	return binlogExportDocs.ShortDesc
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd BinlogExportCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd BinlogExportCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(binlogExportDocs, cmd.ArgParser())
+}
+
+func (cmd BinlogExportCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"directory", "The directory to export the binary log files to."})
+	return ap
+}
+
+// Exec executes the command
+func (cmd BinlogExportCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
+	ap := cmd.ArgParser()
+	apr, usage, terminate, status := ParseArgsOrPrintHelp(ap, commandStr, args, binlogExportDocs)
+	if terminate {
+		return status
+	}
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	filenames, err := binlogreplication.ExportBinaryLogs(dEnv.FS, apr.Arg(0))
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	for _, filename := range filenames {
+		cli.Println(filename)
+	}
+	return 0
+}
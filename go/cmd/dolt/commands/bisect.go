@@ -0,0 +1,117 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var bisectDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Use binary search to find the commit that introduced a bad change",
+	LongDesc: `Searches the commit history of the current branch for the commit that introduced a bad change, such as a bad
+row or a broken constraint. Start a bisect with {{.EmphasisLeft}}dolt bisect start{{.EmphasisRight}}, then mark a commit
+known to be bad and at least one commit known to be good. Dolt then picks a commit halfway between them for you to test.
+Examine that commit, for example with {{.EmphasisLeft}}AS OF{{.EmphasisRight}} queries, and mark it as good or bad.
+Repeat until the first bad commit is found, then end the bisect with {{.EmphasisLeft}}dolt bisect reset{{.EmphasisRight}}.
+
+Bisecting never changes the working set of the current branch. When {{.EmphasisLeft}}good{{.EmphasisRight}},
+{{.EmphasisLeft}}bad{{.EmphasisRight}}, or {{.EmphasisLeft}}skip{{.EmphasisRight}} are given no commit, they apply to the
+commit that was last reported for testing.
+
+{{.EmphasisLeft}}dolt bisect run{{.EmphasisRight}} tests each commit automatically with a SQL query. The query is run
+against each commit, and the commit is marked as bad if the first column of the first row returned is true. A query
+that returns no rows, or a false or NULL value, marks the commit as good. The run stops if the query fails.
+The progress of a run is saved after each commit that is tested.
+
+The bisect state is stored in the working set of the current branch. Versions of Dolt older than the one that added
+bisect can't read that working set until the bisect is reset.
+`,
+	Synopsis: []string{
+		`start [{{.LessThan}}bad{{.GreaterThan}} [{{.LessThan}}good{{.GreaterThan}}...]]`,
+		`(bad | good | skip) [{{.LessThan}}rev{{.GreaterThan}}...]`,
+		`run {{.LessThan}}query{{.GreaterThan}}`,
+		`reset`,
+	},
+}
+
+type BisectCmd struct{}
+
+var _ cli.Command = BisectCmd{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BisectCmd) Name() string {
+	return "bisect"
+}
+
+// Description returns a description of the command
+func (cmd BisectCmd) Description() string {
+	return bisectDocs.ShortDesc
+}
+
+// EventType returns the type of the event to log
+func (cmd BisectCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_BISECT
+}
+
+func (cmd BisectCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(bisectDocs, ap)
+}
+
+func (cmd BisectCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateBisectArgParser()
+}
+
+// Exec executes the command
+func (cmd BisectCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, bisectDocs, ap))
+	cli.ParseArgsOrDie(ap, args, help)
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	query, err := interpolateStoredProcedureCall("DOLT_BISECT", args)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	rows, err := GetRowsForSql(queryist, sqlCtx, query)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	if len(rows) != 1 || len(rows[0]) != 2 {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: unexpected result from dolt_bisect").Build(), usage)
+	}
+	message, ok := rows[0][1].(string)
+	if !ok {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: unexpected message type %T from dolt_bisect", rows[0][1]).Build(), usage)
+	}
+
+	cli.Println(message)
+	return 0
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlecmds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const basisParam = "basis"
+
+var createDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Writes branches and tags to a bundle file",
+	LongDesc: `Writes the given branches and tags, and all the data they reference, to a single bundle file. Bundles move commits between databases which cannot reach each other, such as to a site without network access.
+
+A bundle can be cloned, or fetched from, by giving its path in place of a remote url: {{.EmphasisLeft}}dolt clone db.bundle{{.EmphasisRight}}, or {{.EmphasisLeft}}call dolt_fetch('/path/to/db.bundle'){{.EmphasisRight}}. Fetching from a bundle that has not been added as a remote updates remote tracking branches named after the bundle's file, such as {{.EmphasisLeft}}db/main{{.EmphasisRight}}.
+
+With {{.EmphasisLeft}}--basis{{.EmphasisRight}}, the data reachable from the given commits is left out of the bundle, so that it only holds what has changed since. Such a bundle can only be fetched into a database that already has the basis commits.
+`,
+	Synopsis: []string{
+		"[--basis {{.LessThan}}commit{{.GreaterThan}}] {{.LessThan}}file{{.GreaterThan}} {{.LessThan}}ref{{.GreaterThan}}...",
+	},
+}
+
+type CreateCmd struct{}
+
+// Name implements cli.Command.
+func (cmd CreateCmd) Name() string {
+	return "create"
+}
+
+// Description implements cli.Command.
+func (cmd CreateCmd) Description() string {
+	return createDocs.ShortDesc
+}
+
+// RequiresRepo implements cli.Command.
+func (cmd CreateCmd) RequiresRepo() bool {
+	return true
+}
+
+// Docs implements cli.Command.
+func (cmd CreateCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(createDocs, ap)
+}
+
+// ArgParser implements cli.Command.
+func (cmd CreateCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"file", "The bundle file to write."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"ref", "A branch or tag to write to the bundle."})
+	ap.SupportsStringList(basisParam, "", "commit", "Leaves out the data reachable from {{.LessThan}}commit{{.GreaterThan}}, which databases fetching from the bundle must already have.")
+	return ap
+}
+
+// Exec implements cli.Command.
+func (cmd CreateCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, createDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() < 2 {
+		verr := errhand.BuildDError("dolt bundle create takes a file and at least one branch or tag").Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	refs, err := resolveBundleRefs(ctx, dEnv.DoltDB, apr.Args[1:])
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	var basis []hash.Hash
+	if basisSpecs, ok := apr.GetValueList(basisParam); ok {
+		basis, err = resolveBasis(ctx, dEnv, basisSpecs)
+		if err != nil {
+			return commands.HandleVErrAndExitCode(errhand.BuildDError("error: invalid basis").AddCause(err).Build(), usage)
+		}
+	}
+
+	path, err := dEnv.FS.Abs(apr.Arg(0))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	err = actions.CreateBundle(ctx, dEnv.DoltDB, tmpDir, path, refs, basis)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("error: failed to create bundle").AddCause(err).Build(), usage)
+	}
+
+	return 0
+}
+
+// resolveBundleRefs returns the refs named by |names|, which may be full refs, or the names of branches or tags
+func resolveBundleRefs(ctx context.Context, ddb *doltdb.DoltDB, names []string) ([]doltdb.RefWithHash, error) {
+	refHashes, err := ddb.GetRefsWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]doltdb.RefWithHash, len(refHashes))
+	for _, r := range refHashes {
+		byName[r.Ref.String()] = r
+	}
+
+	var refs []doltdb.RefWithHash
+	seen := make(map[string]struct{})
+	for _, name := range names {
+		candidates := []string{name}
+		if !ref.IsRef(name) {
+			candidates = []string{ref.NewBranchRef(name).String(), ref.NewTagRef(name).String()}
+		}
+
+		found := false
+		for _, c := range candidates {
+			if r, ok := byName[c]; ok {
+				if _, ok := seen[c]; !ok {
+					refs = append(refs, r)
+					seen[c] = struct{}{}
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("error: '%s' is not a branch or tag", name)
+		}
+	}
+
+	return refs, nil
+}
+
+// resolveBasis returns the addresses of the commits named by |specs|
+func resolveBasis(ctx context.Context, dEnv *env.DoltEnv, specs []string) ([]hash.Hash, error) {
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef()
+	if err != nil {
+		return nil, err
+	}
+
+	basis := make([]hash.Hash, len(specs))
+	for i, spec := range specs {
+		cs, err := doltdb.NewCommitSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		optCmt, err := dEnv.DoltDB.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		basis[i], err = cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return basis, nil
+}
@@ -43,9 +43,13 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+The {{.LessThan}}remote-url{{.GreaterThan}} may also be the path of a bundle file written by {{.EmphasisLeft}}dolt bundle create{{.EmphasisRight}}, in which case the new directory is named after the bundle's file, without its {{.EmphasisLeft}}.bundle{{.EmphasisRight}} extension.
+
+With {{.EmphasisLeft}}--tables{{.EmphasisRight}} or {{.EmphasisLeft}}--exclude-tables{{.EmphasisRight}}, only the rows and indexes of the selected tables are cloned. The schemas and history of every table are cloned, and the rows of the other tables are fetched from the remote when they are first read. The filter is remembered by the remote, and used by later fetches and pulls from it.
 `,
 	Synopsis: []string{
-		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
+		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--tables={{.LessThan}}table{{.GreaterThan}},... | --exclude-tables={{.LessThan}}table{{.GreaterThan}},...] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
 	},
 }
 
@@ -127,6 +131,11 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	if verr != nil {
 		return verr
 	}
+	filter, err := cli.ParseTableFilter(apr)
+	if err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
+	env.AddTableFilterParams(params, filter)
 
 	var r env.Remote
 	var srcDB *doltdb.DoltDB
@@ -196,7 +205,7 @@ func parseArgs(apr *argparser.ArgParseResults) (string, string, errhand.VerboseE
 	if apr.NArg() == 2 {
 		dir = apr.Arg(1)
 	} else {
-		dir = path.Base(urlStr)
+		dir = strings.TrimSuffix(path.Base(urlStr), dbfactory.BundleExt)
 		if dir == "." {
 			dir = path.Dir(urlStr)
 		} else if dir == "/" {
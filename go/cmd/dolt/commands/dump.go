@@ -38,6 +38,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/sqlexport"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/xlsx"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -56,7 +57,10 @@ const (
 	sqlFileExt     = "sql"
 	csvFileExt     = "csv"
 	jsonFileExt    = "json"
+	jsonlFileExt   = "jsonl"
 	parquetFileExt = "parquet"
+	arrowFileExt   = "arrow"
+	xlsxFileExt    = "xlsx"
 	emptyFileExt   = ""
 	emptyStr       = ""
 )
@@ -66,8 +70,8 @@ var dumpDocs = cli.CommandDocumentationContent{
 	LongDesc: `{{.EmphasisLeft}}dolt dump{{.EmphasisRight}} dumps all tables in the working set. 
 If a dump file already exists then the operation will fail, unless the {{.EmphasisLeft}}--force | -f{{.EmphasisRight}} flag 
 is provided. The force flag forces the existing dump file to be overwritten. The {{.EmphasisLeft}}-r{{.EmphasisRight}} flag 
-is used to support different file formats of the dump. In the case of non .sql files each table is written to a separate
-csv,json or parquet file. 
+is used to support different file formats of the dump. In the case of csv, json, jsonl, parquet or arrow files each table is written 
+to a separate file. In the case of xlsx files all tables are written to a single workbook, with one sheet per table. 
 `,
 
 	Synopsis: []string{
@@ -95,8 +99,8 @@ func (cmd DumpCmd) Docs() *cli.CommandDocumentation {
 
 func (cmd DumpCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
-	ap.SupportsString(FormatFlag, "r", "result_file_type", "Define the type of the output file. Defaults to sql. Valid values are sql, csv, json and parquet.")
-	ap.SupportsString(filenameFlag, "fn", "file_name", "Define file name for dump file. Defaults to `doltdump.sql`.")
+	ap.SupportsString(FormatFlag, "r", "result_file_type", "Define the type of the output file. Defaults to sql. Valid values are sql, csv, json, jsonl, parquet, arrow and xlsx.")
+	ap.SupportsString(filenameFlag, "fn", "file_name", "Define file name for dump file. Defaults to `doltdump.sql`, or `doltdump.xlsx` for xlsx dumps.")
 	ap.SupportsString(directoryFlag, "d", "directory_name", "Define directory name to dump the files in. Defaults to `doltdump/`.")
 	ap.SupportsFlag(forceParam, "f", "If data already exists in the destination, the force flag will allow the target to be overwritten.")
 	ap.SupportsFlag(batchFlag, "", "Return batch insert statements wherever possible, enabled by default.")
@@ -193,11 +197,22 @@ func (cmd DumpCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		if err != nil {
 			return HandleVErrAndExitCode(err, usage)
 		}
-	case csvFileExt, jsonFileExt, parquetFileExt:
+	case csvFileExt, jsonFileExt, jsonlFileExt, parquetFileExt, arrowFileExt:
 		err = dumpNonSqlTables(ctx, root, dEnv, force, tblNames, resFormat, outputFileOrDirName, false)
 		if err != nil {
 			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 		}
+	case xlsxFileExt:
+		if outputFileOrDirName == emptyStr {
+			outputFileOrDirName = "doltdump.xlsx"
+		} else if !strings.HasSuffix(outputFileOrDirName, ".xlsx") {
+			outputFileOrDirName = fmt.Sprintf("%s.xlsx", outputFileOrDirName)
+		}
+
+		verr := dumpXlsxTables(ctx, root, dEnv, force, tblNames, outputFileOrDirName)
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
 	default:
 		return HandleVErrAndExitCode(errhand.BuildDError("invalid result format").SetPrintUsage().Build(), usage)
 	}
@@ -697,7 +712,15 @@ func validateDumpArgs(apr *argparser.ArgParseResults) (string, errhand.VerboseEr
 			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", directoryFlag, sqlFileExt).SetPrintUsage().Build()
 		}
 		return fn, nil
-	case csvFileExt, jsonFileExt, parquetFileExt:
+	case xlsxFileExt:
+		if dnOk {
+			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", directoryFlag, xlsxFileExt).SetPrintUsage().Build()
+		}
+		if snOk {
+			return emptyStr, errhand.BuildDError("%s dump is not supported for %s exports", schemaOnlyFlag, xlsxFileExt).SetPrintUsage().Build()
+		}
+		return fn, nil
+	case csvFileExt, jsonFileExt, jsonlFileExt, parquetFileExt, arrowFileExt:
 		if fnOk {
 			return emptyStr, errhand.BuildDError("%s is not supported for %s exports", filenameFlag, rf).SetPrintUsage().Build()
 		}
@@ -737,7 +760,7 @@ func newTableArgs(tblName string, destination mvdata.DataLocation, batched, auto
 }
 
 // dumpNonSqlTables returns nil if all tables is dumped successfully, and it returns err if there is one.
-// It handles only csv and json file types(rf).
+// It handles the csv, json, jsonl, parquet and arrow file types(rf).
 func dumpNonSqlTables(ctx context.Context, root doltdb.RootValue, dEnv *env.DoltEnv, force bool, tblNames []string, rf string, dirName string, batched bool) errhand.VerboseError {
 	var fName string
 	if dirName == emptyStr {
@@ -767,6 +790,49 @@ func dumpNonSqlTables(ctx context.Context, root doltdb.RootValue, dEnv *env.Dolt
 	return nil
 }
 
+// dumpXlsxTables returns nil if all tables are dumped successfully to the xlsx workbook |fileName|, with one sheet per
+// table, and it returns err if there is one.
+func dumpXlsxTables(ctx context.Context, root doltdb.RootValue, dEnv *env.DoltEnv, force bool, tblNames []string, fileName string) errhand.VerboseError {
+	dumpOpts := getDumpOptions(fileName, xlsxFileExt, false)
+	fPath, verr := checkAndCreateOpenDestFile(ctx, root, dEnv, force, dumpOpts, fileName)
+	if verr != nil {
+		return verr
+	}
+
+	writer, err := dEnv.FS.OpenForWrite(fPath, os.ModePerm)
+	if err != nil {
+		return errhand.BuildDError("Error opening writer for %s.", fileName).AddCause(err).Build()
+	}
+	workbook := xlsx.NewXLSXWorkbook(writer)
+
+	for _, tbl := range tblNames {
+		rd, err := mvdata.NewSqlEngineReader(ctx, dEnv, tbl)
+		if err != nil {
+			_ = workbook.Close()
+			return errhand.BuildDError("Error creating reader for %s.", tbl).AddCause(err).Build()
+		}
+
+		wr, err := workbook.NewSheetWriter(tbl, rd.GetSchema())
+		if err != nil {
+			_ = rd.Close(ctx)
+			_ = workbook.Close()
+			return errhand.BuildDError("Could not create table writer for %s", tbl).AddCause(err).Build()
+		}
+
+		err = mvdata.NewDataMoverPipeline(ctx, rd, wr).Execute()
+		if err != nil {
+			_ = workbook.Close()
+			return errhand.BuildDError("Error with dumping %s.", tbl).AddCause(err).Build()
+		}
+	}
+
+	if err = workbook.Close(); err != nil {
+		return errhand.BuildDError("Error writing %s.", fileName).AddCause(err).Build()
+	}
+
+	return nil
+}
+
 // addBulkLoadingParadigms adds statements that are used to expedite dump file ingestion.
 // cc. https://dev.mysql.com/doc/refman/8.0/en/optimizing-innodb-bulk-data-loading.html
 // This includes turning off FOREIGN_KEY_CHECKS and UNIQUE_CHECKS off at the beginning of the file.
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -32,10 +33,12 @@ import (
 	_ "github.com/dolthub/go-mysql-server/sql/variables"
 	"github.com/dolthub/vitess/go/vt/sqlparser"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
@@ -51,6 +54,8 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/cmd/dolt/commands/engine")
+
 // SqlEngine packages up the context necessary to run sql queries against dsqle.
 type SqlEngine struct {
 	provider       sql.DatabaseProvider
@@ -81,6 +86,7 @@ type SqlEngineConfig struct {
 	ClusterController       *cluster.Controller
 	BinlogReplicaController binlogreplication.BinlogReplicaController
 	EventSchedulerStatus    eventscheduler.SchedulerStatus
+	Webhooks                []servercfg.WebhookConfig
 }
 
 // NewSqlEngine returns a SqlEngine
@@ -121,6 +127,12 @@ func NewSqlEngine(
 		return nil, err
 	}
 
+	webhookEndpoints := webhookEndpointsFunc(config.Webhooks)
+	err = dsqle.ApplyWebhookConfig(ctx, bThreads, mrEnv, webhookEndpoints, cli.CliErr, dbs...)
+	if err != nil {
+		return nil, err
+	}
+
 	all := dbs[:]
 
 	clusterDB := config.ClusterController.ClusterDatabase()
@@ -137,6 +149,9 @@ func NewSqlEngine(
 	pro = pro.WithRemoteDialer(mrEnv.RemoteDialProvider())
 
 	config.ClusterController.RegisterStoredProcedures(pro)
+	if len(config.Webhooks) > 0 {
+		pro.AddInitDatabaseHook(dsqle.NewWebhookInitDatabaseHook(bThreads, webhookEndpoints, cli.CliErr))
+	}
 	if config.ClusterController != nil {
 		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, cluster.NewInitDatabaseHook(config.ClusterController, bThreads))
 		pro.DropDatabaseHooks = append(pro.DropDatabaseHooks, config.ClusterController.DropDatabaseHook())
@@ -150,6 +165,7 @@ func NewSqlEngine(
 		IsReadOnly:     config.IsReadOnly,
 		IsServerLocked: config.IsServerLocked,
 	}).WithBackgroundThreads(bThreads)
+	pro.SetStatementRunner(engine)
 
 	if err := configureBinlogPrimaryController(engine); err != nil {
 		return nil, err
@@ -194,7 +210,7 @@ func NewSqlEngine(
 	engine.Analyzer.Catalog.StatsProvider = statsPro
 
 	engine.Analyzer.ExecBuilder = rowexec.NewOverrideBuilder(kvexec.Builder{})
-	sessFactory := doltSessionFactory(pro, statsPro, mrEnv.Config(), bcController, config.Autocommit)
+	sessFactory := doltSessionFactory(pro, statsPro, mrEnv.Config(), bcController, dsess.NewGCSafepointController(), config.Autocommit)
 	sqlEngine.provider = pro
 	sqlEngine.contextFactory = sqlContextFactory()
 	sqlEngine.dsessFactory = sessFactory
@@ -265,6 +281,27 @@ func applySystemVariables(vars sql.SystemVariableRegistry, cfg SystemVariables)
 	return nil
 }
 
+// webhookEndpointsFunc returns a function which selects the endpoints of |webhooks| that report on a given database.
+func webhookEndpointsFunc(webhooks []servercfg.WebhookConfig) dsqle.WebhookEndpointsFunc {
+	return func(name string) []doltdb.WebhookEndpoint {
+		var endpoints []doltdb.WebhookEndpoint
+		for _, webhook := range webhooks {
+			if dbs := webhook.Databases(); len(dbs) > 0 && !slices.ContainsFunc(dbs, func(db string) bool {
+				return strings.EqualFold(db, name)
+			}) {
+				continue
+			}
+			endpoints = append(endpoints, doltdb.WebhookEndpoint{
+				URL:     webhook.URL(),
+				Refs:    webhook.Refs(),
+				Headers: webhook.Headers(),
+				Timeout: webhook.Timeout(),
+			})
+		}
+		return endpoints
+	}
+}
+
 // Databases returns a slice of all databases in the engine
 func (se *SqlEngine) Databases(ctx *sql.Context) []dsess.SqlDatabase {
 	databases := se.provider.AllDatabases(ctx)
@@ -413,13 +450,13 @@ func configureEventScheduler(config *SqlEngineConfig, engine *gms.Engine, sessFa
 // sqlContextFactory returns a contextFactory that creates a new sql.Context with the initial database provided
 func sqlContextFactory() contextFactory {
 	return func(ctx context.Context, session sql.Session) (*sql.Context, error) {
-		sqlCtx := sql.NewContext(ctx, sql.WithSession(session))
+		sqlCtx := sql.NewContext(ctx, sql.WithSession(session), sql.WithTracer(tracer))
 		return sqlCtx, nil
 	}
 }
 
 // doltSessionFactory returns a sessionFactory that creates a new DoltSession
-func doltSessionFactory(pro *dsqle.DoltDatabaseProvider, statsPro sql.StatsProvider, config config.ReadWriteConfig, bc *branch_control.Controller, autocommit bool) sessionFactory {
+func doltSessionFactory(pro *dsqle.DoltDatabaseProvider, statsPro sql.StatsProvider, config config.ReadWriteConfig, bc *branch_control.Controller, gcSafepointController *dsess.GCSafepointController, autocommit bool) sessionFactory {
 	return func(mysqlSess *sql.BaseSession, provider sql.DatabaseProvider) (*dsess.DoltSession, error) {
 		doltSession, err := dsess.NewDoltSession(mysqlSess, pro, config, bc, statsPro, writer.NewWriteSession)
 		if err != nil {
@@ -433,6 +470,8 @@ func doltSessionFactory(pro *dsqle.DoltDatabaseProvider, statsPro sql.StatsProvi
 			return nil, err
 		}
 
+		doltSession.SetGCSafepointController(gcSafepointController)
+
 		return doltSession, nil
 	}
 }
@@ -37,10 +37,14 @@ var fetchDocs = cli.CommandDocumentationContent{
 By default dolt will attempt to fetch from a remote named {{.EmphasisLeft}}origin{{.EmphasisRight}}.  The {{.LessThan}}remote{{.GreaterThan}} parameter allows you to specify the name of a different remote you wish to pull from by the remote's name.
 
 When no refspec(s) are specified on the command line, the fetch_specs for the default remote are used.
+
+The {{.LessThan}}remote{{.GreaterThan}} may also be the path of a bundle file written by {{.EmphasisLeft}}dolt bundle create{{.EmphasisRight}}. Branches fetched from a bundle which has not been added as a remote update remote-tracking branches named after the bundle's file, without its {{.EmphasisLeft}}.bundle{{.EmphasisRight}} extension.
+
+With {{.EmphasisLeft}}--tables{{.EmphasisRight}} or {{.EmphasisLeft}}--exclude-tables{{.EmphasisRight}}, only the rows and indexes of the selected tables are fetched, and the rows of the other tables are fetched when they are first read. Otherwise, the filter given when the remote was cloned, if any, is used.
 `,
 
 	Synopsis: []string{
-		"[{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
+		"[--tables={{.LessThan}}table{{.GreaterThan}},... | --exclude-tables={{.LessThan}}table{{.GreaterThan}},...] [{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
 	},
 }
 
@@ -150,6 +154,13 @@ func constructInterpolatedDoltFetchQuery(apr *argparser.ArgParseResults) (string
 		args = append(args, "?")
 		params = append(params, user)
 	}
+	for _, fl := range []string{cli.TablesFlag, cli.ExcludeTablesFlag} {
+		if tables, ok := apr.GetValueList(fl); ok {
+			args = append(args, "'--"+fl+"'")
+			args = append(args, "?")
+			params = append(params, strings.Join(tables, ","))
+		}
+	}
 	for _, arg := range apr.Args {
 		args = append(args, "?")
 		params = append(params, arg)
@@ -294,23 +294,7 @@ func processFilterQuery(ctx context.Context, dEnv *env.DoltEnv, root doltdb.Root
 			cli.Printf("executing query: %s\n", q)
 		}
 
-		err = func() error {
-			_, itr, _, err := eng.Query(sqlCtx, q)
-			if err != nil {
-				return err
-			}
-
-			for {
-				_, err = itr.Next(sqlCtx)
-				if err == io.EOF {
-					break
-				} else if err != nil {
-					return err
-				}
-			}
-			return itr.Close(sqlCtx)
-		}()
-
+		err = runDrainedQuery(sqlCtx, eng, q)
 		if err != nil {
 			if continueOnErr {
 				if verbose {
@@ -331,6 +315,24 @@ func processFilterQuery(ctx context.Context, dEnv *env.DoltEnv, root doltdb.Root
 	return ws.WorkingRoot(), nil
 }
 
+// runDrainedQuery runs the query |q| and reads all of its results.
+func runDrainedQuery(sqlCtx *sql.Context, eng *engine.SqlEngine, q string) error {
+	_, itr, _, err := eng.Query(sqlCtx, q)
+	if err != nil {
+		return err
+	}
+
+	for {
+		_, err = itr.Next(sqlCtx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	return itr.Close(sqlCtx)
+}
+
 // rebaseSqlEngine packages up the context necessary to run sql queries against single root
 // The SQL engine returned has transactions disabled. This is to prevent transactions starts from overwriting the root
 // we set manually with the one at the working set of the HEAD being rebased.
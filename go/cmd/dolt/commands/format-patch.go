@@ -0,0 +1,248 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/patch"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	outputDirParam = "output-directory"
+	stdoutFlag     = "stdout"
+	maxCountParam  = "max-count"
+
+	// maxPatchNameLen is the maximum length of the part of a patch file's name taken from the commit message.
+	maxPatchNameLen = 52
+)
+
+var formatPatchDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Write commits as patch files.`,
+	LongDesc: `Writes a patch file for each commit in a revision range, which can be applied to a clone of the database with {{.EmphasisLeft}}dolt am{{.EmphasisRight}} or {{.EmphasisLeft}}dolt apply{{.EmphasisRight}}. Clones don't need to share a remote, so patches can be used to move changes between databases that can't reach each other.
+
+A patch holds the author, date and message of a commit, the schema changes it made as SQL statements, and the rows it added, removed, and changed. Rows that were removed or changed are written with their old values, so that when the patch is applied, changes made to the same rows since the commit was made are reported as conflicts.
+
+The revision range is either {{.LessThan}}since{{.GreaterThan}}, for the commits reachable from HEAD that aren't reachable from {{.LessThan}}since{{.GreaterThan}}, or {{.LessThan}}since{{.GreaterThan}}..{{.LessThan}}until{{.GreaterThan}}. With {{.EmphasisLeft}}-n{{.EmphasisRight}}, patches are written for the last {{.LessThan}}n{{.GreaterThan}} commits ending at the given revision, or at HEAD.
+
+Each patch is written to a file named after its number in the series and the first line of its commit message, such as {{.EmphasisLeft}}0001-add-users-table.patch{{.EmphasisRight}}. Merge commits, and changes to system tables such as {{.EmphasisLeft}}dolt_schemas{{.EmphasisRight}}, are not written.
+`,
+	Synopsis: []string{
+		`[-o {{.LessThan}}dir{{.GreaterThan}}] [--stdout] {{.LessThan}}since{{.GreaterThan}}[..{{.LessThan}}until{{.GreaterThan}}]`,
+		`[-o {{.LessThan}}dir{{.GreaterThan}}] [--stdout] -n {{.LessThan}}n{{.GreaterThan}} [{{.LessThan}}revision{{.GreaterThan}}]`,
+	},
+}
+
+type FormatPatchCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd FormatPatchCmd) Name() string {
+	return "format-patch"
+}
+
+// Description returns a description of the command
+func (cmd FormatPatchCmd) Description() string {
+	return formatPatchDocs.ShortDesc
+}
+
+func (cmd FormatPatchCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(formatPatchDocs, ap)
+}
+
+func (cmd FormatPatchCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"revision-range", "The commits to write patches for."})
+	ap.SupportsString(outputDirParam, "o", "dir", "Write the patch files to {{.LessThan}}dir{{.GreaterThan}} instead of the current directory.")
+	ap.SupportsFlag(stdoutFlag, "", "Write all the patches to STDOUT instead of to files.")
+	ap.SupportsInt(maxCountParam, "n", "n", "Write patches for the last {{.LessThan}}n{{.GreaterThan}} commits.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd FormatPatchCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, formatPatchDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	var revs string
+	if apr.NArg() == 1 {
+		revs = apr.Arg(0)
+	}
+	n, hasN := apr.GetInt(maxCountParam)
+	if !hasN && revs == "" {
+		usage()
+		return 1
+	}
+	if hasN && (n <= 0 || strings.Contains(revs, "..")) {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: -n takes a positive number of commits and a single revision").SetPrintUsage().Build(), usage)
+	}
+
+	commits, err := patchCommits(ctx, dEnv, revs, n, hasN)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	outDir := apr.GetValueOrDefault(outputDirParam, "")
+	if outDir != "" && !apr.Contains(stdoutFlag) {
+		if err = dEnv.FS.MkDirs(outDir); err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to create %s", outDir).AddCause(err).Build(), usage)
+		}
+	}
+
+	sqlCtx := sql.NewContext(ctx)
+	num := 0
+	for _, cm := range commits {
+		p, err := patch.FromCommit(sqlCtx, cm)
+		if err == patch.ErrMergeCommit {
+			h, _ := cm.HashOf()
+			cli.PrintErrf("skipping commit %s, patches can't be made from merge commits\n", h.String())
+			continue
+		} else if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to make patch").AddCause(err).Build(), usage)
+		}
+		num++
+
+		if apr.Contains(stdoutFlag) {
+			if num > 1 {
+				cli.Println()
+			}
+			err = patch.Write(cli.OutStream, p)
+		} else {
+			err = writePatchFile(dEnv, filepath.Join(outDir, patchFileName(num, p)), p)
+		}
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to write patch").AddCause(err).Build(), usage)
+		}
+	}
+
+	return 0
+}
+
+// patchCommits returns the commits of the revision range |revs|, oldest first. If |hasN| is true, |revs| is a single
+// revision, and the commits are the last |n| commits ending at it.
+func patchCommits(ctx context.Context, dEnv *env.DoltEnv, revs string, n int, hasN bool) ([]*doltdb.Commit, error) {
+	headRef, err := dEnv.RepoStateReader().CWBHeadRef()
+	if err != nil {
+		return nil, err
+	}
+	resolve := func(spec string) (hash.Hash, error) {
+		if spec == "" {
+			spec = "HEAD"
+		}
+		cs, err := doltdb.NewCommitSpec(spec)
+		if err != nil {
+			return hash.Hash{}, err
+		}
+		optCmt, err := dEnv.DoltDB.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return hash.Hash{}, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return hash.Hash{}, doltdb.ErrGhostCommitEncountered
+		}
+		return cm.HashOf()
+	}
+
+	var included, excluded []hash.Hash
+	num := -1
+	if hasN {
+		h, err := resolve(revs)
+		if err != nil {
+			return nil, err
+		}
+		included, num = []hash.Hash{h}, n
+	} else {
+		since, until, _ := strings.Cut(revs, "..")
+		sinceHash, err := resolve(since)
+		if err != nil {
+			return nil, err
+		}
+		untilHash, err := resolve(until)
+		if err != nil {
+			return nil, err
+		}
+		included, excluded = []hash.Hash{untilHash}, []hash.Hash{sinceHash}
+	}
+
+	optCmts, err := commitwalk.GetDotDotRevisions(ctx, dEnv.DoltDB, included, dEnv.DoltDB, excluded, num)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]*doltdb.Commit, len(optCmts))
+	for i, optCmt := range optCmts {
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		commits[len(commits)-1-i] = cm
+	}
+	return commits, nil
+}
+
+// patchFileName returns the name of the file of the |num|th patch of a series, which is made from the first line of
+// the patch's commit message.
+func patchFileName(num int, p *patch.Patch) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(p.Subject()) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '.' {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+		if sb.Len() >= maxPatchNameLen {
+			break
+		}
+	}
+	name := strings.TrimRight(sb.String(), ".")
+	return fmt.Sprintf("%04d-%s.patch", num, name)
+}
+
+func writePatchFile(dEnv *env.DoltEnv, path string, p *patch.Patch) error {
+	wr, err := dEnv.FS.OpenForWrite(path, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	err = patch.Write(wr, p)
+	if cerr := wr.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	cli.Println(path)
+	return nil
+}
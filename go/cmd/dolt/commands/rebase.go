@@ -23,6 +23,8 @@ import (
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
@@ -47,10 +49,24 @@ the same as Git and Dolt's "two dot log" syntax, or |upstreamBranch|..|currentBr
 Rebasing is useful to clean and organize your commit history, especially before merging a feature branch back to a shared 
 branch. For example, you can drop commits that contain debugging or test changes, or squash or fixup small commits into a 
 single commit, or reorder commits so that related changes are adjacent in the new commit history.
+
+Rebasing can also be used to fix up historical data. The {{.EmphasisLeft}}edit{{.EmphasisRight}} action stops the rebase 
+after a commit is applied so that it can be amended, and the {{.EmphasisLeft}}break{{.EmphasisRight}} action stops the 
+rebase at that point in the plan. Continue a stopped rebase with {{.EmphasisLeft}}dolt rebase --continue{{.EmphasisRight}}. 
+Changes staged at an {{.EmphasisLeft}}edit{{.EmphasisRight}} stop are amended into the commit when the rebase is continued. 
+The {{.EmphasisLeft}}exec{{.EmphasisRight}} action runs a SQL statement, and aborts the rebase if the statement fails. If 
+the statement leaves uncommitted changes, the rebase stops so that they can be committed before continuing.
+
+Without {{.EmphasisLeft}}--interactive{{.EmphasisRight}}, the default rebase plan, which picks every commit, is executed 
+right away. Data conflicts can be resolved automatically with {{.EmphasisLeft}}--strategy{{.EmphasisRight}}: 
+{{.EmphasisLeft}}ours{{.EmphasisRight}} keeps the rows from the upstream branch, and {{.EmphasisLeft}}theirs{{.EmphasisRight}} 
+keeps the rows from the commits being rebased. The strategy can also be given to {{.EmphasisLeft}}--continue{{.EmphasisRight}}, and is kept for the rest of the rebase.
 `,
 	Synopsis: []string{
+		`[--empty=drop|keep] [--strategy=ours|theirs] {{.LessThan}}upstream{{.GreaterThan}}`,
 		`(-i | --interactive) [--empty=drop|keep] {{.LessThan}}upstream{{.GreaterThan}}`,
-		`(--continue | --abort)`,
+		`--continue [--strategy=ours|theirs]`,
+		`--abort`,
 	},
 }
 
@@ -114,6 +130,13 @@ func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	rows, err := GetRowsForSql(queryist, sqlCtx, query)
 	if err != nil {
+		// If a non-interactive rebase stopped for data conflicts, the CLI needs to be on the rebase working
+		// branch so that the caller can resolve them
+		if isRebaseStoppedError(err) {
+			if checkoutErr := syncCliBranchToSqlSessionBranch(sqlCtx, dEnv); checkoutErr != nil {
+				return HandleVErrAndExitCode(errhand.VerboseErrorFromError(checkoutErr), usage)
+			}
+		}
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
@@ -125,11 +148,12 @@ func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(errors.New("error: "+rows[0][1].(string))), usage)
 	}
 
-	// If the rebase was successful, or if it was aborted, print out the message and
-	// ensure the branch being rebased is checked out in the CLI
+	// If the rebase was successful, if it was aborted, or if it stopped at an edit or break step, print out
+	// the message and ensure the branch the session is on is checked out in the CLI
 	message := rows[0][1].(string)
 	if strings.Contains(message, dprocedures.SuccessfulRebaseMessage) ||
-		strings.Contains(message, dprocedures.RebaseAbortedMessage) {
+		strings.Contains(message, dprocedures.RebaseAbortedMessage) ||
+		strings.HasPrefix(message, dprocedures.RebaseStoppedMessage) {
 		cli.Println(message)
 		if err = syncCliBranchToSqlSessionBranch(sqlCtx, dEnv); err != nil {
 			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
@@ -172,7 +196,7 @@ func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string,
 	rows, err = GetRowsForSql(queryist, sqlCtx, "CALL DOLT_REBASE('--continue');")
 	if err != nil {
 		// If the error is a data conflict, don't abort the rebase, but let the caller resolve the conflicts
-		if dprocedures.ErrRebaseDataConflict.Is(err) || strings.Contains(err.Error(), dprocedures.ErrRebaseDataConflict.Message[:40]) {
+		if isRebaseStoppedError(err) {
 			if checkoutErr := syncCliBranchToSqlSessionBranch(sqlCtx, dEnv); checkoutErr != nil {
 				return HandleVErrAndExitCode(errhand.VerboseErrorFromError(checkoutErr), usage)
 			}
@@ -201,6 +225,14 @@ func (cmd RebaseCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(errors.New("error: "+rows[0][1].(string))), usage)
 	}
 
+	// If the rebase stopped at an edit or break step, or at an exec step that left uncommitted changes, the CLI
+	// needs to be on the rebase working branch so that the caller can make changes before continuing the rebase
+	if strings.HasPrefix(rows[0][1].(string), dprocedures.RebaseStoppedMessage) {
+		if err = syncCliBranchToSqlSessionBranch(sqlCtx, dEnv); err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
 	cli.Println(rows[0][1].(string))
 	return 0
 }
@@ -257,7 +289,14 @@ func buildInitialRebaseMsg(sqlCtx *sql.Context, queryist cli.Queryist, rebaseBra
 		}
 		commitHash := row[1].(string)
 		commitMessage := row[2].(string)
-		buffer.WriteString(fmt.Sprintf("%s %s %s\n", action, commitHash, commitMessage))
+		switch action {
+		case rebase.RebaseActionBreak:
+			buffer.WriteString(action + "\n")
+		case rebase.RebaseActionExec:
+			buffer.WriteString(fmt.Sprintf("%s %s\n", action, commitMessage))
+		default:
+			buffer.WriteString(fmt.Sprintf("%s %s %s\n", action, commitHash, commitMessage))
+		}
 	}
 	buffer.WriteString("\n")
 
@@ -279,6 +318,9 @@ func buildInitialRebaseMsg(sqlCtx *sql.Context, queryist cli.Queryist, rebaseBra
 	buffer.WriteString("# r, reword <commit> = use commit, but edit the commit message\n")
 	buffer.WriteString("# s, squash <commit> = use commit, but meld into previous commit\n")
 	buffer.WriteString("# f, fixup <commit> = like \"squash\", but discard this commit's message\n")
+	buffer.WriteString("# e, edit <commit> = use commit, but stop for amending\n")
+	buffer.WriteString("# b, break = stop here (continue rebase later with 'dolt rebase --continue')\n")
+	buffer.WriteString("# x, exec <statement> = run a SQL statement, and abort the rebase if it fails\n")
 	buffer.WriteString("# These lines can be re-ordered; they are executed from top to bottom.\n")
 	buffer.WriteString("#\n")
 	buffer.WriteString("# If you remove a line here THAT COMMIT WILL BE LOST.\n")
@@ -301,21 +343,57 @@ func getRebaseAction(col interface{}) (string, bool) {
 	}
 }
 
+// rebaseActionAbbreviations maps the single letter abbreviations listed in the rebase plan help text to the
+// rebase actions they stand for.
+var rebaseActionAbbreviations = map[string]string{
+	"p": rebase.RebaseActionPick,
+	"d": rebase.RebaseActionDrop,
+	"r": rebase.RebaseActionReword,
+	"s": rebase.RebaseActionSquash,
+	"f": rebase.RebaseActionFixup,
+	"e": rebase.RebaseActionEdit,
+	"b": rebase.RebaseActionBreak,
+	"x": rebase.RebaseActionExec,
+}
+
 // parseRebaseMessage parses the rebase message from the editor and adds all uncommented out lines as steps in the rebase plan.
 func parseRebaseMessage(rebaseMsg string) (*rebase.RebasePlan, error) {
 	plan := &rebase.RebasePlan{}
 	splitMsg := strings.Split(rebaseMsg, "\n")
 	for i, line := range splitMsg {
 		if !strings.HasPrefix(line, "#") && strings.TrimSpace(line) != "" {
-			rebaseStepParts := strings.SplitN(line, " ", 3)
-			if len(rebaseStepParts) != 3 {
-				return nil, fmt.Errorf("invalid line %d: %s", i, line)
+			action, rest, _ := strings.Cut(strings.TrimSpace(line), " ")
+			if fullAction, ok := rebaseActionAbbreviations[action]; ok {
+				action = fullAction
+			}
+
+			switch action {
+			case rebase.RebaseActionBreak:
+				if strings.TrimSpace(rest) != "" {
+					return nil, fmt.Errorf("invalid line %d: %s", i, line)
+				}
+				plan.Steps = append(plan.Steps, rebase.RebasePlanStep{
+					Action: rebase.RebaseActionBreak,
+				})
+			case rebase.RebaseActionExec:
+				if strings.TrimSpace(rest) == "" {
+					return nil, fmt.Errorf("invalid line %d: %s", i, line)
+				}
+				plan.Steps = append(plan.Steps, rebase.RebasePlanStep{
+					Action:    rebase.RebaseActionExec,
+					CommitMsg: strings.TrimSpace(rest),
+				})
+			default:
+				rebaseStepParts := strings.SplitN(rest, " ", 2)
+				if len(rebaseStepParts) != 2 {
+					return nil, fmt.Errorf("invalid line %d: %s", i, line)
+				}
+				plan.Steps = append(plan.Steps, rebase.RebasePlanStep{
+					Action:     action,
+					CommitHash: rebaseStepParts[0],
+					CommitMsg:  rebaseStepParts[1],
+				})
 			}
-			plan.Steps = append(plan.Steps, rebase.RebasePlanStep{
-				Action:     rebaseStepParts[0],
-				CommitHash: rebaseStepParts[1],
-				CommitMsg:  rebaseStepParts[2],
-			})
 		}
 	}
 
@@ -331,7 +409,11 @@ func insertRebasePlanIntoDoltRebaseTable(plan *rebase.RebasePlan, sqlCtx *sql.Co
 	}
 
 	for i, step := range plan.Steps {
-		_, err := GetRowsForSql(queryist, sqlCtx, fmt.Sprintf("INSERT INTO dolt_rebase VALUES (%d, '%s', '%s', '%s')", i+1, step.Action, step.CommitHash, step.CommitMsg))
+		query, err := buildInsertRebasePlanStepQuery(i+1, step)
+		if err != nil {
+			return err
+		}
+		_, err = GetRowsForSql(queryist, sqlCtx, query)
 		if err != nil {
 			return err
 		}
@@ -340,6 +422,21 @@ func insertRebasePlanIntoDoltRebaseTable(plan *rebase.RebasePlan, sqlCtx *sql.Co
 	return nil
 }
 
+// buildInsertRebasePlanStepQuery returns the query that inserts |step| into the dolt_rebase table with the rebase
+// order |order|. The values of the step are quoted as SQL string literals, since commit messages and exec statements
+// may contain any characters.
+func buildInsertRebasePlanStepQuery(order int, step rebase.RebasePlanStep) (string, error) {
+	return dbr.InterpolateForDialect("INSERT INTO dolt_rebase VALUES (?, ?, ?, ?)",
+		[]interface{}{order, step.Action, step.CommitHash, step.CommitMsg}, dialect.MySQL)
+}
+
+// isRebaseStoppedError returns true if |err| means the rebase stopped for the caller to resolve data conflicts,
+// rather than failing.
+func isRebaseStoppedError(err error) bool {
+	return dprocedures.ErrRebaseDataConflict.Is(err) ||
+		strings.Contains(err.Error(), dprocedures.ErrRebaseDataConflict.Message[:40])
+}
+
 // syncCliBranchToSqlSessionBranch sets the current branch for the CLI (in repo_state.json) to the active branch
 // for the current session. This is needed during rebasing, since any conflicts need to be resolved while the
 // session is on the rebase working branch (e.g. dolt_rebase_t1) and after the rebase finishes, the session needs
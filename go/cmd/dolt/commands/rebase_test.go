@@ -0,0 +1,77 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+)
+
+func TestParseRebaseMessage(t *testing.T) {
+	msg := "# Rebase plan\n" +
+		"pick abc123 first commit\n" +
+		"e def456 second commit\n" +
+		"b\n" +
+		"x insert into t values (1)\n" +
+		"exec call dolt_commit('-am', 'exec commit')\n" +
+		"break\n" +
+		"f 789abc fix things up\n"
+
+	plan, err := parseRebaseMessage(msg)
+	require.NoError(t, err)
+	assert.Equal(t, []rebase.RebasePlanStep{
+		{Action: rebase.RebaseActionPick, CommitHash: "abc123", CommitMsg: "first commit"},
+		{Action: rebase.RebaseActionEdit, CommitHash: "def456", CommitMsg: "second commit"},
+		{Action: rebase.RebaseActionBreak},
+		{Action: rebase.RebaseActionExec, CommitMsg: "insert into t values (1)"},
+		{Action: rebase.RebaseActionExec, CommitMsg: "call dolt_commit('-am', 'exec commit')"},
+		{Action: rebase.RebaseActionBreak},
+		{Action: rebase.RebaseActionFixup, CommitHash: "789abc", CommitMsg: "fix things up"},
+	}, plan.Steps)
+
+	_, err = parseRebaseMessage("x\n")
+	assert.Error(t, err)
+	_, err = parseRebaseMessage("pick abc123\n")
+	assert.Error(t, err)
+}
+
+func TestBuildInsertRebasePlanStepQuery(t *testing.T) {
+	step := rebase.RebasePlanStep{
+		Action:     rebase.RebaseActionPick,
+		CommitHash: "abc123",
+		CommitMsg:  `it's a \' tricky message`,
+	}
+	query, err := buildInsertRebasePlanStepQuery(1, step)
+	require.NoError(t, err)
+
+	stmt, err := sqlparser.Parse(query)
+	require.NoError(t, err)
+	insert, ok := stmt.(*sqlparser.Insert)
+	require.True(t, ok)
+	rows, ok := insert.Rows.(*sqlparser.AliasedValues)
+	require.True(t, ok)
+	values := rows.Values
+	require.Len(t, values, 1)
+	require.Len(t, values[0], 4)
+	assert.Equal(t, "1", string(values[0][0].(*sqlparser.SQLVal).Val))
+	assert.Equal(t, step.Action, string(values[0][1].(*sqlparser.SQLVal).Val))
+	assert.Equal(t, step.CommitHash, string(values[0][2].(*sqlparser.SQLVal).Val))
+	assert.Equal(t, step.CommitMsg, string(values[0][3].(*sqlparser.SQLVal).Val))
+}
@@ -43,7 +43,7 @@ var remoteDocs = cli.CommandDocumentationContent{
 {{.EmphasisLeft}}add{{.EmphasisRight}}
 Adds a remote named {{.LessThan}}name{{.GreaterThan}} for the repository at {{.LessThan}}url{{.GreaterThan}}. The command dolt fetch {{.LessThan}}name{{.GreaterThan}} can then be used to create and update remote-tracking branches {{.EmphasisLeft}}<name>/<branch>{{.EmphasisRight}}.
 
-The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, gs, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}remotes.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
+The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of http, https, aws, s3, az, gs, ssh, bundle, and file. The url prefix defaults to https. If the {{.LessThan}}url{{.GreaterThan}} parameter is in the format {{.EmphasisLeft}}<organization>/<repository>{{.EmphasisRight}} then dolt will use the {{.EmphasisLeft}}remotes.default_host{{.EmphasisRight}} from your configuration file (Which will be dolthub.com unless changed).
 
 AWS cloud remote urls should be of the form {{.EmphasisLeft}}aws://[dynamo-table:s3-bucket]/database{{.EmphasisRight}}.  You may configure your aws cloud remote using the optional parameters {{.EmphasisLeft}}aws-region{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-type{{.EmphasisRight}}, {{.EmphasisLeft}}aws-creds-file{{.EmphasisRight}}.
 
@@ -53,10 +53,18 @@ aws-creds-type specifies the means by which credentials should be retrieved in o
 	env: Looks for environment variables AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY
 	file: Uses the credentials file specified by the parameter aws-creds-file
 	
+S3 remote urls of the form {{.EmphasisLeft}}s3://s3-bucket/database{{.EmphasisRight}} store the whole database, including its manifest, in the bucket and need no dynamo table. They accept the same aws parameters, and the optional parameter {{.EmphasisLeft}}s3-endpoint{{.EmphasisRight}} (or the environment variable DOLT_S3_ENDPOINT) points them at an S3-compatible object store such as MinIO or Ceph. The store must support conditional writes.
+
+Azure remote urls should be of the form {{.EmphasisLeft}}az://storage-account/container/database{{.EmphasisRight}}. Credentials are read from the environment variables AZURE_STORAGE_CONNECTION_STRING, AZURE_STORAGE_KEY or AZURE_STORAGE_SAS_TOKEN, falling back to the default Azure credential chain (managed identity, Azure CLI login, etc). The optional parameter {{.EmphasisLeft}}az-endpoint{{.EmphasisRight}} (or the environment variable DOLT_AZURE_ENDPOINT) sets the blob service endpoint, for example to use the Azurite emulator.
+
 GCP remote urls should be of the form gs://gcs-bucket/database and will use the credentials setup using the gcloud command line available from Google.
 
 The local filesystem can be used as a remote by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_scheme
 
+SSH remote urls should be of the form {{.EmphasisLeft}}ssh://[user@]host[:port]/path{{.EmphasisRight}}, where paths beginning with {{.EmphasisLeft}}/~/{{.EmphasisRight}} are relative to the remote user's home directory. Dolt must be installed on the remote host, where it is run over ssh to serve the dolt repository or the file remote at the path. A file remote is created there if nothing exists at the path yet. The environment variable DOLT_SSH sets the ssh command to run, and DOLT_SSH_EXEC_PATH sets the path of dolt on the remote host.
+
+Bundle files written by {{.EmphasisLeft}}dolt bundle create{{.EmphasisRight}} can be added as read only remotes, either by their path or with a url in the format bundle://path.
+
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
 Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.`,
 
@@ -101,6 +109,9 @@ func (cmd RemoteCmd) ArgParser() *argparser.ArgParser {
 
 	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file")
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use")
+
+	ap.SupportsString(dbfactory.S3EndpointParam, "", "url", "Endpoint of the S3-compatible object store for s3:// remotes")
+	ap.SupportsString(dbfactory.AzureEndpointParam, "", "url", "Blob service endpoint for az:// remotes")
 	return ap
 }
 
@@ -215,6 +226,10 @@ func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (
 		err = cli.AddAWSParams(remoteUrl, apr, params)
 	case dbfactory.OSSScheme:
 		err = cli.AddOSSParams(remoteUrl, apr, params)
+	case dbfactory.S3Scheme:
+		err = cli.AddS3Params(remoteUrl, apr, params)
+	case dbfactory.AzureScheme:
+		err = cli.AddAzureParams(remoteUrl, apr, params)
 	default:
 		err = cli.VerifyNoAwsParams(apr)
 	}
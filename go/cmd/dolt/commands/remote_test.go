@@ -30,7 +30,8 @@ import (
 func TestGetAbsRemoteUrl(t *testing.T) {
 	cwd := osutil.PathToNative("/User/name/datasets")
 	testRepoDir := filepath.Join(cwd, "test-repo")
-	fs := filesys.NewInMemFS([]string{cwd, testRepoDir}, nil, cwd)
+	bundleFile := filepath.Join(cwd, "db.bundle")
+	fs := filesys.NewInMemFS([]string{cwd, testRepoDir}, map[string][]byte{bundleFile: {}}, cwd)
 	if osutil.IsWindows {
 		cwd = filepath.ToSlash(cwd)
 	}
@@ -111,6 +112,20 @@ func TestGetAbsRemoteUrl(t *testing.T) {
 			"file",
 			false,
 		},
+		{
+			"db.bundle",
+			config.NewMapConfig(map[string]string{}),
+			fmt.Sprintf("bundle://%s/db.bundle", cwd),
+			"bundle",
+			false,
+		},
+		{
+			"bundle://./db.bundle",
+			config.NewMapConfig(map[string]string{}),
+			fmt.Sprintf("bundle://%s/db.bundle", cwd),
+			"bundle",
+			false,
+		},
 		{
 			":/:/:/", // intended to fail earl.Parse
 			config.NewMapConfig(map[string]string{}),
@@ -37,8 +37,12 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/parquet"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/funcitr"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/types"
@@ -79,7 +83,7 @@ A mapping file can be used to map fields between the file being imported and the
 
 ` + MappingFileHelp + `
 
-In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (Currently only csv is supported).  For files separated by a delimiter other than a ',', the --delim parameter can be used to specify a delimiter.
+In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, jsonl, parquet and arrow).  For files separated by a delimiter other than a ',', the --delim parameter can be used to specify a delimiter.
 
 If the parameter {{.EmphasisLeft}}--dry-run{{.EmphasisRight}} is supplied a sql statement will be generated showing what would be executed if this were run without the --dry-run flag
 
@@ -376,22 +380,47 @@ func inferSchemaFromFile(ctx context.Context, nbf *types.NomsBinFormat, impOpts
 		}
 	case "psv":
 		csvInfo.SetDelim("|")
+	case "jsonl", "ndjson":
+		var verr errhand.VerboseError
+		rd, verr = openJSONLInferenceReader(nbf, impOpts.fileName)
+		if verr != nil {
+			return nil, verr
+		}
+	case "parquet", "arrow", "feather", "arrows":
+		// parquet and arrow files are typed, so the column types are taken from the file rather than inferred from
+		// its values
+		var infCols *schema.ColCollection
+		var err error
+		if impOpts.fileType == "parquet" {
+			infCols, err = parquet.InferColumnsFromFile(impOpts.fileName)
+		} else {
+			infCols, err = arrow.InferColumnsFromFile(impOpts.fileName, filesys.LocalFS)
+		}
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to infer schema").AddCause(err).Build()
+		}
+		infCols = schema.MapColCollection(infCols, func(col schema.Column) schema.Column {
+			col.Name = impOpts.ColNameMapper().Map(col.Name)
+			return col
+		})
+		return CombineColCollections(ctx, root, infCols, impOpts)
 	default:
 		return nil, errhand.BuildDError("error: unsupported file type '%s'", impOpts.fileType).Build()
 	}
 
-	f, err := os.Open(impOpts.fileName)
+	if rd == nil {
+		f, err := os.Open(impOpts.fileName)
 
-	if err != nil {
-		return nil, errhand.BuildDError("error: failed to open '%s'", impOpts.fileName).Build()
-	}
-
-	defer f.Close()
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to open '%s'", impOpts.fileName).Build()
+		}
 
-	rd, err = csv.NewCSVReader(nbf, f, csvInfo)
+		rd, err = csv.NewCSVReader(nbf, f, csvInfo)
 
-	if err != nil {
-		return nil, errhand.BuildDError("error: failed to create a CSVReader.").AddCause(err).Build()
+		if err != nil {
+			f.Close()
+			return nil, errhand.BuildDError("error: failed to create a CSVReader.").AddCause(err).Build()
+		}
 	}
 
 	defer rd.Close(ctx)
@@ -405,6 +434,31 @@ func inferSchemaFromFile(ctx context.Context, nbf *types.NomsBinFormat, impOpts
 	return CombineColCollections(ctx, root, infCols, impOpts)
 }
 
+// openJSONLInferenceReader returns a reader for the JSONL file |fileName| that returns every value as a string, for
+// schema inference. The file is read once to find the names of its columns before the reader is created.
+func openJSONLInferenceReader(nbf *types.NomsBinFormat, fileName string) (table.ReadCloser, errhand.VerboseError) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to open '%s'", fileName).Build()
+	}
+	colNames, err := json.ReadJSONLColumnNames(f)
+	f.Close()
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read the columns of '%s'", fileName).AddCause(err).Build()
+	}
+
+	f, err = os.Open(fileName)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to open '%s'", fileName).Build()
+	}
+	rd, err := json.NewUntypedJSONLReader(nbf, f, colNames)
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to create a JSONLReader.").AddCause(err).Build()
+	}
+
+	return rd, nil
+}
+
 func CombineColCollections(ctx context.Context, root doltdb.RootValue, inferredCols *schema.ColCollection, impOpts *importOptions) (schema.Schema, errhand.VerboseError) {
 	existingCols := impOpts.existingSch.GetAllCols()
 
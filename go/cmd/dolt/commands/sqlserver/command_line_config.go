@@ -307,6 +307,18 @@ func (cfg *commandLineServerConfig) ClusterConfig() servercfg.ClusterConfig {
 	return nil
 }
 
+func (cfg *commandLineServerConfig) AutoGCConfig() servercfg.AutoGCConfig {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) Webhooks() []servercfg.WebhookConfig {
+	return nil
+}
+
+func (cfg *commandLineServerConfig) TracingConfig() servercfg.TracingConfig {
+	return nil
+}
+
 // PrivilegeFilePath returns the path to the file which contains all needed privilege information in the form of a
 // JSON string.
 func (cfg *commandLineServerConfig) PrivilegeFilePath() string {
@@ -35,6 +35,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/dolthub/vitess/go/mysql"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	goerrors "gopkg.in/src-d/go-errors.v1"
@@ -49,7 +50,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/autogc"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cdc"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
@@ -151,6 +154,23 @@ func ConfigureServices(
 	}
 	controller.Register(InitLogging)
 
+	var shutdownTracing func() error
+	InitTracing := &svcs.AnonService{
+		InitF: func(ctx context.Context) (err error) {
+			if cfg := serverConfig.TracingConfig(); cfg != nil {
+				shutdownTracing, err = initTracing(ctx, cfg, version)
+			}
+			return err
+		},
+		StopF: func() error {
+			if shutdownTracing == nil {
+				return nil
+			}
+			return shutdownTracing()
+		},
+	}
+	controller.Register(InitTracing)
+
 	controller.Register(newHeartbeatService(version, dEnv))
 
 	fs := dEnv.FS
@@ -249,6 +269,7 @@ func ConfigureServices(
 				SystemVariables:         serverConfig.SystemVars(),
 				ClusterController:       clusterController,
 				BinlogReplicaController: binlogreplication.DoltBinlogReplicaController,
+				Webhooks:                serverConfig.Webhooks(),
 			}
 			return nil
 		},
@@ -344,6 +365,28 @@ func ConfigureServices(
 				binlogreplication.BinlogBranch = logBinBranch
 			}
 
+			_, logBinBranchesValue, ok := sql.SystemVariables.GetGlobal("log_bin_branches")
+			if !ok {
+				return fmt.Errorf("unable to load @@log_bin_branches system variable")
+			}
+			logBinBranches, ok := logBinBranchesValue.(string)
+			if !ok {
+				return fmt.Errorf("unexpected type for @@log_bin_branches system variable: %T", logBinBranchesValue)
+			}
+			binlogreplication.BinlogBranches = nil
+			for _, branch := range strings.Split(logBinBranches, ",") {
+				branch = strings.TrimSpace(branch)
+				if branch == "" || branch == binlogreplication.BinlogBranch {
+					continue
+				}
+				if strings.Contains(branch, "/") {
+					logrus.Warnf("branch names containing '/' are not supported for binlog "+
+						"replication. Not replicating branch %s from @@log_bin_branches", branch)
+					continue
+				}
+				binlogreplication.BinlogBranches = append(binlogreplication.BinlogBranches, branch)
+			}
+
 			if logBin == 1 {
 				logrus.Infof("Enabling binary logging for branch %s", logBinBranch)
 				binlogProducer, err := binlogreplication.NewBinlogProducer(dEnv.FS)
@@ -398,13 +441,20 @@ func ConfigureServices(
 	controller.Register(InitSuperUser)
 
 	var metListener *metricsListener
+	var storageMetrics *storageCollector
 	InitMetricsListener := &svcs.AnonService{
 		InitF: func(context.Context) (err error) {
 			labels := serverConfig.MetricsLabels()
 			metListener, err = newMetricsListener(labels, version, clusterController)
-			return err
+			if err != nil {
+				return err
+			}
+			storageMetrics = newStorageCollector(labels, sqlEngine.NewDefaultContext)
+			prometheus.MustRegister(storageMetrics)
+			return nil
 		},
 		StopF: func() error {
+			prometheus.Unregister(storageMetrics)
 			metListener.Close()
 			return nil
 		},
@@ -513,6 +563,7 @@ func ConfigureServices(
 				lgr.Errorf("error creating SQL engine context for remotesapi server: %v", err)
 				return err
 			}
+			args.CommitValidator = sqle.RemoteSrvCommitValidator(sqlEngine.NewDefaultContext)
 
 			authenticator := newAccessController(sqlEngine.NewDefaultContext, sqlEngine.GetUnderlyingEngine().Analyzer.Catalog.MySQLDb)
 			args = sqle.WithUserPasswordAuth(args, authenticator)
@@ -523,6 +574,7 @@ func ConfigureServices(
 				lgr.Errorf("error creating remotesapi server on port %d: %v", port, err)
 				return err
 			}
+			cdc.NewServer(sqlEngine.NewDefaultContext).Register(remoteSrv.srv.GrpcServer())
 			remoteSrv.lis, err = remoteSrv.srv.Listeners()
 			if err != nil {
 				lgr.Errorf("error starting remotesapi server listeners on port %d: %v", port, err)
@@ -688,6 +740,37 @@ func ConfigureServices(
 	}
 	controller.Register(RunClusterController)
 
+	var autoGCController *autogc.Controller
+	RunAutoGC := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			if cfg := serverConfig.AutoGCConfig(); cfg != nil && cfg.Enable() {
+				autoGCController = autogc.NewController(cfg, lgr)
+			}
+			return nil
+		},
+		RunF: func(ctx context.Context) {
+			if autoGCController == nil {
+				return
+			}
+			processList := sqlEngine.GetUnderlyingEngine().ProcessList
+			autoGCController.Run(ctx, func(ctx context.Context) (*sql.Context, error) {
+				sess, err := sqlEngine.NewDoltSession(ctx, sql.NewBaseSession())
+				if err != nil {
+					return nil, err
+				}
+				return sql.NewContext(ctx, sql.WithSession(sess), sql.WithProcessList(processList)), nil
+			})
+		},
+		StopF: func() error {
+			if autoGCController == nil {
+				return nil
+			}
+			autoGCController.Stop()
+			return nil
+		},
+	}
+	controller.Register(RunAutoGC)
+
 	RunSQLServer := &svcs.AnonService{
 		RunF: func(context.Context) {
 			sqlserver.SetRunningServer(mySQLServer)
@@ -970,6 +1053,7 @@ func getConfigFromServerConfig(serverConfig servercfg.ServerConfig) (server.Conf
 	serverConf.RequireSecureTransport = serverConfig.RequireSecureTransport()
 	serverConf.MaxLoggedQueryLen = serverConfig.MaxLoggedQueryLen()
 	serverConf.EncodeLoggedQuery = serverConfig.ShouldEncodeLoggedQuery()
+	serverConf.Tracer = tracer
 
 	return serverConf, nil
 }
@@ -92,7 +92,9 @@ SUPPORTED CONFIG FILE FIELDS:
 
 {{.EmphasisLeft}}behavior.autocommit{{.EmphasisRight}}: If true every statement is committed automatically. Defaults to true. @@autocommit can also be specified in each session.
 
-{{.EmphasisLeft}}behavior.dolt_transaction_commit{{.EmphasisRight}}: If true all SQL transaction commits will automatically create a Dolt commit, with a generated commit message. This is useful when a system working with Dolt wants to create versioned data, but doesn't want to directly use Dolt features such as dolt_commit(). 
+{{.EmphasisLeft}}behavior.dolt_transaction_commit{{.EmphasisRight}}: If true all SQL transaction commits will automatically create a Dolt commit, with a generated commit message. This is useful when a system working with Dolt wants to create versioned data, but doesn't want to directly use Dolt features such as dolt_commit().
+
+{{.EmphasisLeft}}behavior.auto_gc{{.EmphasisRight}}: If present, the server garbage collects its databases in the background. A database is collected when its journal grows past {{.EmphasisLeft}}journal_size_mb{{.EmphasisRight}} (default 256), when it has {{.EmphasisLeft}}table_file_count{{.EmphasisRight}} table files (default 128), or when the server has been idle for {{.EmphasisLeft}}idle_time_millis{{.EmphasisRight}} (disabled by default). The thresholds are checked every {{.EmphasisLeft}}check_interval_millis{{.EmphasisRight}} (default 60000), and {{.EmphasisLeft}}enable: false{{.EmphasisRight}} turns the collections off. The status of the collections of a database is shown in the {{.EmphasisLeft}}dolt_gc_status{{.EmphasisRight}} system table.
 
 {{.EmphasisLeft}}user.name{{.EmphasisRight}}: The username that connections should use for authentication
 
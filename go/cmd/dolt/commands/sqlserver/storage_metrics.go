@@ -0,0 +1,205 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+const branchLabel = "branch"
+
+var _ prometheus.Collector = (*storageCollector)(nil)
+
+// storageCollector is a prometheus.Collector of the storage and versioning metrics of a sql-server. Unlike the
+// metrics of metricsListener, which are updated as events happen, these metrics are read from the storage layer
+// each time they are collected.
+type storageCollector struct {
+	ctxFactory func(context.Context) (*sql.Context, error)
+
+	// mu guards sess, the session through which the databases of the server are read. It is created on the first
+	// collection and reused by later ones.
+	mu   sync.Mutex
+	sess *dsess.DoltSession
+
+	journalSize          *prometheus.Desc
+	tableFiles           *prometheus.Desc
+	journalFsyncs        *prometheus.Desc
+	journalFsyncSeconds  *prometheus.Desc
+	nodeCacheHits        *prometheus.Desc
+	nodeCacheMisses      *prometheus.Desc
+	chunkCacheHits       *prometheus.Desc
+	chunkCacheMisses     *prometheus.Desc
+	merges               *prometheus.Desc
+	mergeFailures        *prometheus.Desc
+	mergesWithConflicts  *prometheus.Desc
+	dataConflicts        *prometheus.Desc
+	schemaConflicts      *prometheus.Desc
+	constraintViolations *prometheus.Desc
+	commits              *prometheus.Desc
+	gcRuns               *prometheus.Desc
+	gcRunning            *prometheus.Desc
+	gcDuration           *prometheus.Desc
+}
+
+func newStorageCollector(labels prometheus.Labels, ctxFactory func(context.Context) (*sql.Context, error)) *storageCollector {
+	desc := func(name, help string, variableLabels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(name, help, variableLabels, labels)
+	}
+	return &storageCollector{
+		ctxFactory:           ctxFactory,
+		journalSize:          desc("dss_journal_size_bytes", "The size of the chunk journal of the database, in bytes", dbLabel),
+		tableFiles:           desc("dss_table_files", "The number of table files of the database written since its last garbage collection", dbLabel),
+		journalFsyncs:        desc("dss_journal_fsyncs_total", "Count of fsyncs of chunk journals"),
+		journalFsyncSeconds:  desc("dss_journal_fsync_seconds_total", "Total time spent in fsyncs of chunk journals, in seconds"),
+		nodeCacheHits:        desc("dss_node_cache_hits_total", "Count of prolly tree node cache hits"),
+		nodeCacheMisses:      desc("dss_node_cache_misses_total", "Count of prolly tree node cache misses"),
+		chunkCacheHits:       desc("dss_remote_chunk_cache_hits_total", "Count of remote storage chunk cache hits"),
+		chunkCacheMisses:     desc("dss_remote_chunk_cache_misses_total", "Count of remote storage chunk cache misses"),
+		merges:               desc("dss_merges_total", "Count of merges which completed, with or without conflicts"),
+		mergeFailures:        desc("dss_merge_failures_total", "Count of merges which failed with an error"),
+		mergesWithConflicts:  desc("dss_merges_with_conflicts_total", "Count of merges which completed with conflicts or constraint violations"),
+		dataConflicts:        desc("dss_merge_data_conflicts_total", "Count of data conflicts produced by merges"),
+		schemaConflicts:      desc("dss_merge_schema_conflicts_total", "Count of schema conflicts produced by merges"),
+		constraintViolations: desc("dss_merge_constraint_violations_total", "Count of constraint violations produced by merges"),
+		commits:              desc("dss_commits_total", "Count of commits made to the branch", dbLabel, branchLabel),
+		gcRuns:               desc("dss_gc_runs_total", "Count of garbage collections of the database", dbLabel),
+		gcRunning:            desc("dss_gc_running", "one if a garbage collection of the database is running, zero otherwise", dbLabel),
+		gcDuration:           desc("dss_gc_duration_seconds", "The duration of the running or last garbage collection of the database, in seconds", dbLabel),
+	}
+}
+
+func (sc *storageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sc.journalSize
+	ch <- sc.tableFiles
+	ch <- sc.journalFsyncs
+	ch <- sc.journalFsyncSeconds
+	ch <- sc.nodeCacheHits
+	ch <- sc.nodeCacheMisses
+	ch <- sc.chunkCacheHits
+	ch <- sc.chunkCacheMisses
+	ch <- sc.merges
+	ch <- sc.mergeFailures
+	ch <- sc.mergesWithConflicts
+	ch <- sc.dataConflicts
+	ch <- sc.schemaConflicts
+	ch <- sc.constraintViolations
+	ch <- sc.commits
+	ch <- sc.gcRuns
+	ch <- sc.gcRunning
+	ch <- sc.gcDuration
+}
+
+func (sc *storageCollector) Collect(ch chan<- prometheus.Metric) {
+	counter := func(desc *prometheus.Desc, v float64, labelValues ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v, labelValues...)
+	}
+	gauge := func(desc *prometheus.Desc, v float64, labelValues ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, v, labelValues...)
+	}
+
+	syncs, syncDur := nbs.JournalSyncStats()
+	counter(sc.journalFsyncs, float64(syncs))
+	counter(sc.journalFsyncSeconds, syncDur.Seconds())
+
+	hits, misses := tree.NodeCacheStats()
+	counter(sc.nodeCacheHits, float64(hits))
+	counter(sc.nodeCacheMisses, float64(misses))
+
+	hits, misses = remotestorage.ChunkCacheStats()
+	counter(sc.chunkCacheHits, float64(hits))
+	counter(sc.chunkCacheMisses, float64(misses))
+
+	mc := merge.GetMergeCounts()
+	counter(sc.merges, float64(mc.Merges))
+	counter(sc.mergeFailures, float64(mc.Failures))
+	counter(sc.mergesWithConflicts, float64(mc.MergesWithConflicts))
+	counter(sc.dataConflicts, float64(mc.DataConflicts))
+	counter(sc.schemaConflicts, float64(mc.SchemaConflicts))
+	counter(sc.constraintViolations, float64(mc.ConstraintViolations))
+
+	for _, bc := range doltdb.GetBranchCommits() {
+		counter(sc.commits, float64(bc.Commits), bc.Database, bc.Branch)
+	}
+
+	sc.collectDatabaseMetrics(gauge, counter)
+}
+
+// collectDatabaseMetrics collects the metrics of each database of the server.
+func (sc *storageCollector) collectDatabaseMetrics(gauge, counter func(*prometheus.Desc, float64, ...string)) {
+	sess, err := sc.session()
+	if err != nil {
+		logrus.Warnf("unable to collect database metrics: %s", err.Error())
+		return
+	}
+	if sess == nil {
+		return
+	}
+
+	var gcStatuses *dsess.GCStatuses
+	if controller := sess.GCSafepointController(); controller != nil {
+		gcStatuses = controller.Statuses()
+	}
+	for _, db := range sess.Provider().DoltDatabases() {
+		dbName := db.Name()
+		if ddb := db.DbData().Ddb; ddb != nil {
+			stats := ddb.StorageStats()
+			gauge(sc.journalSize, float64(stats.JournalSize), dbName)
+			gauge(sc.tableFiles, float64(stats.TableFiles), dbName)
+		}
+
+		if gcStatuses == nil {
+			continue
+		}
+		if st, ok := gcStatuses.Get(dbName); ok {
+			counter(sc.gcRuns, float64(st.Runs), dbName)
+			if st.Running {
+				gauge(sc.gcRunning, 1.0, dbName)
+				gauge(sc.gcDuration, time.Since(st.Started).Seconds(), dbName)
+			} else {
+				gauge(sc.gcRunning, 0.0, dbName)
+				gauge(sc.gcDuration, st.Finished.Sub(st.Started).Seconds(), dbName)
+			}
+		}
+	}
+}
+
+// session returns the session of |sc|, creating it with |sc.ctxFactory| on the first call. It returns nil if |sc| has
+// no ctxFactory.
+func (sc *storageCollector) session() (*dsess.DoltSession, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.sess != nil || sc.ctxFactory == nil {
+		return sc.sess, nil
+	}
+	sqlCtx, err := sc.ctxFactory(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	sc.sess = dsess.DSessFromSess(sqlCtx.Session)
+	return sc.sess, nil
+}
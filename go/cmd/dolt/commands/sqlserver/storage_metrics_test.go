@@ -0,0 +1,208 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+)
+
+func TestStorageCollector(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(newStorageCollector(prometheus.Labels{"instance": "test"}, nil)))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	names := make(map[string]bool)
+	for _, mf := range families {
+		names[mf.GetName()] = true
+		for _, m := range mf.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			assert.Equal(t, "test", labels["instance"])
+		}
+	}
+	for _, name := range []string{
+		"dss_journal_fsyncs_total",
+		"dss_journal_fsync_seconds_total",
+		"dss_node_cache_hits_total",
+		"dss_node_cache_misses_total",
+		"dss_remote_chunk_cache_hits_total",
+		"dss_remote_chunk_cache_misses_total",
+		"dss_merges_total",
+		"dss_merge_failures_total",
+		"dss_merges_with_conflicts_total",
+		"dss_merge_data_conflicts_total",
+		"dss_merge_schema_conflicts_total",
+		"dss_merge_constraint_violations_total",
+	} {
+		assert.True(t, names[name], "missing metric %s", name)
+	}
+}
+
+func TestStorageCollectorValues(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnvForLocalFilesystem()
+	dir, err := dEnv.FS.Abs("")
+	require.NoError(t, err)
+	defer os.RemoveAll(filepath.Dir(dir))
+	defer dEnv.DoltDB.Close()
+
+	mrEnv, err := env.MultiEnvForDirectory(ctx, dEnv.Config.WriteableConfig(), dEnv.FS, dEnv.Version, dEnv)
+	require.NoError(t, err)
+	eng, err := engine.NewSqlEngine(ctx, mrEnv, &engine.SqlEngineConfig{
+		ServerUser: "root",
+		ServerHost: "localhost",
+		Autocommit: true,
+	})
+	require.NoError(t, err)
+	defer eng.Close()
+	dbName := mrEnv.GetFirstDatabase()
+
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(newStorageCollector(prometheus.Labels{"instance": "test"}, eng.NewDefaultContext)))
+
+	sqlCtx, err := eng.NewLocalContext(ctx)
+	require.NoError(t, err)
+	sqlCtx.SetCurrentDatabase(dbName)
+	run := func(query string) {
+		_, iter, _, err := eng.Query(sqlCtx, query)
+		require.NoError(t, err, query)
+		for {
+			if _, err = iter.Next(sqlCtx); err == io.EOF {
+				break
+			}
+			require.NoError(t, err, query)
+		}
+		require.NoError(t, iter.Close(sqlCtx), query)
+	}
+
+	before := gatherMetrics(t, reg)
+	_, ok := before.value("dss_gc_runs_total", dbLabel, dbName)
+	assert.False(t, ok, "no garbage collection has run")
+
+	run("create table t (pk int primary key, c int)")
+	run("insert into t values (1, 1)")
+	run("call dolt_commit('-Am', 'create t')")
+	run("call dolt_checkout('-b', 'feature')")
+	run("insert into t values (2, 2)")
+	run("call dolt_commit('-am', 'feature row')")
+	run("call dolt_checkout('main')")
+	run("insert into t values (3, 3)")
+	run("call dolt_commit('-am', 'main row')")
+	run("call dolt_merge('feature')")
+
+	after := gatherMetrics(t, reg)
+	assert.Equal(t, before.valueOrZero("dss_merges_total")+1, after.valueOrZero("dss_merges_total"))
+	assert.Equal(t, before.valueOrZero("dss_merge_failures_total"), after.valueOrZero("dss_merge_failures_total"))
+	// the merge commit is counted along with the commits made on main
+	assert.Equal(t, before.valueOrZero("dss_commits_total", dbLabel, dbName, branchLabel, "main")+3,
+		after.valueOrZero("dss_commits_total", dbLabel, dbName, branchLabel, "main"))
+	assert.Equal(t, before.valueOrZero("dss_commits_total", dbLabel, dbName, branchLabel, "feature")+1,
+		after.valueOrZero("dss_commits_total", dbLabel, dbName, branchLabel, "feature"))
+	journalSize, ok := after.value("dss_journal_size_bytes", dbLabel, dbName)
+	require.True(t, ok)
+	assert.Greater(t, journalSize, before.valueOrZero("dss_journal_size_bytes", dbLabel, dbName))
+
+	run("call dolt_gc()")
+	// dolt_gc ends the session it runs in, so the remaining queries run in a new one
+	sqlCtx, err = eng.NewLocalContext(ctx)
+	require.NoError(t, err)
+	sqlCtx.SetCurrentDatabase(dbName)
+
+	afterGC := gatherMetrics(t, reg)
+	gcRuns, ok := afterGC.value("dss_gc_runs_total", dbLabel, dbName)
+	require.True(t, ok)
+	assert.Equal(t, 1.0, gcRuns)
+	assert.Equal(t, 0.0, afterGC.valueOrZero("dss_gc_running", dbLabel, dbName))
+	gcDuration, ok := afterGC.value("dss_gc_duration_seconds", dbLabel, dbName)
+	require.True(t, ok)
+	assert.Greater(t, gcDuration, 0.0)
+	assert.Less(t, afterGC.valueOrZero("dss_journal_size_bytes", dbLabel, dbName), journalSize)
+
+	// a deleted branch no longer has a series
+	run("call dolt_branch('-D', 'feature')")
+	afterDelete := gatherMetrics(t, reg)
+	_, ok = afterDelete.value("dss_commits_total", dbLabel, dbName, branchLabel, "feature")
+	assert.False(t, ok, "deleted branch is still reported")
+	_, ok = afterDelete.value("dss_commits_total", dbLabel, dbName, branchLabel, "main")
+	assert.True(t, ok)
+}
+
+// gatheredMetrics are the values of the metrics gathered from a registry, keyed by their name.
+type gatheredMetrics map[string][]gatheredMetric
+
+type gatheredMetric struct {
+	labels map[string]string
+	value  float64
+}
+
+func gatherMetrics(t *testing.T, reg *prometheus.Registry) gatheredMetrics {
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	metrics := make(gatheredMetrics)
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			gm := gatheredMetric{labels: make(map[string]string)}
+			for _, l := range m.GetLabel() {
+				gm.labels[l.GetName()] = l.GetValue()
+			}
+			if m.GetCounter() != nil {
+				gm.value = m.GetCounter().GetValue()
+			} else {
+				gm.value = m.GetGauge().GetValue()
+			}
+			metrics[mf.GetName()] = append(metrics[mf.GetName()], gm)
+		}
+	}
+	return metrics
+}
+
+// value returns the value of the metric |name| with the label values given as name, value pairs, and whether it was
+// gathered.
+func (gm gatheredMetrics) value(name string, labelPairs ...string) (float64, bool) {
+	for _, m := range gm[name] {
+		matches := true
+		for i := 0; i+1 < len(labelPairs); i += 2 {
+			if m.labels[labelPairs[i]] != labelPairs[i+1] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return m.value, true
+		}
+	}
+	return 0, false
+}
+
+func (gm gatheredMetrics) valueOrZero(name string, labelPairs ...string) float64 {
+	v, _ := gm.value(name, labelPairs...)
+	return v
+}
@@ -25,9 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
-	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
-	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
@@ -99,12 +97,7 @@ func (cmd StashPopCmd) Exec(ctx context.Context, commandStr string, args []strin
 		}
 	}
 
-	workingRoot, err := dEnv.WorkingRoot(sqlCtx)
-	if err != nil {
-		return handleStashPopErr(usage, err)
-	}
-
-	success, err := applyStashAtIdx(sqlCtx, dEnv, workingRoot, idx)
+	success, err := applyStashAtIdx(sqlCtx, dEnv, idx)
 	if err != nil {
 		return handleStashPopErr(usage, err)
 	}
@@ -124,36 +117,8 @@ func (cmd StashPopCmd) Exec(ctx context.Context, commandStr string, args []strin
 	return 0
 }
 
-func applyStashAtIdx(ctx *sql.Context, dEnv *env.DoltEnv, curWorkingRoot doltdb.RootValue, idx int) (bool, error) {
-	stashRoot, headCommit, meta, err := dEnv.DoltDB.GetStashRootAndHeadCommitAtIdx(ctx, idx)
-	if err != nil {
-		return false, err
-	}
-
-	hch, err := headCommit.HashOf()
-	if err != nil {
-		return false, err
-	}
-	headCommitSpec, err := doltdb.NewCommitSpec(hch.String())
-	if err != nil {
-		return false, err
-	}
-	headRef, err := dEnv.RepoStateReader().CWBHeadRef()
-	if err != nil {
-		return false, err
-	}
-	optCmt, err := dEnv.DoltDB.Resolve(ctx, headCommitSpec, headRef)
-	if err != nil {
-		return false, err
-	}
-	parentCommit, ok := optCmt.ToCommit()
-	if !ok {
-		// Should not be possible to get into this situation. The parent of the stashed commit
-		// Must have been present at the time it was created
-		return false, doltdb.ErrGhostCommitEncountered
-	}
-
-	parentRoot, err := parentCommit.GetRootValue(ctx)
+func applyStashAtIdx(ctx *sql.Context, dEnv *env.DoltEnv, idx int) (bool, error) {
+	roots, err := dEnv.Roots(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -164,18 +129,11 @@ func applyStashAtIdx(ctx *sql.Context, dEnv *env.DoltEnv, curWorkingRoot doltdb.
 	}
 
 	opts := editor.Options{Deaf: dEnv.BulkDbEaFactory(), Tempdir: tmpDir}
-	result, err := merge.MergeRoots(ctx, curWorkingRoot, stashRoot, parentRoot, stashRoot, parentCommit, opts, merge.MergeOpts{IsCherryPick: false})
+	roots, tablesWithConflict, err := merge.ApplyStash(ctx, dEnv.DoltDB, roots, idx, opts)
 	if err != nil {
 		return false, err
 	}
 
-	var tablesWithConflict []string
-	for tbl, stats := range result.Stats {
-		if stats.HasConflicts() {
-			tablesWithConflict = append(tablesWithConflict, tbl)
-		}
-	}
-
 	if len(tablesWithConflict) > 0 {
 		tblNames := strings.Join(tablesWithConflict, "', '")
 		cli.Printf("error: Your local changes to the following tables would be overwritten by applying stash %d:\n"+
@@ -184,23 +142,6 @@ func applyStashAtIdx(ctx *sql.Context, dEnv *env.DoltEnv, curWorkingRoot doltdb.
 		return false, nil
 	}
 
-	err = dEnv.UpdateWorkingRoot(ctx, result.Root)
-	if err != nil {
-		return false, err
-	}
-
-	roots, err := dEnv.Roots(ctx)
-	if err != nil {
-		return false, err
-	}
-
-	// added tables need to be staged
-	// since these tables are coming from a stash, don't filter for ignored table names.
-	roots, err = actions.StageTables(ctx, roots, doltdb.ToTableNames(meta.TablesToStage, doltdb.DefaultSchemaName), false)
-	if err != nil {
-		return false, err
-	}
-
 	err = dEnv.UpdateRoots(ctx, roots)
 	if err != nil {
 		return false, err
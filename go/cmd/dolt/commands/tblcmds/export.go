@@ -110,7 +110,7 @@ func getExportDestination(apr *argparser.ArgParseResults) mvdata.DataLocation {
 		if val.Format == mvdata.InvalidDataFormat {
 			val = mvdata.StreamDataLocation{Format: mvdata.CsvFile, Reader: os.Stdin, Writer: iohelp.NopWrCloser(cli.CliOut)}
 			destLoc = val
-		} else if val.Format != mvdata.CsvFile && val.Format != mvdata.PsvFile {
+		} else if val.Format != mvdata.CsvFile && val.Format != mvdata.PsvFile && val.Format != mvdata.JsonlFile && val.Format != mvdata.ArrowStreamFile {
 			cli.PrintErrln(color.RedString("Cannot export this format to stdout"))
 			return nil
 		}
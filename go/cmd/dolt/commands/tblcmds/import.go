@@ -85,6 +85,13 @@ var jsonInputFileHelp = "The expected JSON input file format is:" + `
 	}
 
 where column_name is the name of a column of the table being imported and value is the data for that column in the table.
+
+JSONL (newline delimited JSON) input files hold one JSON object per line, in the same format as each of the rows above:
+
+	{"column_name":"value", ...}
+	{"column_name":"value", ...}
+
+JSONL files are read a line at a time, so they are better suited to large imports than JSON files.
 `
 
 var importDocs = cli.CommandDocumentationContent{
@@ -103,13 +110,15 @@ If the schema for the existing table does not match the schema for the new file,
 
 A mapping file can be used to map fields between the file being imported and the table being written to. This can be used when creating a new table, or updating or replacing an existing table.
 
+The schema of a new table created from a parquet or arrow file is inferred from the types of the file's columns. When a new table is created from a parquet or arrow file without the {{.EmphasisLeft}}--continue{{.EmphasisRight}} flag, its rows are sorted and written directly to storage rather than inserted one at a time, which is much faster for large files.
+
 During import, if there is an error importing any row, the import will be aborted by default. Use the {{.EmphasisLeft}}--continue{{.EmphasisRight}} flag to continue importing when an error is encountered. You can add the {{.EmphasisLeft}}--quiet{{.EmphasisRight}} flag to prevent the import utility from printing all the skipped rows. 
 
 ` + schcmds.MappingFileHelp +
 		`
 ` + jsonInputFileHelp +
 		`
-In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, xlsx).  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimiter`,
+In create, update, and replace scenarios the file's extension is used to infer the type of the file.  If a file does not have the expected extension then the {{.EmphasisLeft}}--file-type{{.EmphasisRight}} parameter should be used to explicitly define the format of the file in one of the supported formats (csv, psv, json, jsonl, xlsx, parquet, arrow, arrows). Arrow files in the IPC file format (arrow, also used by .feather files) and the IPC stream format (arrows) are both supported, and arrow streams can also be read from stdin.  For files separated by a delimiter other than a ',' (type csv) or a '|' (type psv), the --delim parameter can be used to specify a delimiter`,
 
 	Synopsis: []string{
 		"-c [-f] [--pk {{.LessThan}}field{{.GreaterThan}}] [--all-text] [--schema {{.LessThan}}file{{.GreaterThan}}] [--map {{.LessThan}}file{{.GreaterThan}}] [--continue]  [--quiet] [--disable-fk-checks] [--file-type {{.LessThan}}type{{.GreaterThan}}] {{.LessThan}}table{{.GreaterThan}} {{.LessThan}}file{{.GreaterThan}}",
@@ -175,6 +184,17 @@ func (m importOptions) srcIsJson() bool {
 	return isJson
 }
 
+// srcIsTyped returns whether the source is a file with typed columns, such as a parquet or arrow file, whose column
+// types are used as the schema of a new table.
+func (m importOptions) srcIsTyped() bool {
+	switch m.srcOptions.(type) {
+	case mvdata.ParquetOptions, mvdata.ArrowOptions:
+		return true
+	default:
+		return false
+	}
+}
+
 func (m importOptions) srcIsStream() bool {
 	_, isStream := m.src.(mvdata.StreamDataLocation)
 	return isStream
@@ -224,10 +244,12 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 		if val.Format == mvdata.XlsxFile {
 			// table name must match sheet name currently
 			srcOpts = mvdata.XlsxOptions{SheetName: tableName}
-		} else if val.Format == mvdata.JsonFile {
+		} else if val.Format == mvdata.JsonFile || val.Format == mvdata.JsonlFile {
 			srcOpts = mvdata.JSONOptions{TableName: tableName, SchFile: schemaFile}
 		} else if val.Format == mvdata.ParquetFile {
 			srcOpts = mvdata.ParquetOptions{TableName: tableName, SchFile: schemaFile}
+		} else if val.Format == mvdata.ArrowFile || val.Format == mvdata.ArrowStreamFile {
+			srcOpts = mvdata.ArrowOptions{TableName: tableName, SchFile: schemaFile}
 		}
 
 	case mvdata.StreamDataLocation:
@@ -238,6 +260,10 @@ func getImportMoveOptions(ctx context.Context, apr *argparser.ArgParseResults, d
 
 		if hasDelim {
 			srcOpts = mvdata.CsvOptions{Delim: delim}
+		} else if val.Format == mvdata.JsonlFile {
+			srcOpts = mvdata.JSONOptions{TableName: tableName, SchFile: schemaFile}
+		} else if val.Format == mvdata.ArrowStreamFile {
+			srcOpts = mvdata.ArrowOptions{TableName: tableName, SchFile: schemaFile}
 		}
 	}
 
@@ -346,8 +372,8 @@ func validateImportArgs(apr *argparser.ArgParseResults) errhand.VerboseError {
 		_, hasSchema := apr.GetValue(schemaParam)
 		if srcFileLoc.Format == mvdata.JsonFile && apr.Contains(createParam) && !hasSchema {
 			return errhand.BuildDError("Please specify schema file for .json tables.").Build()
-		} else if srcFileLoc.Format == mvdata.ParquetFile && apr.Contains(createParam) && !hasSchema {
-			return errhand.BuildDError("Please specify schema file for .parquet tables.").Build()
+		} else if srcFileLoc.Format == mvdata.JsonlFile && apr.Contains(createParam) && !hasSchema {
+			return errhand.BuildDError("Please specify schema file for .jsonl tables.").Build()
 		}
 	}
 
@@ -488,11 +514,21 @@ func newImportDataReader(ctx context.Context, root doltdb.RootValue, dEnv *env.D
 	return rd, nil
 }
 
-func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, rdSchema schema.Schema, imOpts *importOptions) (*mvdata.SqlEngineTableWriter, *mvdata.DataMoverCreationError) {
+// importTableWriter writes the rows read from the import file to the destination table.
+type importTableWriter interface {
+	WriteRows(ctx context.Context, inputChannel chan sql.Row, badRowCb func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) bool) error
+	Commit(ctx context.Context) error
+	RowOperationSchema() sql.PrimaryKeySchema
+}
+
+var _ importTableWriter = (*mvdata.SqlEngineTableWriter)(nil)
+var _ importTableWriter = (*mvdata.BulkTableWriter)(nil)
+
+func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, rdSchema schema.Schema, imOpts *importOptions) (importTableWriter, *mvdata.DataMoverCreationError) {
 	moveOps := &mvdata.MoverOptions{Force: imOpts.force, TableToWriteTo: imOpts.destTableName, ContinueOnErr: imOpts.contOnErr, Operation: imOpts.operation, DisableFks: imOpts.disableFkChecks}
 
 	// Returns the schema of the table to be created or the existing schema
-	tableSchema, dmce := getImportSchema(ctx, dEnv, imOpts)
+	tableSchema, dmce := getImportSchema(ctx, dEnv, imOpts, rdSchema)
 	if dmce != nil {
 		return nil, dmce
 	}
@@ -537,6 +573,26 @@ func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, rdSchema sc
 		}
 	}
 
+	// parquet and arrow files can be large enough that inserting their rows through the sql engine takes hours, so new
+	// tables are written directly into their row maps when possible
+	if imOpts.srcIsTyped() {
+		root, err := dEnv.WorkingRoot(ctx)
+		if err != nil {
+			return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.CreateWriterErr, Cause: err}
+		}
+		canBulkImport, err := mvdata.CanBulkImport(ctx, root, tableSchema, rowOperationSchema, moveOps)
+		if err != nil {
+			return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.CreateWriterErr, Cause: err}
+		}
+		if canBulkImport {
+			mv, err := mvdata.NewBulkTableWriter(dEnv, tableSchema, rowOperationSchema, moveOps, importStatsCB)
+			if err != nil {
+				return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.CreateWriterErr, Cause: err}
+			}
+			return mv, nil
+		}
+	}
+
 	mv, err := mvdata.NewSqlEngineTableWriter(ctx, dEnv, tableSchema, rowOperationSchema, moveOps, importStatsCB)
 	if err != nil {
 		return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.CreateWriterErr, Cause: err}
@@ -547,8 +603,9 @@ func newImportSqlEngineMover(ctx context.Context, dEnv *env.DoltEnv, rdSchema sc
 
 type badRowFn func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) (quit bool)
 
-func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableWriter, options *importOptions) (int64, error) {
-	g, ctx := errgroup.WithContext(ctx)
+func move(ctx context.Context, rd table.SqlRowReader, wr importTableWriter, options *importOptions) (int64, error) {
+	// the group's context is canceled once the group is done, so it can't be used to commit the rows
+	g, gCtx := errgroup.WithContext(ctx)
 
 	// Set up the necessary data points for the import job
 	parsedRowChan := make(chan sql.Row)
@@ -605,12 +662,12 @@ func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableW
 	g.Go(func() error {
 		defer close(parsedRowChan)
 
-		return moveRows(ctx, wr, rd, options, parsedRowChan, badRowCB)
+		return moveRows(gCtx, wr, rd, options, parsedRowChan, badRowCB)
 	})
 
 	// Start the group that writes rows
 	g.Go(func() error {
-		err := wr.WriteRows(ctx, parsedRowChan, badRowCB)
+		err := wr.WriteRows(gCtx, parsedRowChan, badRowCB)
 		if err != nil {
 			return err
 		}
@@ -641,7 +698,7 @@ func move(ctx context.Context, rd table.SqlRowReader, wr *mvdata.SqlEngineTableW
 
 func moveRows(
 	ctx context.Context,
-	wr *mvdata.SqlEngineTableWriter,
+	wr importTableWriter,
 	rd table.SqlRowReader,
 	options *importOptions,
 	parsedRowChan chan sql.Row,
@@ -685,7 +742,7 @@ func moveRows(
 	}
 }
 
-func getImportSchema(ctx context.Context, dEnv *env.DoltEnv, impOpts *importOptions) (schema.Schema, *mvdata.DataMoverCreationError) {
+func getImportSchema(ctx context.Context, dEnv *env.DoltEnv, impOpts *importOptions, rdSchema schema.Schema) (schema.Schema, *mvdata.DataMoverCreationError) {
 	if impOpts.schFile != "" {
 		tn, out, err := mvdata.SchAndTableNameFromFile(ctx, impOpts.schFile, dEnv)
 		if err != nil {
@@ -703,6 +760,24 @@ func getImportSchema(ctx context.Context, dEnv *env.DoltEnv, impOpts *importOpti
 	}
 
 	if impOpts.operation == mvdata.CreateOp {
+		if impOpts.srcIsTyped() && !impOpts.allText {
+			// the schema of the import reader was inferred from the types of the file's columns, which works for
+			// streams as well, as the types are read before any of the rows
+			root, err := dEnv.WorkingRoot(ctx)
+			if err != nil {
+				return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.SchemaErr, Cause: err}
+			}
+			infCols := schema.MapColCollection(rdSchema.GetAllCols(), func(col schema.Column) schema.Column {
+				col.Name = impOpts.ColNameMapper().Map(col.Name)
+				return col
+			})
+			outSch, err := mvdata.SchemaFromInferredColumns(ctx, root, infCols, impOpts.destTableName, impOpts.primaryKeys)
+			if err != nil {
+				return nil, &mvdata.DataMoverCreationError{ErrType: mvdata.SchemaErr, Cause: err}
+			}
+			return outSch, nil
+		}
+
 		if impOpts.srcIsStream() {
 			// todo: capture stream data to file so we can use schema inference
 			return nil, nil
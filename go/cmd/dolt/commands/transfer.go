@@ -0,0 +1,214 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/yamux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// TransferCmd serves a database to a single client over stdin and stdout. It is run on the remote host of ssh://
+// remotes, and is not meant to be run directly.
+type TransferCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd TransferCmd) Name() string {
+	return dbfactory.TransferCommand
+}
+
+// Description returns a description of the command
+func (cmd TransferCmd) Description() string {
+	return "Serves a database over stdin and stdout to a client connected over ssh."
+}
+
+// Hidden should return true if this command should be hidden from the help text
+func (cmd TransferCmd) Hidden() bool {
+	return true
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd TransferCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd TransferCmd) Docs() *cli.CommandDocumentation {
+	return nil
+}
+
+func (cmd TransferCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+}
+
+// Exec executes the command
+func (cmd TransferCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, cli.CommandDocumentationContent{}, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	// stdout carries the session, so only warnings and errors are logged, to stderr
+	logrus.SetLevel(logrus.WarnLevel)
+	lgr := logrus.New()
+	lgr.SetOutput(cli.CliErr)
+	lgr.SetLevel(logrus.WarnLevel)
+
+	err := serveTransfer(ctx, logrus.NewEntry(lgr), apr.Arg(0), stdioConn{cli.InStream, cli.CliOut}, dEnv.Version)
+	if err != nil {
+		cli.PrintErrln(err.Error())
+		return 1
+	}
+	return 0
+}
+
+// stdioConn is the stdin and stdout of the transfer process
+type stdioConn struct {
+	io.ReadCloser
+	io.Writer
+}
+
+// serveTransfer serves the database at |path| over |conn| until the client closes it. If |path| is a dolt repository,
+// pushes to it are checked against its working set. Otherwise it is a bare database, like those of file:// remotes,
+// which is created if it does not exist.
+func serveTransfer(ctx context.Context, lgr *logrus.Entry, path string, conn io.ReadWriteCloser, version string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	dbCache := &transferDBCache{path: path}
+	fsPath := filepath.Dir(path)
+	concurrency := remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_IGNORE_WORKING_SET
+	if exists, isDir := filesys.LocalFS.Exists(filepath.Join(path, dbfactory.DoltDir)); exists && isDir {
+		repoFS, err := filesys.LocalFilesysWithWorkingDir(path)
+		if err != nil {
+			return err
+		}
+		dEnv := env.Load(ctx, env.GetCurrentUserHomeDir, repoFS, doltdb.LocalDirDoltDB, version)
+		if !dEnv.Valid() {
+			return fmt.Errorf("failed to load the repository at %s: %w", path, dEnv.DBLoadError)
+		}
+		if err = dbCache.setDB(doltdb.HackDatasDatabaseFromDoltDB(dEnv.DoltDB)); err != nil {
+			return err
+		}
+		fsPath = path
+		concurrency = remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_ASSERT_WORKING_SET
+	}
+	defer dbCache.Close()
+
+	// download urls are relative to the root of the server's filesystem, which must be above the store's directory
+	fs, err := filesys.LocalFilesysWithWorkingDir(fsPath)
+	if err != nil {
+		return err
+	}
+
+	srv, err := remotesrv.NewServer(remotesrv.ServerArgs{
+		Logger:             lgr,
+		FS:                 fs,
+		DBCache:            dbCache,
+		ConcurrencyControl: concurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	sess, err := yamux.Server(conn, dbfactory.NewTransferMuxConfig())
+	if err != nil {
+		return err
+	}
+	go srv.Serve(remotesrv.NewListeners(sess))
+	<-sess.CloseChan()
+	srv.GracefulStop()
+
+	return nil
+}
+
+// transferDBCache is the remotesrv.DBCache of a transfer. It serves the database being transferred for every
+// requested path, and opens a bare database the first time one is requested.
+type transferDBCache struct {
+	mu   sync.Mutex
+	path string
+	db   datas.Database
+	cs   remotesrv.RemoteSrvStore
+}
+
+var _ remotesrv.DBCache = (*transferDBCache)(nil)
+
+func (c *transferDBCache) Get(ctx context.Context, _, nbfVerStr string) (remotesrv.RemoteSrvStore, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cs != nil {
+		return c.cs, nil
+	}
+
+	nbf, err := types.GetFormatForVersionString(nbfVerStr)
+	if err != nil {
+		return nil, err
+	}
+	urlStr := earl.FileUrlFromPath(c.path, os.PathSeparator)
+	if err = dbfactory.PrepareDB(ctx, nbf, urlStr, nil); err != nil {
+		return nil, err
+	}
+	db, _, _, err := dbfactory.CreateDB(ctx, nbf, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.setDB(db); err != nil {
+		return nil, err
+	}
+	return c.cs, nil
+}
+
+func (c *transferDBCache) setDB(db datas.Database) error {
+	cs, ok := datas.ChunkStoreFromDatabase(db).(remotesrv.RemoteSrvStore)
+	if !ok {
+		db.Close()
+		return errors.New("database at " + c.path + " cannot be served")
+	}
+	c.db, c.cs = db, cs
+	return nil
+}
+
+func (c *transferDBCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.db == nil {
+		return nil
+	}
+	return c.db.Close()
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/tidwall/gjson"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
@@ -43,6 +44,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/admin"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/bundlecmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/cnfcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/credcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/cvcmds"
@@ -125,7 +127,14 @@ var doltSubCommands = []cli.Command{
 	commands.QueryDiff{},
 	commands.ReflogCmd{},
 	commands.RebaseCmd{},
+	commands.BisectCmd{},
 	commands.ArchiveCmd{},
+	commands.FormatPatchCmd{},
+	commands.AmCmd{},
+	commands.ApplyCmd{},
+	commands.BinlogExportCmd{},
+	commands.TransferCmd{},
+	bundlecmds.Commands,
 }
 
 var commandsWithoutCliCtx = []cli.Command{
@@ -153,6 +162,12 @@ var commandsWithoutCliCtx = []cli.Command{
 	commands.ProfileCmd{},
 	commands.ArchiveCmd{},
 	commands.FsckCmd{},
+	commands.FormatPatchCmd{},
+	commands.AmCmd{},
+	commands.ApplyCmd{},
+	commands.BinlogExportCmd{},
+	commands.TransferCmd{},
+	bundlecmds.Commands,
 }
 
 var commandsWithoutGlobalArgSupport = []cli.Command{
@@ -173,6 +188,7 @@ var commandsWithoutCurrentDirWrites = []cli.Command{
 	commands.VersionCmd{VersionStr: doltversion.Version},
 	commands.ConfigCmd{},
 	commands.ProfileCmd{},
+	commands.TransferCmd{},
 }
 
 func initCliContext(commandName string) bool {
@@ -364,6 +380,7 @@ func runMain() int {
 						)),
 					)
 					otel.SetTracerProvider(tp)
+					otel.SetTextMapPropagator(propagation.TraceContext{})
 					defer tp.Shutdown(context.Background())
 					args = args[1:]
 				}
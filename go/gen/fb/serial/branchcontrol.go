@@ -84,7 +84,23 @@ func (rcv *BranchControl) TryNamespaceTbl(obj *BranchControlNamespace) (*BranchC
 	return nil, nil
 }
 
-const BranchControlNumFields = 2
+func (rcv *BranchControl) TryProtectionTbl(obj *BranchControlProtection) (*BranchControlProtection, error) {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		x := rcv._tab.Indirect(o + rcv._tab.Pos)
+		if obj == nil {
+			obj = new(BranchControlProtection)
+		}
+		obj.Init(rcv._tab.Bytes, x)
+		if BranchControlProtectionNumFields < obj.Table().NumFields() {
+			return nil, flatbuffers.ErrTableHasUnknownFields
+		}
+		return obj, nil
+	}
+	return nil, nil
+}
+
+const BranchControlNumFields = 3
 
 func BranchControlStart(builder *flatbuffers.Builder) {
 	builder.StartObject(BranchControlNumFields)
@@ -95,6 +111,9 @@ func BranchControlAddAccessTbl(builder *flatbuffers.Builder, accessTbl flatbuffe
 func BranchControlAddNamespaceTbl(builder *flatbuffers.Builder, namespaceTbl flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(namespaceTbl), 0)
 }
+func BranchControlAddProtectionTbl(builder *flatbuffers.Builder, protectionTbl flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(protectionTbl), 0)
+}
 func BranchControlEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -694,6 +713,154 @@ func BranchControlNamespaceValueEnd(builder *flatbuffers.Builder) flatbuffers.UO
 	return builder.EndObject()
 }
 
+type BranchControlProtection struct {
+	_tab flatbuffers.Table
+}
+
+func InitBranchControlProtectionRoot(o *BranchControlProtection, buf []byte, offset flatbuffers.UOffsetT) error {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	return o.Init(buf, n+offset)
+}
+
+func TryGetRootAsBranchControlProtection(buf []byte, offset flatbuffers.UOffsetT) (*BranchControlProtection, error) {
+	x := &BranchControlProtection{}
+	return x, InitBranchControlProtectionRoot(x, buf, offset)
+}
+
+func TryGetSizePrefixedRootAsBranchControlProtection(buf []byte, offset flatbuffers.UOffsetT) (*BranchControlProtection, error) {
+	x := &BranchControlProtection{}
+	return x, InitBranchControlProtectionRoot(x, buf, offset+flatbuffers.SizeUint32)
+}
+
+func (rcv *BranchControlProtection) Init(buf []byte, i flatbuffers.UOffsetT) error {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+	if BranchControlProtectionNumFields < rcv.Table().NumFields() {
+		return flatbuffers.ErrTableHasUnknownFields
+	}
+	return nil
+}
+
+func (rcv *BranchControlProtection) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *BranchControlProtection) TryValues(obj *BranchControlProtectionValue, j int) (bool, error) {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+		obj.Init(rcv._tab.Bytes, x)
+		if BranchControlProtectionValueNumFields < obj.Table().NumFields() {
+			return false, flatbuffers.ErrTableHasUnknownFields
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (rcv *BranchControlProtection) ValuesLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+const BranchControlProtectionNumFields = 1
+
+func BranchControlProtectionStart(builder *flatbuffers.Builder) {
+	builder.StartObject(BranchControlProtectionNumFields)
+}
+func BranchControlProtectionAddValues(builder *flatbuffers.Builder, values flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(values), 0)
+}
+func BranchControlProtectionStartValuesVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
+func BranchControlProtectionEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
+
+type BranchControlProtectionValue struct {
+	_tab flatbuffers.Table
+}
+
+func InitBranchControlProtectionValueRoot(o *BranchControlProtectionValue, buf []byte, offset flatbuffers.UOffsetT) error {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	return o.Init(buf, n+offset)
+}
+
+func TryGetRootAsBranchControlProtectionValue(buf []byte, offset flatbuffers.UOffsetT) (*BranchControlProtectionValue, error) {
+	x := &BranchControlProtectionValue{}
+	return x, InitBranchControlProtectionValueRoot(x, buf, offset)
+}
+
+func TryGetSizePrefixedRootAsBranchControlProtectionValue(buf []byte, offset flatbuffers.UOffsetT) (*BranchControlProtectionValue, error) {
+	x := &BranchControlProtectionValue{}
+	return x, InitBranchControlProtectionValueRoot(x, buf, offset+flatbuffers.SizeUint32)
+}
+
+func (rcv *BranchControlProtectionValue) Init(buf []byte, i flatbuffers.UOffsetT) error {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+	if BranchControlProtectionValueNumFields < rcv.Table().NumFields() {
+		return flatbuffers.ErrTableHasUnknownFields
+	}
+	return nil
+}
+
+func (rcv *BranchControlProtectionValue) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *BranchControlProtectionValue) Database() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *BranchControlProtectionValue) Branch() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *BranchControlProtectionValue) RequireMerge() bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.GetBool(o + rcv._tab.Pos)
+	}
+	return false
+}
+
+func (rcv *BranchControlProtectionValue) MutateRequireMerge(n bool) bool {
+	return rcv._tab.MutateBoolSlot(8, n)
+}
+
+const BranchControlProtectionValueNumFields = 3
+
+func BranchControlProtectionValueStart(builder *flatbuffers.Builder) {
+	builder.StartObject(BranchControlProtectionValueNumFields)
+}
+func BranchControlProtectionValueAddDatabase(builder *flatbuffers.Builder, database flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(database), 0)
+}
+func BranchControlProtectionValueAddBranch(builder *flatbuffers.Builder, branch flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(branch), 0)
+}
+func BranchControlProtectionValueAddRequireMerge(builder *flatbuffers.Builder, requireMerge bool) {
+	builder.PrependBoolSlot(2, requireMerge, false)
+}
+func BranchControlProtectionValueEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
+
 type BranchControlBinlog struct {
 	_tab flatbuffers.Table
 }
@@ -852,7 +1019,23 @@ func (rcv *BranchControlBinlogRow) MutatePermissions(n uint64) bool {
 	return rcv._tab.MutateUint64Slot(14, n)
 }
 
-const BranchControlBinlogRowNumFields = 6
+func (rcv *BranchControlBinlogRow) TableName() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *BranchControlBinlogRow) ColumnName() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(18))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+const BranchControlBinlogRowNumFields = 8
 
 func BranchControlBinlogRowStart(builder *flatbuffers.Builder) {
 	builder.StartObject(BranchControlBinlogRowNumFields)
@@ -875,6 +1058,12 @@ func BranchControlBinlogRowAddHost(builder *flatbuffers.Builder, host flatbuffer
 func BranchControlBinlogRowAddPermissions(builder *flatbuffers.Builder, permissions uint64) {
 	builder.PrependUint64Slot(5, permissions, 0)
 }
+func BranchControlBinlogRowAddTableName(builder *flatbuffers.Builder, tableName flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(6, flatbuffers.UOffsetT(tableName), 0)
+}
+func BranchControlBinlogRowAddColumnName(builder *flatbuffers.Builder, columnName flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(7, flatbuffers.UOffsetT(columnName), 0)
+}
 func BranchControlBinlogRowEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
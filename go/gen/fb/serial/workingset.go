@@ -188,7 +188,23 @@ func (rcv *WorkingSet) TryRebaseState(obj *RebaseState) (*RebaseState, error) {
 	return nil, nil
 }
 
-const WorkingSetNumFields = 8
+func (rcv *WorkingSet) TryBisectState(obj *BisectState) (*BisectState, error) {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(20))
+	if o != 0 {
+		x := rcv._tab.Indirect(o + rcv._tab.Pos)
+		if obj == nil {
+			obj = new(BisectState)
+		}
+		obj.Init(rcv._tab.Bytes, x)
+		if BisectStateNumFields < obj.Table().NumFields() {
+			return nil, flatbuffers.ErrTableHasUnknownFields
+		}
+		return obj, nil
+	}
+	return nil, nil
+}
+
+const WorkingSetNumFields = 9
 
 func WorkingSetStart(builder *flatbuffers.Builder) {
 	builder.StartObject(WorkingSetNumFields)
@@ -223,6 +239,9 @@ func WorkingSetAddMergeState(builder *flatbuffers.Builder, mergeState flatbuffer
 func WorkingSetAddRebaseState(builder *flatbuffers.Builder, rebaseState flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(7, flatbuffers.UOffsetT(rebaseState), 0)
 }
+func WorkingSetAddBisectState(builder *flatbuffers.Builder, bisectState flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(8, flatbuffers.UOffsetT(bisectState), 0)
+}
 func WorkingSetEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -579,7 +598,19 @@ func (rcv *RebaseState) MutateRebasingStarted(n bool) bool {
 	return rcv._tab.MutateBoolSlot(16, n)
 }
 
-const RebaseStateNumFields = 7
+func (rcv *RebaseState) ConflictStrategy() byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(18))
+	if o != 0 {
+		return rcv._tab.GetByte(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *RebaseState) MutateConflictStrategy(n byte) bool {
+	return rcv._tab.MutateByteSlot(18, n)
+}
+
+const RebaseStateNumFields = 8
 
 func RebaseStateStart(builder *flatbuffers.Builder) {
 	builder.StartObject(RebaseStateNumFields)
@@ -614,6 +645,170 @@ func RebaseStateAddLastAttemptedStep(builder *flatbuffers.Builder, lastAttempted
 func RebaseStateAddRebasingStarted(builder *flatbuffers.Builder, rebasingStarted bool) {
 	builder.PrependBoolSlot(6, rebasingStarted, false)
 }
+func RebaseStateAddConflictStrategy(builder *flatbuffers.Builder, conflictStrategy byte) {
+	builder.PrependByteSlot(7, conflictStrategy, 0)
+}
 func RebaseStateEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
+
+type BisectState struct {
+	_tab flatbuffers.Table
+}
+
+func InitBisectStateRoot(o *BisectState, buf []byte, offset flatbuffers.UOffsetT) error {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	return o.Init(buf, n+offset)
+}
+
+func TryGetRootAsBisectState(buf []byte, offset flatbuffers.UOffsetT) (*BisectState, error) {
+	x := &BisectState{}
+	return x, InitBisectStateRoot(x, buf, offset)
+}
+
+func TryGetSizePrefixedRootAsBisectState(buf []byte, offset flatbuffers.UOffsetT) (*BisectState, error) {
+	x := &BisectState{}
+	return x, InitBisectStateRoot(x, buf, offset+flatbuffers.SizeUint32)
+}
+
+func (rcv *BisectState) Init(buf []byte, i flatbuffers.UOffsetT) error {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+	if BisectStateNumFields < rcv.Table().NumFields() {
+		return flatbuffers.ErrTableHasUnknownFields
+	}
+	return nil
+}
+
+func (rcv *BisectState) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *BisectState) BadCommitAddr(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *BisectState) BadCommitAddrLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *BisectState) BadCommitAddrBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *BisectState) MutateBadCommitAddr(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
+func (rcv *BisectState) GoodCommitAddrs(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *BisectState) GoodCommitAddrsLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *BisectState) GoodCommitAddrsBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *BisectState) MutateGoodCommitAddrs(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
+func (rcv *BisectState) SkippedCommitAddrs(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *BisectState) SkippedCommitAddrsLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *BisectState) SkippedCommitAddrsBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *BisectState) MutateSkippedCommitAddrs(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
+const BisectStateNumFields = 3
+
+func BisectStateStart(builder *flatbuffers.Builder) {
+	builder.StartObject(BisectStateNumFields)
+}
+func BisectStateAddBadCommitAddr(builder *flatbuffers.Builder, badCommitAddr flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(badCommitAddr), 0)
+}
+func BisectStateStartBadCommitAddrVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
+func BisectStateAddGoodCommitAddrs(builder *flatbuffers.Builder, goodCommitAddrs flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(goodCommitAddrs), 0)
+}
+func BisectStateStartGoodCommitAddrsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
+func BisectStateAddSkippedCommitAddrs(builder *flatbuffers.Builder, skippedCommitAddrs flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(skippedCommitAddrs), 0)
+}
+func BisectStateStartSkippedCommitAddrsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
+func BisectStateEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bisect
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// Result describes the current position of a bisect. Exactly one of Next, FirstBad, or OnlySkipped is set.
+type Result struct {
+	// Next is the commit that should be tested next.
+	Next *doltdb.Commit
+	// Remaining is the number of commits that are left to test after Next, in the worst case.
+	Remaining int
+
+	// FirstBad is the first bad commit, set once the bisect has narrowed the candidates down to a single commit.
+	FirstBad *doltdb.Commit
+
+	// OnlySkipped is set when every commit left to test has been skipped. It holds the commits that could be the
+	// first bad commit, including the commit currently marked as bad.
+	OnlySkipped []*doltdb.Commit
+}
+
+// Steps returns a rough estimate of the number of steps left after testing Next.
+func (r *Result) Steps() int {
+	return bits.Len(uint(r.Remaining))
+}
+
+// Next examines the commits marked in |state| and determines how to proceed with the bisect. The commits that could
+// still be the first bad commit are the commits reachable from the bad commit that are not reachable from any of the
+// good commits, the same as the "two dot log" of good..bad. |state| must have a bad commit and at least one good
+// commit marked.
+func Next(ctx context.Context, ddb *doltdb.DoltDB, state *doltdb.BisectState) (*Result, error) {
+	bad := state.BadCommit()
+	if bad.IsEmpty() || len(state.GoodCommits()) == 0 {
+		return nil, fmt.Errorf("bisect requires a bad commit and at least one good commit")
+	}
+
+	optCmts, err := commitwalk.GetDotDotRevisions(ctx, ddb, []hash.Hash{bad}, ddb, state.GoodCommits(), -1)
+	if err != nil {
+		return nil, err
+	}
+	if len(optCmts) == 0 {
+		return nil, fmt.Errorf("error: the bad commit is an ancestor of a good commit")
+	}
+
+	candidates := make([]hash.Hash, len(optCmts))
+	commits := make(map[hash.Hash]*doltdb.Commit, len(optCmts))
+	for i, optCmt := range optCmts {
+		commit, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		candidates[i], err = commit.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		commits[candidates[i]] = commit
+	}
+
+	if len(candidates) == 1 {
+		return &Result{FirstBad: commits[bad]}, nil
+	}
+
+	parents := make(map[hash.Hash][]hash.Hash, len(candidates))
+	for _, h := range candidates {
+		parentHashes, err := commits[h].ParentHashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range parentHashes {
+			if _, ok := commits[p]; ok {
+				parents[h] = append(parents[h], p)
+			}
+		}
+	}
+
+	skipped := make(map[hash.Hash]struct{}, len(state.SkippedCommits()))
+	for _, h := range state.SkippedCommits() {
+		skipped[h] = struct{}{}
+	}
+	testable := func(h hash.Hash) bool {
+		_, isSkipped := skipped[h]
+		return h != bad && !isSkipped
+	}
+
+	next, weight, ok := findMidpoint(candidates, parents, testable)
+	if !ok {
+		var onlySkipped []*doltdb.Commit
+		for _, h := range candidates {
+			onlySkipped = append(onlySkipped, commits[h])
+		}
+		return &Result{OnlySkipped: onlySkipped}, nil
+	}
+
+	// If |next| is bad, its ancestors other than itself are left to test. If it's good, everything else except the
+	// bad commit is left to test.
+	remaining := weight - 1
+	if len(candidates)-weight-1 > remaining {
+		remaining = len(candidates) - weight - 1
+	}
+
+	return &Result{Next: commits[next], Remaining: remaining}, nil
+}
+
+// findMidpoint returns the testable commit in |candidates| that splits the candidates most evenly, along with the
+// number of candidates reachable from it (including itself). A commit's weight is the number of candidates that are
+// its ancestors, so the best commit to test is the one that maximizes min(weight, len(candidates)-weight). Ties are
+// broken by the order of |candidates|. |parents| maps each candidate to its parents that are also candidates. Returns
+// false if none of the candidates are testable.
+func findMidpoint(candidates []hash.Hash, parents map[hash.Hash][]hash.Hash, testable func(hash.Hash) bool) (hash.Hash, int, bool) {
+	var best hash.Hash
+	bestScore, bestWeight := -1, 0
+	for _, h := range candidates {
+		if !testable(h) {
+			continue
+		}
+
+		weight := countAncestors(h, parents)
+		score := weight
+		if len(candidates)-weight < score {
+			score = len(candidates) - weight
+		}
+		if score > bestScore {
+			best, bestScore, bestWeight = h, score, weight
+		}
+	}
+
+	return best, bestWeight, bestScore >= 0
+}
+
+// countAncestors returns the number of commits reachable from |start| through |parents|, including |start|.
+func countAncestors(start hash.Hash, parents map[hash.Hash][]hash.Hash) int {
+	seen := map[hash.Hash]struct{}{start: {}}
+	stack := []hash.Hash{start}
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, p := range parents[h] {
+			if _, ok := seen[p]; !ok {
+				seen[p] = struct{}{}
+				stack = append(stack, p)
+			}
+		}
+	}
+	return len(seen)
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bisect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestFindMidpoint(t *testing.T) {
+	c := make([]hash.Hash, 8)
+	for i := range c {
+		c[i] = hash.Of([]byte{byte(i)})
+	}
+	allTestable := func(h hash.Hash) bool { return h != c[0] }
+
+	t.Run("linear history", func(t *testing.T) {
+		// c0 (bad) -> c1 -> c2 -> c3 -> c4
+		candidates := c[:5]
+		parents := map[hash.Hash][]hash.Hash{
+			c[0]: {c[1]},
+			c[1]: {c[2]},
+			c[2]: {c[3]},
+			c[3]: {c[4]},
+		}
+		next, weight, ok := findMidpoint(candidates, parents, allTestable)
+		require.True(t, ok)
+		assert.Equal(t, c[2], next)
+		assert.Equal(t, 3, weight)
+	})
+
+	t.Run("merge history", func(t *testing.T) {
+		// c0 (bad) merges c1 and c4; c1 -> c2 -> c3 and c4 -> c5
+		candidates := c[:6]
+		parents := map[hash.Hash][]hash.Hash{
+			c[0]: {c[1], c[4]},
+			c[1]: {c[2]},
+			c[2]: {c[3]},
+			c[4]: {c[5]},
+		}
+		next, weight, ok := findMidpoint(candidates, parents, allTestable)
+		require.True(t, ok)
+		assert.Equal(t, c[1], next)
+		assert.Equal(t, 3, weight)
+	})
+
+	t.Run("skipped commits", func(t *testing.T) {
+		candidates := c[:5]
+		parents := map[hash.Hash][]hash.Hash{
+			c[0]: {c[1]},
+			c[1]: {c[2]},
+			c[2]: {c[3]},
+			c[3]: {c[4]},
+		}
+		next, _, ok := findMidpoint(candidates, parents, func(h hash.Hash) bool {
+			return h != c[0] && h != c[2]
+		})
+		require.True(t, ok)
+		assert.Equal(t, c[3], next)
+
+		_, _, ok = findMidpoint(candidates, parents, func(h hash.Hash) bool {
+			return false
+		})
+		assert.False(t, ok)
+	})
+}
@@ -36,14 +36,41 @@ const (
 	Permissions_None Permissions = 0 // Permissions_None represents a lack of permissions, which defaults to allowing reading
 )
 
+// Scope is the portion of a branch that an entry applies to. Entries with a narrower scope take precedence over those
+// with a wider scope.
+type Scope uint8
+
+const (
+	Scope_Branch Scope = iota // Scope_Branch entries apply to every table and column on a branch
+	Scope_Table               // Scope_Table entries apply to every column of the matching tables
+	Scope_Column              // Scope_Column entries apply to the matching columns of the matching tables
+)
+
+// Allows returns whether the permissions, belonging to an entry with the given scope, allow for the given flags. Admin
+// allows for everything. Reading is always allowed by entries that apply to an entire branch, while entries that are
+// scoped to tables or columns only allow reading when they have any permissions.
+func (perms Permissions) Allows(flags Permissions, scope Scope) bool {
+	if perms&Permissions_Admin == Permissions_Admin {
+		return true
+	}
+	if flags&Permissions_Read == Permissions_Read {
+		if scope != Scope_Branch && perms == Permissions_None {
+			return false
+		}
+		flags &^= Permissions_Read
+	}
+	return perms&flags == flags
+}
+
 // Access contains all of the expressions that comprise the "dolt_branch_control" table, which handles write Access to
 // branches, along with write access to the branch control system tables.
 type Access struct {
-	Root     *MatchNode
-	RWMutex  *sync.RWMutex
-	binlog   *Binlog
-	rows     []AccessRow
-	freeRows []uint32
+	Root       *MatchNode
+	RWMutex    *sync.RWMutex
+	binlog     *Binlog
+	rows       []AccessRow
+	freeRows   []uint32
+	scopedRows int
 }
 
 // AccessRow contains the user-facing values of a particular row, along with the permissions for a row.
@@ -52,6 +79,8 @@ type AccessRow struct {
 	Branch      string
 	User        string
 	Host        string
+	Table       string
+	Column      string
 	Permissions Permissions
 }
 
@@ -68,21 +97,53 @@ func newAccess() *Access {
 	}
 }
 
-// Match returns whether any entries match the given database, branch, user, and host, along with their permissions.
-// Requires external synchronization handling, therefore manually manage the RWMutex.
+// Match returns whether any entries that apply to an entire branch match the given database, branch, user, and host,
+// along with their permissions. Requires external synchronization handling, therefore manually manage the RWMutex.
 func (tbl *Access) Match(database string, branch string, user string, host string) (bool, Permissions) {
-	results := tbl.Root.Match(database, branch, user, host)
-	// We use the result(s) with the longest length
+	ok, perms, _ := tbl.MatchTable(database, branch, user, host, "", "")
+	return ok, perms
+}
+
+// MatchTable returns whether any entries match the given database, branch, user, host, table, and column, along with
+// the permissions and scope of the most specific entries. An empty table only matches entries that apply to every
+// table, while an empty column only matches entries that apply to every column. Requires external synchronization
+// handling, therefore manually manage the RWMutex.
+func (tbl *Access) MatchTable(database string, branch string, user string, host string, table string, column string) (bool, Permissions, Scope) {
+	results := tbl.Root.Match(database, branch, user, host, table, column)
+	// We use the result(s) with the narrowest scope, and then the longest length
+	scope := Scope_Branch
 	length := uint32(0)
 	perms := Permissions_None
 	for _, result := range results {
-		if result.Length > length {
+		resultScope := tbl.rows[result.RowIndex].Scope()
+		if resultScope > scope || (resultScope == scope && result.Length > length) {
+			scope = resultScope
+			length = result.Length
 			perms = result.Permissions
-		} else if result.Length == length {
+		} else if resultScope == scope && result.Length == length {
 			perms |= result.Permissions
 		}
 	}
-	return len(results) > 0, perms
+	return len(results) > 0, perms, scope
+}
+
+// Covers returns whether an existing entry of the same scope matches every value that is matched by the given
+// expressions, along with the permissions of such an entry. Assumes that the given expressions have already been
+// folded. Requires external synchronization handling, therefore manually manage the RWMutex.
+func (tbl *Access) Covers(database string, branch string, user string, host string, table string, column string) (bool, Permissions) {
+	scope := AccessRow{Table: table, Column: column}.Scope()
+	for _, result := range tbl.Root.Match(database, branch, user, host, table, column) {
+		if tbl.rows[result.RowIndex].Scope() == scope {
+			return true, result.Permissions
+		}
+	}
+	return false, Permissions_None
+}
+
+// HasScopedRows returns whether any entries are scoped to tables or columns. When there are none, reading is allowed
+// on every table and column. Requires external synchronization handling, therefore manually manage the RWMutex.
+func (tbl *Access) HasScopedRows() bool {
+	return tbl.scopedRows > 0
 }
 
 // GetBinlog returns the table's binlog.
@@ -108,6 +169,7 @@ func (tbl *Access) reinit() {
 	tbl.binlog = NewAccessBinlog(nil)
 	tbl.rows = nil
 	tbl.freeRows = nil
+	tbl.scopedRows = 0
 }
 
 // Deserialize populates the table with the data from the flatbuffers representation.
@@ -127,9 +189,9 @@ func (tbl *Access) Deserialize(fb *serial.BranchControlAccess) error {
 	// Recreate the table from the binlog
 	for _, binlogRow := range binlog.rows {
 		if binlogRow.IsInsert {
-			tbl.Insert(binlogRow.Database, binlogRow.Branch, binlogRow.User, binlogRow.Host, Permissions(binlogRow.Permissions))
+			tbl.Insert(binlogRow.Database, binlogRow.Branch, binlogRow.User, binlogRow.Host, binlogRow.Table, binlogRow.Column, Permissions(binlogRow.Permissions))
 		} else {
-			tbl.Delete(binlogRow.Database, binlogRow.Branch, binlogRow.User, binlogRow.Host)
+			tbl.Delete(binlogRow.Database, binlogRow.Branch, binlogRow.User, binlogRow.Host, binlogRow.Table, binlogRow.Column)
 		}
 	}
 	return nil
@@ -139,46 +201,32 @@ func (tbl *Access) Deserialize(fb *serial.BranchControlAccess) error {
 // modify any branch control tables. This was the default behavior of Dolt before the introduction of branch permissions.
 func (tbl *Access) insertDefaultRow() {
 	tbl.reinit()
-	tbl.Insert("%", "%", "%", "%", Permissions_Write)
+	tbl.Insert("%", "%", "%", "%", "%", "%", Permissions_Write)
 }
 
 // Insert adds the given expressions to the table. This does not perform any sort of validation whatsoever, so it is
 // important to ensure that the expressions are valid before insertion. Folds all strings that are given. Overwrites any
 // existing entries with the new permissions. Requires external synchronization handling, therefore manually manage the
 // RWMutex.
-func (tbl *Access) Insert(database string, branch string, user string, host string, perms Permissions) {
-	// Database, Branch, and Host are case-insensitive, while User is case-sensitive
-	database = strings.ToLower(FoldExpression(database))
-	branch = strings.ToLower(FoldExpression(branch))
-	user = FoldExpression(user)
-	host = strings.ToLower(FoldExpression(host))
-	// Each expression is capped at 2¹⁶-1 values, so we truncate to 2¹⁶-2 and add the any-match character at the end if it's over
-	if len(database) > math.MaxUint16 {
-		database = string(append([]byte(database[:math.MaxUint16-1]), byte('%')))
-	}
-	if len(branch) > math.MaxUint16 {
-		branch = string(append([]byte(branch[:math.MaxUint16-1]), byte('%')))
-	}
-	if len(user) > math.MaxUint16 {
-		user = string(append([]byte(user[:math.MaxUint16-1]), byte('%')))
-	}
-	if len(host) > math.MaxUint16 {
-		host = string(append([]byte(host[:math.MaxUint16-1]), byte('%')))
-	}
+func (tbl *Access) Insert(database string, branch string, user string, host string, table string, column string, perms Permissions) {
+	database, branch, user, host, table, column = foldAccessExpressions(database, branch, user, host, table, column)
 	// Add the insertion entry to the binlog
-	tbl.binlog.Insert(database, branch, user, host, uint64(perms))
+	tbl.binlog.Insert(database, branch, user, host, table, column, uint64(perms))
 	// Add to the rows and grab the insertion index
+	row := AccessRow{
+		Database:    database,
+		Branch:      branch,
+		User:        user,
+		Host:        host,
+		Table:       table,
+		Column:      column,
+		Permissions: perms,
+	}
 	var index uint32
 	if len(tbl.freeRows) > 0 {
 		index = tbl.freeRows[len(tbl.freeRows)-1]
 		tbl.freeRows = tbl.freeRows[:len(tbl.freeRows)-1]
-		tbl.rows[index] = AccessRow{
-			Database:    database,
-			Branch:      branch,
-			User:        user,
-			Host:        host,
-			Permissions: perms,
-		}
+		tbl.rows[index] = row
 	} else {
 		if len(tbl.rows) >= math.MaxUint32 {
 			// If someone has this many branches in Dolt then they're doing something very interesting, we'll probably
@@ -186,51 +234,61 @@ func (tbl *Access) Insert(database string, branch string, user string, host stri
 			panic(fmt.Errorf("branch control has a maximum limit of %d branches", math.MaxUint32-1))
 		}
 		index = uint32(len(tbl.rows))
-		tbl.rows = append(tbl.rows, AccessRow{
-			Database:    database,
-			Branch:      branch,
-			User:        user,
-			Host:        host,
-			Permissions: perms,
-		})
+		tbl.rows = append(tbl.rows, row)
 	}
-	// Add the entry to the root node
-	tbl.Root.Add(database, branch, user, host, MatchNodeData{
+	// Add the entry to the root node, which reports the index of any entry that we've overwritten
+	if replacedIndex := tbl.Root.Add(database, branch, user, host, table, column, MatchNodeData{
 		Permissions: perms,
 		RowIndex:    index,
-	})
+	}); replacedIndex != math.MaxUint32 {
+		tbl.removeRow(replacedIndex)
+	}
+	if row.Scope() != Scope_Branch {
+		tbl.scopedRows++
+	}
 }
 
 // Delete removes the given expressions from the table. This does not perform any sort of validation whatsoever, so it
 // is important to ensure that the expressions are valid before deletion. Folds all strings that are given. Requires
 // external synchronization handling, therefore manually manage the RWMutex.
-func (tbl *Access) Delete(database string, branch string, user string, host string) {
-	// Database, Branch, and Host are case-insensitive, while User is case-sensitive
-	database = strings.ToLower(FoldExpression(database))
-	branch = strings.ToLower(FoldExpression(branch))
-	user = FoldExpression(user)
-	host = strings.ToLower(FoldExpression(host))
-	// Each expression is capped at 2¹⁶-1 values, so we truncate to 2¹⁶-2 and add the any-match character at the end if it's over
-	if len(database) > math.MaxUint16 {
-		database = string(append([]byte(database[:math.MaxUint16-1]), byte('%')))
-	}
-	if len(branch) > math.MaxUint16 {
-		branch = string(append([]byte(branch[:math.MaxUint16-1]), byte('%')))
-	}
-	if len(user) > math.MaxUint16 {
-		user = string(append([]byte(user[:math.MaxUint16-1]), byte('%')))
-	}
-	if len(host) > math.MaxUint16 {
-		host = string(append([]byte(host[:math.MaxUint16-1]), byte('%')))
-	}
+func (tbl *Access) Delete(database string, branch string, user string, host string, table string, column string) {
+	database, branch, user, host, table, column = foldAccessExpressions(database, branch, user, host, table, column)
 	// Add the deletion entry to the binlog
-	tbl.binlog.Delete(database, branch, user, host, uint64(Permissions_None))
+	tbl.binlog.Delete(database, branch, user, host, table, column, uint64(Permissions_None))
 	// Remove the entry from the root node
-	removedIndex := tbl.Root.Remove(database, branch, user, host)
+	removedIndex := tbl.Root.Remove(database, branch, user, host, table, column)
 	// Remove from the rows
 	if removedIndex != math.MaxUint32 {
-		tbl.freeRows = append(tbl.freeRows, removedIndex)
+		tbl.removeRow(removedIndex)
+	}
+}
+
+// removeRow marks the row at the given index as free.
+func (tbl *Access) removeRow(index uint32) {
+	if tbl.rows[index].Scope() != Scope_Branch {
+		tbl.scopedRows--
+	}
+	tbl.freeRows = append(tbl.freeRows, index)
+}
+
+// foldAccessExpressions folds the given expressions, and truncates any that are too long. Database, Branch, Host,
+// Table, and Column are case-insensitive, while User is case-sensitive.
+func foldAccessExpressions(database, branch, user, host, table, column string) (string, string, string, string, string, string) {
+	return truncateExpression(strings.ToLower(FoldExpression(database))),
+		truncateExpression(strings.ToLower(FoldExpression(branch))),
+		truncateExpression(FoldExpression(user)),
+		truncateExpression(strings.ToLower(FoldExpression(host))),
+		truncateExpression(strings.ToLower(FoldExpression(table))),
+		truncateExpression(strings.ToLower(FoldExpression(column)))
+}
+
+// truncateExpression caps the given expression at 2¹⁶-1 values. Expressions that are over are truncated to 2¹⁶-2
+// values, with the any-match character added at the end.
+func truncateExpression(expr string) string {
+	if len(expr) > math.MaxUint16 {
+		return string(append([]byte(expr[:math.MaxUint16-1]), byte('%')))
 	}
+	return expr
 }
 
 // Iter returns an iterator that goes over all valid rows. The iterator does not acquire a read lock, therefore this
@@ -258,3 +316,15 @@ OuterLoop:
 	}
 	return AccessRow{}, false
 }
+
+// Scope returns the scope of the row, which is determined by its table and column expressions.
+func (row AccessRow) Scope() Scope {
+	switch {
+	case row.Column != "%":
+		return Scope_Column
+	case row.Table != "%":
+		return Scope_Table
+	default:
+		return Scope_Branch
+	}
+}
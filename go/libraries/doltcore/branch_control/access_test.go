@@ -0,0 +1,94 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branch_control
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessMatchTable(t *testing.T) {
+	access := newAccess()
+	access.reinit()
+	access.Insert("%", "%", "%", "%", "%", "%", Permissions_Read)
+	access.Insert("%", "main", "user", "%", "orders_%", "%", Permissions_Write)
+	access.Insert("%", "main", "user", "%", "customers", "%", Permissions_Write)
+	access.Insert("%", "main", "user", "%", "customers", "ssn", Permissions_Read)
+	access.Insert("%", "release%", "%", "%", "%", "ssn", Permissions_None)
+	access.Insert("%", "main", "user", "%", "%_log", "%", Permissions_Write)
+	require.True(t, access.HasScopedRows())
+
+	tests := []struct {
+		branch string
+		table  string
+		column string
+		perms  Permissions
+		scope  Scope
+	}{
+		{"main", "", "", Permissions_Read, Scope_Branch},
+		{"main", "orders_1", "", Permissions_Write, Scope_Table},
+		{"main", "orders_1", "id", Permissions_Write, Scope_Table},
+		{"main", "customers", "", Permissions_Write, Scope_Table},
+		{"main", "customers", "name", Permissions_Write, Scope_Table},
+		{"main", "customers", "ssn", Permissions_Read, Scope_Column},
+		{"main", "access_log", "", Permissions_Write, Scope_Table},
+		{"main", "products", "", Permissions_Read, Scope_Branch},
+		{"release1", "customers", "name", Permissions_Read, Scope_Branch},
+		{"release1", "customers", "ssn", Permissions_None, Scope_Column},
+	}
+	for _, test := range tests {
+		t.Run(test.branch+"."+test.table+"."+test.column, func(t *testing.T) {
+			ok, perms, scope := access.MatchTable("mydb", test.branch, "user", "localhost", test.table, test.column)
+			require.True(t, ok)
+			assert.Equal(t, test.perms, perms)
+			assert.Equal(t, test.scope, scope)
+		})
+	}
+
+	// Branch-wide matching ignores the table and column entries
+	ok, perms := access.Match("mydb", "release1", "user", "localhost")
+	require.True(t, ok)
+	assert.Equal(t, Permissions_Read, perms)
+}
+
+func TestAccessCovers(t *testing.T) {
+	access := newAccess()
+	access.reinit()
+	access.Insert("%", "%", "%", "%", "%", "%", Permissions_Read)
+	access.Insert("%", "main", "%", "%", "orders_%", "%", Permissions_Write)
+
+	ok, _ := access.Covers("mydb", "main", "user", "localhost", "%", "%")
+	assert.True(t, ok)
+	ok, perms := access.Covers("mydb", "main", "user", "localhost", "orders_1", "%")
+	assert.True(t, ok)
+	assert.Equal(t, Permissions_Write, perms)
+	// A narrower scope is not covered by the entries of a wider scope
+	ok, _ = access.Covers("mydb", "main", "user", "localhost", "orders_1", "id")
+	assert.False(t, ok)
+	ok, _ = access.Covers("mydb", "main", "user", "localhost", "customers", "%")
+	assert.False(t, ok)
+}
+
+func TestAllows(t *testing.T) {
+	assert.True(t, Permissions_None.Allows(Permissions_Read, Scope_Branch))
+	assert.False(t, Permissions_None.Allows(Permissions_Read, Scope_Table))
+	assert.False(t, Permissions_None.Allows(Permissions_Read, Scope_Column))
+	assert.True(t, Permissions_Read.Allows(Permissions_Read, Scope_Column))
+	assert.False(t, Permissions_Read.Allows(Permissions_Write, Scope_Table))
+	assert.True(t, Permissions_Write.Allows(Permissions_Read|Permissions_Write, Scope_Column))
+	assert.True(t, Permissions_Admin.Allows(Permissions_Read|Permissions_Write, Scope_Column))
+}
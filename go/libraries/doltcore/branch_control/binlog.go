@@ -39,6 +39,8 @@ type BinlogRow struct {
 	Branch      string
 	User        string
 	Host        string
+	Table       string
+	Column      string
 	Permissions uint64
 }
 
@@ -60,6 +62,8 @@ func NewAccessBinlog(vals []AccessRow) *Binlog {
 			Branch:      val.Branch,
 			User:        val.User,
 			Host:        val.Host,
+			Table:       val.Table,
+			Column:      val.Column,
 			Permissions: uint64(val.Permissions),
 		}
 	}
@@ -136,14 +140,23 @@ func (binlog *Binlog) Deserialize(fb *serial.BranchControlBinlog) error {
 			Branch:      string(serialBinlogRow.Branch()),
 			User:        string(serialBinlogRow.User()),
 			Host:        string(serialBinlogRow.Host()),
+			Table:       string(serialBinlogRow.TableName()),
+			Column:      string(serialBinlogRow.ColumnName()),
 			Permissions: serialBinlogRow.Permissions(),
 		}
+		// Rows which apply to every table and column omit their table and column expressions
+		if len(binlog.rows[i].Table) == 0 {
+			binlog.rows[i].Table = "%"
+		}
+		if len(binlog.rows[i].Column) == 0 {
+			binlog.rows[i].Column = "%"
+		}
 	}
 	return nil
 }
 
 // Insert adds an insert entry to the Binlog.
-func (binlog *Binlog) Insert(database string, branch string, user string, host string, permissions uint64) {
+func (binlog *Binlog) Insert(database string, branch string, user string, host string, table string, column string, permissions uint64) {
 	binlog.RWMutex.Lock()
 	defer binlog.RWMutex.Unlock()
 
@@ -153,12 +166,14 @@ func (binlog *Binlog) Insert(database string, branch string, user string, host s
 		Branch:      branch,
 		User:        user,
 		Host:        host,
+		Table:       table,
+		Column:      column,
 		Permissions: permissions,
 	})
 }
 
 // Delete adds a delete entry to the Binlog.
-func (binlog *Binlog) Delete(database string, branch string, user string, host string, permissions uint64) {
+func (binlog *Binlog) Delete(database string, branch string, user string, host string, table string, column string, permissions uint64) {
 	binlog.RWMutex.Lock()
 	defer binlog.RWMutex.Unlock()
 
@@ -168,6 +183,8 @@ func (binlog *Binlog) Delete(database string, branch string, user string, host s
 		Branch:      branch,
 		User:        user,
 		Host:        host,
+		Table:       table,
+		Column:      column,
 		Permissions: permissions,
 	})
 }
@@ -183,6 +200,15 @@ func (row *BinlogRow) Serialize(b *flatbuffers.Builder) flatbuffers.UOffsetT {
 	branch := b.CreateSharedString(row.Branch)
 	user := b.CreateSharedString(row.User)
 	host := b.CreateSharedString(row.Host)
+	// Rows which apply to every table and column omit their table and column expressions, so that they may still be
+	// read by versions of Dolt that predate table and column expressions
+	var table, column flatbuffers.UOffsetT
+	if len(row.Table) > 0 && row.Table != "%" {
+		table = b.CreateSharedString(row.Table)
+	}
+	if len(row.Column) > 0 && row.Column != "%" {
+		column = b.CreateSharedString(row.Column)
+	}
 
 	serial.BranchControlBinlogRowStart(b)
 	serial.BranchControlBinlogRowAddIsInsert(b, row.IsInsert)
@@ -191,5 +217,11 @@ func (row *BinlogRow) Serialize(b *flatbuffers.Builder) flatbuffers.UOffsetT {
 	serial.BranchControlBinlogRowAddUser(b, user)
 	serial.BranchControlBinlogRowAddHost(b, host)
 	serial.BranchControlBinlogRowAddPermissions(b, row.Permissions)
+	if table != 0 {
+		serial.BranchControlBinlogRowAddTableName(b, table)
+	}
+	if column != 0 {
+		serial.BranchControlBinlogRowAddColumnName(b, column)
+	}
 	return serial.BranchControlBinlogRowEnd(b)
 }
@@ -19,6 +19,7 @@ import (
 	goerrors "errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync/atomic"
 
 	flatbuffers "github.com/dolthub/flatbuffers/v23/go"
@@ -30,16 +31,24 @@ import (
 )
 
 var (
-	ErrIncorrectPermissions  = errors.NewKind("`%s`@`%s` does not have the correct permissions on branch `%s`")
-	ErrCannotCreateBranch    = errors.NewKind("`%s`@`%s` cannot create a branch named `%s`")
-	ErrCannotDeleteBranch    = errors.NewKind("`%s`@`%s` cannot delete the branch `%s`")
-	ErrExpressionsTooLong    = errors.NewKind("expressions are too long [%q, %q, %q, %q]")
-	ErrInsertingAccessRow    = errors.NewKind("`%s`@`%s` cannot add the row [%q, %q, %q, %q, %q]")
-	ErrInsertingNamespaceRow = errors.NewKind("`%s`@`%s` cannot add the row [%q, %q, %q, %q]")
-	ErrUpdatingRow           = errors.NewKind("`%s`@`%s` cannot update the row [%q, %q, %q, %q]")
-	ErrUpdatingToRow         = errors.NewKind("`%s`@`%s` cannot update the row [%q, %q, %q, %q] to the new branch expression [%q, %q]")
-	ErrDeletingRow           = errors.NewKind("`%s`@`%s` cannot delete the row [%q, %q, %q, %q]")
-	ErrMissingController     = errors.NewKind("a context has a non-nil session but is missing its branch controller")
+	ErrIncorrectPermissions         = errors.NewKind("`%s`@`%s` does not have the correct permissions on branch `%s`")
+	ErrIncorrectTablePermissions    = errors.NewKind("`%s`@`%s` does not have the correct permissions on table `%s` on branch `%s`")
+	ErrIncorrectColumnPermissions   = errors.NewKind("`%s`@`%s` does not have the correct permissions on column `%s` of table `%s` on branch `%s`")
+	ErrCannotCreateBranch           = errors.NewKind("`%s`@`%s` cannot create a branch named `%s`")
+	ErrCannotDeleteBranch           = errors.NewKind("`%s`@`%s` cannot delete the branch `%s`")
+	ErrExpressionsTooLong           = errors.NewKind("expressions are too long [%q, %q, %q, %q]")
+	ErrAccessExpressionsTooLong     = errors.NewKind("expressions are too long [%q, %q, %q, %q, %q, %q]")
+	ErrProtectionExpressionsTooLong = errors.NewKind("expressions are too long [%q, %q]")
+	ErrInsertingAccessRow           = errors.NewKind("`%s`@`%s` cannot add the row [%q, %q, %q, %q, %q, %q, %q]")
+	ErrInsertingNamespaceRow        = errors.NewKind("`%s`@`%s` cannot add the row [%q, %q, %q, %q]")
+	ErrUpdatingRow                  = errors.NewKind("`%s`@`%s` cannot update the row [%q, %q, %q, %q]")
+	ErrUpdatingToRow                = errors.NewKind("`%s`@`%s` cannot update the row [%q, %q, %q, %q] to the new branch expression [%q, %q]")
+	ErrDeletingRow                  = errors.NewKind("`%s`@`%s` cannot delete the row [%q, %q, %q, %q]")
+	ErrMissingController            = errors.NewKind("a context has a non-nil session but is missing its branch controller")
+	ErrProtectedBranch              = errors.NewKind("branch `%s` is protected, which does not allow %s")
+	ErrInsertingProtectionRow       = errors.NewKind("`%s`@`%s` cannot add the protection rule [%q, %q]")
+	ErrUpdatingProtectionRow        = errors.NewKind("`%s`@`%s` cannot update the protection rule [%q, %q]")
+	ErrDeletingProtectionRow        = errors.NewKind("`%s`@`%s` cannot delete the protection rule [%q, %q]")
 )
 
 // Context represents the interface that must be inherited from the context.
@@ -55,8 +64,9 @@ type Context interface {
 
 // Controller is the central hub for branch control functions. This is passed within a context.
 type Controller struct {
-	Access    *Access
-	Namespace *Namespace
+	Access     *Access
+	Namespace  *Namespace
+	Protection *Protection
 
 	Serialized atomic.Pointer[[]byte]
 
@@ -86,6 +96,7 @@ func LoadData(ctx context.Context, branchControlFilePath string, doltConfigDirPa
 	controller := &Controller{
 		Access:                accessTbl,
 		Namespace:             newNamespace(accessTbl),
+		Protection:            newProtection(accessTbl),
 		branchControlFilePath: branchControlFilePath,
 		doltConfigDirPath:     doltConfigDirPath,
 	}
@@ -117,6 +128,7 @@ func (controller *Controller) LoadData(ctx context.Context, data []byte, isFirst
 	if len(data) == 0 {
 		// As there is nothing to load, we should populate the controller with the default row to ensure normal (expected) operation
 		controller.Access.insertDefaultRow()
+		controller.Protection.reinit()
 		controller.Serialized.Store(&data)
 		if controller.SavedCallback != nil {
 			controller.SavedCallback(ctx)
@@ -139,6 +151,10 @@ func (controller *Controller) LoadData(ctx context.Context, data []byte, isFirst
 	if err != nil {
 		return err
 	}
+	protection, err := bc.TryProtectionTbl(nil)
+	if err != nil {
+		return err
+	}
 
 	rollback := controller.Serialized.Load()
 
@@ -156,6 +172,11 @@ func (controller *Controller) LoadData(ctx context.Context, data []byte, isFirst
 		controller.LoadData(ctx, *rollback, isFirstLoad)
 		return err
 	}
+	if err = controller.Protection.Deserialize(protection); err != nil {
+		// TODO: More principaled rollback. Hopefully this does not fail.
+		controller.LoadData(ctx, *rollback, isFirstLoad)
+		return err
+	}
 
 	controller.Serialized.Store(&data)
 	if controller.SavedCallback != nil {
@@ -201,9 +222,17 @@ func (controller *Controller) SaveData(ctx context.Context, fs filesys.Filesys)
 	// The Serialize functions acquire read locks, so we don't acquire them here
 	accessOffset := controller.Access.Serialize(b)
 	namespaceOffset := controller.Namespace.Serialize(b)
+	// The protection table is only written when it has rules, so that older versions can still read the file
+	var protectionOffset flatbuffers.UOffsetT
+	if len(controller.Protection.Values) > 0 {
+		protectionOffset = controller.Protection.Serialize(b)
+	}
 	serial.BranchControlStart(b)
 	serial.BranchControlAddAccessTbl(b, accessOffset)
 	serial.BranchControlAddNamespaceTbl(b, namespaceOffset)
+	if protectionOffset != 0 {
+		serial.BranchControlAddProtectionTbl(b, protectionOffset)
+	}
 	root := serial.BranchControlEnd(b)
 	// serial.FinishMessage() limits files to 2^24 bytes, so this works around it while maintaining read compatibility
 	b.Prep(1, flatbuffers.SizeInt32+4+serial.MessagePrefixSz)
@@ -314,6 +343,34 @@ func CanDeleteBranch(ctx context.Context, branchName string) error {
 	return ErrCannotDeleteBranch.New(user, host, branchName)
 }
 
+// CheckProtection returns an error if the given operation is not allowed on the branch with the given name, due to
+// the branch being protected in the current database. Protection rules apply to every user, including admins and
+// super users, who must first remove the rule. However, not all CLI commands use *sql.Context, and therefore will not
+// have any session associated with the context. In these cases, CheckProtection will pass as we want to allow all
+// local commands to ignore branch protection.
+func CheckProtection(ctx context.Context, branchName string, op ProtectedOperation) error {
+	branchAwareSession := GetBranchAwareSession(ctx)
+	// A nil session means we're not in the SQL context, so we allow the operation
+	if branchAwareSession == nil {
+		return nil
+	}
+	controller := branchAwareSession.GetController()
+	// Any context that has a non-nil session should always have a non-nil controller, so this is an error
+	if controller == nil {
+		return ErrMissingController.New()
+	}
+	controller.Protection.RWMutex.RLock()
+	defer controller.Protection.RWMutex.RUnlock()
+
+	// The current database may be qualified with a revision, while the rules only apply to the base name
+	database, _, _ := strings.Cut(branchAwareSession.GetCurrentDatabase(), "/")
+	rule, ok := controller.Protection.Match(database, branchName)
+	if !ok || (op == ProtectedOperation_DirectCommit && !rule.RequireMerge) {
+		return nil
+	}
+	return ErrProtectedBranch.New(branchName, op)
+}
+
 // AddAdminForContext adds an entry in the access table for the user represented by the given context. If the
 // context is missing some functionality that is needed to perform the addition, such as a user or the Controller, then
 // this simply returns.
@@ -338,7 +395,7 @@ func AddAdminForContext(ctx context.Context, branchName string) error {
 		return nil
 	}
 	controller.Access.RWMutex.Lock()
-	controller.Access.Insert(database, branchName, user, host, Permissions_Admin)
+	controller.Access.Insert(database, branchName, user, host, "%", "%", Permissions_Admin)
 	controller.Access.RWMutex.Unlock()
 	return SaveData(ctx)
 }
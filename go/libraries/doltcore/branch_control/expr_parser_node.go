@@ -23,7 +23,7 @@ import (
 
 var (
 	aiciSorter = sql.Collation_utf8mb4_0900_ai_ci.Sorter()
-	sortFuncs  = []func(r rune) int32{aiciSorter, aiciSorter, sql.Collation_utf8mb4_0900_bin.Sorter(), aiciSorter}
+	sortFuncs  = []func(r rune) int32{aiciSorter, aiciSorter, sql.Collation_utf8mb4_0900_bin.Sorter(), aiciSorter, aiciSorter, aiciSorter}
 )
 
 // MatchNode contains a collection of sort orders that allow for an optimized level of traversal compared to
@@ -71,8 +71,8 @@ var concatenatedSortOrderPool = &sync.Pool{
 // standard strings, then this simply matches those strings against the parsed expressions. However, if the parameters
 // represent expressions, then this matches against all parsed expressions that are either duplicates or supersets of
 // the given expressions. This allows the user to "match" against new expressions to see if they are already covered.
-func (mn *MatchNode) Match(database, branch, user, host string) []MatchResult {
-	allSortOrders := mn.parseExpression(database, branch, user, host)
+func (mn *MatchNode) Match(database, branch, user, host, table, column string) []MatchResult {
+	allSortOrders := mn.parseExpression(database, branch, user, host, table, column)
 	defer func() {
 		concatenatedSortOrderPool.Put(allSortOrders)
 	}()
@@ -92,27 +92,17 @@ func (mn *MatchNode) Match(database, branch, user, host string) []MatchResult {
 	for _, sortOrder := range allSortOrders {
 		for _, node := range matchSubset {
 			if len(node.SortOrders) == 0 {
-				// At most we'll look at three children that may match, we can ignore all other children
-				if child, ok := node.Children[singleMatch]; ok {
-					matches = processMatch(matches, matchNodeCounted{
-						MatchNode: *child,
-						Length:    node.Length,
-					}, sortOrder)
-				}
-				if child, ok := node.Children[anyMatch]; ok {
-					matches = processMatch(matches, matchNodeCounted{
-						MatchNode: *child,
-						Length:    node.Length,
-					}, sortOrder)
-				}
-				if child, ok := node.Children[sortOrder]; ok {
-					matches = processMatch(matches, matchNodeCounted{
-						MatchNode: *child,
-						Length:    node.Length,
-					}, sortOrder)
-				}
+				matches = processChildren(matches, node, sortOrder)
 				continue
 			}
+			// A trailing any match may match zero characters, in which case the children are matched against the
+			// sort order instead
+			if len(node.SortOrders) == 1 && node.SortOrders[0] == anyMatch && len(node.Children) > 0 {
+				matches = processChildren(matches, matchNodeCounted{
+					MatchNode: MatchNode{Children: node.Children},
+					Length:    node.Length + 1,
+				}, sortOrder)
+			}
 			matches = processMatch(matches, node, sortOrder)
 		}
 		// Swap the two, and put the slice of matches to be at the beginning of the previous subset array to reuse it
@@ -137,12 +127,47 @@ func (mn *MatchNode) Match(database, branch, user, host string) []MatchResult {
 				})
 			}
 		}
+		// An expression that ends with an any match may have been split into its own child, which matches the empty
+		// remainder of the input (such as an empty table name against a table expression of "%")
+		if len(node.SortOrders) == 0 {
+			if child, ok := node.Children[anyMatch]; ok && child.Data != nil && len(child.SortOrders) == 1 {
+				results = append(results, MatchResult{
+					MatchNodeData: *child.Data,
+					Length:        node.Length + 1,
+				})
+			}
+		}
 	}
 	// Now we're done with the subset slice, so put it back in the pool
 	matchNodeCountedPool.Put(matchSubset)
 	return results
 }
 
+// processChildren processes the sort order against the children of a node that has no remaining sort orders. Returns
+// a new slice with any newly appended nodes (which should overwrite the first parameter in the calling function).
+func processChildren(matches []matchNodeCounted, node matchNodeCounted, sortOrder int32) []matchNodeCounted {
+	// At most we'll look at three children that may match, we can ignore all other children
+	if child, ok := node.Children[singleMatch]; ok {
+		matches = processMatch(matches, matchNodeCounted{
+			MatchNode: *child,
+			Length:    node.Length,
+		}, sortOrder)
+	}
+	if child, ok := node.Children[anyMatch]; ok {
+		matches = processMatch(matches, matchNodeCounted{
+			MatchNode: *child,
+			Length:    node.Length,
+		}, sortOrder)
+	}
+	if child, ok := node.Children[sortOrder]; ok {
+		matches = processMatch(matches, matchNodeCounted{
+			MatchNode: *child,
+			Length:    node.Length,
+		}, sortOrder)
+	}
+	return matches
+}
+
 // processMatch handles the behavior of how to process a sort order against a node. Returns a new slice with any newly
 // appended nodes (which should overwrite the first parameter in the calling function).
 func processMatch(matches []matchNodeCounted, node matchNodeCounted, sortOrder int32) []matchNodeCounted {
@@ -182,16 +207,18 @@ func processMatch(matches []matchNodeCounted, node matchNodeCounted, sortOrder i
 }
 
 // Add will add the given expressions to the node hierarchy. If the expressions already exists, then this overwrites
-// the pre-existing entry. Assumes that the given expressions have already been folded.
-func (mn *MatchNode) Add(databaseExpr, branchExpr, userExpr, hostExpr string, data MatchNodeData) {
+// the pre-existing entry, and returns its row index. Otherwise, returns math.MaxUint32. Assumes that the given
+// expressions have already been folded.
+func (mn *MatchNode) Add(databaseExpr, branchExpr, userExpr, hostExpr, tableExpr, columnExpr string, data MatchNodeData) uint32 {
 	root := mn
-	allSortOrders := mn.parseExpression(databaseExpr, branchExpr, userExpr, hostExpr)
+	allSortOrders := mn.parseExpression(databaseExpr, branchExpr, userExpr, hostExpr, tableExpr, columnExpr)
 	defer func() {
 		concatenatedSortOrderPool.Put(allSortOrders)
 	}()
 
 	remainingRootSortOrders := root.SortOrders
 	allSortOrdersMaxIndex := len(allSortOrders) - 1
+	replacedIndex := uint32(math.MaxUint32)
 ParentLoop:
 	for i, sortOrder := range allSortOrders {
 		if remainingRootSortOrders[0] == sortOrder {
@@ -232,6 +259,9 @@ ParentLoop:
 				break
 			} else {
 				// We have no more sort orders on either side so this is an exact match, therefore we update the data
+				if root.Data != nil {
+					replacedIndex = root.Data.RowIndex
+				}
 				root.Data = &data
 				break
 			}
@@ -260,13 +290,14 @@ ParentLoop:
 			break
 		}
 	}
+	return replacedIndex
 }
 
 // Remove will remove the given expressions to the node hierarchy. If the expressions do not exist, then nothing
 // happens. Assumes that the given expressions have already been folded.
-func (mn *MatchNode) Remove(databaseExpr, branchExpr, userExpr, hostExpr string) uint32 {
+func (mn *MatchNode) Remove(databaseExpr, branchExpr, userExpr, hostExpr, tableExpr, columnExpr string) uint32 {
 	root := mn
-	allSortOrders := mn.parseExpression(databaseExpr, branchExpr, userExpr, hostExpr)
+	allSortOrders := mn.parseExpression(databaseExpr, branchExpr, userExpr, hostExpr, tableExpr, columnExpr)
 	defer func() {
 		concatenatedSortOrderPool.Put(allSortOrders)
 	}()
@@ -353,22 +384,12 @@ ParentLoop:
 // parseExpression parses expressions into a concatenated collection of sort orders. The returned slice belongs to the
 // pool, which, if possible, should be returned once it is no longer needed. As this function doesn't distinguish
 // between strings and expressions, it assumes any given expressions have already been folded.
-func (mn *MatchNode) parseExpression(database, branch, user, host string) []int32 {
-	if len(database) > math.MaxUint16 {
-		database = database[:math.MaxUint16]
-	}
-	if len(branch) > math.MaxUint16 {
-		branch = branch[:math.MaxUint16]
-	}
-	if len(user) > math.MaxUint16 {
-		user = user[:math.MaxUint16]
-	}
-	if len(host) > math.MaxUint16 {
-		host = host[:math.MaxUint16]
-	}
-
+func (mn *MatchNode) parseExpression(exprs ...string) []int32 {
 	allSortOrders := concatenatedSortOrderPool.Get().([]int32)[:0]
-	for i, str := range []string{database, branch, user, host} {
+	for i, str := range exprs {
+		if len(str) > math.MaxUint16 {
+			str = str[:math.MaxUint16]
+		}
 		escaped := false
 		sortFunc := sortFuncs[i]
 		allSortOrders = append(allSortOrders, columnMarker)
@@ -0,0 +1,227 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branch_control
+
+import (
+	"sync"
+
+	flatbuffers "github.com/dolthub/flatbuffers/v23/go"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+)
+
+// ProtectedOperation is an operation that is not allowed on protected branches, regardless of the permissions that a
+// user has on them. The value describes the operation for error messages.
+type ProtectedOperation string
+
+const (
+	// ProtectedOperation_HistoryRewrite covers resetting, rebasing, and overwriting a branch, along with amending its
+	// latest commit
+	ProtectedOperation_HistoryRewrite ProtectedOperation = "rewriting its history"
+	// ProtectedOperation_ForcePush covers force pushing to a remote branch with a protected name
+	ProtectedOperation_ForcePush ProtectedOperation = "force pushing to it"
+	// ProtectedOperation_Delete covers deleting a branch
+	ProtectedOperation_Delete ProtectedOperation = "deleting it"
+	// ProtectedOperation_Rename covers renaming (moving) a branch
+	ProtectedOperation_Rename ProtectedOperation = "renaming it"
+	// ProtectedOperation_DirectCommit covers commits that do not conclude a merge, which are only disallowed by rules
+	// that require merges
+	ProtectedOperation_DirectCommit ProtectedOperation = "committing to it directly, as changes must be merged into it"
+)
+
+// Protection contains all of the expressions that comprise the "dolt_branch_protection" table, which restricts how
+// the matching branches may be modified. Unlike the other branch control tables, the rules apply to every user.
+// Modification of this table is handled by the Access table.
+type Protection struct {
+	access *Access
+
+	Databases []MatchExpression
+	Branches  []MatchExpression
+	Values    []ProtectionValue
+	RWMutex   *sync.RWMutex
+}
+
+// ProtectionValue contains the user-facing values of a particular row.
+type ProtectionValue struct {
+	Database     string
+	Branch       string
+	RequireMerge bool
+}
+
+// newProtection returns a new Protection.
+func newProtection(accessTbl *Access) *Protection {
+	return &Protection{
+		access:    accessTbl,
+		Databases: nil,
+		Branches:  nil,
+		Values:    nil,
+		RWMutex:   accessTbl.RWMutex,
+	}
+}
+
+// Match returns whether the given database and branch are protected, along with the rule that applies to them. When
+// multiple rules match, the rule with the longest branch expression is used, and it requires merges if any of the
+// rules with the same length do.
+func (tbl *Protection) Match(database string, branch string) (ProtectionValue, bool) {
+	filteredIndexes := Match(tbl.Databases, database, sql.Collation_utf8mb4_0900_ai_ci)
+	if len(filteredIndexes) == 0 {
+		indexPool.Put(filteredIndexes)
+		return ProtectionValue{}, false
+	}
+
+	filteredBranches := tbl.filterBranches(filteredIndexes)
+	indexPool.Put(filteredIndexes)
+	matchedSet := Match(filteredBranches, branch, sql.Collation_utf8mb4_0900_ai_ci)
+	matchExprPool.Put(filteredBranches)
+	defer indexPool.Put(matchedSet)
+
+	longest := -1
+	var rule ProtectionValue
+	for _, matched := range matchedSet {
+		matchedValue := tbl.Values[matched]
+		if len(matchedValue.Branch) > longest {
+			longest = len(matchedValue.Branch)
+			rule = matchedValue
+		} else if len(matchedValue.Branch) == longest {
+			rule.RequireMerge = rule.RequireMerge || matchedValue.RequireMerge
+		}
+	}
+	return rule, longest >= 0
+}
+
+// GetIndex returns the index of the given database and branch expressions. If the expressions cannot be found, returns
+// -1. Assumes that the given expressions have already been folded.
+func (tbl *Protection) GetIndex(databaseExpr string, branchExpr string) int {
+	for i, value := range tbl.Values {
+		if value.Database == databaseExpr && value.Branch == branchExpr {
+			return i
+		}
+	}
+	return -1
+}
+
+// Insert adds the given rule to the table. This does not perform any sort of validation whatsoever, so it is important
+// to ensure that the expressions are valid, and that they are not already in the table, before insertion. Assumes that
+// the given expressions have already been folded. Requires external synchronization handling, therefore manually
+// manage the RWMutex.
+func (tbl *Protection) Insert(database string, branch string, requireMerge bool) {
+	nextIdx := uint32(len(tbl.Values))
+	tbl.Databases = append(tbl.Databases, MatchExpression{
+		CollectionIndex: nextIdx,
+		SortOrders:      ParseExpression(database, sql.Collation_utf8mb4_0900_ai_ci),
+	})
+	tbl.Branches = append(tbl.Branches, MatchExpression{
+		CollectionIndex: nextIdx,
+		SortOrders:      ParseExpression(branch, sql.Collation_utf8mb4_0900_ai_ci),
+	})
+	tbl.Values = append(tbl.Values, ProtectionValue{
+		Database:     database,
+		Branch:       branch,
+		RequireMerge: requireMerge,
+	})
+}
+
+// Delete removes the given expressions from the table, if they exist. Assumes that the given expressions have already
+// been folded. Requires external synchronization handling, therefore manually manage the RWMutex.
+func (tbl *Protection) Delete(database string, branch string) {
+	tblIndex := tbl.GetIndex(database, branch)
+	if tblIndex == -1 {
+		return
+	}
+	// Remove the matching row from all slices by first swapping with the last element
+	endIndex := len(tbl.Values) - 1
+	tbl.Databases[tblIndex], tbl.Databases[endIndex] = tbl.Databases[endIndex], tbl.Databases[tblIndex]
+	tbl.Branches[tblIndex], tbl.Branches[endIndex] = tbl.Branches[endIndex], tbl.Branches[tblIndex]
+	tbl.Values[tblIndex], tbl.Values[endIndex] = tbl.Values[endIndex], tbl.Values[tblIndex]
+	tbl.Databases = tbl.Databases[:endIndex]
+	tbl.Branches = tbl.Branches[:endIndex]
+	tbl.Values = tbl.Values[:endIndex]
+	// Then we update the index for the match expressions
+	if tblIndex != endIndex {
+		tbl.Databases[tblIndex].CollectionIndex = uint32(tblIndex)
+		tbl.Branches[tblIndex].CollectionIndex = uint32(tblIndex)
+	}
+}
+
+// Access returns the Access table.
+func (tbl *Protection) Access() *Access {
+	return tbl.access
+}
+
+// Serialize returns the offset for the Protection table written to the given builder. The match expressions are
+// rebuilt from the values when deserializing, so only the values are written.
+func (tbl *Protection) Serialize(b *flatbuffers.Builder) flatbuffers.UOffsetT {
+	valueOffsets := make([]flatbuffers.UOffsetT, len(tbl.Values))
+	for i, val := range tbl.Values {
+		valueOffsets[i] = val.Serialize(b)
+	}
+	serial.BranchControlProtectionStartValuesVector(b, len(valueOffsets))
+	for i := len(valueOffsets) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(valueOffsets[i])
+	}
+	values := b.EndVector(len(valueOffsets))
+	serial.BranchControlProtectionStart(b)
+	serial.BranchControlProtectionAddValues(b, values)
+	return serial.BranchControlProtectionEnd(b)
+}
+
+func (tbl *Protection) reinit() {
+	tbl.Databases = nil
+	tbl.Branches = nil
+	tbl.Values = nil
+}
+
+// Deserialize populates the table with the data from the flatbuffers representation. A nil representation, which is
+// written when there are no protected branches, results in an empty table.
+func (tbl *Protection) Deserialize(fb *serial.BranchControlProtection) error {
+	tbl.reinit()
+	if fb == nil {
+		return nil
+	}
+	for i := 0; i < fb.ValuesLength(); i++ {
+		serialProtectionValue := &serial.BranchControlProtectionValue{}
+		_, err := fb.TryValues(serialProtectionValue, i)
+		if err != nil {
+			return err
+		}
+		tbl.Insert(string(serialProtectionValue.Database()), string(serialProtectionValue.Branch()), serialProtectionValue.RequireMerge())
+	}
+	return nil
+}
+
+// filterBranches returns all branches that match the given collection indexes.
+func (tbl *Protection) filterBranches(filters []uint32) []MatchExpression {
+	if len(filters) == 0 {
+		return nil
+	}
+	matchExprs := matchExprPool.Get().([]MatchExpression)[:0]
+	for _, filter := range filters {
+		matchExprs = append(matchExprs, tbl.Branches[filter])
+	}
+	return matchExprs
+}
+
+// Serialize returns the offset for the ProtectionValue written to the given builder.
+func (val *ProtectionValue) Serialize(b *flatbuffers.Builder) flatbuffers.UOffsetT {
+	database := b.CreateSharedString(val.Database)
+	branch := b.CreateSharedString(val.Branch)
+
+	serial.BranchControlProtectionValueStart(b)
+	serial.BranchControlProtectionValueAddDatabase(b, database)
+	serial.BranchControlProtectionValueAddBranch(b, branch)
+	serial.BranchControlProtectionValueAddRequireMerge(b, val.RequireMerge)
+	return serial.BranchControlProtectionValueEnd(b)
+}
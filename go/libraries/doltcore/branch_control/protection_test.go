@@ -0,0 +1,88 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package branch_control
+
+import (
+	"testing"
+
+	flatbuffers "github.com/dolthub/flatbuffers/v23/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+)
+
+func TestProtectionMatch(t *testing.T) {
+	access := newAccess()
+	access.reinit()
+	protection := newProtection(access)
+	protection.Insert("%", "main", true)
+	protection.Insert("%", "release%", false)
+	protection.Insert("mydb", "release1", true)
+	protection.Insert("otherdb", "%", true)
+
+	tests := []struct {
+		database     string
+		branch       string
+		protected    bool
+		requireMerge bool
+	}{
+		{"mydb", "main", true, true},
+		{"mydb", "MAIN", true, true},
+		{"mydb", "feature", false, false},
+		{"mydb", "release1", true, true},
+		{"mydb", "release2", true, false},
+		{"anydb", "release1", true, false},
+		{"otherdb", "feature", true, true},
+	}
+	for _, test := range tests {
+		t.Run(test.database+"."+test.branch, func(t *testing.T) {
+			rule, ok := protection.Match(test.database, test.branch)
+			assert.Equal(t, test.protected, ok)
+			assert.Equal(t, test.requireMerge, rule.RequireMerge)
+		})
+	}
+
+	protection.Delete("%", "main")
+	_, ok := protection.Match("mydb", "main")
+	assert.False(t, ok)
+	rule, ok := protection.Match("mydb", "release1")
+	require.True(t, ok)
+	assert.True(t, rule.RequireMerge)
+}
+
+func TestProtectionSerialization(t *testing.T) {
+	access := newAccess()
+	access.reinit()
+	protection := newProtection(access)
+	protection.Insert("%", "main", true)
+	protection.Insert("mydb", "release%", false)
+
+	b := flatbuffers.NewBuilder(1024)
+	b.Finish(protection.Serialize(b))
+	fb, err := serial.TryGetRootAsBranchControlProtection(b.FinishedBytes(), 0)
+	require.NoError(t, err)
+
+	deserialized := newProtection(access)
+	require.NoError(t, deserialized.Deserialize(fb))
+	assert.Equal(t, protection.Values, deserialized.Values)
+	rule, ok := deserialized.Match("mydb", "release1")
+	require.True(t, ok)
+	assert.False(t, rule.RequireMerge)
+
+	// A missing table deserializes as an empty table
+	require.NoError(t, deserialized.Deserialize(nil))
+	assert.Empty(t, deserialized.Values)
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// AzureEndpointParam is a creation parameter that can be used to set the blob service endpoint of az:// urls,
+	// for example to use the Azurite emulator or a sovereign cloud.
+	AzureEndpointParam = "az-endpoint"
+)
+
+// AzureFactory is a DBFactory implementation for creating Azure Blob Storage backed databases
+type AzureFactory struct {
+}
+
+// PrepareDB prepares an Azure backed database
+func (fact AzureFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	// nothing to prepare
+	return nil
+}
+
+// CreateDB creates an Azure backed database
+func (fact AzureFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	azStore, err := fact.newChunkStore(ctx, nbf, urlObj, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(azStore)
+	ns := tree.NewNodeStore(azStore)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+func (fact AzureFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (chunks.ChunkStore, error) {
+	account, containerName, prefix, err := parseAzureURL(urlObj)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := getAzureContainerClient(account, containerName, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize azure client: %w", err)
+	}
+
+	bs := blobstore.NewAzureBlobstore(client, containerName, prefix)
+	q := nbs.NewUnlimitedMemQuotaProvider()
+	return nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+}
+
+// parseAzureURL returns the storage account, container and database path of an az://[account]/[container]/[path] url
+func parseAzureURL(urlObj *url.URL) (account, containerName, prefix string, err error) {
+	account = urlObj.Hostname()
+	containerName, prefix, _ = strings.Cut(strings.TrimPrefix(urlObj.Path, "/"), "/")
+	if account == "" || containerName == "" {
+		return "", "", "", errors.New("azure url has an invalid format, expected az://account/container/database")
+	}
+
+	prefix, err = validatePath(prefix)
+	if err != nil {
+		return "", "", "", err
+	}
+	return account, containerName, prefix, nil
+}
+
+// azureContainerURL returns the url of |containerName|, in the blob service at |endpoint| if one is given, and in the
+// public cloud blob service of |account| otherwise.
+func azureContainerURL(account, containerName, endpoint string) string {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", account)
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/" + containerName
+}
+
+// getAzureContainerClient creates a client for |containerName| using the first credentials found of: a connection
+// string, a shared account key, a SAS token, and the default Azure credential chain (environment, workload and managed
+// identities, and the Azure CLI).
+func getAzureContainerClient(account, containerName string, params map[string]interface{}) (*container.Client, error) {
+	if connStr := os.Getenv(dconfig.EnvAzureStorageConnectionString); connStr != "" {
+		return container.NewClientFromConnectionString(connStr, containerName, nil)
+	}
+
+	endpoint := os.Getenv(dconfig.EnvAzureEndpoint)
+	if val, ok := params[AzureEndpointParam]; ok {
+		endpoint = val.(string)
+	}
+	containerURL := azureContainerURL(account, containerName, endpoint)
+
+	if key := os.Getenv(dconfig.EnvAzureStorageKey); key != "" {
+		cred, err := container.NewSharedKeyCredential(account, key)
+		if err != nil {
+			return nil, err
+		}
+		return container.NewClientWithSharedKeyCredential(containerURL, cred, nil)
+	}
+
+	if sasToken := os.Getenv(dconfig.EnvAzureStorageSASToken); sasToken != "" {
+		return container.NewClientWithNoCredential(containerURL+"?"+strings.TrimPrefix(sasToken, "?"), nil)
+	}
+
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, err
+	}
+	return container.NewClient(containerURL, cred, nil)
+}
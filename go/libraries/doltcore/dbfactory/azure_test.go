@@ -0,0 +1,85 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+func TestParseAzureURL(t *testing.T) {
+	tests := []struct {
+		url       string
+		account   string
+		container string
+		prefix    string
+		expectErr bool
+	}{
+		{"az://account/container/database", "account", "container", "database", false},
+		{"az://account/container/path/to/database/", "account", "container", "path/to/database", false},
+		{"az://account/container", "", "", "", true},
+		{"az://account/container/", "", "", "", true},
+		{"az:///container/database", "", "", "", true},
+		{"az://account//database", "", "", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			urlObj, err := url.Parse(test.url)
+			require.NoError(t, err)
+			account, container, prefix, err := parseAzureURL(urlObj)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.account, account)
+			assert.Equal(t, test.container, container)
+			assert.Equal(t, test.prefix, prefix)
+		})
+	}
+}
+
+func TestGetAzureContainerClient(t *testing.T) {
+	t.Setenv(dconfig.EnvAzureStorageConnectionString, "")
+	t.Setenv(dconfig.EnvAzureStorageSASToken, "")
+	t.Setenv(dconfig.EnvAzureStorageKey, base64.StdEncoding.EncodeToString([]byte("key")))
+
+	t.Setenv(dconfig.EnvAzureEndpoint, "")
+	client, err := getAzureContainerClient("account", "container", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://account.blob.core.windows.net/container", client.URL())
+
+	t.Setenv(dconfig.EnvAzureEndpoint, "http://127.0.0.1:10000/account/")
+	client, err = getAzureContainerClient("account", "container", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:10000/account/container", client.URL())
+
+	client, err = getAzureContainerClient("account", "container", map[string]interface{}{AzureEndpointParam: "https://account.blob.core.usgovcloudapi.net"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://account.blob.core.usgovcloudapi.net/container", client.URL())
+
+	t.Setenv(dconfig.EnvAzureStorageKey, "")
+	t.Setenv(dconfig.EnvAzureStorageSASToken, "?sv=2021-01-01&sig=abc")
+	client, err = getAzureContainerClient("account", "container", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:10000/account/container?sv=2021-01-01&sig=abc", client.URL())
+}
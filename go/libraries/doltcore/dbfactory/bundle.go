@@ -0,0 +1,150 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// BundleHeaderFile is the name of the first file in a bundle, which describes the bundle's contents
+	BundleHeaderFile = "bundle.json"
+
+	// BundleFormatVersion is the version of the bundle format written by this version of dolt
+	BundleFormatVersion = 1
+
+	// BundleExt is the extension of bundle files
+	BundleExt = ".bundle"
+)
+
+// ErrBundleReadOnly is returned when preparing a bundle to be pushed to
+var ErrBundleReadOnly = errors.New("bundles are read only")
+
+// BundleRef is a ref in a bundle, and the address of the commit or tag it points to
+type BundleRef struct {
+	Ref  string `json:"ref"`
+	Hash string `json:"hash"`
+}
+
+// BundleHeader describes the contents of a bundle. A bundle is an uncompressed tar archive containing this header,
+// followed by the manifest and table files of a database holding the bundle's refs.
+type BundleHeader struct {
+	Version    int         `json:"version"`
+	NbfVersion string      `json:"nbf_version"`
+	Refs       []BundleRef `json:"refs"`
+	// Prerequisites are the commits a database must have to fetch from the bundle. Chunks reachable from them are
+	// left out of the bundle.
+	Prerequisites []string `json:"prerequisites,omitempty"`
+}
+
+// ReadBundleHeader reads the header of the bundle stored in |bs|
+func ReadBundleHeader(ctx context.Context, bs blobstore.Blobstore) (BundleHeader, error) {
+	data, _, err := blobstore.GetBytes(ctx, bs, BundleHeaderFile, blobstore.AllRange)
+	if blobstore.IsNotFoundError(err) {
+		return BundleHeader{}, fmt.Errorf("%s is not a dolt bundle", bs.Path())
+	} else if err != nil {
+		return BundleHeader{}, err
+	}
+
+	var header BundleHeader
+	if err = json.Unmarshal(data, &header); err != nil {
+		return BundleHeader{}, fmt.Errorf("%s has an invalid bundle header: %w", bs.Path(), err)
+	}
+	if header.Version > BundleFormatVersion {
+		return BundleHeader{}, fmt.Errorf("%s was written by a newer version of dolt (bundle version %d). upgrade dolt to read it", bs.Path(), header.Version)
+	}
+	return header, nil
+}
+
+// BundleChunkStore is the chunks.ChunkStore of a database read from a bundle
+type BundleChunkStore interface {
+	chunks.ChunkStore
+	// Prerequisites returns the commits a database must have to fetch from the bundle
+	Prerequisites() []hash.Hash
+}
+
+// BundleFactory is a DBFactory implementation for reading databases from bundle files, which are written by
+// `dolt bundle create`. Bundles are read only.
+type BundleFactory struct {
+}
+
+// PrepareDB returns an error, as bundles cannot be pushed to
+func (fact BundleFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	return fmt.Errorf("cannot write to %s: %w", urlObj.String(), ErrBundleReadOnly)
+}
+
+// CreateDB creates a database backed by a bundle file
+func (fact BundleFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	path, err := filepath.Abs(filepath.Join(urlObj.Host, urlObj.Path))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	bs, err := blobstore.NewTarBlobstore(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	header, err := ReadBundleHeader(ctx, bs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	prerequisites := make([]hash.Hash, len(header.Prerequisites))
+	for i, s := range header.Prerequisites {
+		h, ok := hash.MaybeParse(s)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("%s has an invalid prerequisite commit: '%s'", path, s)
+		}
+		prerequisites[i] = h
+	}
+
+	q := nbs.NewUnlimitedMemQuotaProvider()
+	store, err := nbs.NewNoConjoinBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cs := bundleChunkStore{NomsBlockStore: store, prerequisites: prerequisites}
+	vrw := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+type bundleChunkStore struct {
+	*nbs.NomsBlockStore
+	prerequisites []hash.Hash
+}
+
+var _ BundleChunkStore = bundleChunkStore{}
+
+func (cs bundleChunkStore) Prerequisites() []hash.Hash {
+	return cs.prerequisites
+}
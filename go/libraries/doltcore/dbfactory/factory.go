@@ -53,6 +53,18 @@ const (
 
 	OSSScheme = "oss"
 
+	// S3Scheme
+	S3Scheme = "s3"
+
+	// AzureScheme
+	AzureScheme = "az"
+
+	// SSHScheme
+	SSHScheme = "ssh"
+
+	// BundleScheme
+	BundleScheme = "bundle"
+
 	defaultScheme       = HTTPSScheme
 	defaultMemTableSize = 256 * 1024 * 1024
 )
@@ -71,11 +83,15 @@ type DBFactory interface {
 var DBFactories = map[string]DBFactory{
 	AWSScheme:     AWSFactory{},
 	OSSScheme:     OSSFactory{},
+	S3Scheme:      S3Factory{},
+	AzureScheme:   AzureFactory{},
 	GSScheme:      GSFactory{},
 	OCIScheme:     OCIFactory{},
 	FileScheme:    FileFactory{},
 	MemScheme:     MemFactory{},
 	LocalBSScheme: LocalBSFactory{},
+	SSHScheme:     SSHFactory{},
+	BundleScheme:  BundleFactory{},
 	HTTPScheme:    NewDoltRemoteFactory(true),
 	HTTPSScheme:   NewDoltRemoteFactory(false),
 }
@@ -0,0 +1,121 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/blobstore"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// S3EndpointParam is a creation parameter that can be used to point s3:// urls at an S3-compatible object store,
+	// such as MinIO or Ceph, instead of AWS.
+	S3EndpointParam = "s3-endpoint"
+
+	defaultS3Region = "us-east-1"
+)
+
+// S3Factory is a DBFactory implementation for creating databases backed by an S3 bucket alone. Unlike AWSFactory,
+// the manifest is stored in the bucket and updated with conditional writes, so no DynamoDB table is needed.
+type S3Factory struct {
+}
+
+// PrepareDB prepares an S3 backed database
+func (fact S3Factory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	// nothing to prepare
+	return nil
+}
+
+// CreateDB creates an S3 backed database
+func (fact S3Factory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	s3Store, err := fact.newChunkStore(ctx, nbf, urlObj, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(s3Store)
+	ns := tree.NewNodeStore(s3Store)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+func (fact S3Factory) newChunkStore(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (chunks.ChunkStore, error) {
+	// s3://[bucket]/[path]
+	bucket := urlObj.Hostname()
+	if bucket == "" {
+		return nil, errors.New("s3 url has an invalid format, expected s3://bucket/path")
+	}
+
+	prefix, err := validatePath(urlObj.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := s3ConfigFromParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := session.NewSessionWithOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if aws.StringValue(sess.Config.Region) == "" {
+		// S3-compatible stores generally ignore the region, but the SDK needs one to sign requests
+		sess.Config.Region = aws.String(defaultS3Region)
+	}
+	_, err = sess.Config.Credentials.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	bs := blobstore.NewS3Blobstore(s3.New(sess), bucket, prefix)
+	q := nbs.NewUnlimitedMemQuotaProvider()
+	return nbs.NewBSStore(ctx, nbf.VersionString(), bs, defaultMemTableSize, q)
+}
+
+// s3ConfigFromParams returns the aws session options for |params|. When an endpoint is given, either as a param or
+// through the environment, requests use path style addressing, which S3-compatible stores generally require.
+func s3ConfigFromParams(params map[string]interface{}) (session.Options, error) {
+	opts, err := awsConfigFromParams(params)
+	if err != nil {
+		return opts, err
+	}
+
+	endpoint := os.Getenv(dconfig.EnvS3Endpoint)
+	if val, ok := params[S3EndpointParam]; ok {
+		endpoint = val.(string)
+	}
+	if endpoint != "" {
+		opts.Config.MergeIn(aws.NewConfig().WithEndpoint(endpoint).WithS3ForcePathStyle(true))
+	}
+
+	return opts, nil
+}
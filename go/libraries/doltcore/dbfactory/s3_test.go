@@ -0,0 +1,64 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestS3ConfigFromParams(t *testing.T) {
+	t.Run("no endpoint", func(t *testing.T) {
+		t.Setenv(dconfig.EnvS3Endpoint, "")
+		opts, err := s3ConfigFromParams(map[string]interface{}{AWSRegionParam: "us-west-2"})
+		require.NoError(t, err)
+		assert.Nil(t, opts.Config.Endpoint)
+		assert.Nil(t, opts.Config.S3ForcePathStyle)
+		assert.Equal(t, "us-west-2", aws.StringValue(opts.Config.Region))
+	})
+
+	t.Run("endpoint from env", func(t *testing.T) {
+		t.Setenv(dconfig.EnvS3Endpoint, "http://localhost:9000")
+		opts, err := s3ConfigFromParams(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:9000", aws.StringValue(opts.Config.Endpoint))
+		assert.True(t, aws.BoolValue(opts.Config.S3ForcePathStyle))
+	})
+
+	t.Run("endpoint param overrides env", func(t *testing.T) {
+		t.Setenv(dconfig.EnvS3Endpoint, "http://localhost:9000")
+		opts, err := s3ConfigFromParams(map[string]interface{}{S3EndpointParam: "https://ceph.example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://ceph.example.com", aws.StringValue(opts.Config.Endpoint))
+		assert.True(t, aws.BoolValue(opts.Config.S3ForcePathStyle))
+	})
+}
+
+func TestS3FactoryInvalidURL(t *testing.T) {
+	for _, urlStr := range []string{"s3:///database", "s3://bucket", "s3://bucket/"} {
+		urlObj, err := url.Parse(urlStr)
+		require.NoError(t, err)
+		_, _, _, err = S3Factory{}.CreateDB(context.Background(), types.Format_Default, urlObj, nil)
+		assert.Error(t, err, urlStr)
+	}
+}
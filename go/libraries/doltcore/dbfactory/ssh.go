@@ -0,0 +1,303 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/yamux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/libraries/events"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	// TransferCommand is the dolt command run on the remote host of an ssh:// url. It serves the database at the path
+	// given as its argument over its stdin and stdout.
+	TransferCommand = "transfer"
+
+	defaultSSHCommand  = "ssh"
+	defaultSSHExecPath = "dolt"
+
+	// maxSSHStderrSize is the amount of the ssh process's stderr kept to report when the connection fails
+	maxSSHStderrSize = 4 * 1024
+
+	// sshExitTimeout is how long a closed ssh process is given to exit before it is killed
+	sshExitTimeout = 5 * time.Second
+)
+
+// SSHFactory is a DBFactory implementation for creating databases served by a `dolt transfer` process on a remote
+// host. The process is started over ssh, and speaks the GRPC rpcs defined by remoteapis.ChunkStoreServiceClient, as
+// well as the HTTP requests for table files, over the stdin and stdout of the ssh channel.
+type SSHFactory struct {
+}
+
+// PrepareDB prepares an ssh backed database
+func (fact SSHFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) error {
+	// the transfer process creates the database the first time it is accessed
+	return nil
+}
+
+// CreateDB creates an ssh backed database
+func (fact SSHFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	cs, err := fact.newChunkStore(ctx, nbf, urlObj, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	vrw := types.NewValueStore(cs)
+	ns := tree.NewNodeStore(cs)
+	db := datas.NewTypesDatabase(vrw, ns)
+
+	return db, vrw, ns, nil
+}
+
+func (fact SSHFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (chunks.ChunkStore, error) {
+	path, err := sshRemotePath(urlObj)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := sshTransferCmd(urlObj, path)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := startSSHTransfer(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return transfer.Open()
+		}),
+		grpc.WithChainUnaryInterceptor(remotestorage.TracingUnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(remotestorage.TracingStreamClientInterceptor),
+		grpc.WithChainUnaryInterceptor(remotestorage.EventsUnaryClientInterceptor(events.GlobalCollector())),
+		grpc.WithChainUnaryInterceptor(remotestorage.RetryingUnaryClientInterceptor),
+	}
+
+	conn, err := grpc.Dial(urlObj.Host, opts...)
+	if err != nil {
+		transfer.Close()
+		return nil, err
+	}
+
+	csClient := remotesapi.NewChunkStoreServiceClient(conn)
+	cs, err := remotestorage.NewDoltChunkStoreFromPath(ctx, nbf, path, urlObj.Host, false, csClient)
+	if err != nil {
+		conn.Close()
+		transfer.Close()
+		return nil, fmt.Errorf("could not access dolt url '%s': %w", urlObj.String(), transfer.withStderr(err))
+	}
+
+	// table files are read and written with HTTP requests made over their own streams of the same session
+	cs = cs.WithHTTPFetcher(&http.Client{
+		Transport: &http.Transport{
+			DialContext: func(context.Context, string, string) (net.Conn, error) {
+				return transfer.Open()
+			},
+			MaxIdleConnsPerHost: 16,
+		},
+	})
+	cs.SetFinalizer(func() error {
+		conn.Close()
+		return transfer.Close()
+	})
+
+	if _, ok := params[NoCachingParameter]; ok {
+		cs = cs.WithNoopChunkCache()
+	}
+
+	return cs, nil
+}
+
+// sshRemotePath returns the path of the database on the remote host of an ssh://[user@]host[:port]/path url. Paths
+// beginning with /~/ are relative to the home directory of the user, and all others are absolute.
+func sshRemotePath(urlObj *url.URL) (string, error) {
+	if urlObj.Hostname() == "" || strings.Trim(urlObj.Path, "/") == "" {
+		return "", errors.New("ssh url has an invalid format, expected ssh://[user@]host[:port]/path")
+	}
+	if strings.HasPrefix(urlObj.Path, "/~/") {
+		return strings.TrimPrefix(urlObj.Path, "/~/"), nil
+	}
+	return urlObj.Path, nil
+}
+
+// sshTransferCmd returns the command that runs `dolt transfer` for |path| on the host of |urlObj|. The ssh command run,
+// along with any arguments it is given, can be set with DOLT_SSH, and the path of dolt on the remote host can be set
+// with DOLT_SSH_EXEC_PATH. A host or user beginning with '-' is rejected, as ssh would read it as an option.
+func sshTransferCmd(urlObj *url.URL, path string) (*exec.Cmd, error) {
+	host := urlObj.Hostname()
+	if strings.HasPrefix(host, "-") {
+		return nil, fmt.Errorf("invalid ssh url '%s': host may not begin with '-'", urlObj.Redacted())
+	}
+	if urlObj.User != nil && urlObj.User.Username() != "" {
+		if strings.HasPrefix(urlObj.User.Username(), "-") {
+			return nil, fmt.Errorf("invalid ssh url '%s': user may not begin with '-'", urlObj.Redacted())
+		}
+		host = urlObj.User.Username() + "@" + host
+	}
+
+	sshCmd := strings.Fields(os.Getenv(dconfig.EnvSSH))
+	if len(sshCmd) == 0 {
+		sshCmd = []string{defaultSSHCommand}
+	}
+	execPath := os.Getenv(dconfig.EnvSSHExecPath)
+	if execPath == "" {
+		execPath = defaultSSHExecPath
+	}
+
+	args := sshCmd[1:]
+	if port := urlObj.Port(); port != "" {
+		args = append(args, "-p", port)
+	}
+	args = append(args, "--", host, execPath+" "+TransferCommand+" "+shellQuote(path))
+
+	return exec.Command(sshCmd[0], args...), nil
+}
+
+// shellQuote quotes |s| so that the remote user's shell passes it as a single argument
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// NewTransferMuxConfig returns the configuration of the yamux sessions that multiplex the gRPC and HTTP connections
+// between a client and a `dolt transfer` process over a single ssh channel.
+func NewTransferMuxConfig() *yamux.Config {
+	cfg := yamux.DefaultConfig()
+	// writes go through ssh, which may be much slower than a local connection
+	cfg.ConnectionWriteTimeout = time.Minute
+	cfg.LogOutput = io.Discard
+	return cfg
+}
+
+// sshTransfer is the client session of a `dolt transfer` process run over ssh. Each of its streams is a connection to
+// the transfer process.
+type sshTransfer struct {
+	*yamux.Session
+	cmd    *exec.Cmd
+	stderr *sshStderr
+	exited chan struct{}
+}
+
+// sshPipe is the stdout and stdin of an ssh process. Closing it closes stdin, which ends the remote process.
+type sshPipe struct {
+	io.Reader
+	io.WriteCloser
+}
+
+func startSSHTransfer(cmd *exec.Cmd) (*sshTransfer, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, stdoutWr := io.Pipe()
+	cmd.Stdout = stdoutWr
+
+	t := &sshTransfer{
+		cmd:    cmd,
+		stderr: &sshStderr{},
+		exited: make(chan struct{}),
+	}
+	cmd.Stderr = t.stderr
+
+	if err = cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run '%s': %w", cmd.Path, err)
+	}
+	go func() {
+		defer close(t.exited)
+		err := cmd.Wait()
+		if err == nil {
+			err = io.EOF
+		}
+		stdoutWr.CloseWithError(err)
+	}()
+
+	t.Session, err = yamux.Client(sshPipe{stdout, stdin}, NewTransferMuxConfig())
+	if err != nil {
+		stdin.Close()
+		<-t.exited
+		return nil, err
+	}
+	return t, nil
+}
+
+// withStderr adds anything the ssh process wrote to stderr to |err|, once the process has exited.
+func (t *sshTransfer) withStderr(err error) error {
+	select {
+	case <-t.exited:
+	case <-time.After(time.Second):
+	}
+	if msg := t.stderr.String(); msg != "" {
+		return fmt.Errorf("%w\n%s", err, msg)
+	}
+	return err
+}
+
+// Close closes the session and waits for the ssh process to exit.
+func (t *sshTransfer) Close() error {
+	err := t.Session.Close()
+	select {
+	case <-t.exited:
+	case <-time.After(sshExitTimeout):
+		t.cmd.Process.Kill()
+		<-t.exited
+	}
+	return err
+}
+
+// sshStderr keeps the beginning of what an ssh process writes to stderr
+type sshStderr struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (e *sshStderr) Write(p []byte) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if remaining := maxSSHStderrSize - e.buf.Len(); remaining > 0 {
+		e.buf.Write(p[:min(len(p), remaining)])
+	}
+	return len(p), nil
+}
+
+func (e *sshStderr) String() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return strings.TrimSpace(e.buf.String())
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestSSHRemotePath(t *testing.T) {
+	tests := []struct {
+		url       string
+		path      string
+		expectErr bool
+	}{
+		{"ssh://host/var/lib/dolt/db", "/var/lib/dolt/db", false},
+		{"ssh://user@host:2222/db", "/db", false},
+		{"ssh://host/~/repos/db", "repos/db", false},
+		{"ssh://host/", "", true},
+		{"ssh://host", "", true},
+		{"ssh:///db", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			urlObj, err := url.Parse(test.url)
+			require.NoError(t, err)
+			path, err := sshRemotePath(urlObj)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.path, path)
+		})
+	}
+}
+
+func TestSSHTransferCmd(t *testing.T) {
+	urlObj, err := url.Parse("ssh://user@host:2222/path/it's a db")
+	require.NoError(t, err)
+
+	t.Setenv(dconfig.EnvSSH, "")
+	t.Setenv(dconfig.EnvSSHExecPath, "")
+	cmd, err := sshTransferCmd(urlObj, urlObj.Path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ssh", "-p", "2222", "--", "user@host", `dolt transfer '/path/it'\''s a db'`}, cmd.Args)
+
+	t.Setenv(dconfig.EnvSSH, "ssh -i ~/.ssh/dolt_key -o BatchMode=yes")
+	t.Setenv(dconfig.EnvSSHExecPath, "/opt/dolt/bin/dolt")
+	urlObj, err = url.Parse("ssh://host/db")
+	require.NoError(t, err)
+	cmd, err = sshTransferCmd(urlObj, urlObj.Path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ssh", "-i", "~/.ssh/dolt_key", "-o", "BatchMode=yes", "--", "host", `/opt/dolt/bin/dolt transfer '/db'`}, cmd.Args)
+}
+
+func TestSSHTransferCmdRejectsOptions(t *testing.T) {
+	t.Setenv(dconfig.EnvSSH, "")
+	t.Setenv(dconfig.EnvSSHExecPath, "")
+
+	for _, u := range []string{
+		"ssh://-oProxyCommand=id/db",
+		"ssh://-oProxyCommand=id:2222/db",
+		"ssh://-oProxyCommand=id@host/db",
+		"ssh://-l@host/db",
+	} {
+		t.Run(u, func(t *testing.T) {
+			urlObj, err := url.Parse(u)
+			require.NoError(t, err)
+			_, err = sshTransferCmd(urlObj, urlObj.Path)
+			assert.Error(t, err)
+		})
+	}
+
+	// a host that only contains a '-' past its first character is still allowed
+	urlObj, err := url.Parse("ssh://my-host/db")
+	require.NoError(t, err)
+	cmd, err := sshTransferCmd(urlObj, urlObj.Path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ssh", "--", "my-host", `dolt transfer '/db'`}, cmd.Args)
+}
+
+func TestSSHFactoryReportsStderr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	// the "ssh" command fails like ssh does when it cannot connect
+	script := filepath.Join(t.TempDir(), "ssh")
+	err := os.WriteFile(script, []byte("#!/bin/sh\necho \"ssh: connect to host $2 port 22: Connection refused\" >&2\nexit 255\n"), 0755)
+	require.NoError(t, err)
+	t.Setenv(dconfig.EnvSSH, script)
+
+	urlObj, err := url.Parse("ssh://host/db")
+	require.NoError(t, err)
+	_, _, _, err = SSHFactory{}.CreateDB(context.Background(), types.Format_Default, urlObj, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ssh: connect to host host port 22: Connection refused")
+}
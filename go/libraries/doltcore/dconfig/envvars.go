@@ -35,6 +35,13 @@ const (
 	EnvOssEndpoint                   = "OSS_ENDPOINT"
 	EnvOssAccessKeyID                = "OSS_ACCESS_KEY_ID"
 	EnvOssAccessKeySecret            = "OSS_ACCESS_KEY_SECRET"
+	EnvS3Endpoint                    = "DOLT_S3_ENDPOINT"
+	EnvAzureEndpoint                 = "DOLT_AZURE_ENDPOINT"
+	EnvAzureStorageConnectionString  = "AZURE_STORAGE_CONNECTION_STRING"
+	EnvAzureStorageKey               = "AZURE_STORAGE_KEY"
+	EnvAzureStorageSASToken          = "AZURE_STORAGE_SAS_TOKEN"
+	EnvSSH                           = "DOLT_SSH"
+	EnvSSHExecPath                   = "DOLT_SSH_EXEC_PATH"
 	EnvVerboseAssertTableFilesClosed = "DOLT_VERBOSE_ASSERT_TABLE_FILES_CLOSED"
 	EnvDisableGcProcedure            = "DOLT_DISABLE_GC_PROCEDURE"
 	EnvEditTableBufferRows           = "DOLT_EDIT_TABLE_BUFFER_ROWS"
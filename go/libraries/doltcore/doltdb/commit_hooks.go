@@ -15,9 +15,14 @@
 package doltdb
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"path"
+	"sort"
 	"sync"
 	"time"
 
@@ -200,6 +205,330 @@ func (*LogHook) ExecuteForWorkingSets() bool {
 	return false
 }
 
+// WebhookEndpoint is an HTTP endpoint which a WebhookHook POSTs a WebhookPayload to.
+type WebhookEndpoint struct {
+	URL string
+	// Refs are patterns, matched with path.Match, of the full names of the branches and tags reported to this
+	// endpoint. Every branch and tag is reported if empty.
+	Refs    []string
+	Headers map[string]string
+	// Timeout bounds each delivery attempt to the endpoint. webhookDefaultTimeout is used if it's zero.
+	Timeout time.Duration
+}
+
+func (e WebhookEndpoint) matches(refName string) bool {
+	if len(e.Refs) == 0 {
+		return true
+	}
+	for _, pattern := range e.Refs {
+		if ok, _ := path.Match(pattern, refName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookPayload is the JSON body describing a branch or tag that moved. The old and new hashes are commit hashes,
+// and are empty when the ref was created or deleted. The author, message and changed tables describe the new commit,
+// and the changed tables are only reported when the ref moved from one commit to another.
+type WebhookPayload struct {
+	Database      string         `json:"database"`
+	Ref           string         `json:"ref"`
+	OldHash       string         `json:"old_hash"`
+	NewHash       string         `json:"new_hash"`
+	Author        *WebhookAuthor `json:"author,omitempty"`
+	Message       string         `json:"message,omitempty"`
+	ChangedTables []string       `json:"changed_tables"`
+}
+
+type WebhookAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// ChangedTablesFunc returns the names of the tables which differ between two root values.
+type ChangedTablesFunc func(ctx context.Context, from, to RootValue) ([]string, error)
+
+type webhookEvent struct {
+	ref      ref.DoltRef
+	old, new hash.Hash
+}
+
+const (
+	webhookBufferSize     = 1024
+	webhookAttempts       = 3
+	webhookRetryBackoff   = time.Second
+	webhookDefaultTimeout = 10 * time.Second
+)
+
+// WebhookHook is a CommitHook which notifies HTTP endpoints when the branches and tags of a database move. The
+// payloads are built and delivered in order by a background thread, so that slow or failing endpoints don't hold up
+// commits. A delivery which fails is retried a few times before it is logged and dropped, and updates are logged and
+// dropped without being queued while the queue of deliveries is full.
+type WebhookHook struct {
+	ddb           *DoltDB
+	database      string
+	endpoints     []WebhookEndpoint
+	changedTables ChangedTablesFunc
+	client        *http.Client
+	out           io.Writer
+	ch            chan webhookEvent
+
+	mu sync.Mutex
+	// heads are the commits of the branches and tags of the database, by ref, which an update is compared to.
+	heads map[string]hash.Hash
+}
+
+var _ CommitHook = (*WebhookHook)(nil)
+
+// NewWebhookHook creates a WebhookHook for the database |ddb| named |database|, which runs its deliveries in
+// |bThreads|. The changed tables of a payload are computed by |changedTables|.
+func NewWebhookHook(ctx context.Context, bThreads *sql.BackgroundThreads, ddb *DoltDB, database string, endpoints []WebhookEndpoint, changedTables ChangedTablesFunc) (*WebhookHook, error) {
+	heads := make(map[string]hash.Hash)
+	branches, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range branches {
+		heads[b.Ref.String()] = b.Hash
+	}
+	tags, err := ddb.GetTagsWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		heads[ref.NewTagRef(t.Tag.Name).String()] = t.Hash
+	}
+
+	wh := &WebhookHook{
+		ddb:           ddb,
+		database:      database,
+		endpoints:     endpoints,
+		changedTables: changedTables,
+		client:        &http.Client{},
+		ch:            make(chan webhookEvent, webhookBufferSize),
+		heads:         heads,
+	}
+	err = bThreads.Add("webhook_delivery_"+database, wh.run)
+	if err != nil {
+		return nil, err
+	}
+	return wh, nil
+}
+
+// Execute implements CommitHook, queueing a payload if a branch or tag moved
+func (wh *WebhookHook) Execute(ctx context.Context, ds datas.Dataset, db datas.Database) (func(context.Context) error, error) {
+	if !ref.IsRef(ds.ID()) {
+		return nil, nil
+	}
+	dref, err := ref.Parse(ds.ID())
+	if err != nil {
+		return nil, err
+	}
+	if dref.GetType() != ref.BranchRefType && dref.GetType() != ref.TagRefType {
+		return nil, nil
+	}
+	if !wh.reported(dref.String()) {
+		return nil, nil
+	}
+
+	var addr hash.Hash
+	if ds.IsTag() {
+		_, addr, err = ds.HeadTag()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		addr, _ = ds.MaybeHeadAddr()
+	}
+
+	// the event is queued while holding the lock, so that concurrent moves of the same ref are queued in the order
+	// their heads were recorded
+	wh.mu.Lock()
+	defer wh.mu.Unlock()
+	old := wh.heads[dref.String()]
+	if addr.IsEmpty() {
+		delete(wh.heads, dref.String())
+	} else {
+		wh.heads[dref.String()] = addr
+	}
+	if old == addr {
+		return nil, nil
+	}
+
+	select {
+	case wh.ch <- webhookEvent{ref: dref, old: old, new: addr}:
+	default:
+		wh.logf("webhook: dropping the update of %s in %s, as the delivery queue is full\n", dref.String(), wh.database)
+	}
+	return nil, nil
+}
+
+func (wh *WebhookHook) reported(refName string) bool {
+	for _, e := range wh.endpoints {
+		if e.matches(refName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (wh *WebhookHook) run(ctx context.Context) {
+	for {
+		select {
+		case ev := <-wh.ch:
+			wh.deliver(ctx, ev)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (wh *WebhookHook) deliver(ctx context.Context, ev webhookEvent) {
+	payload, err := wh.payload(ctx, ev)
+	if err != nil {
+		wh.logf("webhook: error describing the update of %s in %s: %v\n", ev.ref.String(), wh.database, err)
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		wh.logf("webhook: error encoding the update of %s in %s: %v\n", ev.ref.String(), wh.database, err)
+		return
+	}
+
+	for _, e := range wh.endpoints {
+		if !e.matches(ev.ref.String()) {
+			continue
+		}
+		for attempt := 1; ; attempt++ {
+			err = wh.post(ctx, e, body)
+			if err == nil {
+				break
+			}
+			if attempt == webhookAttempts {
+				wh.logf("webhook: error delivering the update of %s in %s to %s: %v\n", ev.ref.String(), wh.database, e.URL, err)
+				break
+			}
+			select {
+			case <-time.After(webhookRetryBackoff * time.Duration(attempt)):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// payload returns the WebhookPayload for |ev|. If the commits of the update can't be read, the returned payload only
+// describes the ref and hashes, along with the error.
+func (wh *WebhookHook) payload(ctx context.Context, ev webhookEvent) (*WebhookPayload, error) {
+	payload := &WebhookPayload{
+		Database:      wh.database,
+		Ref:           ev.ref.String(),
+		ChangedTables: []string{},
+	}
+	if !ev.old.IsEmpty() {
+		payload.OldHash = ev.old.String()
+	}
+	if ev.new.IsEmpty() {
+		return payload, nil
+	}
+	payload.NewHash = ev.new.String()
+
+	newCm, err := wh.readCommit(ctx, ev.new)
+	if err != nil {
+		return payload, err
+	}
+	meta, err := newCm.GetCommitMeta(ctx)
+	if err != nil {
+		return payload, err
+	}
+	payload.Author = &WebhookAuthor{Name: meta.Name, Email: meta.Email}
+	payload.Message = meta.Description
+
+	if ev.old.IsEmpty() || wh.changedTables == nil {
+		return payload, nil
+	}
+	oldCm, err := wh.readCommit(ctx, ev.old)
+	if err != nil {
+		return payload, err
+	}
+	fromRoot, err := oldCm.GetRootValue(ctx)
+	if err != nil {
+		return payload, err
+	}
+	toRoot, err := newCm.GetRootValue(ctx)
+	if err != nil {
+		return payload, err
+	}
+	tables, err := wh.changedTables(ctx, fromRoot, toRoot)
+	if err != nil {
+		return payload, err
+	}
+	sort.Strings(tables)
+	payload.ChangedTables = append(payload.ChangedTables, tables...)
+	return payload, nil
+}
+
+func (wh *WebhookHook) readCommit(ctx context.Context, h hash.Hash) (*Commit, error) {
+	optCmt, err := wh.ddb.ReadCommit(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
+func (wh *WebhookHook) post(ctx context.Context, e WebhookEndpoint, body []byte) error {
+	timeout := e.Timeout
+	if timeout <= 0 {
+		timeout = webhookDefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := wh.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return nil
+}
+
+func (wh *WebhookHook) logf(format string, args ...interface{}) {
+	if wh.out != nil {
+		wh.out.Write([]byte(fmt.Sprintf(format, args...)))
+	}
+}
+
+// HandleError implements CommitHook
+func (wh *WebhookHook) HandleError(ctx context.Context, err error) error {
+	wh.logf("webhook: %s\n", err.Error())
+	return nil
+}
+
+// SetLogger implements CommitHook
+func (wh *WebhookHook) SetLogger(ctx context.Context, wr io.Writer) error {
+	wh.out = wr
+	return nil
+}
+
+func (*WebhookHook) ExecuteForWorkingSets() bool {
+	return false
+}
+
 func RunAsyncReplicationThreads(bThreads *sql.BackgroundThreads, ch chan PushArg, destDB *DoltDB, tmpDir string, logger io.Writer) error {
 	mu := &sync.Mutex{}
 	var newHeads = make(map[string]PushArg, asyncPushBufferSize)
@@ -17,8 +17,11 @@ package doltdb
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
 	"testing"
 	"time"
@@ -34,6 +37,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/test"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -328,6 +332,141 @@ func TestAsyncPushOnWrite(t *testing.T) {
 
 var _ CommitHook = (*countingCommitHook)(nil)
 
+func TestWebhookHook(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+
+	type request struct {
+		payload WebhookPayload
+		header  http.Header
+	}
+	requests := make(chan request, 8)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload WebhookPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		requests <- request{payload, r.Header}
+	}))
+	defer srv.Close()
+	next := func() request {
+		select {
+		case r := <-requests:
+			return r
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for a webhook request")
+			return request{}
+		}
+	}
+
+	bThreads := sql.NewBackgroundThreads()
+	defer bThreads.Shutdown()
+	endpoints := []WebhookEndpoint{{URL: srv.URL, Refs: []string{"refs/heads/*"}, Headers: map[string]string{"Authorization": "Bearer token"}}}
+	changedTables := func(ctx context.Context, from, to RootValue) ([]string, error) {
+		return []string{"test"}, nil
+	}
+	hook, err := NewWebhookHook(ctx, bThreads, ddb, "mydb", endpoints, changedTables)
+	require.NoError(t, err)
+	execute := func(refName string) {
+		ds, err := ddb.db.GetDataset(ctx, refName)
+		require.NoError(t, err)
+		_, err = hook.Execute(ctx, ds, ddb.db)
+		require.NoError(t, err)
+	}
+
+	mainRef := ref.NewBranchRef("main")
+	initial, err := ddb.ResolveCommitRef(ctx, mainRef)
+	require.NoError(t, err)
+	initialHash, err := initial.HashOf()
+	require.NoError(t, err)
+	root, err := initial.GetRootValue(ctx)
+	require.NoError(t, err)
+	tSchema := createTestSchema(t)
+	rowData, err := durable.NewEmptyIndex(ctx, ddb.vrw, ddb.ns, tSchema)
+	require.NoError(t, err)
+	tbl, err := CreateTestTable(ddb.vrw, ddb.ns, tSchema, rowData)
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, TableName{Name: "test"}, tbl)
+	require.NoError(t, err)
+	_, valHash, err := ddb.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := datas.NewCommitMeta("Jane Doe", "jane@fake.horse", "add test table")
+	require.NoError(t, err)
+	cm, err := ddb.Commit(ctx, valHash, mainRef, meta)
+	require.NoError(t, err)
+	cmHash, err := cm.HashOf()
+	require.NoError(t, err)
+
+	execute(mainRef.String())
+	r := next()
+	assert.Equal(t, "Bearer token", r.header.Get("Authorization"))
+	assert.Equal(t, WebhookPayload{
+		Database:      "mydb",
+		Ref:           "refs/heads/main",
+		OldHash:       initialHash.String(),
+		NewHash:       cmHash.String(),
+		Author:        &WebhookAuthor{Name: "Jane Doe", Email: "jane@fake.horse"},
+		Message:       "add test table",
+		ChangedTables: []string{"test"},
+	}, r.payload)
+
+	// Tags aren't matched by the endpoint, and a branch which didn't move isn't reported
+	tagRef := ref.NewTagRef("v1")
+	require.NoError(t, ddb.NewTagAtCommit(ctx, tagRef, cm, datas.NewTagMeta("Jane Doe", "jane@fake.horse", "")))
+	execute(tagRef.String())
+	execute(mainRef.String())
+
+	featureRef := ref.NewBranchRef("feature")
+	require.NoError(t, ddb.NewBranchAtCommit(ctx, featureRef, cm, nil))
+	execute(featureRef.String())
+	r = next()
+	assert.Equal(t, "refs/heads/feature", r.payload.Ref)
+	assert.Equal(t, "", r.payload.OldHash)
+	assert.Equal(t, cmHash.String(), r.payload.NewHash)
+	assert.Empty(t, r.payload.ChangedTables)
+
+	require.NoError(t, ddb.DeleteBranch(ctx, featureRef, nil))
+	execute(featureRef.String())
+	r = next()
+	assert.Equal(t, "refs/heads/feature", r.payload.Ref)
+	assert.Equal(t, cmHash.String(), r.payload.OldHash)
+	assert.Equal(t, "", r.payload.NewHash)
+	assert.Nil(t, r.payload.Author)
+}
+
+func TestWebhookHookDropsUpdatesWhenQueueIsFull(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB, filesys.LocalFS)
+	require.NoError(t, err)
+	require.NoError(t, ddb.WriteEmptyRepo(ctx, "main", "Bill Billerson", "bigbillieb@fake.horse"))
+	cm, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+	require.NoError(t, err)
+
+	// Without a delivery thread, the queue fills up after its first update
+	var out bytes.Buffer
+	hook := &WebhookHook{
+		ddb:       ddb,
+		database:  "mydb",
+		endpoints: []WebhookEndpoint{{URL: "http://localhost:0"}},
+		client:    &http.Client{},
+		out:       &out,
+		ch:        make(chan webhookEvent, 1),
+		heads:     make(map[string]hash.Hash),
+	}
+	for _, name := range []string{"b1", "b2"} {
+		branchRef := ref.NewBranchRef(name)
+		require.NoError(t, ddb.NewBranchAtCommit(ctx, branchRef, cm, nil))
+		ds, err := ddb.db.GetDataset(ctx, branchRef.String())
+		require.NoError(t, err)
+		_, err = hook.Execute(ctx, ds, ddb.db)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, hook.ch, 1)
+	assert.Equal(t, "refs/heads/b1", (<-hook.ch).ref.String())
+	assert.Contains(t, out.String(), "dropping the update of refs/heads/b2 in mydb, as the delivery queue is full")
+}
+
 type countingCommitHook struct {
 	// The number of times Execute() got called for given dataset.
 	counts map[string]int
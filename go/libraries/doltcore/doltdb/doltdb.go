@@ -861,6 +861,7 @@ func (ddb *DoltDB) CommitValue(ctx context.Context, dref ref.DoltRef, val types.
 	if err != nil {
 		return nil, err
 	}
+	ddb.recordCommit(dref)
 
 	r, ok, err := ds.MaybeHeadRef()
 	if err != nil {
@@ -1345,7 +1346,11 @@ func (ddb *DoltDB) deleteRef(ctx context.Context, dref ref.DoltRef, replicationS
 	}
 
 	_, err = ddb.db.withReplicationStatusController(replicationStatus).Delete(ctx, ds, wsPath)
-	return err
+	if err != nil {
+		return err
+	}
+	ddb.forgetCommits(dref)
+	return nil
 }
 
 // DeleteAllRefs Very destructive, use with caution. Not only does this drop all data, Dolt assume there is always
@@ -1497,6 +1502,7 @@ func (ddb *DoltDB) CommitWithWorkingSet(
 	if err != nil {
 		return nil, err
 	}
+	ddb.recordCommit(headRef)
 
 	commitRef, ok, err := commitDataset.MaybeHeadRef()
 	if err != nil {
@@ -1625,15 +1631,16 @@ func (ddb *DoltDB) Rebase(ctx context.Context) error {
 
 // GC performs garbage collection on this ddb.
 //
-// If |safepointF| is non-nil, it will be called at some point after the GC begins
-// and before the GC ends. It will be called without
-// Database/ValueStore/NomsBlockStore locks held. If should establish
+// If |safepoint| is non-nil, its methods will be called at some point after
+// the GC begins and before the GC ends. They will be called without
+// Database/ValueStore/NomsBlockStore locks held. They should establish
 // safepoints in every application-level in-progress read and write workflow
-// against this DoltDB. Examples of doing this include, for example, blocking
-// until no possibly-stale ChunkStore state is retained in memory, or failing
-// certain in-progress operations which cannot be finalized in a timely manner,
-// etc.
-func (ddb *DoltDB) GC(ctx context.Context, safepointF func() error) error {
+// against this DoltDB, and keep the chunks those workflows hold references
+// to. Examples of doing this include, for example, blocking until no
+// in-progress work is running, and then keeping the in-memory roots of every
+// session, or failing certain in-progress operations which cannot be
+// finalized in a timely manner, etc.
+func (ddb *DoltDB) GC(ctx context.Context, safepoint types.GCSafepointController) error {
 	collector, ok := ddb.db.Database.(datas.GarbageCollector)
 	if !ok {
 		return fmt.Errorf("this database does not support garbage collection")
@@ -1677,7 +1684,7 @@ func (ddb *DoltDB) GC(ctx context.Context, safepointF func() error) error {
 		return err
 	}
 
-	return collector.GC(ctx, oldGen, newGen, safepointF)
+	return collector.GC(ctx, oldGen, newGen, safepoint)
 }
 
 func (ddb *DoltDB) ShallowGC(ctx context.Context) error {
@@ -1739,10 +1746,21 @@ func pullHash(
 	tempDir string,
 	statsCh chan pull.Stats,
 	skipHashes hash.HashSet,
+) error {
+	waf := types.WalkAddrsForNBF(srcDB.Format(), skipHashes)
+	return pullHashWithWalk(ctx, destDB, srcDB, targetHashes, tempDir, statsCh, waf)
+}
+
+func pullHashWithWalk(
+	ctx context.Context,
+	destDB, srcDB datas.Database,
+	targetHashes []hash.Hash,
+	tempDir string,
+	statsCh chan pull.Stats,
+	waf pull.WalkAddrs,
 ) error {
 	srcCS := datas.ChunkStoreFromDatabase(srcDB)
 	destCS := datas.ChunkStoreFromDatabase(destDB)
-	waf := types.WalkAddrsForNBF(srcDB.Format(), skipHashes)
 
 	if datas.CanUsePuller(srcDB) && datas.CanUsePuller(destDB) {
 		puller, err := pull.NewPuller(ctx, tempDir, defaultChunksPerTF, srcCS, destCS, waf, targetHashes, statsCh)
@@ -1758,6 +1776,44 @@ func pullHash(
 	}
 }
 
+// ReachableChunks returns the addresses of every chunk reachable from |addrs|, including |addrs| themselves.
+func (ddb *DoltDB) ReachableChunks(ctx context.Context, addrs []hash.Hash) (hash.HashSet, error) {
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	waf := types.WalkAddrsForNBF(ddb.Format(), nil)
+
+	var mu sync.Mutex
+	reachable := hash.NewHashSet(addrs...)
+	next := hash.NewHashSet(addrs...)
+	for next.Size() > 0 {
+		batch := next
+		next = make(hash.HashSet)
+		var walkErr error
+		err := cs.GetMany(ctx, batch, func(ctx context.Context, c *chunks.Chunk) {
+			err := waf(*c, func(h hash.Hash, _ bool) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if !reachable.Has(h) {
+					reachable.Insert(h)
+					next.Insert(h)
+				}
+				return nil
+			})
+			if err != nil {
+				mu.Lock()
+				walkErr = err
+				mu.Unlock()
+			}
+		})
+		if err != nil {
+			return nil, err
+		} else if walkErr != nil {
+			return nil, walkErr
+		}
+	}
+
+	return reachable, nil
+}
+
 func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- pull.TableFileEvent) error {
 	return pull.Clone(ctx, datas.ChunkStoreFromDatabase(ddb.db), datas.ChunkStoreFromDatabase(destDB.db), eventCh)
 }
@@ -1768,6 +1824,16 @@ func (ddb *DoltDB) IsTableFileStore() bool {
 	return ok
 }
 
+// BundlePrerequisites returns the commits a database must have to fetch from this DoltDB, and true, if it was read
+// from a bundle. It returns false otherwise.
+func (ddb *DoltDB) BundlePrerequisites() ([]hash.Hash, bool) {
+	cs, ok := datas.ChunkStoreFromDatabase(ddb.db).(dbfactory.BundleChunkStore)
+	if !ok {
+		return nil, false
+	}
+	return cs.Prerequisites(), true
+}
+
 // ChunkJournal returns the ChunkJournal for this DoltDB, if one is in use.
 func (ddb *DoltDB) ChunkJournal() *nbs.ChunkJournal {
 	tableFileStore, ok := datas.ChunkStoreFromDatabase(ddb.db).(chunks.TableFileStore)
@@ -1789,6 +1855,79 @@ func (ddb *DoltDB) ChunkJournal() *nbs.ChunkJournal {
 	return nbs.ChunkJournal()
 }
 
+// BranchCommits is the number of commits made to a branch of a database by this process.
+type BranchCommits struct {
+	Database string
+	Branch   string
+	Commits  uint64
+}
+
+type branchCommitsKey struct {
+	database string
+	branch   string
+}
+
+var branchCommits = struct {
+	mu     sync.Mutex
+	counts map[branchCommitsKey]uint64
+}{counts: make(map[branchCommitsKey]uint64)}
+
+// recordCommit counts a commit made to |dref|, if it is a branch.
+func (ddb *DoltDB) recordCommit(dref ref.DoltRef) {
+	if dref.GetType() != ref.BranchRefType {
+		return
+	}
+	branchCommits.mu.Lock()
+	defer branchCommits.mu.Unlock()
+	branchCommits.counts[branchCommitsKey{database: ddb.databaseName, branch: dref.GetPath()}]++
+}
+
+// forgetCommits stops counting the commits made to |dref| once it is deleted, so that the counts don't grow with
+// every branch ever committed to.
+func (ddb *DoltDB) forgetCommits(dref ref.DoltRef) {
+	if dref.GetType() != ref.BranchRefType {
+		return
+	}
+	branchCommits.mu.Lock()
+	defer branchCommits.mu.Unlock()
+	delete(branchCommits.counts, branchCommitsKey{database: ddb.databaseName, branch: dref.GetPath()})
+}
+
+// GetBranchCommits returns the number of commits made to each branch by this process, for each branch with commits.
+func GetBranchCommits() []BranchCommits {
+	branchCommits.mu.Lock()
+	defer branchCommits.mu.Unlock()
+	commits := make([]BranchCommits, 0, len(branchCommits.counts))
+	for k, n := range branchCommits.counts {
+		commits = append(commits, BranchCommits{Database: k.database, Branch: k.branch, Commits: n})
+	}
+	return commits
+}
+
+// StorageStats describe the storage of a DoltDB which grows between garbage collections.
+type StorageStats struct {
+	// JournalSize is the size, in bytes, of the chunk journal, or 0 if there is none.
+	JournalSize uint64
+	// TableFiles is the number of table files written since the last garbage collection.
+	TableFiles int
+}
+
+// StorageStats returns the StorageStats of this DoltDB. For stores which are not generational, the stats are zero.
+func (ddb *DoltDB) StorageStats() StorageStats {
+	generationalNbs, ok := datas.ChunkStoreFromDatabase(ddb.db).(*nbs.GenerationalNBS)
+	if !ok {
+		return StorageStats{}
+	}
+	newGen, ok := generationalNbs.NewGen().(*nbs.NomsBlockStore)
+	if !ok {
+		return StorageStats{}
+	}
+	return StorageStats{
+		JournalSize: newGen.JournalSize(),
+		TableFiles:  newGen.TableFileCount(),
+	}
+}
+
 func (ddb *DoltDB) TableFileStoreHasJournal(ctx context.Context) (bool, error) {
 	tableFileStore, ok := datas.ChunkStoreFromDatabase(ddb.db).(chunks.TableFileStore)
 	if !ok {
@@ -0,0 +1,152 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// MergeRuleStrategy is the name of a strategy that resolves concurrent changes to the same cell during a merge.
+type MergeRuleStrategy string
+
+const (
+	// MergeRuleOurs resolves a cell conflict by keeping the value from our side of the merge.
+	MergeRuleOurs MergeRuleStrategy = "ours"
+	// MergeRuleTheirs resolves a cell conflict by taking the value from their side of the merge.
+	MergeRuleTheirs MergeRuleStrategy = "theirs"
+	// MergeRuleSum resolves a cell conflict on a numeric column by applying the changes made on both sides of the
+	// merge, so the result is ours + theirs - base. A NULL value counts as zero.
+	MergeRuleSum MergeRuleStrategy = "sum"
+	// MergeRuleMax resolves a cell conflict by taking the greater of the two values. NULL values are ignored.
+	MergeRuleMax MergeRuleStrategy = "max"
+	// MergeRuleMin resolves a cell conflict by taking the lesser of the two values. NULL values are ignored.
+	MergeRuleMin MergeRuleStrategy = "min"
+)
+
+// MergeRuleAllColumns is the column name that applies a merge rule to every column of a table that doesn't have a
+// rule of its own.
+const MergeRuleAllColumns = "*"
+
+var mergeRuleStrategies = []MergeRuleStrategy{MergeRuleOurs, MergeRuleTheirs, MergeRuleSum, MergeRuleMax, MergeRuleMin}
+
+// ParseMergeRuleStrategy returns the MergeRuleStrategy named by |s|, ignoring case.
+func ParseMergeRuleStrategy(s string) (MergeRuleStrategy, error) {
+	for _, strategy := range mergeRuleStrategies {
+		if strings.EqualFold(s, string(strategy)) {
+			return strategy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid merge rule strategy '%s'; valid strategies are ours, theirs, sum, max, and min", s)
+}
+
+// MergeRule is a row of the dolt_merge_rules table, which declares how cell conflicts in a column are resolved.
+type MergeRule struct {
+	Table    string
+	Column   string
+	Strategy MergeRuleStrategy
+}
+
+// MergeRules is the set of rules declared for a single table.
+type MergeRules []MergeRule
+
+// StrategyForColumn returns the strategy used to resolve conflicts in the column named |column|. A rule for the
+// column itself takes precedence over a rule for all columns of the table. Returns false if no rule applies.
+func (rules MergeRules) StrategyForColumn(column string) (MergeRuleStrategy, bool) {
+	var tableStrategy MergeRuleStrategy
+	var found bool
+	for _, rule := range rules {
+		if strings.EqualFold(rule.Column, column) {
+			return rule.Strategy, true
+		}
+		if rule.Column == MergeRuleAllColumns {
+			tableStrategy, found = rule.Strategy, true
+		}
+	}
+	return tableStrategy, found
+}
+
+// GetMergeRules returns the rules in the dolt_merge_rules table of |root|, keyed by the lower-cased name of the table
+// they apply to. A merge uses the rules from our side of the merge; rules that only exist on their side, or in the
+// merge base, are not consulted.
+func GetMergeRules(ctx context.Context, root RootValue) (map[string]MergeRules, error) {
+	table, found, err := root.GetTable(ctx, TableName{Name: MergeRulesTableName})
+	if err != nil {
+		return nil, err
+	}
+	if !found || table.Format() == types.Format_LD_1 {
+		// dolt_merge_rules is not supported for the legacy storage format.
+		return nil, nil
+	}
+
+	index, err := table.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keyDesc, valueDesc := sch.GetMapDescriptors()
+
+	if !keyDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.StringEnc}, val.Type{Enc: val.StringEnc})) {
+		return nil, fmt.Errorf("dolt_merge_rules had unexpected key type, this should never happen")
+	}
+	if !valueDesc.Equals(val.NewTupleDescriptor(val.Type{Enc: val.StringEnc})) {
+		return nil, fmt.Errorf("dolt_merge_rules had unexpected value type, this should never happen")
+	}
+
+	iter, err := durable.ProllyMapFromIndex(index).IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make(map[string]MergeRules)
+	for {
+		keyTuple, valueTuple, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tableName, ok := keyDesc.GetString(0, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read table_name")
+		}
+		column, ok := keyDesc.GetString(1, keyTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read column_name")
+		}
+		s, ok := valueDesc.GetString(0, valueTuple)
+		if !ok {
+			return nil, fmt.Errorf("could not read strategy")
+		}
+		strategy, err := ParseMergeRuleStrategy(s)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(tableName)
+		rules[key] = append(rules[key], MergeRule{Table: tableName, Column: column, Strategy: strategy})
+	}
+	return rules, nil
+}
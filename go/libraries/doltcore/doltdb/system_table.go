@@ -143,6 +143,8 @@ var writeableSystemTables = []string{
 	SchemasTableName,
 	ProceduresTableName,
 	IgnoreTableName,
+	MergeRulesTableName,
+	TestsTableName,
 	RebaseTableName,
 }
 
@@ -152,6 +154,8 @@ var persistedSystemTables = []string{
 	SchemasTableName,
 	ProceduresTableName,
 	IgnoreTableName,
+	MergeRulesTableName,
+	TestsTableName,
 }
 
 var generatedSystemTables = []string{
@@ -291,11 +295,23 @@ const (
 
 	IgnoreTableName = "dolt_ignore"
 
+	// MergeRulesTableName is the merge rules system table name
+	MergeRulesTableName = "dolt_merge_rules"
+
+	// TestsTableName is the tests system table name
+	TestsTableName = "dolt_tests"
+
 	// RebaseTableName is the rebase system table name.
 	RebaseTableName = "dolt_rebase"
 
 	// StatisticsTableName is the statistics system table name
 	StatisticsTableName = "dolt_statistics"
+
+	// StashesTableName is the stashes system table name
+	StashesTableName = "dolt_stashes"
+
+	// GCStatusTableName is the garbage collection status system table name
+	GCStatusTableName = "dolt_gc_status"
 )
 
 const (
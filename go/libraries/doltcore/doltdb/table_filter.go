@@ -0,0 +1,284 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var ErrLazyFetchNotSupported = errors.New("database does not support partial clones and fetches")
+
+// TableFilter selects the tables whose data is pulled by a partial clone or fetch. The rows and indexes of other
+// tables are left out, and are fetched from the remote when they are first read. Their schemas are always pulled.
+type TableFilter struct {
+	// Tables, when not empty, are the only tables whose data is pulled
+	Tables []string
+	// ExcludeTables are tables whose data is not pulled
+	ExcludeTables []string
+}
+
+// IsEmpty returns whether the filter pulls the data of every table
+func (f TableFilter) IsEmpty() bool {
+	return len(f.Tables) == 0 && len(f.ExcludeTables) == 0
+}
+
+// Includes returns whether the data of the table named |name| is pulled. Table names are case-insensitive.
+func (f TableFilter) Includes(name string) bool {
+	for _, t := range f.ExcludeTables {
+		if strings.EqualFold(t, name) {
+			return false
+		}
+	}
+	if len(f.Tables) == 0 {
+		return true
+	}
+	for _, t := range f.Tables {
+		if strings.EqualFold(t, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// PullChunksWithTableFilter pulls the chunks reachable from |targetHashes| in |srcDB| like PullChunks, leaving out the
+// data of the tables not included by |filter|. The chunks left out are recorded as lazy chunks, which are fetched
+// from a remote when they are first read. See SetLazyFetcher.
+func (ddb *DoltDB) PullChunksWithTableFilter(
+	ctx context.Context,
+	tempDir string,
+	srcDB *DoltDB,
+	targetHashes []hash.Hash,
+	statsCh chan pull.Stats,
+	skipHashes hash.HashSet,
+	filter TableFilter,
+) error {
+	if filter.IsEmpty() {
+		return ddb.PullChunks(ctx, tempDir, srcDB, targetHashes, statsCh, skipHashes)
+	}
+	if !types.IsFormat_DOLT(srcDB.Format()) {
+		return errors.New("partial clones and fetches are only supported for the __DOLT__ storage format")
+	}
+
+	ghosts, err := ddb.ghostStore()
+	if err != nil {
+		return err
+	}
+
+	w := newTableDataWalker(types.WalkAddrsForNBF(srcDB.Format(), skipHashes), filter)
+	err = pullHashWithWalk(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, w.walkAddrs)
+	if err != nil {
+		return err
+	}
+
+	groups, err := w.lazyGroups(ctx, datas.ChunkStoreFromDatabase(ddb.db))
+	if err != nil || len(groups) == 0 {
+		return err
+	}
+	return ghosts.AddLazyHashes(ctx, groups)
+}
+
+// SetLazyFetcher sets the function used to fetch the chunks left out by PullChunksWithTableFilter when they are first
+// read from this database. |fetch| must pull the chunks |hashes| into |sink|.
+func (ddb *DoltDB) SetLazyFetcher(fetch func(ctx context.Context, sink *DoltDB, hashes []hash.Hash) error) error {
+	gcs, ok := datas.ChunkStoreFromDatabase(ddb.db).(*nbs.GenerationalNBS)
+	if !ok {
+		return ErrLazyFetchNotSupported
+	}
+	gcs.SetLazyFetcher(func(ctx context.Context, sink chunks.ChunkStore, hashes hash.HashSet) error {
+		addrs := make([]hash.Hash, 0, hashes.Size())
+		for h := range hashes {
+			addrs = append(addrs, h)
+		}
+		return fetch(ctx, DoltDBFromCS(sink), addrs)
+	})
+	return nil
+}
+
+func (ddb *DoltDB) ghostStore() (*nbs.GhostBlockStore, error) {
+	gcs, ok := datas.ChunkStoreFromDatabase(ddb.db).(chunks.GenerationalCS)
+	if !ok {
+		return nil, ErrLazyFetchNotSupported
+	}
+	ghosts, ok := gcs.GhostGen().(*nbs.GhostBlockStore)
+	if !ok || ghosts == nil {
+		return nil, ErrLazyFetchNotSupported
+	}
+	return ghosts, nil
+}
+
+// tableDataWalker walks the addresses of chunks being pulled, leaving out the primary and secondary indexes of tables
+// excluded by a TableFilter. Root values are walked before the tables they reference, so their table maps are used to
+// find the names of the tables being walked.
+type tableDataWalker struct {
+	walk   pull.WalkAddrs
+	filter TableFilter
+
+	mu sync.Mutex
+	// tableMaps are the internal nodes of root values' table maps
+	tableMaps hash.HashSet
+	// included and excluded are the addresses of tables whose data is, and is not, pulled. A table which is included
+	// under any name is pulled.
+	included hash.HashSet
+	excluded hash.HashSet
+	// lazy are the addresses left out of each excluded table which has been walked
+	lazy map[hash.Hash]hash.HashSet
+}
+
+func newTableDataWalker(walk pull.WalkAddrs, filter TableFilter) *tableDataWalker {
+	return &tableDataWalker{
+		walk:      walk,
+		filter:    filter,
+		tableMaps: make(hash.HashSet),
+		included:  make(hash.HashSet),
+		excluded:  make(hash.HashSet),
+		lazy:      make(map[hash.Hash]hash.HashSet),
+	}
+}
+
+func (w *tableDataWalker) walkAddrs(c chunks.Chunk, cb func(h hash.Hash, isleaf bool) error) error {
+	switch serial.GetFileID(c.Data()) {
+	case serial.RootValueFileID:
+		var msg serial.RootValue
+		err := serial.InitRootValueRoot(&msg, c.Data(), serial.MessagePrefixSz)
+		if err != nil {
+			return err
+		}
+		if err = w.visitTableMap(msg.TablesBytes()); err != nil {
+			return err
+		}
+	case serial.AddressMapFileID:
+		if w.isTableMap(c.Hash()) {
+			if err := w.visitTableMap(c.Data()); err != nil {
+				return err
+			}
+		}
+	case serial.TableFileID:
+		if w.isExcluded(c.Hash()) {
+			data, err := tableDataAddrs(c.Data())
+			if err != nil {
+				return err
+			}
+			w.mu.Lock()
+			w.lazy[c.Hash()] = data
+			w.mu.Unlock()
+
+			return w.walk(c, func(h hash.Hash, isleaf bool) error {
+				if data.Has(h) {
+					return nil
+				}
+				return cb(h, isleaf)
+			})
+		}
+	}
+
+	return w.walk(c, cb)
+}
+
+// visitTableMap records the tables of the table map node |msg|, or its children if it is an internal node
+func (w *tableDataWalker) visitTableMap(msg []byte) error {
+	nd, err := tree.NodeFromBytes(msg)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := 0; i < nd.Count(); i++ {
+		addr := hash.New(nd.GetValue(i))
+		if !nd.IsLeaf() {
+			w.tableMaps.Insert(addr)
+		} else if w.filter.Includes(tableNameFromAddressMapKey(string(nd.GetKey(i)))) {
+			w.included.Insert(addr)
+		} else {
+			w.excluded.Insert(addr)
+		}
+	}
+	return nil
+}
+
+func (w *tableDataWalker) isTableMap(h hash.Hash) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.tableMaps.Has(h)
+}
+
+func (w *tableDataWalker) isExcluded(h hash.Hash) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.excluded.Has(h) && !w.included.Has(h)
+}
+
+// lazyGroups returns the addresses left out of each excluded table which are missing from |cs|
+func (w *tableDataWalker) lazyGroups(ctx context.Context, cs chunks.ChunkStore) ([]hash.HashSet, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var groups []hash.HashSet
+	for _, data := range w.lazy {
+		absent, err := cs.HasMany(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		if absent.Size() > 0 {
+			groups = append(groups, absent)
+		}
+	}
+	return groups, nil
+}
+
+// tableDataAddrs returns the addresses referenced by the primary and secondary indexes of the table message |msg|
+func tableDataAddrs(msg []byte) (hash.HashSet, error) {
+	var tbl serial.Table
+	err := serial.InitTableRoot(&tbl, msg, serial.MessagePrefixSz)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make(hash.HashSet)
+	cb := func(h hash.Hash) error {
+		addrs.Insert(h)
+		return nil
+	}
+	if err = types.SerialMessage(tbl.SecondaryIndexesBytes()).WalkAddrs(types.Format_DOLT, cb); err != nil {
+		return nil, err
+	}
+	if err = types.SerialMessage(tbl.PrimaryIndexBytes()).WalkAddrs(types.Format_DOLT, cb); err != nil {
+		return nil, err
+	}
+	return addrs, nil
+}
+
+// tableNameFromAddressMapKey returns the name of the table stored under |key| in a root value's table map, without
+// the schema it may be qualified with
+func tableNameFromAddressMapKey(key string) string {
+	if len(key) > 0 && key[0] == 0 {
+		if i := strings.IndexByte(key[1:], 0); i >= 0 {
+			return key[i+2:]
+		}
+	}
+	return key
+}
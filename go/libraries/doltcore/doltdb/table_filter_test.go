@@ -0,0 +1,53 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var tableFilterTests = []struct {
+	name     string
+	filter   TableFilter
+	table    string
+	includes bool
+}{
+	{"empty filter", TableFilter{}, "t1", true},
+	{"included", TableFilter{Tables: []string{"t1", "t2"}}, "t2", true},
+	{"not included", TableFilter{Tables: []string{"t1", "t2"}}, "t3", false},
+	{"excluded", TableFilter{ExcludeTables: []string{"t1"}}, "t1", false},
+	{"not excluded", TableFilter{ExcludeTables: []string{"t1"}}, "t2", true},
+	{"case insensitive", TableFilter{Tables: []string{"T1"}}, "t1", true},
+	{"exclude wins", TableFilter{Tables: []string{"t1"}, ExcludeTables: []string{"t1"}}, "t1", false},
+}
+
+func TestTableFilter(t *testing.T) {
+	for _, test := range tableFilterTests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.includes, test.filter.Includes(test.table))
+		})
+	}
+
+	require.True(t, TableFilter{}.IsEmpty())
+	require.False(t, TableFilter{ExcludeTables: []string{"t1"}}.IsEmpty())
+}
+
+func TestTableNameFromAddressMapKey(t *testing.T) {
+	require.Equal(t, "t1", tableNameFromAddressMapKey("t1"))
+	require.Equal(t, "t1", tableNameFromAddressMapKey("\x00mySchema\x00t1"))
+	require.Equal(t, "", tableNameFromAddressMapKey(""))
+}
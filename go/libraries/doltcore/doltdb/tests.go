@@ -0,0 +1,203 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// TestAssertionType is the kind of result that a test in the dolt_tests table makes an assertion about.
+type TestAssertionType string
+
+const (
+	// TestAssertionExpectedRows asserts on the number of rows returned by the test query.
+	TestAssertionExpectedRows TestAssertionType = "expected_rows"
+	// TestAssertionExpectedColumns asserts on the number of columns returned by the test query.
+	TestAssertionExpectedColumns TestAssertionType = "expected_columns"
+	// TestAssertionExpectedSingleValue asserts on the only value returned by the test query, which must return exactly
+	// one row with one column.
+	TestAssertionExpectedSingleValue TestAssertionType = "expected_single_value"
+)
+
+var testAssertionTypes = []TestAssertionType{TestAssertionExpectedRows, TestAssertionExpectedColumns, TestAssertionExpectedSingleValue}
+
+var testComparators = []string{"==", "!=", "<", "<=", ">", ">="}
+
+// DoltTest is a row of the dolt_tests table, which declares a query along with an assertion about its result.
+type DoltTest struct {
+	Name          string
+	Group         string
+	Query         string
+	AssertionType TestAssertionType
+	Comparator    string
+	// Value is the value the result is compared to, which is nil for a NULL assertion value.
+	Value *string
+}
+
+// ValidateTestAssertion returns an error if the given assertion type, comparator and value can't be used together in a
+// test. The assertion type and comparator are returned in their canonical form.
+func ValidateTestAssertion(assertionType string, comparator string, value *string) (TestAssertionType, string, error) {
+	var found bool
+	var typ TestAssertionType
+	for _, t := range testAssertionTypes {
+		if strings.EqualFold(assertionType, string(t)) {
+			typ, found = t, true
+			break
+		}
+	}
+	if !found {
+		return "", "", fmt.Errorf("invalid assertion type '%s'; valid types are expected_rows, expected_columns, and expected_single_value", assertionType)
+	}
+
+	comparator = strings.TrimSpace(comparator)
+	if comparator == "=" {
+		comparator = "=="
+	} else if comparator == "<>" {
+		comparator = "!="
+	}
+	found = false
+	for _, c := range testComparators {
+		if comparator == c {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", "", fmt.Errorf("invalid assertion comparator '%s'; valid comparators are ==, !=, <, <=, >, and >=", comparator)
+	}
+
+	if typ != TestAssertionExpectedSingleValue {
+		if value == nil {
+			return "", "", fmt.Errorf("the assertion value for %s must be an integer", typ)
+		}
+		if _, err := strconv.ParseInt(strings.TrimSpace(*value), 10, 64); err != nil {
+			return "", "", fmt.Errorf("the assertion value for %s must be an integer, got '%s'", typ, *value)
+		}
+	}
+	return typ, comparator, nil
+}
+
+// Check returns an error describing why the test failed if the given result of its query doesn't satisfy its
+// assertion.
+func (t DoltTest) Check(sch sql.Schema, rows []sql.Row) error {
+	switch t.AssertionType {
+	case TestAssertionExpectedRows:
+		return t.checkCount("rows", int64(len(rows)))
+	case TestAssertionExpectedColumns:
+		return t.checkCount("columns", int64(len(sch)))
+	case TestAssertionExpectedSingleValue:
+		if len(rows) != 1 || len(sch) != 1 {
+			return fmt.Errorf("expected a single value, but the query returned %d rows and %d columns", len(rows), len(sch))
+		}
+		return t.checkValue(rows[0][0])
+	default:
+		return fmt.Errorf("invalid assertion type '%s'", t.AssertionType)
+	}
+}
+
+func (t DoltTest) checkCount(name string, actual int64) error {
+	if t.Value == nil {
+		return fmt.Errorf("the assertion value for %s must be an integer", t.AssertionType)
+	}
+	expected, err := strconv.ParseInt(strings.TrimSpace(*t.Value), 10, 64)
+	if err != nil {
+		return fmt.Errorf("the assertion value for %s must be an integer, got '%s'", t.AssertionType, *t.Value)
+	}
+	cmp := 0
+	if actual < expected {
+		cmp = -1
+	} else if actual > expected {
+		cmp = 1
+	}
+	if !t.satisfies(cmp) {
+		return fmt.Errorf("expected %s %s %d, but got %d", name, t.Comparator, expected, actual)
+	}
+	return nil
+}
+
+// checkValue compares |actual| to the assertion value. Values that are both numbers are compared numerically, while
+// any other values are compared as strings. A NULL is only equal to another NULL, and can't be ordered.
+func (t DoltTest) checkValue(actual interface{}) error {
+	if actual == nil || t.Value == nil {
+		bothNull := actual == nil && t.Value == nil
+		if (t.Comparator == "==" && bothNull) || (t.Comparator == "!=" && !bothNull) {
+			return nil
+		}
+		return fmt.Errorf("expected value %s %s, but got %s", t.Comparator, formatTestValue(t.Value), formatTestResult(actual))
+	}
+
+	var actualStr string
+	switch v := actual.(type) {
+	case []byte:
+		actualStr = string(v)
+	default:
+		actualStr = fmt.Sprint(v)
+	}
+
+	var cmp int
+	actualNum, aErr := strconv.ParseFloat(actualStr, 64)
+	expectedNum, eErr := strconv.ParseFloat(strings.TrimSpace(*t.Value), 64)
+	if aErr == nil && eErr == nil {
+		if actualNum < expectedNum {
+			cmp = -1
+		} else if actualNum > expectedNum {
+			cmp = 1
+		}
+	} else {
+		cmp = strings.Compare(actualStr, *t.Value)
+	}
+	if !t.satisfies(cmp) {
+		return fmt.Errorf("expected value %s %s, but got %s", t.Comparator, formatTestValue(t.Value), formatTestResult(actualStr))
+	}
+	return nil
+}
+
+// satisfies returns whether the result of comparing the actual result to the assertion value satisfies the comparator.
+func (t DoltTest) satisfies(cmp int) bool {
+	switch t.Comparator {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return false
+	}
+}
+
+func formatTestValue(v *string) string {
+	if v == nil {
+		return "NULL"
+	}
+	return "'" + *v + "'"
+}
+
+func formatTestResult(v interface{}) string {
+	if v == nil {
+		return "NULL"
+	}
+	return fmt.Sprintf("'%v'", v)
+}
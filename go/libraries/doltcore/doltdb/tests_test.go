@@ -0,0 +1,142 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestValidateTestAssertion(t *testing.T) {
+	tests := []struct {
+		assertionType string
+		comparator    string
+		value         *string
+		expectedType  TestAssertionType
+		expectedComp  string
+		expectErr     bool
+	}{
+		{"expected_rows", "==", strPtr("1"), TestAssertionExpectedRows, "==", false},
+		{"EXPECTED_COLUMNS", "=", strPtr("2"), TestAssertionExpectedColumns, "==", false},
+		{"expected_single_value", "<>", nil, TestAssertionExpectedSingleValue, "!=", false},
+		{"expected_single_value", ">=", strPtr("abc"), TestAssertionExpectedSingleValue, ">=", false},
+		{"expected_value", "==", strPtr("1"), "", "", true},
+		{"expected_rows", "~", strPtr("1"), "", "", true},
+		{"expected_rows", "==", strPtr("one"), "", "", true},
+		{"expected_columns", "==", nil, "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.assertionType+" "+test.comparator, func(t *testing.T) {
+			typ, comp, err := ValidateTestAssertion(test.assertionType, test.comparator, test.value)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedType, typ)
+			assert.Equal(t, test.expectedComp, comp)
+		})
+	}
+}
+
+func TestDoltTestCheck(t *testing.T) {
+	oneCol := sql.Schema{{Name: "a", Type: types.Int64}}
+	twoCols := sql.Schema{{Name: "a", Type: types.Int64}, {Name: "b", Type: types.Text}}
+
+	tests := []struct {
+		name      string
+		test      DoltTest
+		sch       sql.Schema
+		rows      []sql.Row
+		expectErr string
+	}{
+		{
+			name: "rows equal",
+			test: DoltTest{AssertionType: TestAssertionExpectedRows, Comparator: "==", Value: strPtr("2")},
+			sch:  oneCol,
+			rows: []sql.Row{{1}, {2}},
+		},
+		{
+			name:      "rows less than",
+			test:      DoltTest{AssertionType: TestAssertionExpectedRows, Comparator: "<", Value: strPtr("2")},
+			sch:       oneCol,
+			rows:      []sql.Row{{1}, {2}},
+			expectErr: "expected rows < 2, but got 2",
+		},
+		{
+			name: "columns",
+			test: DoltTest{AssertionType: TestAssertionExpectedColumns, Comparator: ">", Value: strPtr("1")},
+			sch:  twoCols,
+		},
+		{
+			name: "numeric value",
+			test: DoltTest{AssertionType: TestAssertionExpectedSingleValue, Comparator: "<", Value: strPtr("10")},
+			sch:  oneCol,
+			rows: []sql.Row{{int64(9)}},
+		},
+		{
+			name:      "numeric value fails",
+			test:      DoltTest{AssertionType: TestAssertionExpectedSingleValue, Comparator: "==", Value: strPtr("0")},
+			sch:       oneCol,
+			rows:      []sql.Row{{int64(3)}},
+			expectErr: "expected value == '0', but got '3'",
+		},
+		{
+			name: "string value",
+			test: DoltTest{AssertionType: TestAssertionExpectedSingleValue, Comparator: "==", Value: strPtr("abc")},
+			sch:  oneCol,
+			rows: []sql.Row{{"abc"}},
+		},
+		{
+			name: "null value",
+			test: DoltTest{AssertionType: TestAssertionExpectedSingleValue, Comparator: "==", Value: nil},
+			sch:  oneCol,
+			rows: []sql.Row{{nil}},
+		},
+		{
+			name:      "null is not ordered",
+			test:      DoltTest{AssertionType: TestAssertionExpectedSingleValue, Comparator: "<", Value: strPtr("1")},
+			sch:       oneCol,
+			rows:      []sql.Row{{nil}},
+			expectErr: "expected value < '1', but got NULL",
+		},
+		{
+			name:      "single value with many rows",
+			test:      DoltTest{AssertionType: TestAssertionExpectedSingleValue, Comparator: "==", Value: strPtr("1")},
+			sch:       oneCol,
+			rows:      []sql.Row{{1}, {1}},
+			expectErr: "expected a single value, but the query returned 2 rows and 1 columns",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.test.Check(test.sch, test.rows)
+			if test.expectErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, test.expectErr, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -52,6 +52,23 @@ const (
 	StopOnEmptyCommit
 )
 
+// RebaseConflictStrategy describes how a rebase resolves the data conflicts it encounters while executing the
+// rebase plan.
+type RebaseConflictStrategy int
+
+const (
+	// RebaseConflictStrategyNone stops the rebase when data conflicts are encountered, so that they can be
+	// resolved manually.
+	RebaseConflictStrategyNone RebaseConflictStrategy = iota
+
+	// RebaseConflictStrategyOurs resolves data conflicts by keeping the rows from the upstream commits that the
+	// rebased commits are being applied on top of.
+	RebaseConflictStrategyOurs
+
+	// RebaseConflictStrategyTheirs resolves data conflicts by keeping the rows from the commits being rebased.
+	RebaseConflictStrategyTheirs
+)
+
 // RebaseState tracks the state of an in-progress rebase action. It records the name of the branch being rebased, the
 // commit onto which the new commits will be rebased, and the root value of the previous working set, which is used if
 // the rebase is aborted and the working set needs to be restored to its previous state.
@@ -75,6 +92,10 @@ type RebaseState struct {
 	// rebasingStarted is true once the rebase plan has been started to execute. Once rebasingStarted is true, the
 	// value in lastAttemptedStep has been initialized and is valid to read.
 	rebasingStarted bool
+
+	// conflictStrategy specifies how data conflicts are resolved while the rebase plan is executed. It is recorded
+	// so that it still applies when a stopped rebase is continued.
+	conflictStrategy RebaseConflictStrategy
 }
 
 // Branch returns the name of the branch being actively rebased. This is the branch that will be updated to point
@@ -120,6 +141,70 @@ func (rs RebaseState) WithRebasingStarted(rebasingStarted bool) *RebaseState {
 	return &rs
 }
 
+func (rs RebaseState) ConflictStrategy() RebaseConflictStrategy {
+	return rs.conflictStrategy
+}
+
+func (rs RebaseState) WithConflictStrategy(conflictStrategy RebaseConflictStrategy) *RebaseState {
+	rs.conflictStrategy = conflictStrategy
+	return &rs
+}
+
+// BisectState tracks the state of an in-progress bisect. It records the commit that has been marked as bad, the
+// commits that have been marked as good, and the commits that have been skipped because they could not be tested.
+// Bisecting does not modify the working set's roots, so there is nothing to restore when a bisect is reset.
+type BisectState struct {
+	// badCommit is the commit that has been marked as bad, or the empty hash if no commit has been marked bad yet.
+	badCommit hash.Hash
+
+	goodCommits    []hash.Hash
+	skippedCommits []hash.Hash
+}
+
+// BadCommit returns the hash of the commit marked as bad, or the empty hash if no commit has been marked as bad yet.
+func (bs BisectState) BadCommit() hash.Hash {
+	return bs.badCommit
+}
+
+// GoodCommits returns the hashes of the commits marked as good.
+func (bs BisectState) GoodCommits() []hash.Hash {
+	return bs.goodCommits
+}
+
+// SkippedCommits returns the hashes of the commits that have been skipped.
+func (bs BisectState) SkippedCommits() []hash.Hash {
+	return bs.skippedCommits
+}
+
+// WithBadCommit returns a copy of this BisectState with |commit| marked as the bad commit.
+func (bs BisectState) WithBadCommit(commit hash.Hash) *BisectState {
+	bs.badCommit = commit
+	return &bs
+}
+
+// WithGoodCommit returns a copy of this BisectState with |commit| added to the good commits.
+func (bs BisectState) WithGoodCommit(commit hash.Hash) *BisectState {
+	bs.goodCommits = appendHashIfMissing(bs.goodCommits, commit)
+	return &bs
+}
+
+// WithSkippedCommit returns a copy of this BisectState with |commit| added to the skipped commits.
+func (bs BisectState) WithSkippedCommit(commit hash.Hash) *BisectState {
+	bs.skippedCommits = appendHashIfMissing(bs.skippedCommits, commit)
+	return &bs
+}
+
+func appendHashIfMissing(hashes []hash.Hash, h hash.Hash) []hash.Hash {
+	for _, existing := range hashes {
+		if existing == h {
+			return hashes
+		}
+	}
+	ret := make([]hash.Hash, len(hashes), len(hashes)+1)
+	copy(ret, hashes)
+	return append(ret, h)
+}
+
 type MergeState struct {
 	// the source commit
 	commit *Commit
@@ -265,6 +350,7 @@ type WorkingSet struct {
 	stagedRoot  RootValue
 	mergeState  *MergeState
 	rebaseState *RebaseState
+	bisectState *BisectState
 }
 
 var _ Rootish = &WorkingSet{}
@@ -296,6 +382,11 @@ func (ws WorkingSet) WithRebaseState(rebaseState *RebaseState) *WorkingSet {
 	return &ws
 }
 
+func (ws WorkingSet) WithBisectState(bisectState *BisectState) *WorkingSet {
+	ws.bisectState = bisectState
+	return &ws
+}
+
 func (ws WorkingSet) WithUnmergableTables(tables []TableName) *WorkingSet {
 	ws.mergeState.unmergableTables = tables
 	return &ws
@@ -319,16 +410,18 @@ func (ws WorkingSet) StartMerge(commit *Commit, commitSpecStr string) *WorkingSe
 // StartRebase adds rebase tracking metadata to a new working set instance and returns it. Callers must then persist
 // the returned working set in a session in order for the new working set to be recorded. |ontoCommit| specifies the
 // commit that serves as the base commit for the new commits that will be created by the rebase process, |branch| is
-// the branch that is being rebased, and |previousRoot| is root value of the branch being rebased. The HEAD and STAGED
+// the branch that is being rebased, |previousRoot| is root value of the branch being rebased, and |conflictStrategy|
+// specifies how data conflicts are resolved while the rebase plan is executed. The HEAD and STAGED
 // root values of the branch being rebased must match |previousRoot|; WORKING may be a different root value, but ONLY
 // if it contains only ignored tables.
-func (ws WorkingSet) StartRebase(ctx *sql.Context, ontoCommit *Commit, branch string, previousRoot RootValue, commitBecomesEmptyHandling EmptyCommitHandling, emptyCommitHandling EmptyCommitHandling) (*WorkingSet, error) {
+func (ws WorkingSet) StartRebase(ctx *sql.Context, ontoCommit *Commit, branch string, previousRoot RootValue, commitBecomesEmptyHandling EmptyCommitHandling, emptyCommitHandling EmptyCommitHandling, conflictStrategy RebaseConflictStrategy) (*WorkingSet, error) {
 	ws.rebaseState = &RebaseState{
 		ontoCommit:                 ontoCommit,
 		preRebaseWorking:           previousRoot,
 		branch:                     branch,
 		commitBecomesEmptyHandling: commitBecomesEmptyHandling,
 		emptyCommitHandling:        emptyCommitHandling,
+		conflictStrategy:           conflictStrategy,
 	}
 
 	ontoRoot, err := ontoCommit.GetRootValue(ctx)
@@ -341,6 +434,13 @@ func (ws WorkingSet) StartRebase(ctx *sql.Context, ontoCommit *Commit, branch st
 	return &ws, nil
 }
 
+// StartBisect adds empty bisect tracking metadata to a new working set instance and returns it. Callers must then
+// persist the returned working set in a session in order for the new working set to be recorded.
+func (ws WorkingSet) StartBisect() *WorkingSet {
+	ws.bisectState = &BisectState{}
+	return &ws
+}
+
 // StartCherryPick creates and returns a new working set based off of the current |ws| with the specified |commit|
 // and |commitSpecStr| referring to the commit being cherry-picked. The returned WorkingSet records that a cherry-pick
 // operation is in progress (i.e. conflicts being resolved). Note that this function does not update the current
@@ -379,6 +479,11 @@ func (ws WorkingSet) ClearRebase() *WorkingSet {
 	return &ws
 }
 
+func (ws WorkingSet) ClearBisect() *WorkingSet {
+	ws.bisectState = nil
+	return &ws
+}
+
 func (ws *WorkingSet) WorkingRoot() RootValue {
 	return ws.workingRoot
 }
@@ -395,6 +500,10 @@ func (ws *WorkingSet) RebaseState() *RebaseState {
 	return ws.rebaseState
 }
 
+func (ws *WorkingSet) BisectState() *BisectState {
+	return ws.bisectState
+}
+
 func (ws *WorkingSet) MergeActive() bool {
 	return ws.mergeState != nil
 }
@@ -403,6 +512,10 @@ func (ws *WorkingSet) RebaseActive() bool {
 	return ws.rebaseState != nil
 }
 
+func (ws *WorkingSet) BisectActive() bool {
+	return ws.bisectState != nil
+}
+
 // MergeCommitParents returns true if there is an active merge in progress and
 // the recorded commit being merged into the active branch should be included as
 // a second parent of the created commit. This is the expected behavior for a
@@ -548,6 +661,18 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 			emptyCommitHandling:        EmptyCommitHandling(dsws.RebaseState.EmptyCommitHandling(ctx)),
 			lastAttemptedStep:          dsws.RebaseState.LastAttemptedStep(ctx),
 			rebasingStarted:            dsws.RebaseState.RebasingStarted(ctx),
+			conflictStrategy:           RebaseConflictStrategy(dsws.RebaseState.ConflictStrategy(ctx)),
+		}
+	}
+
+	var bisectState *BisectState
+	if dsws.BisectState != nil {
+		bisectState = &BisectState{
+			goodCommits:    dsws.BisectState.GoodCommitAddrs(),
+			skippedCommits: dsws.BisectState.SkippedCommitAddrs(),
+		}
+		if badCommitAddr := dsws.BisectState.BadCommitAddr(); badCommitAddr != nil {
+			bisectState.badCommit = *badCommitAddr
 		}
 	}
 
@@ -561,6 +686,7 @@ func newWorkingSet(ctx context.Context, name string, vrw types.ValueReadWriter,
 		stagedRoot:  stagedRoot,
 		mergeState:  mergeState,
 		rebaseState: rebaseState,
+		bisectState: bisectState,
 	}, nil
 }
 
@@ -646,7 +772,16 @@ func (ws *WorkingSet) writeValues(ctx context.Context, db *DoltDB, meta *datas.W
 
 		rebaseState = datas.NewRebaseState(preRebaseWorking.TargetHash(), dCommit.Addr(), ws.rebaseState.branch,
 			uint8(ws.rebaseState.commitBecomesEmptyHandling), uint8(ws.rebaseState.emptyCommitHandling),
-			ws.rebaseState.lastAttemptedStep, ws.rebaseState.rebasingStarted)
+			ws.rebaseState.lastAttemptedStep, ws.rebaseState.rebasingStarted, uint8(ws.rebaseState.conflictStrategy))
+	}
+
+	var bisectState *datas.BisectState
+	if ws.bisectState != nil {
+		var badCommitAddr *hash.Hash
+		if !ws.bisectState.badCommit.IsEmpty() {
+			badCommitAddr = &ws.bisectState.badCommit
+		}
+		bisectState = datas.NewBisectState(badCommitAddr, ws.bisectState.goodCommits, ws.bisectState.skippedCommits)
 	}
 
 	return &datas.WorkingSetSpec{
@@ -655,5 +790,6 @@ func (ws *WorkingSet) writeValues(ctx context.Context, db *DoltDB, meta *datas.W
 		StagedRoot:  stagedRoot,
 		MergeState:  mergeState,
 		RebaseState: rebaseState,
+		BisectState: bisectState,
 	}, nil
 }
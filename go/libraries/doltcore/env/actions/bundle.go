@@ -0,0 +1,196 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+// bundleManifestFile is the name of the manifest of a noms block store, both on disk and in a blobstore
+const bundleManifestFile = "manifest"
+
+var ErrMissingBundlePrerequisites = errors.New("bundle requires commits which are not in this database")
+
+// CreateBundle writes a bundle containing |refs| from |ddb| to the file at |path|. Chunks reachable from the |basis|
+// commits are left out of the bundle, which can then only be fetched into databases which have those commits.
+func CreateBundle(ctx context.Context, ddb *doltdb.DoltDB, tempDir, path string, refs []doltdb.RefWithHash, basis []hash.Hash) error {
+	if len(refs) == 0 {
+		return errors.New("refusing to create an empty bundle")
+	}
+
+	var skip hash.HashSet
+	if len(basis) > 0 {
+		var err error
+		skip, err = ddb.ReachableChunks(ctx, basis)
+		if err != nil {
+			return err
+		}
+	}
+
+	storeDir, err := os.MkdirTemp(tempDir, "bundle")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(storeDir)
+
+	store, err := nbs.NewLocalStore(ctx, ddb.Format().VersionString(), storeDir, 0, nbs.NewUnlimitedMemQuotaProvider())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	bundleDB := doltdb.DoltDBFromCS(store)
+	targets := make([]hash.Hash, len(refs))
+	header := dbfactory.BundleHeader{
+		Version:    dbfactory.BundleFormatVersion,
+		NbfVersion: ddb.Format().VersionString(),
+		Refs:       make([]dbfactory.BundleRef, len(refs)),
+	}
+	for i, r := range refs {
+		targets[i] = r.Hash
+		header.Refs[i] = dbfactory.BundleRef{Ref: r.Ref.String(), Hash: r.Hash.String()}
+	}
+	for _, h := range basis {
+		header.Prerequisites = append(header.Prerequisites, h.String())
+	}
+
+	if err = bundleDB.PullChunks(ctx, tempDir, ddb, targets, nil, skip); err != nil {
+		return err
+	}
+	for _, r := range refs {
+		if err = bundleDB.SetHead(ctx, r.Ref, r.Hash); err != nil {
+			return err
+		}
+	}
+
+	_, tableFiles, _, err := store.Sources(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the bundle is written next to its destination and moved into place once complete
+	tmpPath := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	err = func() (err error) {
+		f, err := os.Create(tmpPath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}()
+
+		headerData, err := json.MarshalIndent(header, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		tw := tar.NewWriter(f)
+		if err = writeBundleEntry(tw, dbfactory.BundleHeaderFile, int64(len(headerData)), bytes.NewReader(headerData)); err != nil {
+			return err
+		}
+		if err = copyBundleEntry(tw, bundleManifestFile, filepath.Join(storeDir, bundleManifestFile)); err != nil {
+			return err
+		}
+		for _, tf := range tableFiles {
+			rd, size, err := tf.Open(ctx)
+			if err != nil {
+				return err
+			}
+			err = writeBundleEntry(tw, tf.FileID(), int64(size), rd)
+			rd.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return tw.Close()
+	}()
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return file.Rename(tmpPath, path)
+}
+
+func copyBundleEntry(tw *tar.Writer, name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return writeBundleEntry(tw, name, info.Size(), f)
+}
+
+func writeBundleEntry(tw *tar.Writer, name string, size int64, rd io.Reader) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     size,
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyN(tw, rd, size)
+	return err
+}
+
+// checkBundlePrerequisites returns an error if |srcDB| was read from a bundle which requires commits that |destDB|
+// does not have.
+func checkBundlePrerequisites(ctx context.Context, srcDB, destDB *doltdb.DoltDB) error {
+	prerequisites, ok := srcDB.BundlePrerequisites()
+	if !ok {
+		return nil
+	}
+
+	var missing []string
+	for _, h := range prerequisites {
+		has, err := destDB.Has(ctx, h)
+		if err != nil {
+			return err
+		} else if !has {
+			missing = append(missing, h.String())
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingBundlePrerequisites, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -166,6 +167,10 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 	// We support two forms of cloning: full and shallow. These two approaches have little in common, with the exception
 	// of the first and last steps. Determining the branch to check out and setting the working set to the checked out commit.
 
+	if err := checkBundlePrerequisites(ctx, srcDB, dEnv.DoltDB); err != nil {
+		return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
+	}
+
 	srcRefHashes, branch, err := getSrcRefs(ctx, branch, srcDB, dEnv)
 	if err != nil {
 		return fmt.Errorf("%w; %s", ErrCloneFailed, err.Error())
@@ -180,13 +185,22 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 		remoteName = "origin"
 	}
 
+	remotes, err := dEnv.GetRemotes()
+	if err != nil {
+		return err
+	}
+	remote, _ := remotes.Get(remoteName)
+	filter := remote.TableFilter()
+
 	var checkedOutCommit *doltdb.Commit
 
 	// Step 1) Pull the remote information we care about to a local disk.
-	if depth <= 0 {
-		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch)
-	} else {
+	if depth > 0 {
 		checkedOutCommit, err = shallowCloneDataPull(ctx, dEnv.DbData(), srcDB, remoteName, branch, depth)
+	} else if !filter.IsEmpty() {
+		checkedOutCommit, err = partialClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch, filter)
+	} else {
+		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch)
 	}
 
 	if err != nil {
@@ -225,6 +239,8 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 		return err
 	}
 
+	dEnv.ConfigureLazyFetch()
+
 	return nil
 }
 
@@ -272,32 +288,63 @@ func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, src
 		return nil, err
 	}
 
-	cs, _ := doltdb.NewCommitSpec(branch)
-	optCmt, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+	err = dEnv.DoltDB.DeleteAllRefs(ctx)
 	if err != nil {
 		return nil, err
 	}
-	cm, ok := optCmt.ToCommit()
-	if !ok {
-		return nil, doltdb.ErrGhostCommitEncountered
+
+	return setClonedRefs(ctx, dEnv, srcRefHashes, branch, remoteName, singleBranch)
+}
+
+// partialClone pulls the refs being cloned, leaving out the data of the tables not included by |filter|. Unlike
+// fullClone, which copies the remote's table files, it walks the chunks it pulls so that it can leave them out.
+func partialClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool, filter doltdb.TableFilter) (*doltdb.Commit, error) {
+	var toFetch []hash.Hash
+	for _, refHash := range srcRefHashes {
+		if isClonedRef(refHash.Ref, branch, singleBranch) {
+			toFetch = append(toFetch, refHash.Hash)
+		}
 	}
 
-	err = dEnv.DoltDB.DeleteAllRefs(ctx)
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	err = dEnv.DoltDB.PullChunksWithTableFilter(ctx, tmpDir, srcDB, toFetch, nil, nil, filter)
 	if err != nil {
 		return nil, err
 	}
 
+	return setClonedRefs(ctx, dEnv, srcRefHashes, branch, remoteName, singleBranch)
+}
+
+// isClonedRef returns whether a clone of |branch| pulls the remote ref |r|
+func isClonedRef(r ref.DoltRef, branch string, singleBranch bool) bool {
+	switch r.GetType() {
+	case ref.BranchRefType:
+		return !singleBranch || r.GetPath() == branch
+	case ref.TagRefType:
+		return true
+	default:
+		return false
+	}
+}
+
+// setClonedRefs creates the remote branches, local branch and tags of a clone of |branch|, and returns the commit
+// to check out.
+func setClonedRefs(ctx context.Context, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool) (*doltdb.Commit, error) {
 	// Preserve only branch and tag references from the remote. Branches are translated into remote branches, tags are preserved.
 	for _, refHash := range srcRefHashes {
+		if !isClonedRef(refHash.Ref, branch, singleBranch) {
+			continue
+		}
 		if refHash.Ref.GetType() == ref.BranchRefType {
 			br := refHash.Ref.(ref.BranchRef)
-			if !singleBranch || br.GetPath() == branch {
-				remoteRef := ref.NewRemoteRef(remoteName, br.GetPath())
-				err = dEnv.DoltDB.SetHead(ctx, remoteRef, refHash.Hash)
-				if err != nil {
-					return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateRemoteRef, remoteRef.String(), err.Error())
+			remoteRef := ref.NewRemoteRef(remoteName, br.GetPath())
+			err := dEnv.DoltDB.SetHead(ctx, remoteRef, refHash.Hash)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateRemoteRef, remoteRef.String(), err.Error())
 
-				}
 			}
 			if br.GetPath() == branch {
 				// This is the only local branch after the clone is complete.
@@ -306,15 +353,24 @@ func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, src
 					return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateLocalBranch, br.String(), err.Error())
 				}
 			}
-		} else if refHash.Ref.GetType() == ref.TagRefType {
+		} else {
 			tr := refHash.Ref.(ref.TagRef)
-			err = dEnv.DoltDB.SetHead(ctx, tr, refHash.Hash)
+			err := dEnv.DoltDB.SetHead(ctx, tr, refHash.Hash)
 			if err != nil {
 				return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateTagRef, tr.String(), err.Error())
 			}
 		}
 	}
 
+	cs, _ := doltdb.NewCommitSpec(branch)
+	optCmt, err := dEnv.DoltDB.Resolve(ctx, cs, nil)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
 	return cm, nil
 }
 
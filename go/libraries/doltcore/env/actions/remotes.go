@@ -527,6 +527,10 @@ func fetchRefSpecsWithDepth(
 	progStarter ProgStarter,
 	progStopper ProgStopper,
 ) error {
+	if err := checkBundlePrerequisites(ctx, srcDB, dbData.Ddb); err != nil {
+		return err
+	}
+
 	var branchRefs []doltdb.RefWithHash
 	err := srcDB.VisitRefsOfType(ctx, ref.HeadRefTypes, func(r ref.DoltRef, addr hash.Hash) error {
 		branchRefs = append(branchRefs, doltdb.RefWithHash{Ref: r, Hash: addr})
@@ -618,7 +622,7 @@ func fetchRefSpecsWithDepth(
 			defer progStopper(cancelFunc, wg, statsCh)
 		}
 
-		err = dbData.Ddb.PullChunks(ctx, tmpDir, srcDB, toFetch, statsCh, skipCmts)
+		err = dbData.Ddb.PullChunksWithTableFilter(ctx, tmpDir, srcDB, toFetch, statsCh, skipCmts, remote.TableFilter())
 		if err == pull.ErrDBUpToDate {
 			err = nil
 		}
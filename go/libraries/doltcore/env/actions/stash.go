@@ -0,0 +1,153 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// HasLocalChanges returns whether |roots| have any changes that would be saved by a stash. Untracked tables only
+// count with |includeUntracked|, and ignored tables only with |all|.
+func HasLocalChanges(ctx context.Context, roots doltdb.Roots, includeUntracked, all bool) (bool, error) {
+	headHash, err := roots.Head.HashOf()
+	if err != nil {
+		return false, err
+	}
+	workingHash, err := roots.Working.HashOf()
+	if err != nil {
+		return false, err
+	}
+	stagedHash, err := roots.Staged.HashOf()
+	if err != nil {
+		return false, err
+	}
+
+	// Are there staged changes? If so, stash them.
+	if !headHash.Equal(stagedHash) {
+		return true, nil
+	}
+
+	// No staged changes, but are there any unstaged changes? If not, no work is needed.
+	if headHash.Equal(workingHash) {
+		return false, nil
+	}
+
+	// There are unstaged changes, is --all set? If so, nothing else matters. Stash them.
+	if all {
+		return true, nil
+	}
+
+	// --all was not set, so we can ignore tables. Is every table ignored?
+	allIgnored, err := diff.WorkingSetContainsOnlyIgnoredTables(ctx, roots)
+	if err != nil {
+		return false, err
+	}
+	if allIgnored {
+		return false, nil
+	}
+
+	// There are unignored, unstaged tables. Is --include-untracked set? If so, nothing else matters. Stash them.
+	if includeUntracked {
+		return true, nil
+	}
+
+	// --include-untracked was not set, so we can skip untracked tables. Is every table untracked?
+	// Untracked tables are part of the working set changes, but are not stashed unless they are staged.
+	_, unstaged, err := diff.GetStagedUnstagedTableDeltas(ctx, roots)
+	if err != nil {
+		return false, err
+	}
+	for _, tableDelta := range unstaged {
+		if !tableDelta.IsAdd() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// StashChanges saves the staged and unstaged changes in |roots| as a new stash entry on top of |headCommit|, the head
+// of the branch |headRef|, and returns the roots with those changes removed from the staged and working sets.
+// Untracked tables are only stashed with |includeUntracked|, and ignored tables only with |all|.
+func StashChanges(ctx context.Context, ddb *doltdb.DoltDB, roots doltdb.Roots, headRef ref.DoltRef, headCommit *doltdb.Commit, includeUntracked, all bool) (doltdb.Roots, error) {
+	roots, err := StageModifiedAndDeletedTables(ctx, roots)
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+
+	// all tables with changes that are going to be stashed are staged at this point
+	allTblsToBeStashed, addedTblsToStage, err := stashedTableSets(ctx, roots)
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+
+	// stage untracked tables to include them in the stash, but do not include them in the added table set,
+	// because they should not be staged when the stash is applied.
+	if includeUntracked || all {
+		allTblsToBeStashed, err = doltdb.UnionTableNames(ctx, roots.Staged, roots.Working)
+		if err != nil {
+			return doltdb.Roots{}, err
+		}
+
+		roots, err = StageTables(ctx, roots, allTblsToBeStashed, !all)
+		if err != nil {
+			return doltdb.Roots{}, err
+		}
+	}
+
+	commitMeta, err := headCommit.GetCommitMeta(ctx)
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+
+	err = ddb.AddStash(ctx, headCommit, roots.Staged, datas.NewStashMeta(headRef.String(), commitMeta.Description, doltdb.FlattenTableNames(addedTblsToStage)))
+	if err != nil {
+		return doltdb.Roots{}, err
+	}
+
+	// setting STAGED to the current HEAD root value resets the staged set of changes, so these changes are now in
+	// the working set, which needs to be checked out.
+	roots.Staged = roots.Head
+	return MoveTablesFromHeadToWorking(ctx, roots, allTblsToBeStashed)
+}
+
+// stashedTableSets returns the names of all tables that are being stashed, and of the added tables among them. These
+// table names are determined from the staged set of changes, since only those are stashed.
+func stashedTableSets(ctx context.Context, roots doltdb.Roots) ([]doltdb.TableName, []doltdb.TableName, error) {
+	var addedTblsInStaged []doltdb.TableName
+	var allTbls []doltdb.TableName
+	staged, _, err := diff.GetStagedUnstagedTableDeltas(ctx, roots)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, tableDelta := range staged {
+		tblName := tableDelta.ToName
+		if tableDelta.IsAdd() {
+			addedTblsInStaged = append(addedTblsInStaged, tableDelta.ToName)
+		}
+		if tableDelta.IsDrop() {
+			tblName = tableDelta.FromName
+		}
+		allTbls = append(allTbls, tblName)
+	}
+
+	return allTbls, addedTblsInStaged, nil
+}
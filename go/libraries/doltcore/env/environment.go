@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -208,6 +209,8 @@ func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr s
 	}
 
 	if dEnv.RSLoadErr == nil && dbLoadErr == nil {
+		dEnv.ConfigureLazyFetch()
+
 		// If the working set isn't present in the DB, create it from the repo state. This step can be removed post 1.0.
 		_, err := dEnv.WorkingSet(ctx)
 		if errors.Is(err, doltdb.ErrWorkingSetNotFound) {
@@ -1133,6 +1136,11 @@ func GetRefSpecs(rsr RepoStateReader, remoteName string) ([]ref.RemoteRefSpec, e
 		return nil, err
 	}
 
+	return getRefSpecsForRemote(remote)
+}
+
+// getRefSpecsForRemote returns the fetch refspecs of |remote|
+func getRefSpecsForRemote(remote Remote) ([]ref.RemoteRefSpec, error) {
 	var refSpecs []ref.RemoteRefSpec
 	for _, fs := range remote.FetchSpecs {
 		rs, err := ref.ParseRefSpecForRemote(remote.Name, fs)
@@ -1207,6 +1215,55 @@ func (dEnv *DoltEnv) TempTableFilesDir() (string, error) {
 	return absPath, nil
 }
 
+// ConfigureLazyFetch sets up the database to fetch the table data left out of partial clones and fetches from the
+// env's remotes when it is first read. Remotes with a table filter are tried first.
+func (dEnv *DoltEnv) ConfigureLazyFetch() {
+	if dEnv.DoltDB == nil || dEnv.RepoState == nil || dEnv.RepoState.Remotes == nil || dEnv.RepoState.Remotes.Len() == 0 {
+		return
+	}
+
+	// databases which cannot hold lazy chunks have nothing to fetch
+	_ = dEnv.DoltDB.SetLazyFetcher(func(ctx context.Context, sink *doltdb.DoltDB, hashes []hash.Hash) error {
+		tmpDir, err := dEnv.TempTableFilesDir()
+		if err != nil {
+			return err
+		}
+
+		var errs []error
+		for _, r := range dEnv.lazyFetchRemotes() {
+			srcDB, err := r.GetRemoteDB(ctx, dEnv.DoltDB.Format(), dEnv)
+			if err == nil {
+				err = sink.PullChunks(ctx, tmpDir, srcDB, hashes, nil, nil)
+			}
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, fmt.Errorf("remote '%s': %w", r.Name, err))
+		}
+		if len(errs) == 0 {
+			return errors.New("no remote to fetch table data from")
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// lazyFetchRemotes returns the remotes to fetch lazy chunks from, in the order to try them
+func (dEnv *DoltEnv) lazyFetchRemotes() []Remote {
+	var remotes []Remote
+	dEnv.RepoState.Remotes.Iter(func(name string, r Remote) bool {
+		remotes = append(remotes, r)
+		return true
+	})
+	sort.Slice(remotes, func(i, j int) bool {
+		iFiltered, jFiltered := !remotes[i].TableFilter().IsEmpty(), !remotes[j].TableFilter().IsEmpty()
+		if iFiltered != jFiltered {
+			return iFiltered
+		}
+		return remotes[i].Name < remotes[j].Name
+	})
+	return remotes
+}
+
 func (dEnv *DoltEnv) DbEaFactory() editor.DbEaFactory {
 	tmpDir, err := dEnv.TempTableFilesDir()
 	if err != nil {
@@ -142,6 +142,50 @@ func (r Remote) WithParams(params map[string]string) Remote {
 	return r
 }
 
+const (
+	// RemoteTablesParam is the remote param listing the only tables whose data is pulled by fetches from the remote. It
+	// is set by partial clones.
+	RemoteTablesParam = "tables"
+	// RemoteExcludeTablesParam is the remote param listing the tables whose data is not pulled by fetches from the
+	// remote. It is set by partial clones.
+	RemoteExcludeTablesParam = "exclude-tables"
+)
+
+// TableFilter returns the filter selecting the tables whose data is pulled by fetches from the remote
+func (r *Remote) TableFilter() doltdb.TableFilter {
+	var filter doltdb.TableFilter
+	if tables := r.Params[RemoteTablesParam]; tables != "" {
+		filter.Tables = strings.Split(tables, ",")
+	}
+	if tables := r.Params[RemoteExcludeTablesParam]; tables != "" {
+		filter.ExcludeTables = strings.Split(tables, ",")
+	}
+	return filter
+}
+
+// WithTableFilter returns a copy of the remote whose fetches pull the data of the tables selected by |filter|
+func (r Remote) WithTableFilter(filter doltdb.TableFilter) Remote {
+	params := make(map[string]string, len(r.Params))
+	for k, v := range r.Params {
+		if k != RemoteTablesParam && k != RemoteExcludeTablesParam {
+			params[k] = v
+		}
+	}
+	AddTableFilterParams(params, filter)
+	r.Params = params
+	return r
+}
+
+// AddTableFilterParams records |filter| in the remote |params|
+func AddTableFilterParams(params map[string]string, filter doltdb.TableFilter) {
+	if len(filter.Tables) > 0 {
+		params[RemoteTablesParam] = strings.Join(filter.Tables, ",")
+	}
+	if len(filter.ExcludeTables) > 0 {
+		params[RemoteExcludeTablesParam] = strings.Join(filter.ExcludeTables, ",")
+	}
+}
+
 // PushOptions contains information needed for push for
 // one or more branches or a tag for a specific remote database.
 type PushOptions struct {
@@ -394,12 +438,9 @@ func RemoteForFetchArgs(args []string, rsr RepoStateReader) (Remote, []string, e
 		return NoRemote, nil, err
 	}
 
-	if remotes.Len() == 0 {
-		return NoRemote, nil, ErrNoRemote
-	}
-
 	var remName string
-	if len(args) == 0 {
+	remNameGiven := len(args) > 0
+	if !remNameGiven {
 		remName = "origin"
 	} else {
 		remName = args[0]
@@ -408,6 +449,13 @@ func RemoteForFetchArgs(args []string, rsr RepoStateReader) (Remote, []string, e
 
 	remote, ok := remotes.Get(remName)
 	if !ok {
+		// bundles can be fetched from without being added as a remote
+		if bundleUrl, ok := getAbsBundleUrl(filesys2.LocalFS, remName); ok && remNameGiven {
+			return bundleRemote(remotes, bundleUrl, args)
+		}
+		if remotes.Len() == 0 {
+			return NoRemote, nil, ErrNoRemote
+		}
 		msg := "does not appear to be a dolt database. could not read from the remote database. please make sure you have the correct access rights and the database exists"
 		return NoRemote, nil, fmt.Errorf("%w; '%s' %s", ErrUnknownRemote, remName, msg)
 	}
@@ -415,6 +463,16 @@ func RemoteForFetchArgs(args []string, rsr RepoStateReader) (Remote, []string, e
 	return remote, args, nil
 }
 
+// bundleRemote returns a remote for the bundle at |bundleUrl|, named after the bundle's file. Its branches are fetched
+// into remote tracking branches with that name, unless that is the name of a configured remote.
+func bundleRemote(remotes *concurrentmap.Map[string, Remote], bundleUrl string, args []string) (Remote, []string, error) {
+	name := strings.TrimSuffix(path.Base(bundleUrl), dbfactory.BundleExt)
+	if _, ok := remotes.Get(name); ok {
+		return NoRemote, nil, fmt.Errorf("%w: cannot fetch bundle '%s' into the remote tracking branches of remote '%s'", ErrRemoteAlreadyExists, bundleUrl, name)
+	}
+	return NewRemote(name, bundleUrl, nil), args, nil
+}
+
 // ParseRefSpecs returns the ref specs for the string arguments given for the remote provided, or the default ref
 // specs for that remote if no arguments are provided. In the event that the default ref specs are returned, the
 // returned boolean value will be true.
@@ -422,10 +480,20 @@ func ParseRefSpecs(args []string, rsr RepoStateReader, remote Remote) ([]ref.Rem
 	if len(args) != 0 {
 		specs, err := ParseRSFromArgs(remote.Name, args)
 		return specs, false, err
-	} else {
-		specs, err := GetRefSpecs(rsr, remote.Name)
+	}
+
+	remotes, err := rsr.GetRemotes()
+	if err != nil {
+		return nil, false, err
+	}
+	if _, ok := remotes.Get(remote.Name); !ok {
+		// a remote which is not configured, such as a bundle, uses its own fetch specs
+		specs, err := getRefSpecsForRemote(remote)
 		return specs, true, err
 	}
+
+	specs, err := GetRefSpecs(rsr, remote.Name)
+	return specs, true, err
 }
 
 func ParseRSFromArgs(remName string, args []string) ([]ref.RemoteRefSpec, error) {
@@ -648,6 +716,12 @@ func GetAbsRemoteUrl(fs filesys2.Filesys, cfg config.ReadableConfig, urlArg stri
 		return "", "", err
 	}
 
+	if fs != nil {
+		if bundleUrl, ok := getAbsBundleUrl(fs, urlArg); ok {
+			return dbfactory.BundleScheme, bundleUrl, nil
+		}
+	}
+
 	if u.Scheme != "" && fs != nil {
 		if u.Scheme == dbfactory.FileScheme || u.Scheme == dbfactory.LocalBSScheme {
 			absUrl, err := getAbsFileRemoteUrl(u, fs)
@@ -708,6 +782,29 @@ func getAbsFileRemoteUrl(u *url.URL, fs filesys2.Filesys) (string, error) {
 	return scheme + "://" + urlStr, nil
 }
 
+// getAbsBundleUrl returns the absolute bundle:// url of the bundle file named by |urlArg|, which may be a bundle:// url
+// or the path of a file. It returns false if |urlArg| does not name a file.
+func getAbsBundleUrl(fs filesys2.Filesys, urlArg string) (string, bool) {
+	path := urlArg
+	if strings.HasPrefix(strings.ToLower(urlArg), dbfactory.BundleScheme+"://") {
+		u, err := earl.Parse(urlArg)
+		if err != nil {
+			return "", false
+		}
+		path = u.Host + u.Path
+	}
+
+	path, err := fs.Abs(filepath.Clean(path))
+	if err != nil {
+		return "", false
+	}
+	if exists, isDir := fs.Exists(path); !exists || isDir {
+		return "", false
+	}
+
+	return dbfactory.BundleScheme + "://" + filepath.ToSlash(path), true
+}
+
 // GetDefaultBranch returns the default branch from among the branches given, returning
 // the configs default config branch first, then init branch main, then the old init branch master,
 // and finally the first lexicographical branch if none of the others are found
@@ -0,0 +1,65 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+// ApplyStash merges the stash entry at |idx| of |ddb| into the working set of |roots|, and stages the tables that
+// the stash added. If applying the stash would result in conflicts, |roots| are returned unchanged along with the
+// names of the conflicting tables, so that a stash entry is never dropped without its changes having been applied.
+func ApplyStash(ctx *sql.Context, ddb *doltdb.DoltDB, roots doltdb.Roots, idx int, opts editor.Options) (doltdb.Roots, []string, error) {
+	stashRoot, parentCommit, meta, err := ddb.GetStashRootAndHeadCommitAtIdx(ctx, idx)
+	if err != nil {
+		return doltdb.Roots{}, nil, err
+	}
+
+	parentRoot, err := parentCommit.GetRootValue(ctx)
+	if err != nil {
+		return doltdb.Roots{}, nil, err
+	}
+
+	result, err := MergeRoots(ctx, roots.Working, stashRoot, parentRoot, stashRoot, parentCommit, opts, MergeOpts{IsCherryPick: false})
+	if err != nil {
+		return doltdb.Roots{}, nil, err
+	}
+
+	var tablesWithConflict []string
+	for tbl, stats := range result.Stats {
+		if stats.HasConflicts() {
+			tablesWithConflict = append(tablesWithConflict, tbl)
+		}
+	}
+	if len(tablesWithConflict) > 0 {
+		sort.Strings(tablesWithConflict)
+		return roots, tablesWithConflict, nil
+	}
+
+	roots.Working = result.Root
+
+	// added tables need to be staged. Since these tables are coming from a stash, don't filter for ignored table names.
+	roots, err = actions.StageTables(ctx, roots, doltdb.ToTableNames(meta.TablesToStage, doltdb.DefaultSchemaName), false)
+	if err != nil {
+		return doltdb.Roots{}, nil, err
+	}
+	return roots, nil, nil
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	goerrors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -29,6 +31,8 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/libraries/doltcore/merge")
+
 var ErrFastForward = errors.New("fast forward")
 var ErrTableDeletedAndModified = errors.New("conflict: table with same name deleted and modified ")
 var ErrTableDeletedAndSchemaModified = errors.New("conflict: table with same name deleted and its schema modified ")
@@ -166,6 +170,27 @@ func MergeRoots(
 	theirs, ancestor doltdb.Rootish,
 	opts editor.Options,
 	mergeOpts MergeOpts,
+) (*Result, error) {
+	spanCtx, span := tracer.Start(ctx, "merge.MergeRoots")
+	defer span.End()
+	ctx = ctx.WithContext(spanCtx)
+
+	result, err := mergeRoots(ctx, ourRoot, theirRoot, ancRoot, theirs, ancestor, opts, mergeOpts)
+	recordMerge(result, err)
+	if err != nil {
+		span.RecordError(err)
+	} else if result != nil {
+		span.SetAttributes(attribute.Int("num_tables", len(result.Stats)), attribute.Bool("has_artifacts", result.HasMergeArtifacts()))
+	}
+	return result, err
+}
+
+func mergeRoots(
+	ctx *sql.Context,
+	ourRoot, theirRoot, ancRoot doltdb.RootValue,
+	theirs, ancestor doltdb.Rootish,
+	opts editor.Options,
+	mergeOpts MergeOpts,
 ) (*Result, error) {
 	var (
 		conflictStash  *conflictStash
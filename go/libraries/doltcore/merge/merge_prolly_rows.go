@@ -75,6 +75,7 @@ func mergeProllyTable(
 	}
 	leftRows := durable.ProllyMapFromIndex(lr)
 	valueMerger := newValueMerger(mergedSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool(), tm.ns)
+	valueMerger.setMergeRules(tm.mergeRules)
 
 	if !valueMerger.leftMapping.IsIdentityMapping() {
 		mergeInfo.LeftNeedsRewrite = true
@@ -1625,6 +1626,9 @@ type valueMerger struct {
 	syncPool                               pool.BuffPool
 	keyless                                bool
 	ns                                     tree.NodeStore
+	// mergeRules holds the dolt_merge_rules rule for each non-virtual column of the merged schema, or is nil if the
+	// table has no merge rules. Columns without a rule have an empty strategy.
+	mergeRules []columnMergeRule
 }
 
 func newValueMerger(merged, leftSch, rightSch, baseSch schema.Schema, syncPool pool.BuffPool, ns tree.NodeStore) *valueMerger {
@@ -1849,10 +1853,10 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 	leftCol, leftColIdx, leftColExists := getColumn(&left, &m.leftMapping, i)
 	rightCol, rightColIdx, rightColExists := getColumn(&right, &m.rightMapping, i)
 	resultType := m.resultVD.Types[i]
-	resultColumn := m.resultSchema.GetNonPKCols().GetByIndex(i)
+	resultColumn := m.resultSchema.GetNonPKCols().GetByStoredIndex(i)
 	generatedColumn := resultColumn.Generated != ""
 
-	sqlType := m.resultSchema.GetNonPKCols().GetByIndex(i).TypeInfo.ToSqlType()
+	sqlType := resultColumn.TypeInfo.ToSqlType()
 
 	// We previously asserted that left and right are not nil.
 	// But base can be nil in the event of convergent inserts.
@@ -1896,6 +1900,10 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return leftCol, false, nil
 		}
 
+		if result, resolved, conflict, err := m.resolveWithMergeRule(ctx, i, leftCol, rightCol, nil); resolved || err != nil {
+			return result, conflict, err
+		}
+
 		// conflicting inserts
 		return nil, true, nil
 	}
@@ -1961,6 +1969,10 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 			return leftCol, false, nil
 		}
 		// concurrent modification
+		// if a merge rule is declared for this column, it takes precedence over any other resolution.
+		if result, resolved, conflict, err := m.resolveWithMergeRule(ctx, i, leftCol, rightCol, baseCol); resolved || err != nil {
+			return result, conflict, err
+		}
 		// if the result type is JSON, we can attempt to merge the JSON changes.
 		dontMergeJsonVar, err := ctx.Session.GetSessionVariable(ctx, "dolt_dont_merge_json")
 		if err != nil {
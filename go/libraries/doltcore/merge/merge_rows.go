@@ -16,8 +16,11 @@ package merge
 
 import (
 	"context"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/conflict"
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
@@ -76,6 +79,11 @@ type TableMerger struct {
 	// exception is for the dolt_verify_constraints() stored procedure, which allows callers to
 	// only record constraint violations for a specified subset of tables.
 	recordViolations bool
+
+	// mergeRules are the rules from the dolt_merge_rules table on the left side of the merge that apply to this
+	// table. They are used to resolve conflicting changes to the same cell. The rules on the right side of the merge
+	// are never consulted, so when the two sides disagree about a rule, our side wins.
+	mergeRules doltdb.MergeRules
 }
 
 func (tm TableMerger) tableHashes() (left, right, anc hash.Hash, err error) {
@@ -107,6 +115,11 @@ type RootMerger struct {
 
 	vrw types.ValueReadWriter
 	ns  tree.NodeStore
+
+	// mergeRules are the rules from the dolt_merge_rules table of the left root, keyed by lower-cased table name.
+	// They are loaded once, the first time a table is merged.
+	mergeRules       map[string]doltdb.MergeRules
+	mergeRulesLoaded bool
 }
 
 // NewMerger creates a new merger utility object.
@@ -152,6 +165,10 @@ func (rm *RootMerger) MergeTable(
 	opts editor.Options,
 	mergeOpts MergeOpts,
 ) (*MergedTable, *MergeStats, error) {
+	spanCtx, span := tracer.Start(ctx, "merge.MergeTable", trace.WithAttributes(attribute.String("table", tblName.String())))
+	defer span.End()
+	ctx = ctx.WithContext(spanCtx)
+
 	tm, err := rm.makeTableMerger(ctx, tblName, mergeOpts)
 	if err != nil {
 		return nil, nil, err
@@ -214,6 +231,15 @@ func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName doltdb.TableN
 	}
 
 	var err error
+	if !rm.mergeRulesLoaded {
+		rm.mergeRules, err = doltdb.GetMergeRules(ctx, rm.left)
+		if err != nil {
+			return nil, err
+		}
+		rm.mergeRulesLoaded = true
+	}
+	tm.mergeRules = rm.mergeRules[strings.ToLower(tblName.Name)]
+
 	var leftSideTableExists, rightSideTableExists, ancTableExists bool
 
 	tm.leftTbl, leftSideTableExists, err = rm.left.GetTable(ctx, tblName)
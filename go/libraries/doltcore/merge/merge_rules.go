@@ -0,0 +1,158 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// columnMergeRule is the merge rule for a single column of the merged schema.
+type columnMergeRule struct {
+	strategy doltdb.MergeRuleStrategy
+	sqlType  sql.Type
+}
+
+// setMergeRules configures the valueMerger to resolve cell conflicts using the dolt_merge_rules |rules| declared for
+// the table being merged. Rules for columns that are not in the merged schema are ignored, as are sum rules for
+// columns that are not numeric, such as a column whose type was changed after its rule was declared. Conflicts in
+// those columns are reported as usual.
+func (m *valueMerger) setMergeRules(rules doltdb.MergeRules) {
+	if len(rules) == 0 {
+		return
+	}
+
+	// the value tuples of the merged table don't store virtual columns, so they are skipped when indexing the rules.
+	m.mergeRules = make([]columnMergeRule, m.numCols)
+	i := 0
+	for _, col := range m.resultSchema.GetNonPKCols().GetColumns() {
+		if col.Virtual {
+			continue
+		}
+		sqlType := col.TypeInfo.ToSqlType()
+		if strategy, ok := rules.StrategyForColumn(col.Name); ok {
+			if strategy != doltdb.MergeRuleSum || IsSummable(sqlType) {
+				m.mergeRules[i] = columnMergeRule{strategy: strategy, sqlType: sqlType}
+			}
+		}
+		i++
+	}
+}
+
+// IsSummable returns whether the merge rule strategy sum can be applied to a column of type |sqlType|.
+func IsSummable(sqlType sql.Type) bool {
+	return types.IsInteger(sqlType) || types.IsFloat(sqlType) || types.IsDecimal(sqlType)
+}
+
+// resolveWithMergeRule attempts to resolve conflicting changes to column |i| of the merged schema using the merge rule
+// declared for that column. |left|, |right|, and |base| must already be converted to the type of the merged column,
+// and |base| is nil if the row or the column doesn't exist in the merge base. Returns false for |resolved| if the
+// column has no merge rule, and returns true for |conflict| if the rule could not produce a result.
+func (m *valueMerger) resolveWithMergeRule(ctx context.Context, i int, left, right, base []byte) (result []byte, resolved, conflict bool, err error) {
+	if m.mergeRules == nil || m.mergeRules[i].strategy == "" {
+		return nil, false, false, nil
+	}
+
+	resultType := m.resultVD.Types[i]
+	strategy := m.mergeRules[i].strategy
+	switch strategy {
+	case doltdb.MergeRuleOurs:
+		return left, true, false, nil
+	case doltdb.MergeRuleTheirs:
+		return right, true, false, nil
+	case doltdb.MergeRuleMax, doltdb.MergeRuleMin:
+		if left == nil {
+			return right, true, false, nil
+		} else if right == nil {
+			return left, true, false, nil
+		}
+		cmp := m.resultVD.Comparator().CompareValues(i, left, right, resultType)
+		if (cmp >= 0) == (strategy == doltdb.MergeRuleMax) {
+			return left, true, false, nil
+		}
+		return right, true, false, nil
+	case doltdb.MergeRuleSum:
+		result, conflict, err = m.sumDeltas(ctx, i, left, right, base)
+		return result, true, conflict, err
+	default:
+		return nil, false, false, fmt.Errorf("unknown merge rule strategy: %s", strategy)
+	}
+}
+
+// sumDeltas returns the value of column |i| after applying both the change from |base| to |left| and the change from
+// |base| to |right|. NULL values are treated as zero. Returns true for |conflict| if the result is out of range for
+// the column's type.
+func (m *valueMerger) sumDeltas(ctx context.Context, i int, left, right, base []byte) (result []byte, conflict bool, err error) {
+	typ := m.resultVD.Types[i]
+	// If a merge results in assigning NULL to a non-null column, don't panic.
+	// Instead we validate the merged tuple before merging it into the table.
+	typ.Nullable = true
+
+	var values [3]interface{}
+	for j, cell := range [][]byte{left, right, base} {
+		if values[j], err = tree.GetField(ctx, val.NewTupleDescriptor(typ), 0, val.NewTuple(m.syncPool, cell), m.ns); err != nil {
+			return nil, false, err
+		}
+	}
+
+	sqlType := m.mergeRules[i].sqlType
+	var sum interface{}
+	if types.IsFloat(sqlType) {
+		var fs [3]float64
+		for j, v := range values {
+			if v == nil {
+				continue
+			}
+			f, _, err := types.Float64.Convert(v)
+			if err != nil {
+				return nil, false, err
+			}
+			fs[j] = f.(float64)
+		}
+		sum = fs[0] + fs[1] - fs[2]
+	} else {
+		var ds [3]decimal.Decimal
+		for j, v := range values {
+			if v == nil {
+				continue
+			}
+			d, _, err := types.InternalDecimalType.Convert(v)
+			if err != nil {
+				return nil, false, err
+			}
+			ds[j] = d.(decimal.Decimal)
+		}
+		sum = ds[0].Add(ds[1]).Sub(ds[2])
+	}
+
+	converted, inRange, err := sqlType.Convert(sum)
+	if err != nil || inRange != sql.InRange {
+		// the sum doesn't fit in the column, so this can't be resolved automatically
+		return nil, true, nil
+	}
+	result, err = tree.Serialize(ctx, m.ns, typ, converted)
+	if err != nil {
+		return nil, false, err
+	}
+	return result, false, nil
+}
@@ -14,6 +14,8 @@
 
 package merge
 
+import "sync"
+
 type TableMergeOp int
 
 const (
@@ -52,3 +54,49 @@ func (ms *MergeStats) HasSchemaConflicts() bool {
 func (ms *MergeStats) HasConstraintViolations() bool {
 	return ms.ConstraintViolations > 0
 }
+
+// MergeCounts counts the root value merges run by this process.
+type MergeCounts struct {
+	// Merges is the number of merges which completed.
+	Merges uint64
+	// Failures is the number of merges which returned an error.
+	Failures uint64
+	// MergesWithConflicts is the number of completed merges which produced conflicts or constraint violations.
+	MergesWithConflicts uint64
+	// DataConflicts, SchemaConflicts and ConstraintViolations are the totals of the MergeStats of completed merges.
+	DataConflicts        uint64
+	SchemaConflicts      uint64
+	ConstraintViolations uint64
+}
+
+var mergeCounts struct {
+	mu sync.Mutex
+	MergeCounts
+}
+
+// GetMergeCounts returns the MergeCounts of this process.
+func GetMergeCounts() MergeCounts {
+	mergeCounts.mu.Lock()
+	defer mergeCounts.mu.Unlock()
+	return mergeCounts.MergeCounts
+}
+
+// recordMerge adds the merge which returned |result| and |err| to the MergeCounts of this process.
+func recordMerge(result *Result, err error) {
+	mergeCounts.mu.Lock()
+	defer mergeCounts.mu.Unlock()
+	if err != nil || result == nil {
+		mergeCounts.Failures++
+		return
+	}
+
+	mergeCounts.Merges++
+	if result.HasMergeArtifacts() {
+		mergeCounts.MergesWithConflicts++
+	}
+	for _, stats := range result.Stats {
+		mergeCounts.DataConflicts += uint64(stats.DataConflicts)
+		mergeCounts.SchemaConflicts += uint64(stats.SchemaConflicts)
+		mergeCounts.ConstraintViolations += uint64(stats.ConstraintViolations)
+	}
+}
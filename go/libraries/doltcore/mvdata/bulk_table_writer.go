@@ -0,0 +1,368 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvdata
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
+	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/sort"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	// bulkSortBatchSize is the size of the batches of rows that are sorted in memory before being spilled to disk.
+	bulkSortBatchSize = 32 * 1024 * 1024 // 32MB
+	bulkSortFileMax   = 128
+)
+
+// BulkTableWriter is a utility for importing a set of rows into a new table by writing them directly into the
+// table's row map, rather than inserting them through the sql engine. Rows are sorted by their primary key with an
+// external sort, the row map is built from the sorted rows in a single pass, and then the secondary indexes are built
+// from the row map. Only tables that CanBulkImport accepts can be written by a BulkTableWriter.
+type BulkTableWriter struct {
+	dEnv      *env.DoltEnv
+	tableName string
+	sch       schema.Schema
+
+	// ordinals maps the fields of the tuples being sorted, which are the stored key columns followed by the stored
+	// value columns of |sch|, to their index in the rows being written.
+	ordinals val.OrdinalMapping
+	// sqlCols are the columns of the fields of the tuples being sorted
+	sqlCols []*sql.Column
+
+	statsCB noms.StatsCB
+	stats   types.AppliedEditStats
+
+	// root is the working root with the new table, once all the rows are written
+	root doltdb.RootValue
+
+	tableSchema        sql.PrimaryKeySchema
+	rowOperationSchema sql.PrimaryKeySchema
+}
+
+// CanBulkImport returns whether the rows of an import can be written with a BulkTableWriter. That is the case when
+// the import creates a new table in the root |root| with a primary key, bad rows aren't skipped, and every column of
+// the table is imported. The sql engine would need to enforce any check constraints, auto increment columns and
+// generated columns of the table, so tables that have them can't be bulk imported.
+func CanBulkImport(ctx context.Context, root doltdb.RootValue, tableSchema, rowOperationSchema schema.Schema, options *MoverOptions) (bool, error) {
+	if options.Operation != CreateOp || options.ContinueOnErr || !types.IsFormat_DOLT(root.VRW().Format()) {
+		return false, nil
+	}
+
+	if schema.IsKeyless(tableSchema) || tableSchema.Checks().Count() > 0 || schema.HasAutoIncrement(tableSchema) {
+		return false, nil
+	}
+
+	allCols := tableSchema.GetAllCols()
+	if rowOperationSchema.GetAllCols().Size() != allCols.Size() || allCols.Size() != allCols.StoredSize() {
+		return false, nil
+	}
+	for _, col := range allCols.GetColumns() {
+		if col.Generated != "" {
+			return false, nil
+		}
+	}
+
+	exists, err := root.HasTable(ctx, doltdb.TableName{Name: options.TableToWriteTo})
+	if err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// NewBulkTableWriter returns a BulkTableWriter that creates the table |options.TableToWriteTo| with the schema
+// |createTableSchema| in the working root of |dEnv|.
+func NewBulkTableWriter(dEnv *env.DoltEnv, createTableSchema, rowOperationSchema schema.Schema, options *MoverOptions, statsCB noms.StatsCB) (*BulkTableWriter, error) {
+	doltCreateTableSchema, err := sqlutil.FromDoltSchema("", options.TableToWriteTo, createTableSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	doltRowOperationSchema, err := sqlutil.FromDoltSchema("", options.TableToWriteTo, rowOperationSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	var storedCols []schema.Column
+	storedCols = append(storedCols, createTableSchema.GetPKCols().GetColumns()...)
+	storedCols = append(storedCols, createTableSchema.GetNonPKCols().GetColumns()...)
+	ordinals := make(val.OrdinalMapping, len(storedCols))
+	sqlCols := make([]*sql.Column, len(storedCols))
+	for i, col := range storedCols {
+		ordinals[i] = doltRowOperationSchema.Schema.IndexOfColName(col.Name)
+		if ordinals[i] < 0 {
+			return nil, errors.New("every column of the table must be imported for a bulk import")
+		}
+		sqlCols[i] = doltCreateTableSchema.Schema[doltCreateTableSchema.Schema.IndexOfColName(col.Name)]
+	}
+
+	return &BulkTableWriter{
+		dEnv:      dEnv,
+		tableName: options.TableToWriteTo,
+		sch:       createTableSchema,
+		ordinals:  ordinals,
+		sqlCols:   sqlCols,
+		statsCB:   statsCB,
+
+		tableSchema:        doltCreateTableSchema,
+		rowOperationSchema: doltRowOperationSchema,
+	}, nil
+}
+
+// WriteRows creates the table and writes the rows received on |inputChannel| to it. The rows are in the order of the
+// row operation schema. The new table is added to the working root by Commit.
+func (b *BulkTableWriter) WriteRows(ctx context.Context, inputChannel chan sql.Row, badRowCb func(row sql.Row, rowSchema sql.PrimaryKeySchema, tableName string, lineNumber int, err error) bool) error {
+	root, err := b.dEnv.WorkingRoot(ctx)
+	if err != nil {
+		return err
+	}
+
+	tableName := doltdb.TableName{Name: b.tableName}
+	root, err = doltdb.CreateEmptyTable(ctx, root, tableName, b.sch)
+	if err != nil {
+		return err
+	}
+	tbl, _, err := root.GetTable(ctx, tableName)
+	if err != nil {
+		return err
+	}
+	rows, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	m := durable.ProllyMapFromIndex(rows)
+
+	keyDesc, valDesc := m.Descriptors()
+	tb := val.NewTupleBuilder(sortTupleDesc(keyDesc, valDesc))
+	sorter := sort.NewTupleSorter(bulkSortBatchSize, bulkSortFileMax, func(l, r val.Tuple) bool {
+		// the sorted tuples start with the key fields, so they can be compared as keys
+		return keyDesc.Compare(l, r) < 0
+	}, tempfiles.MovableTempFileProvider)
+	defer sorter.Close()
+
+	line := 1
+	for {
+		var row sql.Row
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case row, ok = <-inputChannel:
+		}
+		if !ok {
+			break
+		}
+		line++
+
+		tup, err := b.buildTuple(ctx, m.NodeStore(), m.Pool(), tb, row)
+		if err != nil {
+			if badRowCb(row, b.rowOperationSchema, b.tableName, line, err) {
+				return err
+			}
+			continue
+		}
+		if err = sorter.Insert(ctx, tup); err != nil {
+			return err
+		}
+
+		b.stats.Additions++
+		if b.statsCB != nil && b.stats.Additions%tableWriterStatUpdateRate == 0 {
+			b.statsCB(b.stats)
+		}
+	}
+
+	sorted, err := sorter.Flush(ctx)
+	if err != nil {
+		return err
+	}
+	defer sorted.Close()
+	iter, err := sorted.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	m, err = buildRowMap(ctx, m, iter)
+	if err != nil {
+		return err
+	}
+	tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(m))
+	if err != nil {
+		return err
+	}
+
+	sqlCtx := sql.NewContext(ctx)
+	for _, idx := range b.sch.Indexes().AllIndexes() {
+		idxRows, err := creation.BuildSecondaryProllyIndex(sqlCtx, tbl.ValueReadWriter(), tbl.NodeStore(), b.sch, b.tableName, idx, m)
+		if err != nil {
+			return err
+		}
+		tbl, err = tbl.SetIndexRows(ctx, idx.Name(), idxRows)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.root, err = root.PutTable(ctx, tableName, tbl)
+	if err != nil {
+		return err
+	}
+
+	if b.statsCB != nil {
+		b.statsCB(b.stats)
+	}
+	return nil
+}
+
+// buildTuple returns a tuple with the stored key columns of |row| followed by its stored value columns.
+func (b *BulkTableWriter) buildTuple(ctx context.Context, ns tree.NodeStore, p pool.BuffPool, tb *val.TupleBuilder, row sql.Row) (val.Tuple, error) {
+	for i, from := range b.ordinals {
+		col := b.sqlCols[i]
+		v := row[from]
+		if v != nil {
+			var inRange sql.ConvertInRange
+			var err error
+			v, inRange, err = col.Type.Convert(v)
+			if err != nil {
+				tb.Recycle()
+				return nil, err
+			} else if inRange == sql.OutOfRange {
+				tb.Recycle()
+				return nil, sql.ErrValueOutOfRange.New(row[from], col.Type)
+			}
+		}
+
+		if v == nil {
+			if !col.Nullable {
+				tb.Recycle()
+				return nil, sql.ErrInsertIntoNonNullableProvidedNull.New(col.Name)
+			}
+			continue
+		}
+
+		if err := tree.PutField(ctx, ns, tb, i, v); err != nil {
+			tb.Recycle()
+			return nil, err
+		}
+	}
+
+	return tb.Build(p), nil
+}
+
+// buildRowMap writes the sorted tuples in |iter| to the empty row map |m|.
+func buildRowMap(ctx context.Context, m prolly.Map, iter sort.KeyIter) (prolly.Map, error) {
+	keyDesc, valDesc := m.Descriptors()
+	tupIter := &splitTupleIter{
+		iter:    iter,
+		keyDesc: keyDesc,
+		kb:      val.NewTupleBuilder(keyDesc),
+		vb:      val.NewTupleBuilder(valDesc),
+		pool:    m.Pool(),
+	}
+	m, err := prolly.MutateMapWithTupleIter(ctx, m, tupIter)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	if tupIter.err != nil {
+		return prolly.Map{}, tupIter.err
+	}
+
+	return m, nil
+}
+
+// Commit adds the new table to the working root.
+func (b *BulkTableWriter) Commit(ctx context.Context) error {
+	if b.root == nil {
+		return errors.New("no rows were written to the table")
+	}
+	return b.dEnv.UpdateWorkingRoot(ctx, b.root)
+}
+
+func (b *BulkTableWriter) RowOperationSchema() sql.PrimaryKeySchema {
+	return b.rowOperationSchema
+}
+
+func (b *BulkTableWriter) TableSchema() sql.PrimaryKeySchema {
+	return b.tableSchema
+}
+
+// sortTupleDesc returns the descriptor of tuples with the fields of |keyDesc| followed by the fields of |valDesc|.
+func sortTupleDesc(keyDesc, valDesc val.TupleDesc) val.TupleDesc {
+	types := append(append([]val.Type{}, keyDesc.Types...), valDesc.Types...)
+	if len(keyDesc.Handlers) == 0 && len(valDesc.Handlers) == 0 {
+		return val.NewTupleDescriptor(types...)
+	}
+
+	handlers := make([]val.TupleTypeHandler, len(types))
+	copy(handlers, keyDesc.Handlers)
+	copy(handlers[keyDesc.Count():], valDesc.Handlers)
+	return val.NewTupleDescriptorWithArgs(val.TupleDescriptorArgs{Handlers: handlers}, types...)
+}
+
+// splitTupleIter splits the sorted tuples built by a BulkTableWriter into the keys and values of a row map. It stops
+// with an error if two tuples have the same key.
+type splitTupleIter struct {
+	iter    sort.KeyIter
+	keyDesc val.TupleDesc
+	kb, vb  *val.TupleBuilder
+	pool    pool.BuffPool
+
+	lastKey val.Tuple
+	err     error
+}
+
+var _ prolly.TupleIter = (*splitTupleIter)(nil)
+
+func (s *splitTupleIter) Next(ctx context.Context) (val.Tuple, val.Tuple) {
+	tup, err := s.iter.Next(ctx)
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			s.err = err
+		}
+		return nil, nil
+	}
+
+	if s.lastKey != nil && s.keyDesc.Compare(s.lastKey, tup) == 0 {
+		s.err = sql.NewUniqueKeyErr(creation.FormatKeyForUniqKeyErr(tup, s.keyDesc), true, nil)
+		return nil, nil
+	}
+	s.lastKey = tup
+
+	numKeyFields := s.keyDesc.Count()
+	for i := 0; i < numKeyFields; i++ {
+		s.kb.PutRaw(i, tup.GetField(i))
+	}
+	for i := 0; i < s.vb.Desc.Count(); i++ {
+		s.vb.PutRaw(i, tup.GetField(numKeyFields+i))
+	}
+
+	return s.kb.Build(s.pool), s.vb.Build(s.pool)
+}
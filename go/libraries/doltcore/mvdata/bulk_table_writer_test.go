@@ -0,0 +1,136 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvdata
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const bulkTestSchema = `
+CREATE TABLE people (
+	id BIGINT NOT NULL,
+	name VARCHAR(20),
+	age INT NOT NULL,
+	PRIMARY KEY (id),
+	INDEX age_idx (age)
+);`
+
+func newBulkTestWriter(t *testing.T, dEnv *env.DoltEnv) *BulkTableWriter {
+	ctx := context.Background()
+	require.NoError(t, dEnv.FS.WriteFile(testSchemaFileName, []byte(bulkTestSchema), os.ModePerm))
+	tableName, sch, err := SchAndTableNameFromFile(ctx, testSchemaFileName, dEnv)
+	require.NoError(t, err)
+
+	// the rows are in a different order than the columns of the table
+	rowSch := schema.MustSchemaFromCols(schema.NewColCollection(
+		sch.GetAllCols().NameToCol["name"],
+		sch.GetAllCols().NameToCol["age"],
+		sch.GetAllCols().NameToCol["id"],
+	))
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	opts := &MoverOptions{Operation: CreateOp, TableToWriteTo: tableName}
+	ok, err := CanBulkImport(ctx, root, sch, rowSch, opts)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	wr, err := NewBulkTableWriter(dEnv, sch, rowSch, opts, nil)
+	require.NoError(t, err)
+	return wr
+}
+
+func writeBulkRows(ctx context.Context, wr *BulkTableWriter, rows []sql.Row) (badRows []sql.Row, err error) {
+	ch := make(chan sql.Row, len(rows))
+	for _, r := range rows {
+		ch <- r
+	}
+	close(ch)
+
+	err = wr.WriteRows(ctx, ch, func(row sql.Row, _ sql.PrimaryKeySchema, _ string, _ int, _ error) bool {
+		badRows = append(badRows, row)
+		return true
+	})
+	return badRows, err
+}
+
+func TestBulkTableWriter(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+
+	wr := newBulkTestWriter(t, dEnv)
+	badRows, err := writeBulkRows(ctx, wr, []sql.Row{
+		{"carl", int32(30), int64(3)},
+		{nil, "41", int64(1)},
+		{"bob", int64(20), int64(2)},
+	})
+	require.NoError(t, err)
+	require.Empty(t, badRows)
+	require.NoError(t, wr.Commit(ctx))
+
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	rows, err := sqle.ExecuteSelect(dEnv, root, "SELECT id, name, age FROM people ORDER BY id")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{
+		{int64(1), nil, int32(41)},
+		{int64(2), "bob", int32(20)},
+		{int64(3), "carl", int32(30)},
+	}, rows)
+
+	rows, err = sqle.ExecuteSelect(dEnv, root, "SELECT id FROM people WHERE age < 35 ORDER BY age")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int64(2)}, {int64(3)}}, rows)
+
+	ok, err := CanBulkImport(ctx, root, wr.sch, wr.sch, &MoverOptions{Operation: CreateOp, TableToWriteTo: "people"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestBulkTableWriterErrors(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB.Close()
+
+	wr := newBulkTestWriter(t, dEnv)
+	badRows, err := writeBulkRows(ctx, wr, []sql.Row{
+		{"carl", int32(30), int64(3)},
+		{"bob", nil, int64(2)},
+	})
+	assert.True(t, sql.ErrInsertIntoNonNullableProvidedNull.Is(err))
+	assert.Equal(t, []sql.Row{{"bob", nil, int64(2)}}, badRows)
+
+	wr = newBulkTestWriter(t, dEnv)
+	_, err = writeBulkRows(ctx, wr, []sql.Row{
+		{"carl", int32(30), int64(3)},
+		{"bob", int32(20), int64(2)},
+		{"carla", int32(31), int64(3)},
+	})
+	assert.True(t, sql.ErrPrimaryKeyViolation.Is(err))
+
+	assert.Error(t, wr.Commit(ctx))
+}
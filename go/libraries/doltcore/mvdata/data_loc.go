@@ -52,11 +52,21 @@ const (
 	// JsonFile is the format of a data location that is a json file
 	JsonFile DataFormat = ".json"
 
+	// JsonlFile is the format of a data location that is a newline delimited json file
+	JsonlFile DataFormat = ".jsonl"
+
 	// SqlFile is the format of a data location that is a .sql file
 	SqlFile DataFormat = ".sql"
 
 	// ParquetFile is the format of a data location that is a .paquet file
 	ParquetFile DataFormat = ".parquet"
+
+	// ArrowFile is the format of a data location that is a file in the arrow IPC file format, such as a .arrow or
+	// .feather file
+	ArrowFile DataFormat = ".arrow"
+
+	// ArrowStreamFile is the format of a data location that is a file or stream in the arrow IPC stream format
+	ArrowStreamFile DataFormat = ".arrows"
 )
 
 // ReadableStr returns a human readable string for a DataFormat
@@ -72,10 +82,16 @@ func (df DataFormat) ReadableStr() string {
 		return "xlsx file"
 	case JsonFile:
 		return "json file"
+	case JsonlFile:
+		return "jsonl file"
 	case SqlFile:
 		return "sql file"
 	case ParquetFile:
 		return "parquet file"
+	case ArrowFile:
+		return "arrow file"
+	case ArrowStreamFile:
+		return "arrow stream"
 	default:
 		return "invalid"
 	}
@@ -116,10 +132,16 @@ func NewDataLocation(path, fileFmtStr string) DataLocation {
 			dataFmt = XlsxFile
 		case string(JsonFile):
 			dataFmt = JsonFile
+		case string(JsonlFile), ".ndjson":
+			dataFmt = JsonlFile
 		case string(SqlFile):
 			dataFmt = SqlFile
 		case string(ParquetFile):
 			dataFmt = ParquetFile
+		case string(ArrowFile), ".feather":
+			dataFmt = ArrowFile
+		case string(ArrowStreamFile):
+			dataFmt = ArrowStreamFile
 		}
 	}
 
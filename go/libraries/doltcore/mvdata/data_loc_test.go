@@ -85,6 +85,11 @@ func TestBasics(t *testing.T) {
 		{NewDataLocation("file.csv", ""), CsvFile.ReadableStr() + ":file.csv", true},
 		{NewDataLocation("file.psv", ""), PsvFile.ReadableStr() + ":file.psv", true},
 		{NewDataLocation("file.json", ""), JsonFile.ReadableStr() + ":file.json", true},
+		{NewDataLocation("file.jsonl", ""), JsonlFile.ReadableStr() + ":file.jsonl", true},
+		{NewDataLocation("file.ndjson", ""), JsonlFile.ReadableStr() + ":file.ndjson", true},
+		{NewDataLocation("file.arrow", ""), ArrowFile.ReadableStr() + ":file.arrow", true},
+		{NewDataLocation("file.feather", ""), ArrowFile.ReadableStr() + ":file.feather", true},
+		{NewDataLocation("file.arrows", ""), ArrowStreamFile.ReadableStr() + ":file.arrows", true},
 		//{NewDataLocation("file.nbf", ""), NbfFile, "file.nbf", true},
 	}
 
@@ -132,6 +137,7 @@ func TestExists(t *testing.T) {
 		NewDataLocation("file.csv", ""),
 		NewDataLocation("file.psv", ""),
 		NewDataLocation("file.json", ""),
+		NewDataLocation("file.jsonl", ""),
 		//NewDataLocation("file.nbf", ""),
 	}
 
@@ -191,6 +197,7 @@ func TestCreateRdWr(t *testing.T) {
 		{NewDataLocation("file.csv", ""), reflect.TypeOf((*csv.CSVReader)(nil)).Elem(), reflect.TypeOf((*csv.CSVWriter)(nil)).Elem()},
 		{NewDataLocation("file.psv", ""), reflect.TypeOf((*csv.CSVReader)(nil)).Elem(), reflect.TypeOf((*csv.CSVWriter)(nil)).Elem()},
 		{NewDataLocation("file.json", ""), reflect.TypeOf((*json.JSONReader)(nil)).Elem(), reflect.TypeOf((*json.RowWriter)(nil)).Elem()},
+		{NewDataLocation("file.jsonl", ""), reflect.TypeOf((*json.JSONLReader)(nil)).Elem(), reflect.TypeOf((*json.RowWriter)(nil)).Elem()},
 		//{NewDataLocation("file.nbf", ""), reflect.TypeOf((*nbf.NBFReader)(nil)).Elem(), reflect.TypeOf((*nbf.NBFWriter)(nil)).Elem()},
 	}
 
@@ -48,6 +48,11 @@ type ParquetOptions struct {
 	SchFile   string
 }
 
+type ArrowOptions struct {
+	TableName string
+	SchFile   string
+}
+
 type MoverOptions struct {
 	ContinueOnErr  bool
 	Force          bool
@@ -125,13 +130,18 @@ func SchAndTableNameFromFile(ctx context.Context, path string, dEnv *env.DoltEnv
 }
 
 func InferSchema(ctx context.Context, root doltdb.RootValue, rd table.ReadCloser, tableName string, pks []string, args actions.InferenceArgs) (schema.Schema, error) {
-	var err error
-
 	infCols, err := actions.InferColumnTypesFromTableReader(ctx, rd, args)
 	if err != nil {
 		return nil, err
 	}
 
+	return SchemaFromInferredColumns(ctx, root, infCols, tableName, pks)
+}
+
+// SchemaFromInferredColumns returns the schema of the new table |tableName| with the columns |infCols|, which were
+// inferred from the file being imported. The columns named in |pks| make up the primary key, and new tags are
+// generated for every column.
+func SchemaFromInferredColumns(ctx context.Context, root doltdb.RootValue, infCols *schema.ColCollection, tableName string, pks []string) (schema.Schema, error) {
 	pkSet := set.NewStrSet(pks)
 	newCols := schema.MapColCollection(infCols, func(col schema.Column) schema.Column {
 		col.IsPartOfPK = pkSet.Contains(col.Name)
@@ -159,7 +169,7 @@ func InferSchema(ctx context.Context, root doltdb.RootValue, rd table.ReadCloser
 	}
 
 	// NOTE: This code is only used in the import codepath for Dolt, so we don't use a schema to qualify the table name
-	newCols, err = doltdb.GenerateTagsForNewColColl(ctx, root, tableName, newCols)
+	newCols, err := doltdb.GenerateTagsForNewColColl(ctx, root, tableName, newCols)
 	if err != nil {
 		return nil, errhand.BuildDError("failed to generate new schema").AddCause(err).Build()
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/parquet"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
@@ -47,10 +48,16 @@ func DFFromString(dfStr string) DataFormat {
 		return XlsxFile
 	case "json", ".json":
 		return JsonFile
+	case "jsonl", ".jsonl", "ndjson", ".ndjson":
+		return JsonlFile
 	case "sql", ".sql":
 		return SqlFile
 	case "parquet", ".parquet":
 		return ParquetFile
+	case "arrow", ".arrow", "feather", ".feather":
+		return ArrowFile
+	case "arrows", ".arrows":
+		return ArrowStreamFile
 	default:
 		return InvalidDataFormat
 	}
@@ -118,72 +125,118 @@ func (dl FileDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, opt
 		return rd, false, err
 
 	case JsonFile:
-		var sch schema.Schema
-		jsonOpts, _ := opts.(JSONOptions)
-		if jsonOpts.SchFile != "" {
-			tn, s, err := SchAndTableNameFromFile(ctx, jsonOpts.SchFile, dEnv)
-			if err != nil {
-				return nil, false, err
-			}
-			if tn != jsonOpts.TableName {
-				return nil, false, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, jsonOpts.SchFile, jsonOpts.TableName)
-			}
-			sch = s
-		} else {
-			if opts == nil {
-				return nil, false, errors.New("Unable to determine table name on JSON import")
-			}
-			tbl, exists, err := root.GetTable(context.TODO(), doltdb.TableName{Name: jsonOpts.TableName})
-			if !exists {
-				return nil, false, fmt.Errorf("The following table could not be found:\n%v", jsonOpts.TableName)
-			}
-			if err != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table:\n%v", err.Error())
-			}
-			sch, err = tbl.GetSchema(context.TODO())
-			if err != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table schema:\n%v", err.Error())
-			}
+		sch, err := jsonImportSchema(ctx, dEnv, root, opts)
+		if err != nil {
+			return nil, false, err
 		}
 
 		rd, err := json.OpenJSONReader(root.VRW(), dl.Path, fs, sch)
 		return rd, false, err
 
+	case JsonlFile:
+		sch, err := jsonImportSchema(ctx, dEnv, root, opts)
+		if err != nil {
+			return nil, false, err
+		}
+
+		rd, err := json.OpenJSONLReader(dl.Path, fs, sch)
+		return rd, false, err
+
 	case ParquetFile:
-		var tableSch schema.Schema
 		parquetOpts, _ := opts.(ParquetOptions)
-		if parquetOpts.SchFile != "" {
-			tn, s, tnErr := SchAndTableNameFromFile(ctx, parquetOpts.SchFile, dEnv)
-			if tnErr != nil {
-				return nil, false, tnErr
-			}
-			if tn != parquetOpts.TableName {
-				return nil, false, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, parquetOpts.SchFile, parquetOpts.TableName)
-			}
-			tableSch = s
-		} else {
-			if opts == nil {
-				return nil, false, errors.New("Unable to determine table name on JSON import")
-			}
-			tbl, tableExists, tErr := root.GetTable(context.TODO(), doltdb.TableName{Name: parquetOpts.TableName})
-			if !tableExists {
-				return nil, false, fmt.Errorf("The following table could not be found:\n%v", parquetOpts.TableName)
-			}
-			if tErr != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table:\n%v", err.Error())
-			}
-			tableSch, err = tbl.GetSchema(context.TODO())
-			if err != nil {
-				return nil, false, fmt.Errorf("An error occurred attempting to read the table schema:\n%v", err.Error())
-			}
+		sch, err := typedImportSchema(ctx, dEnv, root, parquetOpts.TableName, parquetOpts.SchFile, opts != nil, "parquet")
+		if err != nil {
+			return nil, false, err
+		}
+		if sch == nil {
+			// parquet files are typed, so the schema of a new table can be inferred from the file
+			rd, err := parquet.OpenParquetReaderWithInferredSchema(root.VRW(), dl.Path)
+			return rd, false, err
 		}
-		rd, rErr := parquet.OpenParquetReader(root.VRW(), dl.Path, tableSch)
-		return rd, false, rErr
+		rd, err := parquet.OpenParquetReader(root.VRW(), dl.Path, sch)
+		return rd, false, err
+
+	case ArrowFile, ArrowStreamFile:
+		arrowOpts, _ := opts.(ArrowOptions)
+		sch, err := typedImportSchema(ctx, dEnv, root, arrowOpts.TableName, arrowOpts.SchFile, opts != nil, "arrow")
+		if err != nil {
+			return nil, false, err
+		}
+		if sch == nil {
+			rd, err := arrow.OpenArrowReaderWithInferredSchema(dl.Path, fs)
+			return rd, false, err
+		}
+		rd, err := arrow.OpenArrowReader(dl.Path, fs, sch)
+		return rd, false, err
 	}
 
 	return nil, false, errors.New("unsupported format")
 }
 
+// typedImportSchema returns the schema of the rows being imported from a typed file, such as a parquet or arrow file,
+// which comes from the schema file |schFile| if there is one, and from the table being imported to otherwise. A nil
+// schema is returned when the table doesn't exist, as the schema of a new table can be inferred from the file.
+func typedImportSchema(ctx context.Context, dEnv *env.DoltEnv, root doltdb.RootValue, tableName, schFile string, hasOpts bool, fileType string) (schema.Schema, error) {
+	if schFile != "" {
+		tn, s, err := SchAndTableNameFromFile(ctx, schFile, dEnv)
+		if err != nil {
+			return nil, err
+		}
+		if tn != tableName {
+			return nil, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, schFile, tableName)
+		}
+		return s, nil
+	}
+
+	if !hasOpts {
+		return nil, fmt.Errorf("Unable to determine table name on %s import", fileType)
+	}
+	tbl, exists, err := root.GetTable(context.TODO(), doltdb.TableName{Name: tableName})
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred attempting to read the table:\n%v", err.Error())
+	}
+	if !exists {
+		return nil, nil
+	}
+	sch, err := tbl.GetSchema(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred attempting to read the table schema:\n%v", err.Error())
+	}
+	return sch, nil
+}
+
+// jsonImportSchema returns the schema of the rows being imported from a json or jsonl file, which comes from the
+// schema file in |opts| if there is one, and from the table being imported to otherwise.
+func jsonImportSchema(ctx context.Context, dEnv *env.DoltEnv, root doltdb.RootValue, opts interface{}) (schema.Schema, error) {
+	jsonOpts, _ := opts.(JSONOptions)
+	if jsonOpts.SchFile != "" {
+		tn, s, err := SchAndTableNameFromFile(ctx, jsonOpts.SchFile, dEnv)
+		if err != nil {
+			return nil, err
+		}
+		if tn != jsonOpts.TableName {
+			return nil, fmt.Errorf("table name '%s' from schema file %s does not match table arg '%s'", tn, jsonOpts.SchFile, jsonOpts.TableName)
+		}
+		return s, nil
+	}
+
+	if opts == nil {
+		return nil, errors.New("Unable to determine table name on JSON import")
+	}
+	tbl, exists, err := root.GetTable(context.TODO(), doltdb.TableName{Name: jsonOpts.TableName})
+	if !exists {
+		return nil, fmt.Errorf("The following table could not be found:\n%v", jsonOpts.TableName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred attempting to read the table:\n%v", err.Error())
+	}
+	sch, err := tbl.GetSchema(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("An error occurred attempting to read the table schema:\n%v", err.Error())
+	}
+	return sch, nil
+}
+
 // NewCreatingWriter will create a TableWriteCloser for a DataLocation that will create a new table, or overwrite
 // an existing table.
 func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMoverOptions, root doltdb.RootValue, outSch schema.Schema, opts editor.Options, wr io.WriteCloser) (table.SqlRowWriter, error) {
@@ -193,9 +246,11 @@ func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMov
 	case PsvFile:
 		return csv.NewCSVWriter(wr, outSch, csv.NewCSVInfo().SetDelim("|"))
 	case XlsxFile:
-		panic("writing to xlsx files is not supported yet")
+		return xlsx.NewXLSXWriter(wr, outSch, xlsx.NewXLSXInfo(mvOpts.SrcName()))
 	case JsonFile:
 		return json.NewJSONWriter(wr, outSch)
+	case JsonlFile:
+		return json.NewJSONLWriter(wr, outSch)
 	case SqlFile:
 		if mvOpts.IsBatched() {
 			return sqlexport.OpenBatchedSQLExportWriter(ctx, wr, root, mvOpts.SrcName(), mvOpts.IsAutocommitOff(), outSch, opts)
@@ -204,6 +259,10 @@ func (dl FileDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataMov
 		}
 	case ParquetFile:
 		return parquet.NewParquetRowWriterForFile(outSch, mvOpts.DestName())
+	case ArrowFile:
+		return arrow.NewArrowFileWriter(wr, outSch)
+	case ArrowStreamFile:
+		return arrow.NewArrowStreamWriter(wr, outSch)
 	}
 
 	panic("Invalid Data Format." + string(dl.Format))
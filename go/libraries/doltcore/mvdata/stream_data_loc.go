@@ -25,6 +25,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/arrow"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -73,6 +75,25 @@ func (dl StreamDataLocation) NewReader(ctx context.Context, dEnv *env.DoltEnv, o
 	case PsvFile:
 		rd, err := csv.NewCSVReader(root.VRW().Format(), io.NopCloser(dl.Reader), csv.NewCSVInfo().SetDelim("|"))
 		return rd, false, err
+
+	case JsonlFile:
+		sch, err := jsonImportSchema(ctx, dEnv, root, opts)
+		if err != nil {
+			return nil, false, err
+		}
+
+		rd, err := json.NewJSONLReader(io.NopCloser(dl.Reader), sch)
+		return rd, false, err
+
+	case ArrowStreamFile:
+		arrowOpts, _ := opts.(ArrowOptions)
+		sch, err := typedImportSchema(ctx, dEnv, root, arrowOpts.TableName, arrowOpts.SchFile, opts != nil, "arrow")
+		if err != nil {
+			return nil, false, err
+		}
+
+		rd, err := arrow.NewArrowReader(io.NopCloser(dl.Reader), sch)
+		return rd, false, err
 	}
 
 	return nil, false, errors.New(string(dl.Format) + "is an unsupported format to read from stdin")
@@ -87,6 +108,12 @@ func (dl StreamDataLocation) NewCreatingWriter(ctx context.Context, mvOpts DataM
 
 	case PsvFile:
 		return csv.NewCSVWriter(iohelp.NopWrCloser(dl.Writer), outSch, csv.NewCSVInfo().SetDelim("|"))
+
+	case JsonlFile:
+		return json.NewJSONLWriter(iohelp.NopWrCloser(dl.Writer), outSch)
+
+	case ArrowStreamFile:
+		return arrow.NewArrowStreamWriter(iohelp.NopWrCloser(dl.Writer), outSch)
 	}
 
 	return nil, errors.New(string(dl.Format) + "is an unsupported format to write to stdout")
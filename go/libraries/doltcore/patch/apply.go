@@ -0,0 +1,191 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// ErrPatchDoesNotApply is returned when the changes of a patch can't be made to the tables it is applied to, such as
+// when a table it changes doesn't exist, or has a different schema than the one the patch was made from. It is also
+// returned when reading a patch whose statements or values aren't allowed in a patch.
+var ErrPatchDoesNotApply = errors.New("patch does not apply")
+
+// StatementRunner runs the SQL statements |stmts| against |root| and returns the resulting root value.
+type StatementRunner func(ctx context.Context, root doltdb.RootValue, stmts []string) (doltdb.RootValue, error)
+
+// Apply merges the changes of |p| into |ours|, the working root of the branch whose HEAD is |head|.
+//
+// Patches hold the changes made by a commit rather than the commit itself, so the ancestor of the merge is made from
+// |ours| by restoring the old values of the rows changed by the patch, and removing the rows it adds. The patch is
+// then applied to the ancestor, and the result merged into |ours| like a cherry-pick. Rows changed by the patch that
+// have been changed differently in |ours| are reported as conflicts. The ancestor and the applied patch are written
+// as commits that aren't on any branch, and the commit of the applied patch is returned so that a merge with
+// conflicts can be recorded in the working set.
+func Apply(ctx *sql.Context, ddb *doltdb.DoltDB, head *doltdb.Commit, ours doltdb.RootValue, p *Patch, run StatementRunner, opts editor.Options) (*merge.Result, *doltdb.Commit, error) {
+	ancRoot, err := run(ctx, ours, p.AncestorStatements())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrPatchDoesNotApply, err.Error())
+	}
+	theirRoot, err := run(ctx, ancRoot, p.Statements())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrPatchDoesNotApply, err.Error())
+	}
+
+	message := p.Message
+	if strings.TrimSpace(message) == "" {
+		message = fmt.Sprintf("patch %s", p.Commit.String())
+	}
+	ancMeta, err := datas.NewCommitMetaWithUserTS(p.Name, p.Email, fmt.Sprintf("ancestor of patch %s", p.Commit.String()), p.Date)
+	if err != nil {
+		return nil, nil, err
+	}
+	ancCommit, err := commitDangling(ctx, ddb, ancRoot, head, ancMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+	theirMeta, err := datas.NewCommitMetaWithUserTS(p.Name, p.Email, message, p.Date)
+	if err != nil {
+		return nil, nil, err
+	}
+	theirCommit, err := commitDangling(ctx, ddb, theirRoot, ancCommit, theirMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := merge.MergeRoots(ctx, ours, theirRoot, ancRoot, theirCommit, ancCommit, opts, merge.MergeOpts{IsCherryPick: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, schConflict := range result.SchemaConflicts {
+		if schConflict.ModifyDeleteConflict {
+			return nil, nil, schConflict
+		}
+	}
+
+	return result, theirCommit, nil
+}
+
+func commitDangling(ctx context.Context, ddb *doltdb.DoltDB, root doltdb.RootValue, parent *doltdb.Commit, meta *datas.CommitMeta) (*doltdb.Commit, error) {
+	_, h, err := ddb.WriteRootValue(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	return ddb.CommitDanglingWithParentCommits(ctx, h, []*doltdb.Commit{parent}, meta)
+}
+
+// AncestorStatements returns the statements that make the ancestor of the merge of |p| from the root it is applied
+// to. The old values of the rows the patch removes or changes are restored, and the rows it adds are removed.
+func (p *Patch) AncestorStatements() []string {
+	var stmts []string
+	for _, td := range p.Tables {
+		if td.FromName == "" {
+			continue
+		}
+
+		if td.KeyLen == 0 {
+			// rows without a primary key are matched on all of their values, so they can't be restored without
+			// duplicating rows that weren't changed
+			continue
+		}
+
+		oldKeys := make(map[string]struct{})
+		for _, r := range td.Rows {
+			if r.Old != nil {
+				oldKeys[strings.Join(r.Old[:td.KeyLen], ",")] = struct{}{}
+				stmts = append(stmts, replaceStmt(td.FromName, td.FromCols, r.Old))
+			}
+		}
+		for _, r := range td.Rows {
+			if r.New == nil {
+				continue
+			}
+			if _, ok := oldKeys[strings.Join(r.New[:td.KeyLen], ",")]; !ok {
+				stmts = append(stmts, deleteStmt(td.FromName, td.FromCols[:td.KeyLen], r.New[:td.KeyLen], false))
+			}
+		}
+	}
+	return stmts
+}
+
+// Statements returns the statements that make the changes of |p|. Rows are removed before the schemas of the tables
+// are changed, and added after.
+func (p *Patch) Statements() []string {
+	var stmts []string
+	for _, td := range p.Tables {
+		for _, r := range td.Rows {
+			if r.Old == nil {
+				continue
+			}
+			if td.KeyLen > 0 {
+				stmts = append(stmts, deleteStmt(td.FromName, td.FromCols[:td.KeyLen], r.Old[:td.KeyLen], false))
+			} else {
+				stmts = append(stmts, deleteStmt(td.FromName, td.FromCols, r.Old, true))
+			}
+		}
+	}
+
+	for _, td := range p.Tables {
+		stmts = append(stmts, td.Schema...)
+	}
+
+	for _, td := range p.Tables {
+		for _, r := range td.Rows {
+			if r.New != nil {
+				stmts = append(stmts, insertStmt("INSERT", td.ToName, td.ToCols, r.New))
+			}
+		}
+	}
+	return stmts
+}
+
+func replaceStmt(tableName string, cols, vals []string) string {
+	return insertStmt("REPLACE", tableName, cols, vals)
+}
+
+func insertStmt(verb, tableName string, cols, vals []string) string {
+	return fmt.Sprintf("%s INTO %s %s VALUES (%s);", verb, sqlfmt.QuoteIdentifier(tableName), columnList(cols), strings.Join(vals, ","))
+}
+
+// deleteStmt returns a statement that deletes the rows of |tableName| whose |cols| have the values |vals|. If
+// |single| is true, |cols| are all the columns of the table, and only one of the matching rows is deleted. Otherwise
+// |cols| are the primary key of the table.
+func deleteStmt(tableName string, cols, vals []string, single bool) string {
+	op := "="
+	if single {
+		op = "<=>"
+	}
+	conds := make([]string, len(cols))
+	for i, col := range cols {
+		conds[i] = fmt.Sprintf("%s %s %s", sqlfmt.QuoteIdentifier(col), op, vals[i])
+	}
+
+	stmt := fmt.Sprintf("DELETE FROM %s WHERE %s", sqlfmt.QuoteIdentifier(tableName), strings.Join(conds, " AND "))
+	if single {
+		stmt += " LIMIT 1"
+	}
+	return stmt + ";"
+}
@@ -0,0 +1,334 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ErrMergeCommit is returned when a patch is made from a commit that doesn't have exactly one parent.
+var ErrMergeCommit = errors.New("patches can only be made from commits with a single parent")
+
+// FromCommit returns the patch of the changes made by |cm| to its parent. Changes to system tables, such as
+// dolt_schemas and dolt_docs, are not included.
+func FromCommit(ctx *sql.Context, cm *doltdb.Commit) (*Patch, error) {
+	if cm.NumParents() != 1 {
+		return nil, ErrMergeCommit
+	}
+
+	optCmt, err := cm.GetParent(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p := &Patch{
+		Name:    meta.Name,
+		Email:   meta.Email,
+		Date:    meta.Time(),
+		Message: meta.Description,
+	}
+	if p.Commit, err = cm.HashOf(); err != nil {
+		return nil, err
+	}
+	if p.Parent, err = parent.HashOf(); err != nil {
+		return nil, err
+	}
+
+	fromRoot, err := parent.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	toRoot, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.Tables, err = DiffRoots(ctx, fromRoot, toRoot); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DiffRoots returns the table diffs of the changes made to the user tables of |fromRoot| in |toRoot|.
+func DiffRoots(ctx *sql.Context, fromRoot, toRoot doltdb.RootValue) ([]TableDiff, error) {
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	var tds []TableDiff
+	for _, td := range deltas {
+		if td.FromTable == nil && td.ToTable == nil {
+			// database collation changes aren't included
+			continue
+		}
+		if doltdb.HasDoltPrefix(td.FromName.Name) || doltdb.HasDoltPrefix(td.ToName.Name) {
+			continue
+		}
+
+		changed, err := td.HasChanges()
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			continue
+		}
+
+		tblDiff, err := diffTable(ctx, fromRoot, toRoot, td)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", td.CurName(), err)
+		}
+		tds = append(tds, tblDiff)
+	}
+	return tds, nil
+}
+
+func diffTable(ctx *sql.Context, fromRoot, toRoot doltdb.RootValue, td diff.TableDelta) (TableDiff, error) {
+	tblDiff := TableDiff{}
+	if td.FromTable != nil {
+		tblDiff.FromName = td.FromName.Name
+	}
+	if td.ToTable != nil {
+		tblDiff.ToName = td.ToName.Name
+	}
+
+	schemaChanged, err := td.HasSchemaChanged(ctx)
+	if err != nil {
+		return TableDiff{}, err
+	}
+	if schemaChanged {
+		if tblDiff.Schema, err = sqlfmt.GenerateSqlPatchSchemaStatements(ctx, toRoot, td); err != nil {
+			return TableDiff{}, err
+		}
+	}
+
+	// the rows of dropped tables aren't written, they are removed with the table
+	if td.IsDrop() {
+		return tblDiff, nil
+	}
+
+	fromIdx, toIdx, err := td.GetRowData(ctx)
+	if err != nil {
+		return TableDiff{}, err
+	}
+
+	var fromRows, toRows *rowConverter
+	if fromIdx != nil {
+		if fromRows, err = newRowConverter(td.FromSch, fromRoot.NodeStore()); err != nil {
+			return TableDiff{}, err
+		}
+		tblDiff.FromCols = fromRows.names
+	}
+	toRows, err = newRowConverter(td.ToSch, toRoot.NodeStore())
+	if err != nil {
+		return TableDiff{}, err
+	}
+	tblDiff.ToCols = toRows.names
+
+	diffable := fromIdx != nil && schema.ArePrimaryKeySetsDiffable(td.Format(), td.FromSch, td.ToSch)
+	if (diffable || fromIdx == nil) && !schema.IsKeyless(td.ToSch) {
+		tblDiff.KeyLen = td.ToSch.GetPKCols().Size()
+	}
+
+	if !diffable {
+		// rows of new tables, and of tables whose primary key changed, are written as removing every old row and
+		// adding every new row
+		if fromIdx != nil {
+			if tblDiff.Rows, err = fromRows.allRows(ctx, durable.ProllyMapFromIndex(fromIdx), false, tblDiff.Rows); err != nil {
+				return TableDiff{}, err
+			}
+		}
+		if tblDiff.Rows, err = toRows.allRows(ctx, durable.ProllyMapFromIndex(toIdx), true, tblDiff.Rows); err != nil {
+			return TableDiff{}, err
+		}
+		return tblDiff, nil
+	}
+
+	keyless := schema.IsKeyless(td.ToSch)
+	err = prolly.DiffMaps(ctx, durable.ProllyMapFromIndex(fromIdx), durable.ProllyMapFromIndex(toIdx), false, func(ctx context.Context, d tree.Diff) error {
+		var oldRow, newRow []string
+		var err error
+		if d.Type != tree.AddedDiff {
+			if oldRow, err = fromRows.literals(ctx, val.Tuple(d.Key), val.Tuple(d.From)); err != nil {
+				return err
+			}
+		}
+		if d.Type != tree.RemovedDiff {
+			if newRow, err = toRows.literals(ctx, val.Tuple(d.Key), val.Tuple(d.To)); err != nil {
+				return err
+			}
+		}
+
+		if !keyless {
+			tblDiff.Rows = append(tblDiff.Rows, RowDiff{Old: oldRow, New: newRow})
+			return nil
+		}
+
+		// the rows of keyless tables are changed by changing the number of copies of the row
+		var from, to uint64
+		if oldRow != nil {
+			from = val.ReadKeylessCardinality(val.Tuple(d.From))
+		}
+		if newRow != nil {
+			to = val.ReadKeylessCardinality(val.Tuple(d.To))
+		}
+		for ; from > to; from-- {
+			tblDiff.Rows = append(tblDiff.Rows, RowDiff{Old: oldRow})
+		}
+		for ; to > from; to-- {
+			tblDiff.Rows = append(tblDiff.Rows, RowDiff{New: newRow})
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return TableDiff{}, err
+	}
+
+	return tblDiff, nil
+}
+
+// rowConverter converts the key and value tuples of the rows of a table to the SQL literals of their values. The
+// values of virtual and generated columns aren't included, as they are computed by the table.
+type rowConverter struct {
+	keyDesc val.TupleDesc
+	valDesc val.TupleDesc
+	ns      tree.NodeStore
+	keyless bool
+
+	// keyIdxs and valIdxs are the indexes of the written fields of the key and value tuples
+	keyIdxs []int
+	valIdxs []int
+	names   []string
+	sch     schema.Schema
+}
+
+func newRowConverter(sch schema.Schema, ns tree.NodeStore) (*rowConverter, error) {
+	rc := &rowConverter{ns: ns, keyless: schema.IsKeyless(sch)}
+	rc.keyDesc, rc.valDesc = sch.GetMapDescriptors()
+
+	var cols []schema.Column
+	if !rc.keyless {
+		for i, col := range sch.GetPKCols().GetColumns() {
+			rc.keyIdxs = append(rc.keyIdxs, i)
+			cols = append(cols, col)
+		}
+	}
+
+	// the value tuples of keyless tables start with the row's cardinality
+	i := 0
+	if rc.keyless {
+		i = 1
+	}
+	for _, col := range sch.GetNonPKCols().GetColumns() {
+		if col.Virtual {
+			continue
+		}
+		if col.Generated == "" {
+			rc.valIdxs = append(rc.valIdxs, i)
+			cols = append(cols, col)
+		}
+		i++
+	}
+
+	for _, col := range cols {
+		rc.names = append(rc.names, col.Name)
+	}
+	// the columns are only used to format their values, so the schema doesn't need a primary key
+	rc.sch = schema.UnkeyedSchemaFromCols(schema.NewColCollection(cols...))
+	return rc, nil
+}
+
+// literals returns the SQL literals of the values of the row with the key |key| and the value |value|.
+func (rc *rowConverter) literals(ctx context.Context, key, value val.Tuple) ([]string, error) {
+	r := make(sql.Row, 0, len(rc.names))
+	for _, i := range rc.keyIdxs {
+		f, err := tree.GetField(ctx, rc.keyDesc, i, key, rc.ns)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, f)
+	}
+	for _, i := range rc.valIdxs {
+		f, err := tree.GetField(ctx, rc.valDesc, i, value, rc.ns)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, f)
+	}
+
+	tuple, err := sqlfmt.SqlRowAsTupleString(r, rc.sch)
+	if err != nil {
+		return nil, err
+	}
+	return SplitTuple(tuple)
+}
+
+// allRows appends every row of |m| to |rows|, as an added row if |added| is true, or a removed row otherwise.
+func (rc *rowConverter) allRows(ctx context.Context, m prolly.Map, added bool, rows []RowDiff) ([]RowDiff, error) {
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		r, err := rc.literals(ctx, k, v)
+		if err != nil {
+			return nil, err
+		}
+
+		n := uint64(1)
+		if rc.keyless {
+			n = val.ReadKeylessCardinality(v)
+		}
+		for ; n > 0; n-- {
+			if added {
+				rows = append(rows, RowDiff{New: r})
+			} else {
+				rows = append(rows, RowDiff{Old: r})
+			}
+		}
+	}
+}
@@ -0,0 +1,646 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package patch reads and writes patch files, which hold the changes made by a commit in a text format that can be
+// applied to a clone of a database that doesn't share a remote with the database the patch was made from.
+//
+// A patch file holds one or more patches. Each patch starts with a header that describes the commit, followed by the
+// diff of each table it changed:
+//
+//	From 1l3bcbjr7bk4nfcpbmj6aljfgb2p3kh2
+//	Parent: u0uekh73ob2qudh5lnam2j1cq1kpk7np
+//	Author: Bill Billerson <bill@dolthub.com>
+//	Date: Mon Jan 15 10:14:28 -0800 2024
+//
+//	    update the price of widgets
+//
+//	diff --dolt a/products b/products
+//	--- a/products
+//	+++ b/products
+//	@@ schema @@
+//	 ALTER TABLE `products` ADD `discount` int;
+//	@@ rows -(`id`,`name`,`price`) +(`id`,`name`,`price`,`discount`) key 1 @@
+//	-(1,'widget',10)
+//	+(1,'widget',12,NULL)
+//
+// Rows are written as tuples of SQL literals, with the primary key columns first. Rows that are removed or changed
+// by the commit are written with their old values, so that changes made to the same rows since the patch was made can
+// be detected when it is applied.
+package patch
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	fromPrefix    = "From "
+	parentPrefix  = "Parent: "
+	authorPrefix  = "Author: "
+	datePrefix    = "Date: "
+	messageIndent = "    "
+	diffPrefix    = "diff --dolt "
+	oldPrefix     = "--- "
+	newPrefix     = "+++ "
+	schemaHeader  = "@@ schema @@"
+	rowsPrefix    = "@@ rows "
+	rowsSuffix    = " @@"
+	devNull       = "/dev/null"
+
+	// DateFormat is the format of the commit dates of patches, which is the format they are displayed in by dolt log.
+	DateFormat = time.RubyDate
+)
+
+// ErrMalformedPatch is returned when a patch file can't be read.
+var ErrMalformedPatch = errors.New("malformed patch")
+
+// Patch holds the changes made by a single commit to the tables of a database.
+type Patch struct {
+	// Commit is the hash of the commit the patch was made from.
+	Commit hash.Hash
+	// Parent is the hash of the parent of the commit the patch was made from.
+	Parent hash.Hash
+
+	Name    string
+	Email   string
+	Date    time.Time
+	Message string
+
+	Tables []TableDiff
+}
+
+// Subject returns the first line of the patch's commit message.
+func (p *Patch) Subject() string {
+	subject, _, _ := strings.Cut(p.Message, "\n")
+	return subject
+}
+
+// TableDiff holds the changes made to a single table.
+type TableDiff struct {
+	// FromName is the name of the table before the change, or empty if the table was created.
+	FromName string
+	// ToName is the name of the table after the change, or empty if the table was dropped.
+	ToName string
+
+	// Schema holds the DDL statements that change the schema of the table.
+	Schema []string
+
+	// FromCols are the columns of the values of removed rows, primary key columns first.
+	FromCols []string
+	// ToCols are the columns of the values of added rows, primary key columns first.
+	ToCols []string
+	// KeyLen is the number of primary key columns at the start of FromCols and ToCols. Rows of tables without a
+	// primary key, or whose primary key is changed, are matched on all of their values.
+	KeyLen int
+
+	Rows []RowDiff
+}
+
+// RowDiff is a change to a row. A row that is removed has only Old values, a row that is added has only New values,
+// and a row that is changed has both.
+type RowDiff struct {
+	// Old are the SQL literals of the values of the row before the change, in the order of the table's FromCols.
+	Old []string
+	// New are the SQL literals of the values of the row after the change, in the order of the table's ToCols.
+	New []string
+}
+
+// Write writes the patch |p| to |wr|.
+func Write(wr io.Writer, p *Patch) error {
+	bw := bufio.NewWriter(wr)
+	fmt.Fprintf(bw, "%s%s\n", fromPrefix, p.Commit.String())
+	fmt.Fprintf(bw, "%s%s\n", parentPrefix, p.Parent.String())
+	fmt.Fprintf(bw, "%s%s <%s>\n", authorPrefix, p.Name, p.Email)
+	fmt.Fprintf(bw, "%s%s\n\n", datePrefix, p.Date.Format(DateFormat))
+	for _, line := range strings.Split(p.Message, "\n") {
+		fmt.Fprintf(bw, "%s%s\n", messageIndent, line)
+	}
+
+	for _, td := range p.Tables {
+		bw.WriteString("\n")
+		writeTableDiff(bw, td)
+	}
+
+	return bw.Flush()
+}
+
+func writeTableDiff(bw *bufio.Writer, td TableDiff) {
+	fromName, toName := td.FromName, td.ToName
+	if fromName == "" {
+		fromName = toName
+	} else if toName == "" {
+		toName = fromName
+	}
+	fmt.Fprintf(bw, "%sa/%s b/%s\n", diffPrefix, fromName, toName)
+	fmt.Fprintf(bw, "%s%s\n", oldPrefix, pathOf("a/", td.FromName))
+	fmt.Fprintf(bw, "%s%s\n", newPrefix, pathOf("b/", td.ToName))
+
+	if len(td.Schema) > 0 {
+		fmt.Fprintln(bw, schemaHeader)
+		for _, stmt := range td.Schema {
+			for _, line := range strings.Split(stmt, "\n") {
+				fmt.Fprintf(bw, " %s\n", line)
+			}
+		}
+	}
+
+	if len(td.Rows) > 0 {
+		bw.WriteString(rowsPrefix)
+		if len(td.FromCols) > 0 {
+			bw.WriteString("-" + columnList(td.FromCols) + " ")
+		}
+		if len(td.ToCols) > 0 {
+			bw.WriteString("+" + columnList(td.ToCols) + " ")
+		}
+		fmt.Fprintf(bw, "key %d%s\n", td.KeyLen, rowsSuffix)
+
+		for _, r := range td.Rows {
+			if r.Old != nil {
+				fmt.Fprintf(bw, "-(%s)\n", strings.Join(r.Old, ","))
+			}
+			if r.New != nil {
+				fmt.Fprintf(bw, "+(%s)\n", strings.Join(r.New, ","))
+			}
+		}
+	}
+}
+
+func pathOf(prefix, name string) string {
+	if name == "" {
+		return devNull
+	}
+	return prefix + name
+}
+
+func columnList(cols []string) string {
+	quoted := make([]string, len(cols))
+	for i, col := range cols {
+		quoted[i] = sqlfmt.QuoteIdentifier(col)
+	}
+	return "(" + strings.Join(quoted, ",") + ")"
+}
+
+// Read reads all the patches in |rd|. Patches that are well formed but contain SQL other than schema changes to the
+// tables of their diffs, or row values other than literals, are rejected with ErrPatchDoesNotApply.
+func Read(rd io.Reader) ([]*Patch, error) {
+	pr := &patchReader{scanner: bufio.NewScanner(rd)}
+	pr.scanner.Buffer(nil, 1024*1024*1024)
+
+	var patches []*Patch
+	for pr.next() {
+		if pr.line == "" {
+			continue
+		}
+		if !strings.HasPrefix(pr.line, fromPrefix) {
+			return nil, pr.errorf("expected a line starting with '%s'", fromPrefix)
+		}
+		p, err := pr.readPatch()
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, p)
+	}
+	if err := pr.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("%w: no patches found", ErrMalformedPatch)
+	}
+	return patches, nil
+}
+
+// patchReader reads patches a line at a time. The current line can be left unread by calling unread, so that it's
+// returned again by the next call to next.
+type patchReader struct {
+	scanner *bufio.Scanner
+	line    string
+	lineNum int
+	unreadl bool
+}
+
+func (pr *patchReader) next() bool {
+	if pr.unreadl {
+		pr.unreadl = false
+		return true
+	}
+	if !pr.scanner.Scan() {
+		return false
+	}
+	pr.line = strings.TrimSuffix(pr.scanner.Text(), "\r")
+	pr.lineNum++
+	return true
+}
+
+func (pr *patchReader) unread() {
+	pr.unreadl = true
+}
+
+func (pr *patchReader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: line %d: %s", ErrMalformedPatch, pr.lineNum, fmt.Sprintf(format, args...))
+}
+
+// rejectf returns an error for a patch that is well formed, but has statements or values that a patch can't
+// contain. The text of a patch is run as SQL when it is applied, so anything other than changes to the schema of the
+// table a diff is for, and literal row values, is rejected.
+func (pr *patchReader) rejectf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: line %d: %s", ErrPatchDoesNotApply, pr.lineNum, fmt.Sprintf(format, args...))
+}
+
+// readPatch reads a patch whose first line is the current line.
+func (pr *patchReader) readPatch() (*Patch, error) {
+	p := &Patch{}
+
+	var ok bool
+	if p.Commit, ok = hash.MaybeParse(strings.TrimPrefix(pr.line, fromPrefix)); !ok {
+		return nil, pr.errorf("invalid commit hash")
+	}
+
+	if !pr.next() || !strings.HasPrefix(pr.line, parentPrefix) {
+		return nil, pr.errorf("expected a line starting with '%s'", parentPrefix)
+	}
+	if p.Parent, ok = hash.MaybeParse(strings.TrimPrefix(pr.line, parentPrefix)); !ok {
+		return nil, pr.errorf("invalid parent hash")
+	}
+
+	if !pr.next() || !strings.HasPrefix(pr.line, authorPrefix) {
+		return nil, pr.errorf("expected a line starting with '%s'", authorPrefix)
+	}
+	author := strings.TrimPrefix(pr.line, authorPrefix)
+	start, end := strings.LastIndex(author, "<"), strings.LastIndex(author, ">")
+	if start == -1 || end < start {
+		return nil, pr.errorf("invalid author")
+	}
+	p.Name, p.Email = strings.TrimSpace(author[:start]), author[start+1:end]
+
+	if !pr.next() || !strings.HasPrefix(pr.line, datePrefix) {
+		return nil, pr.errorf("expected a line starting with '%s'", datePrefix)
+	}
+	var err error
+	if p.Date, err = time.Parse(DateFormat, strings.TrimPrefix(pr.line, datePrefix)); err != nil {
+		return nil, pr.errorf("invalid date: %s", err.Error())
+	}
+
+	var message []string
+	for pr.next() {
+		if strings.HasPrefix(pr.line, messageIndent) {
+			message = append(message, strings.TrimPrefix(pr.line, messageIndent))
+		} else if pr.line != "" || len(message) > 0 {
+			pr.unread()
+			break
+		}
+	}
+	p.Message = strings.Join(message, "\n")
+
+	for pr.next() {
+		if pr.line == "" {
+			continue
+		}
+		if !strings.HasPrefix(pr.line, diffPrefix) {
+			pr.unread()
+			break
+		}
+		td, err := pr.readTableDiff()
+		if err != nil {
+			return nil, err
+		}
+		p.Tables = append(p.Tables, td)
+	}
+
+	return p, nil
+}
+
+// readTableDiff reads a table diff whose diff line is the current line.
+func (pr *patchReader) readTableDiff() (TableDiff, error) {
+	var td TableDiff
+	var err error
+
+	if !pr.next() || !strings.HasPrefix(pr.line, oldPrefix) {
+		return td, pr.errorf("expected a line starting with '%s'", oldPrefix)
+	}
+	if td.FromName, err = pr.tableName(strings.TrimPrefix(pr.line, oldPrefix), "a/"); err != nil {
+		return td, err
+	}
+	if !pr.next() || !strings.HasPrefix(pr.line, newPrefix) {
+		return td, pr.errorf("expected a line starting with '%s'", newPrefix)
+	}
+	if td.ToName, err = pr.tableName(strings.TrimPrefix(pr.line, newPrefix), "b/"); err != nil {
+		return td, err
+	}
+	if td.FromName == "" && td.ToName == "" {
+		return td, pr.errorf("table diff has no table")
+	}
+
+	if !pr.next() {
+		return td, nil
+	}
+	if pr.line == schemaHeader {
+		var lines []string
+		for pr.next() {
+			if !strings.HasPrefix(pr.line, " ") {
+				pr.unread()
+				break
+			}
+			lines = append(lines, pr.line[1:])
+		}
+		pieces, err := sqlparser.SplitStatementToPieces(strings.Join(lines, "\n"))
+		if err != nil {
+			return td, pr.errorf("invalid schema statements: %s", err.Error())
+		}
+		for _, piece := range pieces {
+			if piece = strings.TrimSpace(piece); piece != "" {
+				if err = pr.checkSchemaStatement(piece, td); err != nil {
+					return td, err
+				}
+				td.Schema = append(td.Schema, piece+";")
+			}
+		}
+	} else {
+		pr.unread()
+	}
+
+	if !pr.next() {
+		return td, nil
+	}
+	if !strings.HasPrefix(pr.line, rowsPrefix) {
+		pr.unread()
+		return td, nil
+	}
+	if err = pr.readRowsHeader(&td); err != nil {
+		return td, err
+	}
+	for pr.next() {
+		if pr.line == "" || (pr.line[0] != '-' && pr.line[0] != '+') {
+			pr.unread()
+			break
+		}
+
+		cols := td.FromCols
+		if pr.line[0] == '+' {
+			cols = td.ToCols
+		}
+		vals, err := SplitTuple(pr.line[1:])
+		if err != nil {
+			return td, pr.errorf("%s", err.Error())
+		}
+		if len(vals) != len(cols) {
+			return td, pr.errorf("expected %d values, found %d", len(cols), len(vals))
+		}
+		for i := range vals {
+			if vals[i], err = pr.literal(vals[i]); err != nil {
+				return td, err
+			}
+		}
+
+		// a removed row followed by an added row with the same key is a change to the row
+		n := len(td.Rows)
+		if pr.line[0] == '+' && n > 0 && td.Rows[n-1].New == nil && sameKey(td.Rows[n-1].Old, vals, td.KeyLen) {
+			td.Rows[n-1].New = vals
+		} else if pr.line[0] == '+' {
+			td.Rows = append(td.Rows, RowDiff{New: vals})
+		} else {
+			td.Rows = append(td.Rows, RowDiff{Old: vals})
+		}
+	}
+
+	return td, nil
+}
+
+// checkSchemaStatement returns an error unless |stmt| is a CREATE, ALTER, DROP or RENAME TABLE statement for the
+// table of |td|.
+func (pr *patchReader) checkSchemaStatement(stmt string, td TableDiff) error {
+	parsed, err := sqlparser.Parse(stmt)
+	if err != nil {
+		return pr.rejectf("invalid schema statement: %s", err.Error())
+	}
+
+	formatted := sqlparser.String(parsed)
+	var tables sqlparser.TableNames
+	switch s := parsed.(type) {
+	case *sqlparser.DDL:
+		switch {
+		case s.Action == sqlparser.CreateStr && strings.HasPrefix(formatted, "create table ") && s.OptSelect == nil && s.OptLike == nil:
+			tables = sqlparser.TableNames{s.Table}
+		case s.Action == sqlparser.DropStr && strings.HasPrefix(formatted, "drop table "):
+			tables = s.FromTables
+		case s.Action == sqlparser.RenameStr && strings.HasPrefix(formatted, "rename table "):
+			tables = append(append(tables, s.FromTables...), s.ToTables...)
+		default:
+			return pr.rejectf("schema statement is not a CREATE, ALTER, DROP or RENAME TABLE statement: %s", stmt)
+		}
+	case *sqlparser.AlterTable:
+		tables = sqlparser.TableNames{s.Table}
+		for _, ddl := range s.Statements {
+			tables = append(tables, ddl.ToTables...)
+		}
+	default:
+		return pr.rejectf("schema statement is not a CREATE, ALTER, DROP or RENAME TABLE statement: %s", stmt)
+	}
+
+	for _, t := range tables {
+		name := t.Name.String()
+		if !t.DbQualifier.IsEmpty() || !t.SchemaQualifier.IsEmpty() || name == "" || (name != td.FromName && name != td.ToName) {
+			return pr.rejectf("schema statement changes a table other than the table of the diff: %s", stmt)
+		}
+	}
+	return nil
+}
+
+// literal returns the SQL literal |v|, formatted by the parser, or an error if |v| is anything other than a single
+// literal, or a cast of one. The formatted literal is returned so that comments in |v| can't change the meaning of
+// the statements it is put in.
+func (pr *patchReader) literal(v string) (string, error) {
+	stmt, err := sqlparser.Parse("SELECT " + v)
+	if err != nil {
+		return "", pr.rejectf("invalid value %s", v)
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs) != 1 {
+		return "", pr.rejectf("invalid value %s", v)
+	}
+	expr, ok := sel.SelectExprs[0].(*sqlparser.AliasedExpr)
+	if !ok || !isLiteral(expr.Expr) {
+		return "", pr.rejectf("value is not a literal: %s", v)
+	}
+	lit := sqlparser.String(expr.Expr)
+	if sqlparser.String(stmt) != "select "+lit {
+		// an alias, or clauses such as INTO OUTFILE or FROM, follow the value
+		return "", pr.rejectf("value is not a literal: %s", v)
+	}
+	if _, ok := expr.Expr.(*sqlparser.NullVal); ok {
+		return "NULL", nil
+	}
+	return lit, nil
+}
+
+// isLiteral returns whether |expr| is a string, number, hex or bit value, boolean or NULL, or a cast of one.
+func isLiteral(expr sqlparser.Expr) bool {
+	switch e := expr.(type) {
+	case *sqlparser.SQLVal:
+		return e.Type != sqlparser.ValArg
+	case *sqlparser.NullVal, sqlparser.BoolVal:
+		return true
+	case *sqlparser.UnaryExpr:
+		val, ok := e.Expr.(*sqlparser.SQLVal)
+		return (e.Operator == sqlparser.UMinusStr || e.Operator == sqlparser.UPlusStr) && ok && (val.Type == sqlparser.IntVal || val.Type == sqlparser.FloatVal)
+	case *sqlparser.ConvertExpr:
+		return isLiteral(e.Expr)
+	default:
+		return false
+	}
+}
+
+// sameKey returns whether the rows |a| and |b| have the same primary key, whose values are their first |keyLen|
+// values. Rows without a primary key never have the same key.
+func sameKey(a, b []string, keyLen int) bool {
+	if keyLen == 0 || len(a) < keyLen || len(b) < keyLen {
+		return false
+	}
+	for i := 0; i < keyLen; i++ {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (pr *patchReader) tableName(path, prefix string) (string, error) {
+	if path == devNull {
+		return "", nil
+	}
+	if !strings.HasPrefix(path, prefix) || len(path) == len(prefix) {
+		return "", pr.errorf("invalid table path %s", path)
+	}
+	return strings.TrimPrefix(path, prefix), nil
+}
+
+// readRowsHeader reads the columns and key length of the rows of |td| from the current line.
+func (pr *patchReader) readRowsHeader(td *TableDiff) error {
+	if !strings.HasSuffix(pr.line, rowsSuffix) {
+		return pr.errorf("invalid rows header")
+	}
+	header := strings.TrimSuffix(strings.TrimPrefix(pr.line, rowsPrefix), rowsSuffix)
+
+	var err error
+	if strings.HasPrefix(header, "-") {
+		if td.FromCols, header, err = parseColumnList(header[1:]); err != nil {
+			return pr.errorf("%s", err.Error())
+		}
+		header = strings.TrimPrefix(header, " ")
+	}
+	if strings.HasPrefix(header, "+") {
+		if td.ToCols, header, err = parseColumnList(header[1:]); err != nil {
+			return pr.errorf("%s", err.Error())
+		}
+		header = strings.TrimPrefix(header, " ")
+	}
+
+	if !strings.HasPrefix(header, "key ") {
+		return pr.errorf("invalid rows header")
+	}
+	if td.KeyLen, err = strconv.Atoi(strings.TrimPrefix(header, "key ")); err != nil {
+		return pr.errorf("invalid key length")
+	}
+	if td.KeyLen < 0 || (td.FromCols != nil && td.KeyLen > len(td.FromCols)) || (td.ToCols != nil && td.KeyLen > len(td.ToCols)) {
+		return pr.errorf("invalid key length")
+	}
+	return nil
+}
+
+// parseColumnList parses a parenthesized list of quoted identifiers at the start of |s|, and returns the names of
+// the columns and the rest of |s|.
+func parseColumnList(s string) ([]string, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return nil, "", errors.New("invalid column list")
+	}
+	s = s[1:]
+
+	var cols []string
+	for {
+		if !strings.HasPrefix(s, "`") {
+			return nil, "", errors.New("invalid column list")
+		}
+		var col strings.Builder
+		i := 1
+		for ; i < len(s); i++ {
+			if s[i] == '`' {
+				if i+1 < len(s) && s[i+1] == '`' {
+					col.WriteByte('`')
+					i++
+					continue
+				}
+				break
+			}
+			col.WriteByte(s[i])
+		}
+		if i >= len(s) {
+			return nil, "", errors.New("invalid column list")
+		}
+		cols = append(cols, col.String())
+
+		s = s[i+1:]
+		if strings.HasPrefix(s, ",") {
+			s = s[1:]
+		} else if strings.HasPrefix(s, ")") {
+			return cols, s[1:], nil
+		} else {
+			return nil, "", errors.New("invalid column list")
+		}
+	}
+}
+
+// SplitTuple returns the SQL literals of the parenthesized tuple |s|.
+func SplitTuple(s string) ([]string, error) {
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("invalid tuple: %s", s)
+	}
+	s = s[1 : len(s)-1]
+
+	var vals []string
+	start := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+			} else {
+				quote = 0
+			}
+		case quote != 0:
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ',':
+			vals = append(vals, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("invalid tuple, unterminated string: %s", s)
+	}
+	return append(vals, strings.TrimSpace(s[start:])), nil
+}
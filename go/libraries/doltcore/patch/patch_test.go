@@ -0,0 +1,244 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func testPatches() []*Patch {
+	date := time.Date(2024, 1, 15, 10, 14, 28, 0, time.FixedZone("", -8*60*60))
+	return []*Patch{
+		{
+			Commit:  hash.Parse("1l3bcbjr7bk4nfcpbmj6aljfgb2p3kh2"),
+			Parent:  hash.Parse("u0uekh73ob2qudh5lnam2j1cq1kpk7np"),
+			Name:    "Bill Billerson",
+			Email:   "bill@dolthub.com",
+			Date:    date,
+			Message: "update the price of widgets\n\nand add discounts",
+			Tables: []TableDiff{
+				{
+					FromName: "products",
+					ToName:   "products",
+					Schema:   []string{"ALTER TABLE `products` ADD `discount` int;"},
+					FromCols: []string{"id", "name", "price"},
+					ToCols:   []string{"id", "name", "price", "discount"},
+					KeyLen:   1,
+					Rows: []RowDiff{
+						{Old: []string{"1", "'widget'", "10"}, New: []string{"1", "'widget'", "12", "NULL"}},
+						{Old: []string{"2", "'it\\'s, a gadget'", "5"}},
+						{New: []string{"3", "'gizmo'", "7", "1"}},
+					},
+				},
+				{
+					FromName: "tags",
+					ToName:   "tags",
+					FromCols: []string{"tag", "note"},
+					ToCols:   []string{"tag", "note"},
+					Rows: []RowDiff{
+						{Old: []string{"'a'", "'b'"}},
+						{Old: []string{"'a'", "'b'"}},
+					},
+				},
+			},
+		},
+		{
+			Commit:  hash.Parse("3tf7uj4utmv0tah7bm22jfb7i1gc8h5u"),
+			Parent:  hash.Parse("1l3bcbjr7bk4nfcpbmj6aljfgb2p3kh2"),
+			Name:    "Bill Billerson",
+			Email:   "bill@dolthub.com",
+			Date:    date.Add(time.Hour),
+			Message: "drop old tables",
+			Tables: []TableDiff{
+				{
+					FromName: "old",
+					Schema:   []string{"DROP TABLE `old`;"},
+				},
+				{
+					ToName: "new table",
+					Schema: []string{"CREATE TABLE `new table` (\n  `id` int NOT NULL,\n  PRIMARY KEY (`id`)\n);"},
+					ToCols: []string{"id"},
+					KeyLen: 1,
+					Rows:   []RowDiff{{New: []string{"1"}}},
+				},
+			},
+		},
+	}
+}
+
+func TestWriteAndRead(t *testing.T) {
+	patches := testPatches()
+
+	var buf bytes.Buffer
+	for i, p := range patches {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		require.NoError(t, Write(&buf, p))
+	}
+
+	read, err := Read(&buf)
+	require.NoError(t, err)
+	require.Len(t, read, len(patches))
+	for i := range patches {
+		assert.True(t, patches[i].Date.Equal(read[i].Date))
+		read[i].Date = patches[i].Date
+		assert.Equal(t, patches[i], read[i])
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testPatches()[0]))
+
+	expected := `From 1l3bcbjr7bk4nfcpbmj6aljfgb2p3kh2
+Parent: u0uekh73ob2qudh5lnam2j1cq1kpk7np
+Author: Bill Billerson <bill@dolthub.com>
+Date: Mon Jan 15 10:14:28 -0800 2024
+
+    update the price of widgets
+    ` + `
+    and add discounts
+
+diff --dolt a/products b/products
+--- a/products
++++ b/products
+@@ schema @@
+ ALTER TABLE ` + "`products` ADD `discount`" + ` int;
+@@ rows -(` + "`id`,`name`,`price`) +(`id`,`name`,`price`,`discount`" + `) key 1 @@
+-(1,'widget',10)
++(1,'widget',12,NULL)
+-(2,'it\'s, a gadget',5)
++(3,'gizmo',7,1)
+
+diff --dolt a/tags b/tags
+--- a/tags
++++ b/tags
+@@ rows -(` + "`tag`,`note`) +(`tag`,`note`" + `) key 0 @@
+-('a','b')
+-('a','b')
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestReadMalformed(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testPatches()[0]))
+	valid := buf.String()
+
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{"no header", strings.Replace(valid, "From ", "Form ", 1)},
+		{"bad hash", strings.Replace(valid, "Parent: u0uekh73ob2qudh5lnam2j1cq1kpk7np", "Parent: xyz", 1)},
+		{"bad date", strings.Replace(valid, "Date: Mon", "Date: Someday", 1)},
+		{"bad row", strings.Replace(valid, "+(3,'gizmo',7,1)", "+(3,'gizmo,7,1)", 1)},
+		{"wrong row length", strings.Replace(valid, "+(3,'gizmo',7,1)", "+(3,'gizmo',7)", 1)},
+		{"bad key length", strings.Replace(valid, "key 1", "key 9", 1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(test.patch))
+			assert.ErrorIs(t, err, ErrMalformedPatch)
+		})
+	}
+}
+
+func TestReadRejectsNonPatchSQL(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testPatches()[0]))
+	valid := buf.String()
+	alter := " ALTER TABLE `products` ADD `discount` int;"
+
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{"call", strings.Replace(valid, alter, alter+"\n CALL dolt_reset('--hard', 'HEAD~1');", 1)},
+		{"other table", strings.Replace(valid, alter, " DROP TABLE `users`;", 1)},
+		{"view", strings.Replace(valid, alter, " CREATE VIEW `products` AS SELECT 1;", 1)},
+		{"create table as select", strings.Replace(valid, alter, " CREATE TABLE `products` AS SELECT LOAD_FILE('/etc/passwd');", 1)},
+		{"into outfile", strings.Replace(valid, "+(3,'gizmo',7,1)", "+(3,'gizmo',7,1 INTO OUTFILE '/tmp/out')", 1)},
+		{"subquery", strings.Replace(valid, "+(3,'gizmo',7,1)", "+(3,(SELECT name FROM users LIMIT 1),7,1)", 1)},
+		{"function", strings.Replace(valid, "+(3,'gizmo',7,1)", "+(3,LOAD_FILE('/etc/passwd'),7,1)", 1)},
+		{"comment", strings.Replace(valid, "+(3,'gizmo',7,1)", "+(3,'gizmo' /*,'*/,(SELECT 1),',1)", 1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(test.patch))
+			assert.ErrorIs(t, err, ErrPatchDoesNotApply)
+		})
+	}
+
+	literals := strings.Replace(valid, "+(3,'gizmo',7,1)", "+(-3,X'6869',CAST('7.5' AS DECIMAL),0x01)", 1)
+	literals = strings.Replace(literals, alter, alter+"\n RENAME TABLE `products` TO `products`;", 1)
+	read, err := Read(strings.NewReader(literals))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-3", "X'6869'", "CAST('7.5' as DECIMAL)", "0x01"}, read[0].Tables[0].Rows[2].New)
+}
+
+func TestSplitTuple(t *testing.T) {
+	tests := []struct {
+		tuple    string
+		expected []string
+	}{
+		{"(1)", []string{"1"}},
+		{"(1,NULL,'a')", []string{"1", "NULL", "'a'"}},
+		{"('a,b','c''d',\"e,f\")", []string{"'a,b'", "'c''d'", "\"e,f\""}},
+		{`('it\'s, here','\\',2)`, []string{`'it\'s, here'`, `'\\'`, "2"}},
+		{"( 1 , 2 )", []string{"1", "2"}},
+	}
+	for _, test := range tests {
+		t.Run(test.tuple, func(t *testing.T) {
+			vals, err := SplitTuple(test.tuple)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, vals)
+		})
+	}
+
+	for _, tuple := range []string{"1,2", "('a)", "(1,2"} {
+		_, err := SplitTuple(tuple)
+		assert.Error(t, err, tuple)
+	}
+}
+
+func TestStatements(t *testing.T) {
+	p := testPatches()[0]
+
+	assert.Equal(t, []string{
+		"REPLACE INTO `products` (`id`,`name`,`price`) VALUES (1,'widget',10);",
+		"REPLACE INTO `products` (`id`,`name`,`price`) VALUES (2,'it\\'s, a gadget',5);",
+		"DELETE FROM `products` WHERE `id` = 3;",
+	}, p.AncestorStatements())
+
+	assert.Equal(t, []string{
+		"DELETE FROM `products` WHERE `id` = 1;",
+		"DELETE FROM `products` WHERE `id` = 2;",
+		"DELETE FROM `tags` WHERE `tag` <=> 'a' AND `note` <=> 'b' LIMIT 1;",
+		"DELETE FROM `tags` WHERE `tag` <=> 'a' AND `note` <=> 'b' LIMIT 1;",
+		"ALTER TABLE `products` ADD `discount` int;",
+		"INSERT INTO `products` (`id`,`name`,`price`,`discount`) VALUES (1,'widget',12,NULL);",
+		"INSERT INTO `products` (`id`,`name`,`price`,`discount`) VALUES (3,'gizmo',7,1);",
+	}, p.Statements())
+}
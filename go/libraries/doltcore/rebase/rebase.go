@@ -17,6 +17,7 @@ package rebase
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/shopspring/decimal"
@@ -33,11 +34,18 @@ const (
 	RebaseActionFixup  = "fixup"
 	RebaseActionDrop   = "drop"
 	RebaseActionReword = "reword"
+	RebaseActionEdit   = "edit"
+	RebaseActionBreak  = "break"
+	RebaseActionExec   = "exec"
 )
 
 // ErrInvalidRebasePlanSquashFixupWithoutPick is returned when a rebase plan attempts to squash or
 // fixup a commit without first picking or rewording a commit.
-var ErrInvalidRebasePlanSquashFixupWithoutPick = fmt.Errorf("invalid rebase plan: squash and fixup actions must appear after a pick or reword action")
+var ErrInvalidRebasePlanSquashFixupWithoutPick = fmt.Errorf("invalid rebase plan: squash and fixup actions must appear after a pick, reword, or edit action")
+
+// ErrInvalidRebasePlanExecWithoutStatement is returned when a rebase plan contains an exec action without a
+// SQL statement to execute.
+var ErrInvalidRebasePlanExecWithoutStatement = fmt.Errorf("invalid rebase plan: exec actions must specify a SQL statement in the commit_message column")
 
 // RebasePlanDatabase is a database that can save and load a rebase plan.
 type RebasePlanDatabase interface {
@@ -54,7 +62,9 @@ type RebasePlan struct {
 }
 
 // RebasePlanStep describes a single step in a rebase plan, such as dropping a
-// commit, squashing a commit into the previous commit, etc.
+// commit, squashing a commit into the previous commit, etc. The break and exec
+// actions don't apply a commit, so CommitHash is ignored for those steps, and for
+// exec steps, CommitMsg holds the SQL statement to execute.
 type RebasePlanStep struct {
 	RebaseOrder decimal.Decimal
 	Action      string
@@ -69,6 +79,12 @@ func (rps *RebasePlanStep) RebaseOrderAsFloat() float32 {
 	return float32(f64)
 }
 
+// AppliesCommit returns true if this step applies the commit identified by CommitHash. Steps with the break
+// or exec actions don't reference a commit.
+func (rps *RebasePlanStep) AppliesCommit() bool {
+	return rps.Action != RebaseActionBreak && rps.Action != RebaseActionExec
+}
+
 // CreateDefaultRebasePlan creates and returns the default rebase plan for the commits between
 // |startCommit| and |upstreamCommit|, equivalent to the log of startCommit..upstreamCommit. The
 // default plan includes each of those commits, in the same order they were originally applied, and
@@ -108,7 +124,7 @@ func CreateDefaultRebasePlan(ctx *sql.Context, startCommit, upstreamCommit *dolt
 }
 
 // ValidateRebasePlan returns a validation error for invalid states in a rebase plan, such as
-// squash or fixup actions appearing in the plan before a pick or reword action.
+// squash or fixup actions appearing in the plan before a pick, reword, or edit action.
 func ValidateRebasePlan(ctx *sql.Context, plan *RebasePlan) error {
 	seenPick := false
 	seenReword := false
@@ -120,7 +136,7 @@ func ValidateRebasePlan(ctx *sql.Context, plan *RebasePlan) error {
 		}
 
 		switch step.Action {
-		case RebaseActionPick:
+		case RebaseActionPick, RebaseActionEdit:
 			seenPick = true
 
 		case RebaseActionReword:
@@ -130,8 +146,16 @@ func ValidateRebasePlan(ctx *sql.Context, plan *RebasePlan) error {
 			if !seenPick && !seenReword {
 				return ErrInvalidRebasePlanSquashFixupWithoutPick
 			}
+
+		case RebaseActionExec:
+			if strings.TrimSpace(step.CommitMsg) == "" {
+				return ErrInvalidRebasePlanExecWithoutStatement
+			}
 		}
 
+		if !step.AppliesCommit() {
+			continue
+		}
 		if err := validateCommit(ctx, step.CommitHash); err != nil {
 			return err
 		}
@@ -54,6 +54,9 @@ type RemoteChunkStore struct {
 	fs      filesys.Filesys
 	lgr     *logrus.Entry
 	sealer  Sealer
+
+	// CommitValidator, if set, may reject a push before the root is updated.
+	CommitValidator CommitValidator
 	remotesapi.UnimplementedChunkStoreServiceServer
 }
 
@@ -501,6 +504,13 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 	currHash := hash.New(req.Current)
 	lastHash := hash.New(req.Last)
 
+	if rs.CommitValidator != nil {
+		if err = rs.CommitValidator(ctx, repoPath, currHash, lastHash); err != nil {
+			logger.WithError(err).Info("push rejected by commit validator")
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
 	var ok bool
 	ok, err = cs.Commit(ctx, currHash, lastHash)
 	if err != nil {
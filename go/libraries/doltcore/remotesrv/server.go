@@ -24,15 +24,23 @@ import (
 	"sync"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/libraries/doltcore/remotesrv")
+
 type Server struct {
 	wg       sync.WaitGroup
 	stopChan chan struct{}
@@ -73,8 +81,18 @@ type ServerArgs struct {
 	// listeners. The scheme used in the URLs returned from the gRPC server
 	// will be https.
 	TLSConfig *tls.Config
+
+	// If supplied, every push is validated by CommitValidator before the
+	// root of the pushed repository is updated.
+	CommitValidator CommitValidator
 }
 
+// CommitValidator is called before a push moves the root of the repository
+// at |repoPath| from |last| to |current|. The chunks reachable from
+// |current| can already be read from the repository's store. Returning an
+// error rejects the push.
+type CommitValidator func(ctx context.Context, repoPath string, current, last hash.Hash) error
+
 func NewServer(args ServerArgs) (*Server, error) {
 	if args.Logger == nil {
 		args.Logger = logrus.NewEntry(logrus.StandardLogger())
@@ -104,15 +122,21 @@ func NewServer(args ServerArgs) (*Server, error) {
 
 	s.wg.Add(2)
 	s.grpcListenAddr = args.GrpcListenAddr
-	s.grpcSrv = grpc.NewServer(append([]grpc.ServerOption{grpc.MaxRecvMsgSize(128 * 1024 * 1024)}, args.Options...)...)
-	var chnkSt remotesapi.ChunkStoreServiceServer = NewHttpFSBackedChunkStore(args.Logger, args.HttpHost, args.DBCache, args.FS, scheme, args.ConcurrencyControl, sealer)
+	s.grpcSrv = grpc.NewServer(append([]grpc.ServerOption{
+		grpc.MaxRecvMsgSize(128 * 1024 * 1024),
+		grpc.ChainUnaryInterceptor(remotestorage.TracingUnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(remotestorage.TracingStreamServerInterceptor),
+	}, args.Options...)...)
+	rcs := NewHttpFSBackedChunkStore(args.Logger, args.HttpHost, args.DBCache, args.FS, scheme, args.ConcurrencyControl, sealer)
+	rcs.CommitValidator = args.CommitValidator
+	var chnkSt remotesapi.ChunkStoreServiceServer = rcs
 
 	if args.ReadOnly {
 		chnkSt = ReadOnlyChunkStore{chnkSt}
 	}
 	remotesapi.RegisterChunkStoreServiceServer(s.grpcSrv, chnkSt)
 
-	var handler http.Handler = newFileHandler(args.Logger, args.DBCache, args.FS, args.ReadOnly, sealer)
+	var handler http.Handler = tracingHandler(newFileHandler(args.Logger, args.DBCache, args.FS, args.ReadOnly, sealer))
 	if args.HttpInterceptor != nil {
 		handler = args.HttpInterceptor(handler)
 	}
@@ -150,6 +174,13 @@ type Listeners struct {
 	grpc net.Listener
 }
 
+// NewListeners returns Listeners which serve both the HTTP and the gRPC
+// requests of a Server from |l|. The Server must have been created with the
+// same HttpListenAddr and GrpcListenAddr.
+func NewListeners(l net.Listener) Listeners {
+	return Listeners{http: l}
+}
+
 func (l Listeners) Close() error {
 	if l.http != nil {
 		err := l.http.Close()
@@ -244,3 +275,15 @@ func (s *Server) Serve(listeners Listeners) {
 
 	s.wg.Wait()
 }
+
+// tracingHandler records a span for each request to |handler|, as a child of the client's span if the client
+// propagated its trace context.
+func tracingHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.Start(ctx, "remotesrv.HTTP "+r.Method, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("path", r.URL.Path), attribute.String("range", r.Header.Get("Range"))))
+		defer span.End()
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -21,6 +21,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 
@@ -53,6 +55,9 @@ type ChunkFetcher struct {
 
 	abortCh chan struct{}
 	stats   StatsRecorder
+
+	// span covers the lifetime of the ChunkFetcher, from NewChunkFetcher to Close.
+	span trace.Span
 }
 
 const (
@@ -63,6 +68,7 @@ const (
 )
 
 func NewChunkFetcher(ctx context.Context, dcs *DoltChunkStore) *ChunkFetcher {
+	ctx, span := tracer.Start(ctx, "remotestorage.ChunkFetcher", trace.WithAttributes(attribute.String("host", dcs.host), attribute.String("repo_path", dcs.repoPath)))
 	eg, ctx := errgroup.WithContext(ctx)
 	ret := &ChunkFetcher{
 		eg:    eg,
@@ -73,6 +79,8 @@ func NewChunkFetcher(ctx context.Context, dcs *DoltChunkStore) *ChunkFetcher {
 
 		abortCh: make(chan struct{}),
 		stats:   StatsFactory(),
+
+		span: span,
 	}
 
 	locsReqCh := make(chan *remotesapi.GetDownloadLocsRequest)
@@ -145,9 +153,14 @@ func (f *ChunkFetcher) Recv(ctx context.Context) (nbs.CompressedChunk, error) {
 // without error is to read |Recv| until it returns |io.EOF|, and then to
 // |Close| the |ChunkFetcher|.
 func (f *ChunkFetcher) Close() error {
+	defer f.span.End()
 	defer StatsFlusher(f.stats)
 	close(f.abortCh)
-	return f.eg.Wait()
+	err := f.eg.Wait()
+	if err != nil {
+		f.span.RecordError(err)
+	}
+	return err
 }
 
 // Reads HashSets from reqCh and batches all the received addresses
@@ -576,10 +589,20 @@ func fetcherDownloadURLThread(ctx context.Context, fetchReqCh chan fetchReq, don
 			case <-ctx.Done():
 				return context.Cause(ctx)
 			case fetchResp := <-respCh:
-				f := fetchResp.get.GetDownloadFunc(ctx, stats, health, fetcher, params, chunkCh, func(ctx context.Context, lastError error, resourcePath string) (string, error) {
+				downloadCtx, span := tracer.Start(ctx, "remotestorage.DownloadRange", trace.WithAttributes(
+					attribute.String("resource_path", fetchResp.get.ResourcePath()),
+					attribute.Int("num_chunks", fetchResp.get.NumChunks())))
+				if fetchResp.get.NumChunks() > 0 {
+					span.SetAttributes(attribute.Int64("range_len", int64(fetchResp.get.RangeLen())))
+				}
+				f := fetchResp.get.GetDownloadFunc(downloadCtx, stats, health, fetcher, params, chunkCh, func(ctx context.Context, lastError error, resourcePath string) (string, error) {
 					return fetchResp.refresh(ctx, lastError, client)
 				})
 				err := f()
+				if err != nil {
+					span.RecordError(err)
+				}
+				span.End()
 				if err != nil {
 					return err
 				}
@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 type HTTPFetcher interface {
@@ -140,6 +142,7 @@ func StreamingRangeDownload(ctx context.Context, req StreamingRangeRequest) Stre
 
 			rangeHeaderVal := fmt.Sprintf("bytes=%d-%d", offset, rangeEnd)
 			httpReq.Header.Set("Range", rangeHeaderVal)
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
 
 			// We use a TimeoutController to enforce a timeout for
 			// receiving the response headers. If the request is
@@ -16,11 +16,20 @@ package remotestorage
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/nbs"
 )
 
+var chunkCacheHits, chunkCacheMisses atomic.Uint64
+
+// ChunkCacheStats returns the number of chunks looked up in the remote chunk caches of this process which were found
+// in the cache, |hits|, and which were not, |misses|.
+func ChunkCacheStats() (hits, misses uint64) {
+	return chunkCacheHits.Load(), chunkCacheMisses.Load()
+}
+
 // mapChunkCache is a ChunkCache implementation that stores everything in an in memory map.
 type mapChunkCache struct {
 	mu          *sync.Mutex
@@ -85,13 +94,18 @@ func (mcc *mapChunkCache) Get(hashes hash.HashSet) map[hash.Hash]nbs.CompressedC
 	mcc.mu.Lock()
 	defer mcc.mu.Unlock()
 
+	var hits, misses uint64
 	for h := range hashes {
 		if c, ok := mcc.hashToChunk[h]; ok {
 			hashToChunk[h] = c
+			hits++
 		} else {
 			hashToChunk[h] = nbs.EmptyCompressedChunk
+			misses++
 		}
 	}
+	chunkCacheHits.Add(hits)
+	chunkCacheMisses.Add(misses)
 
 	return hashToChunk
 }
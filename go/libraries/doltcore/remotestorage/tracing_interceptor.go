@@ -0,0 +1,117 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataCarrier is a propagation.TextMapCarrier for the metadata of a gRPC call.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	vals := metadata.MD(c).Get(key)
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// injectTraceContext returns |ctx| with the trace context of its span added to its outgoing gRPC metadata.
+func injectTraceContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// extractTraceContext returns |ctx| with the remote span of the trace context in its incoming gRPC metadata, if any.
+func extractTraceContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+}
+
+// TracingUnaryClientInterceptor records a span for each unary call, and propagates its trace context to the server.
+func TracingUnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, span := tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	err := invoker(injectTraceContext(ctx), method, req, reply, cc, opts...)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+// TracingStreamClientInterceptor propagates the trace context of each streaming call to the server.
+func TracingStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(injectTraceContext(ctx), desc, cc, method, opts...)
+}
+
+// TracingUnaryServerInterceptor records a span for each unary call, as a child of the client's span if the client
+// propagated its trace context.
+func TracingUnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := tracer.Start(extractTraceContext(ctx), info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+	resp, err := handler(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return resp, err
+}
+
+// TracingStreamServerInterceptor records a span for each streaming call, as a child of the client's span if the
+// client propagated its trace context.
+func TracingStreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := tracer.Start(extractTraceContext(ss.Context()), info.FullMethod, trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+	err := handler(srv, tracingServerStream{ServerStream: ss, ctx: ctx})
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+type tracingServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s tracingServerStream) Context() context.Context {
+	return s.ctx
+}
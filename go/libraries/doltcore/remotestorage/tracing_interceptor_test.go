@@ -0,0 +1,69 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTracingInterceptorsPropagateTraceContext(t *testing.T) {
+	defer otel.SetTextMapPropagator(otel.GetTextMapPropagator())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	traceID, err := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("0102030405060708")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-existing", "value")
+
+	var sent metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	require.NoError(t, TracingUnaryClientInterceptor(ctx, "/test/Method", nil, nil, nil, invoker))
+	require.NotEmpty(t, sent.Get("traceparent"))
+	assert.Equal(t, []string{"value"}, sent.Get("x-existing"))
+
+	var received trace.SpanContext
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		received = trace.SpanContextFromContext(ctx)
+		return nil, nil
+	}
+	serverCtx := metadata.NewIncomingContext(context.Background(), sent)
+	_, err = TracingUnaryServerInterceptor(serverCtx, nil, &grpc.UnaryServerInfo{FullMethod: "/test/Method"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, traceID, received.TraceID())
+	assert.True(t, received.IsRemote())
+
+	// Without an incoming trace context, the handler is not part of any trace
+	_, err = TracingUnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test/Method"}, handler)
+	require.NoError(t, err)
+	assert.False(t, received.IsValid())
+}
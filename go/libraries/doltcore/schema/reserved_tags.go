@@ -115,3 +115,20 @@ const (
 	DoltIgnorePatternTag = iota + SystemTableReservedMin + uint64(8000)
 	DoltIgnoreIgnoredTag
 )
+
+// Tags for the dolt_merge_rules table
+const (
+	DoltMergeRulesTableNameTag = iota + SystemTableReservedMin + uint64(9000)
+	DoltMergeRulesColumnNameTag
+	DoltMergeRulesStrategyTag
+)
+
+// Tags for the dolt_tests table
+const (
+	DoltTestsNameTag = iota + SystemTableReservedMin + uint64(10000)
+	DoltTestsGroupTag
+	DoltTestsQueryTag
+	DoltTestsAssertionTypeTag
+	DoltTestsAssertionComparatorTag
+	DoltTestsAssertionValueTag
+)
@@ -19,9 +19,12 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/url"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 )
 
 var DefaultUnixSocketFilePath = DefaultMySQLUnixSocketFilePath
@@ -62,6 +65,20 @@ const (
 	DefaultMySQLUnixSocketFilePath = "/tmp/mysql.sock"
 	DefaultMaxLoggedQueryLen       = 0
 	DefaultEncodeLoggedQuery       = false
+	DefaultAutoGCCheckInterval     = 60 * 1000 // 1 minute
+	DefaultAutoGCJournalSizeMB     = 256
+	DefaultAutoGCTableFileCount    = 128
+	DefaultWebhookTimeout          = 10 * 1000 // 10 seconds
+	DefaultTracingEndpoint         = "localhost:4317"
+	DefaultTracingServiceName      = "dolt-sql-server"
+	DefaultTracingSampleRatio      = 1.0
+)
+
+const (
+	// TracingExporterOTLP exports spans to an OpenTelemetry collector using OTLP over gRPC.
+	TracingExporterOTLP = "otlp"
+	// TracingExporterFile writes spans, as JSON, to a file.
+	TracingExporterFile = "file"
 )
 
 const (
@@ -117,6 +134,60 @@ type ClusterStandbyRemoteConfig interface {
 	RemoteURLTemplate() string
 }
 
+// AutoGCConfig configures the garbage collections which the server runs in the background. A full GC runs when the
+// chunk journal or the number of table files of a database grows past its threshold, or when the server has been idle
+// for IdleTime since the database was last written. A threshold of zero disables its trigger.
+type AutoGCConfig interface {
+	// Enable is true if the server runs garbage collections in the background.
+	Enable() bool
+	// CheckInterval is how often the server checks whether its databases need to be garbage collected.
+	CheckInterval() time.Duration
+	// JournalSize is the size in bytes of the chunk journal at which a database is garbage collected.
+	JournalSize() uint64
+	// TableFileCount is the number of table files at which a database is garbage collected.
+	TableFileCount() int
+	// IdleTime is how long the server must be idle before a database which was written since its last garbage
+	// collection is collected again.
+	IdleTime() time.Duration
+}
+
+// TracingConfig configures the OpenTelemetry tracing of the server. When configured, the server records spans for the
+// queries it runs, and for the merges, storage reads and remote calls they make.
+type TracingConfig interface {
+	// Exporter is where spans are exported, either TracingExporterOTLP or TracingExporterFile.
+	Exporter() string
+	// Endpoint is the host:port of the OTLP collector which spans are sent to.
+	Endpoint() string
+	// Insecure is true if the connection to the OTLP collector does not use TLS.
+	Insecure() bool
+	// Headers are gRPC headers, such as an authorization header, which are sent to the OTLP collector.
+	Headers() map[string]string
+	// File is the path of the file which spans are written to by the file exporter.
+	File() string
+	// SampleRatio is the fraction, between 0 and 1, of traces started by the server which are recorded.
+	SampleRatio() float64
+	// ServiceName is the service name the server's spans are reported with.
+	ServiceName() string
+}
+
+// WebhookConfig configures an HTTP endpoint which the server notifies, by POSTing a JSON payload, when a branch or tag
+// of one of its databases moves.
+type WebhookConfig interface {
+	// URL is the http or https URL which the payloads are POSTed to.
+	URL() string
+	// Databases are the names of the databases whose branches and tags are reported. Every database is reported if
+	// empty.
+	Databases() []string
+	// Refs are patterns, such as "refs/heads/main" or "refs/tags/*", matching the full names of the branches and tags
+	// which are reported. Every branch and tag is reported if empty.
+	Refs() []string
+	// Headers are HTTP headers, such as an Authorization header, which are sent with every payload.
+	Headers() map[string]string
+	// Timeout is how long the server waits for the endpoint to respond to a payload. A timeout_millis of 0 uses the
+	// default timeout.
+	Timeout() time.Duration
+}
+
 type JwksConfig struct {
 	Name        string            `yaml:"name"`
 	LocationUrl string            `yaml:"location_url"`
@@ -205,6 +276,15 @@ type ServerConfig interface {
 	ClusterConfig() ClusterConfig
 	// EventSchedulerStatus is the configuration for enabling or disabling the event scheduler in this server.
 	EventSchedulerStatus() string
+	// AutoGCConfig is the configuration for background garbage collection in this sql-server. Nil if background
+	// garbage collection is not configured.
+	AutoGCConfig() AutoGCConfig
+	// Webhooks are the HTTP endpoints which are notified when the branches and tags of the databases of this
+	// sql-server move.
+	Webhooks() []WebhookConfig
+	// TracingConfig is the configuration for the OpenTelemetry tracing of this sql-server. Nil if tracing is not
+	// configured.
+	TracingConfig() TracingConfig
 	// ValueSet returns whether the value string provided was explicitly set in the config
 	ValueSet(value string) bool
 }
@@ -274,9 +354,71 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if err := ValidateAutoGCConfig(config.AutoGCConfig()); err != nil {
+		return err
+	}
+	if err := ValidateWebhooksConfig(config.Webhooks()); err != nil {
+		return err
+	}
+	if err := ValidateTracingConfig(config.TracingConfig()); err != nil {
+		return err
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
+func ValidateAutoGCConfig(config AutoGCConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.CheckInterval() <= 0 {
+		return errors.New("auto_gc config: check_interval_millis must be greater than 0.")
+	}
+	if config.TableFileCount() < 0 {
+		return fmt.Errorf("auto_gc config: table_file_count must not be negative: %d", config.TableFileCount())
+	}
+	return nil
+}
+
+func ValidateWebhooksConfig(webhooks []WebhookConfig) error {
+	for _, webhook := range webhooks {
+		u, err := url.Parse(webhook.URL())
+		if err != nil {
+			return fmt.Errorf("webhooks config: invalid url %q: %w", webhook.URL(), err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhooks config: url must be an http or https url: %q", webhook.URL())
+		}
+		for _, pattern := range webhook.Refs() {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("webhooks config: invalid ref pattern %q for url %q: %w", pattern, webhook.URL(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func ValidateTracingConfig(config TracingConfig) error {
+	if config == nil {
+		return nil
+	}
+	switch config.Exporter() {
+	case TracingExporterOTLP:
+		if config.Endpoint() == "" {
+			return fmt.Errorf("tracing config: endpoint must be set for the %s exporter", TracingExporterOTLP)
+		}
+	case TracingExporterFile:
+		if config.File() == "" {
+			return fmt.Errorf("tracing config: file must be set for the %s exporter", TracingExporterFile)
+		}
+	default:
+		return fmt.Errorf("tracing config: exporter must be %s or %s: %q", TracingExporterOTLP, TracingExporterFile, config.Exporter())
+	}
+	if ratio := config.SampleRatio(); ratio < 0 || ratio > 1 {
+		return fmt.Errorf("tracing config: sample_ratio must be between 0 and 1: %v", ratio)
+	}
+	return nil
+}
+
 const (
 	MaxConnectionsKey = "max_connections"
 	ReadTimeoutKey    = "net_read_timeout"
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -65,6 +66,9 @@ type BehaviorYAMLConfig struct {
 	DoltTransactionCommit *bool `yaml:"dolt_transaction_commit"`
 
 	EventSchedulerStatus *string `yaml:"event_scheduler,omitempty" minver:"1.17.0"`
+
+	// AutoGC configures garbage collection in the background. See AutoGCConfig.
+	AutoGC *AutoGCYAMLConfig `yaml:"auto_gc,omitempty" minver:"TBD"`
 }
 
 // UserYAMLConfig contains server configuration regarding the user account clients must use to connect
@@ -135,6 +139,8 @@ type YAMLConfig struct {
 	MetricsConfig     MetricsYAMLConfig     `yaml:"metrics"`
 	RemotesapiConfig  RemotesapiYAMLConfig  `yaml:"remotesapi"`
 	ClusterCfg        *ClusterYAMLConfig    `yaml:"cluster,omitempty"`
+	Webhooks_         []WebhookYAMLConfig   `yaml:"webhooks,omitempty" minver:"TBD"`
+	Tracing_          *TracingYAMLConfig    `yaml:"tracing,omitempty" minver:"TBD"`
 	PrivilegeFile     *string               `yaml:"privilege_file,omitempty"`
 	BranchControlFile *string               `yaml:"branch_control_file,omitempty"`
 	// TODO: Rename to UserVars_
@@ -186,6 +192,7 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			ptr(cfg.DisableClientMultiStatements()),
 			ptr(cfg.DoltTransactionCommit()),
 			ptr(cfg.EventSchedulerStatus()),
+			autoGCConfigAsYAMLConfig(cfg.AutoGCConfig()),
 		},
 		UserConfig: UserYAMLConfig{
 			Name:     ptr(cfg.User()),
@@ -218,6 +225,8 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 			ReadOnly_: cfg.RemotesapiReadOnly(),
 		},
 		ClusterCfg:        clusterConfigAsYAMLConfig(cfg.ClusterConfig()),
+		Webhooks_:         webhooksConfigAsYAMLConfig(cfg.Webhooks()),
+		Tracing_:          tracingConfigAsYAMLConfig(cfg.TracingConfig()),
 		PrivilegeFile:     ptr(cfg.PrivilegeFilePath()),
 		BranchControlFile: ptr(cfg.BranchControlFilePath()),
 		SystemVars_:       systemVars,
@@ -226,6 +235,54 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 	}
 }
 
+func autoGCConfigAsYAMLConfig(config AutoGCConfig) *AutoGCYAMLConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &AutoGCYAMLConfig{
+		Enable_:              ptr(config.Enable()),
+		CheckIntervalMillis_: ptr(uint64(config.CheckInterval().Milliseconds())),
+		JournalSizeMB_:       ptr(config.JournalSize() / (1 << 20)),
+		TableFileCount_:      ptr(config.TableFileCount()),
+		IdleTimeMillis_:      ptr(uint64(config.IdleTime().Milliseconds())),
+	}
+}
+
+func webhooksConfigAsYAMLConfig(webhooks []WebhookConfig) []WebhookYAMLConfig {
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	ret := make([]WebhookYAMLConfig, len(webhooks))
+	for i, webhook := range webhooks {
+		ret[i] = WebhookYAMLConfig{
+			URL_:           ptr(webhook.URL()),
+			Databases_:     webhook.Databases(),
+			Refs_:          webhook.Refs(),
+			Headers_:       webhook.Headers(),
+			TimeoutMillis_: ptr(uint64(webhook.Timeout().Milliseconds())),
+		}
+	}
+	return ret
+}
+
+func tracingConfigAsYAMLConfig(config TracingConfig) *TracingYAMLConfig {
+	if config == nil {
+		return nil
+	}
+
+	return &TracingYAMLConfig{
+		Exporter_:    ptr(config.Exporter()),
+		Endpoint_:    ptr(config.Endpoint()),
+		Insecure_:    ptr(config.Insecure()),
+		Headers_:     config.Headers(),
+		File_:        nillableStrPtr(config.File()),
+		SampleRatio_: ptr(config.SampleRatio()),
+		ServiceName_: ptr(config.ServiceName()),
+	}
+}
+
 func clusterConfigAsYAMLConfig(config ClusterConfig) *ClusterYAMLConfig {
 	if config == nil {
 		return nil
@@ -592,6 +649,164 @@ func (cfg YAMLConfig) EventSchedulerStatus() string {
 	}
 }
 
+func (cfg YAMLConfig) AutoGCConfig() AutoGCConfig {
+	if cfg.BehaviorConfig.AutoGC == nil {
+		return nil
+	}
+	return cfg.BehaviorConfig.AutoGC
+}
+
+type AutoGCYAMLConfig struct {
+	Enable_              *bool   `yaml:"enable,omitempty" minver:"TBD"`
+	CheckIntervalMillis_ *uint64 `yaml:"check_interval_millis,omitempty" minver:"TBD"`
+	JournalSizeMB_       *uint64 `yaml:"journal_size_mb,omitempty" minver:"TBD"`
+	TableFileCount_      *int    `yaml:"table_file_count,omitempty" minver:"TBD"`
+	IdleTimeMillis_      *uint64 `yaml:"idle_time_millis,omitempty" minver:"TBD"`
+}
+
+func (c *AutoGCYAMLConfig) Enable() bool {
+	if c.Enable_ == nil {
+		return true
+	}
+	return *c.Enable_
+}
+
+func (c *AutoGCYAMLConfig) CheckInterval() time.Duration {
+	if c.CheckIntervalMillis_ == nil {
+		return DefaultAutoGCCheckInterval * time.Millisecond
+	}
+	return time.Duration(*c.CheckIntervalMillis_) * time.Millisecond
+}
+
+func (c *AutoGCYAMLConfig) JournalSize() uint64 {
+	if c.JournalSizeMB_ == nil {
+		return DefaultAutoGCJournalSizeMB << 20
+	}
+	return *c.JournalSizeMB_ << 20
+}
+
+func (c *AutoGCYAMLConfig) TableFileCount() int {
+	if c.TableFileCount_ == nil {
+		return DefaultAutoGCTableFileCount
+	}
+	return *c.TableFileCount_
+}
+
+func (c *AutoGCYAMLConfig) IdleTime() time.Duration {
+	if c.IdleTimeMillis_ == nil {
+		return 0
+	}
+	return time.Duration(*c.IdleTimeMillis_) * time.Millisecond
+}
+
+func (cfg YAMLConfig) Webhooks() []WebhookConfig {
+	if len(cfg.Webhooks_) == 0 {
+		return nil
+	}
+	ret := make([]WebhookConfig, len(cfg.Webhooks_))
+	for i := range cfg.Webhooks_ {
+		ret[i] = &cfg.Webhooks_[i]
+	}
+	return ret
+}
+
+type WebhookYAMLConfig struct {
+	URL_           *string           `yaml:"url,omitempty" minver:"TBD"`
+	Databases_     []string          `yaml:"databases,omitempty" minver:"TBD"`
+	Refs_          []string          `yaml:"refs,omitempty" minver:"TBD"`
+	Headers_       map[string]string `yaml:"headers,omitempty" minver:"TBD"`
+	TimeoutMillis_ *uint64           `yaml:"timeout_millis,omitempty" minver:"TBD"`
+}
+
+func (c *WebhookYAMLConfig) URL() string {
+	if c.URL_ == nil {
+		return ""
+	}
+	return *c.URL_
+}
+
+func (c *WebhookYAMLConfig) Databases() []string {
+	return c.Databases_
+}
+
+func (c *WebhookYAMLConfig) Refs() []string {
+	return c.Refs_
+}
+
+func (c *WebhookYAMLConfig) Headers() map[string]string {
+	return c.Headers_
+}
+
+func (c *WebhookYAMLConfig) Timeout() time.Duration {
+	if c.TimeoutMillis_ == nil || *c.TimeoutMillis_ == 0 {
+		return DefaultWebhookTimeout * time.Millisecond
+	}
+	return time.Duration(*c.TimeoutMillis_) * time.Millisecond
+}
+
+func (cfg YAMLConfig) TracingConfig() TracingConfig {
+	if cfg.Tracing_ == nil {
+		return nil
+	}
+	return cfg.Tracing_
+}
+
+type TracingYAMLConfig struct {
+	Exporter_    *string           `yaml:"exporter,omitempty" minver:"TBD"`
+	Endpoint_    *string           `yaml:"endpoint,omitempty" minver:"TBD"`
+	Insecure_    *bool             `yaml:"insecure,omitempty" minver:"TBD"`
+	Headers_     map[string]string `yaml:"headers,omitempty" minver:"TBD"`
+	File_        *string           `yaml:"file,omitempty" minver:"TBD"`
+	SampleRatio_ *float64          `yaml:"sample_ratio,omitempty" minver:"TBD"`
+	ServiceName_ *string           `yaml:"service_name,omitempty" minver:"TBD"`
+}
+
+func (c *TracingYAMLConfig) Exporter() string {
+	if c.Exporter_ == nil {
+		return TracingExporterOTLP
+	}
+	return strings.ToLower(*c.Exporter_)
+}
+
+func (c *TracingYAMLConfig) Endpoint() string {
+	if c.Endpoint_ == nil {
+		return DefaultTracingEndpoint
+	}
+	return *c.Endpoint_
+}
+
+func (c *TracingYAMLConfig) Insecure() bool {
+	if c.Insecure_ == nil {
+		return false
+	}
+	return *c.Insecure_
+}
+
+func (c *TracingYAMLConfig) Headers() map[string]string {
+	return c.Headers_
+}
+
+func (c *TracingYAMLConfig) File() string {
+	if c.File_ == nil {
+		return ""
+	}
+	return *c.File_
+}
+
+func (c *TracingYAMLConfig) SampleRatio() float64 {
+	if c.SampleRatio_ == nil {
+		return DefaultTracingSampleRatio
+	}
+	return *c.SampleRatio_
+}
+
+func (c *TracingYAMLConfig) ServiceName() string {
+	if c.ServiceName_ == nil {
+		return DefaultTracingServiceName
+	}
+	return *c.ServiceName_
+}
+
 type ClusterYAMLConfig struct {
 	StandbyRemotes_ []StandbyRemoteYAMLConfig   `yaml:"standby_remotes"`
 	BootstrapRole_  string                      `yaml:"bootstrap_role"`
@@ -16,6 +16,7 @@ package servercfg
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -181,6 +182,198 @@ cluster:
 	require.Equal(t, "http://doltdb-1.doltdb:50051/{database}", config.ClusterConfig().StandbyRemotes()[0].RemoteURLTemplate())
 }
 
+func TestUnmarshallAutoGC(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	require.Nil(t, config.AutoGCConfig())
+
+	testStr := `
+behavior:
+  auto_gc:
+    check_interval_millis: 5000
+    journal_size_mb: 64
+    idle_time_millis: 60000
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.NotNil(t, config.AutoGCConfig())
+	assert.True(t, config.AutoGCConfig().Enable())
+	assert.Equal(t, 5*time.Second, config.AutoGCConfig().CheckInterval())
+	assert.Equal(t, uint64(64<<20), config.AutoGCConfig().JournalSize())
+	assert.Equal(t, DefaultAutoGCTableFileCount, config.AutoGCConfig().TableFileCount())
+	assert.Equal(t, time.Minute, config.AutoGCConfig().IdleTime())
+
+	testStr = `
+behavior:
+  auto_gc:
+    enable: false
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.NotNil(t, config.AutoGCConfig())
+	assert.False(t, config.AutoGCConfig().Enable())
+	assert.Equal(t, time.Minute, config.AutoGCConfig().CheckInterval())
+	assert.Equal(t, uint64(DefaultAutoGCJournalSizeMB<<20), config.AutoGCConfig().JournalSize())
+	assert.Equal(t, time.Duration(0), config.AutoGCConfig().IdleTime())
+
+	roundTripped, err := NewYamlConfig([]byte(ServerConfigAsYAMLConfig(config).String()))
+	require.NoError(t, err)
+	require.NotNil(t, roundTripped.AutoGCConfig())
+	assert.False(t, roundTripped.AutoGCConfig().Enable())
+	assert.Equal(t, config.AutoGCConfig().JournalSize(), roundTripped.AutoGCConfig().JournalSize())
+}
+
+func TestValidateAutoGCConfig(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+behavior:
+  auto_gc:
+    table_file_count: 0
+`))
+	require.NoError(t, err)
+	require.NoError(t, ValidateAutoGCConfig(config.AutoGCConfig()))
+
+	config, err = NewYamlConfig([]byte(`
+behavior:
+  auto_gc:
+    check_interval_millis: 0
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateAutoGCConfig(config.AutoGCConfig()))
+
+	config, err = NewYamlConfig([]byte(`
+behavior:
+  auto_gc:
+    table_file_count: -1
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateAutoGCConfig(config.AutoGCConfig()))
+}
+
+func TestUnmarshallWebhooks(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	require.Nil(t, config.Webhooks())
+
+	testStr := `
+webhooks:
+- url: https://example.com/dolt
+  databases: [db1]
+  refs: ["refs/heads/main", "refs/tags/*"]
+  headers:
+    Authorization: Bearer token
+  timeout_millis: 500
+- url: http://localhost:8080/hook
+- url: http://localhost:8080/other
+  timeout_millis: 0
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.Len(t, config.Webhooks(), 3)
+	webhook := config.Webhooks()[0]
+	assert.Equal(t, "https://example.com/dolt", webhook.URL())
+	assert.Equal(t, []string{"db1"}, webhook.Databases())
+	assert.Equal(t, []string{"refs/heads/main", "refs/tags/*"}, webhook.Refs())
+	assert.Equal(t, map[string]string{"Authorization": "Bearer token"}, webhook.Headers())
+	assert.Equal(t, 500*time.Millisecond, webhook.Timeout())
+	webhook = config.Webhooks()[1]
+	assert.Equal(t, "http://localhost:8080/hook", webhook.URL())
+	assert.Empty(t, webhook.Databases())
+	assert.Empty(t, webhook.Refs())
+	assert.Equal(t, DefaultWebhookTimeout*time.Millisecond, webhook.Timeout())
+	// A timeout of zero uses the default rather than waiting forever
+	assert.Equal(t, DefaultWebhookTimeout*time.Millisecond, config.Webhooks()[2].Timeout())
+	require.NoError(t, ValidateWebhooksConfig(config.Webhooks()))
+
+	roundTripped, err := NewYamlConfig([]byte(ServerConfigAsYAMLConfig(config).String()))
+	require.NoError(t, err)
+	require.Len(t, roundTripped.Webhooks(), 3)
+	assert.Equal(t, config.Webhooks()[0].Refs(), roundTripped.Webhooks()[0].Refs())
+	assert.Equal(t, config.Webhooks()[0].Headers(), roundTripped.Webhooks()[0].Headers())
+	assert.Equal(t, config.Webhooks()[1].Timeout(), roundTripped.Webhooks()[1].Timeout())
+}
+
+func TestValidateWebhooksConfig(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config string
+	}{
+		{"missing url", "webhooks:\n- refs: [refs/heads/main]\n"},
+		{"not an http url", "webhooks:\n- url: ftp://example.com/dolt\n"},
+		{"invalid ref pattern", "webhooks:\n- url: https://example.com/dolt\n  refs: [\"refs/heads/[\"]\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			config, err := NewYamlConfig([]byte(c.Config))
+			require.NoError(t, err)
+			assert.Error(t, ValidateWebhooksConfig(config.Webhooks()))
+		})
+	}
+}
+
+func TestUnmarshallTracing(t *testing.T) {
+	config, err := NewYamlConfig([]byte(""))
+	require.NoError(t, err)
+	require.Nil(t, config.TracingConfig())
+
+	config, err = NewYamlConfig([]byte("tracing: {}\n"))
+	require.NoError(t, err)
+	tracing := config.TracingConfig()
+	require.NotNil(t, tracing)
+	assert.Equal(t, TracingExporterOTLP, tracing.Exporter())
+	assert.Equal(t, DefaultTracingEndpoint, tracing.Endpoint())
+	assert.False(t, tracing.Insecure())
+	assert.Equal(t, DefaultTracingSampleRatio, tracing.SampleRatio())
+	assert.Equal(t, DefaultTracingServiceName, tracing.ServiceName())
+	require.NoError(t, ValidateTracingConfig(tracing))
+
+	testStr := `
+tracing:
+  exporter: file
+  file: /tmp/traces.json
+  headers:
+    Authorization: Bearer token
+  sample_ratio: 0.25
+  service_name: dolt-primary
+`
+	config, err = NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	tracing = config.TracingConfig()
+	require.NotNil(t, tracing)
+	assert.Equal(t, TracingExporterFile, tracing.Exporter())
+	assert.Equal(t, "/tmp/traces.json", tracing.File())
+	assert.Equal(t, map[string]string{"Authorization": "Bearer token"}, tracing.Headers())
+	assert.Equal(t, 0.25, tracing.SampleRatio())
+	assert.Equal(t, "dolt-primary", tracing.ServiceName())
+	require.NoError(t, ValidateTracingConfig(tracing))
+
+	roundTripped, err := NewYamlConfig([]byte(ServerConfigAsYAMLConfig(config).String()))
+	require.NoError(t, err)
+	require.NotNil(t, roundTripped.TracingConfig())
+	assert.Equal(t, tracing.Exporter(), roundTripped.TracingConfig().Exporter())
+	assert.Equal(t, tracing.File(), roundTripped.TracingConfig().File())
+	assert.Equal(t, tracing.SampleRatio(), roundTripped.TracingConfig().SampleRatio())
+}
+
+func TestValidateTracingConfig(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Config string
+	}{
+		{"unknown exporter", "tracing:\n  exporter: zipkin\n"},
+		{"empty endpoint", "tracing:\n  endpoint: \"\"\n"},
+		{"missing file", "tracing:\n  exporter: file\n"},
+		{"negative sample ratio", "tracing:\n  sample_ratio: -0.5\n"},
+		{"sample ratio too large", "tracing:\n  sample_ratio: 2\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			config, err := NewYamlConfig([]byte(c.Config))
+			require.NoError(t, err)
+			assert.Error(t, ValidateTracingConfig(config.TracingConfig()))
+		})
+	}
+}
+
 func TestValidateClusterConfig(t *testing.T) {
 	cases := []struct {
 		Name   string
@@ -0,0 +1,186 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package autogc runs the garbage collections of the databases of a sql-server in the background.
+package autogc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+// The triggers of the GCs run by a Controller, as reported in the GC status of a database.
+const (
+	TriggerJournalSize    = "journal_size"
+	TriggerTableFileCount = "table_file_count"
+	TriggerIdle           = "idle"
+)
+
+// Controller garbage collects the databases of a server when the storage written since their last collection grows
+// past the thresholds of its servercfg.AutoGCConfig, or when the server has been idle for long enough.
+//
+// Like dolt_gc(), a Controller only runs full GCs on the primary of a cluster. On a standby, which cannot run a full
+// GC, it runs a shallow GC instead, which removes the table files that are no longer referenced.
+type Controller struct {
+	cfg servercfg.AutoGCConfig
+	lgr *logrus.Entry
+
+	// lastStats are the storage stats of each database after its last GC, by lower-cased database name
+	lastStats map[string]doltdb.StorageStats
+
+	mu      sync.Mutex
+	stopped bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewController returns a new Controller which runs GCs as configured by |cfg|.
+func NewController(cfg servercfg.AutoGCConfig, lgr *logrus.Logger) *Controller {
+	return &Controller{
+		cfg:       cfg,
+		lgr:       lgr.WithField("component", "auto_gc"),
+		lastStats: make(map[string]doltdb.StorageStats),
+	}
+}
+
+// Run checks the databases of the server every check interval until Stop is called. The GCs run in the session of the
+// context returned by |ctxFactory|, which must be a DoltSession using the process list of the server, so that the GCs
+// establish their safepoints with the running queries.
+func (c *Controller) Run(ctx context.Context, ctxFactory func(context.Context) (*sql.Context, error)) {
+	c.mu.Lock()
+	if c.stopped {
+		c.mu.Unlock()
+		return
+	}
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.wg.Add(1)
+	c.mu.Unlock()
+	defer c.wg.Done()
+
+	sqlCtx, err := ctxFactory(ctx)
+	if err != nil {
+		c.lgr.Errorf("unable to create a context for background garbage collection: %v", err)
+		return
+	}
+
+	ticker := time.NewTicker(c.cfg.CheckInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Check(sqlCtx)
+		}
+	}
+}
+
+// Stop stops the Controller, canceling a running GC, and waits for Run to return.
+func (c *Controller) Stop() {
+	c.mu.Lock()
+	c.stopped = true
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.mu.Unlock()
+	c.wg.Wait()
+}
+
+// Check checks each database of the session of |ctx| once, and runs a GC for the databases which need one.
+func (c *Controller) Check(ctx *sql.Context) {
+	dSess := dsess.DSessFromSess(ctx.Session)
+	idle := c.idle(ctx, dSess)
+	full, role := c.fullGCAllowed()
+	for _, db := range dSess.Provider().DoltDatabases() {
+		if ctx.Err() != nil {
+			return
+		}
+		name := db.Name()
+		ddb := db.DbData().Ddb
+		stats := ddb.StorageStats()
+		trigger := c.trigger(name, stats, idle)
+		if trigger == "" {
+			continue
+		}
+
+		if !full {
+			c.lgr.Debugf("running a shallow gc of database %s; cluster role is %s", name, role)
+		}
+		c.lgr.Infof("garbage collecting database %s (%s); journal size: %d, table files: %d", name, trigger, stats.JournalSize, stats.TableFiles)
+		ctx.SetCurrentDatabase(name)
+		start := time.Now()
+		err := dprocedures.RunGC(ctx, name, !full, trigger)
+		if err != nil && !errors.Is(err, chunks.ErrNothingToCollect) {
+			c.lgr.Warnf("error garbage collecting database %s: %v", name, err)
+			continue
+		}
+		c.lgr.Infof("garbage collected database %s in %v", name, time.Since(start))
+		c.lastStats[strings.ToLower(name)] = ddb.StorageStats()
+	}
+}
+
+// trigger returns the reason the database named |dbName| needs a GC, or the empty string if it does not.
+func (c *Controller) trigger(dbName string, stats doltdb.StorageStats, idle bool) string {
+	// A database which hasn't been written since its last GC can't be collected any further.
+	if last, ok := c.lastStats[strings.ToLower(dbName)]; ok && last == stats {
+		return ""
+	}
+	switch {
+	case c.cfg.JournalSize() > 0 && stats.JournalSize >= c.cfg.JournalSize():
+		return TriggerJournalSize
+	case c.cfg.TableFileCount() > 0 && stats.TableFiles >= c.cfg.TableFileCount():
+		return TriggerTableFileCount
+	case idle && (stats.JournalSize > 0 || stats.TableFiles > 0):
+		return TriggerIdle
+	}
+	return ""
+}
+
+// idle returns true if no queries have started for the configured idle time, and none are running.
+func (c *Controller) idle(ctx *sql.Context, sess *dsess.DoltSession) bool {
+	controller := sess.GCSafepointController()
+	if c.cfg.IdleTime() <= 0 || controller == nil {
+		return false
+	}
+	if time.Since(controller.LastQueryTime()) < c.cfg.IdleTime() {
+		return false
+	}
+	for _, p := range ctx.ProcessList.Processes() {
+		if p.Command == sql.ProcessCommandQuery {
+			return false
+		}
+	}
+	return true
+}
+
+// fullGCAllowed returns whether a full GC can be run in this server, along with the cluster role of the server, if
+// it has one. Like dolt_gc(), full GCs are only run on the primary of a cluster.
+func (c *Controller) fullGCAllowed() (bool, string) {
+	if _, role, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleVariable); ok {
+		return role.(string) == "primary", role.(string)
+	}
+	return true, ""
+}
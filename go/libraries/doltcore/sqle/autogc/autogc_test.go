@@ -0,0 +1,91 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autogc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+func testController(t *testing.T, config string) *Controller {
+	cfg, err := servercfg.NewYamlConfig([]byte(config))
+	require.NoError(t, err)
+	return NewController(cfg.AutoGCConfig(), logrus.New())
+}
+
+func TestTrigger(t *testing.T) {
+	c := testController(t, `
+behavior:
+  auto_gc:
+    journal_size_mb: 1
+    table_file_count: 4
+`)
+
+	tests := []struct {
+		name     string
+		stats    doltdb.StorageStats
+		idle     bool
+		expected string
+	}{
+		{"empty", doltdb.StorageStats{}, false, ""},
+		{"empty and idle", doltdb.StorageStats{}, true, ""},
+		{"below thresholds", doltdb.StorageStats{JournalSize: 1024, TableFiles: 3}, false, ""},
+		{"below thresholds and idle", doltdb.StorageStats{JournalSize: 1024, TableFiles: 3}, true, TriggerIdle},
+		{"journal size", doltdb.StorageStats{JournalSize: 1 << 20}, false, TriggerJournalSize},
+		{"table files", doltdb.StorageStats{TableFiles: 4}, false, TriggerTableFileCount},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, c.trigger("db", test.stats, test.idle))
+		})
+	}
+
+	// A database isn't collected again until it is written.
+	stats := doltdb.StorageStats{JournalSize: 2 << 20}
+	c.lastStats["db"] = stats
+	assert.Equal(t, "", c.trigger("DB", stats, true))
+	stats.JournalSize++
+	assert.Equal(t, TriggerJournalSize, c.trigger("DB", stats, true))
+}
+
+func TestTriggerDisabledThresholds(t *testing.T) {
+	c := testController(t, `
+behavior:
+  auto_gc:
+    journal_size_mb: 0
+    table_file_count: 0
+`)
+	assert.Equal(t, "", c.trigger("db", doltdb.StorageStats{JournalSize: 1 << 40, TableFiles: 1 << 20}, false))
+}
+
+func TestStopBeforeRun(t *testing.T) {
+	c := testController(t, `
+behavior:
+  auto_gc: {}
+`)
+	c.Stop()
+	c.Run(context.Background(), func(context.Context) (*sql.Context, error) {
+		require.Fail(t, "a stopped controller must not run")
+		return nil, nil
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const binlogBranchDatabasesFilename = "binlog-branch-databases"
+
+// loadBranchDatabases loads the names of the databases that have been created on replicas for the additional
+// branches in BinlogBranches from the .doltcfg/binlog-branch-databases file at the root of |fs|. If the file doesn't
+// exist, no databases have been created yet and an empty set is returned.
+func loadBranchDatabases(fs filesys.Filesys) (map[string]struct{}, error) {
+	databases := make(map[string]struct{})
+	path := filepath.Join(binlogPositionDirectory, binlogBranchDatabasesFilename)
+	if exists, _ := fs.Exists(path); !exists {
+		return databases, nil
+	}
+
+	bytes, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(string(bytes), "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			databases[name] = struct{}{}
+		}
+	}
+	return databases, nil
+}
+
+// saveBranchDatabases saves the names of the databases that have been created on replicas for additional branches
+// to the .doltcfg/binlog-branch-databases file at the root of |fs|, so that they are not created again, along with
+// their data, when the server restarts.
+func saveBranchDatabases(fs filesys.Filesys, databases map[string]struct{}) error {
+	if err := createDoltCfgDir(fs); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(databases))
+	for name := range databases {
+		names = append(names, name+"\n")
+	}
+	sort.Strings(names)
+	return fs.WriteFile(filepath.Join(binlogPositionDirectory, binlogBranchDatabasesFilename), []byte(strings.Join(names, "")), 0666)
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestBranchDatabases(t *testing.T) {
+	fs, err := filesys.LocalFilesysWithWorkingDir(t.TempDir())
+	require.NoError(t, err)
+
+	databases, err := loadBranchDatabases(fs)
+	require.NoError(t, err)
+	assert.Empty(t, databases)
+
+	databases["db01_dev"] = struct{}{}
+	databases["db01_feature"] = struct{}{}
+	require.NoError(t, saveBranchDatabases(fs, databases))
+
+	loaded, err := loadBranchDatabases(fs)
+	require.NoError(t, err)
+	assert.Equal(t, databases, loaded)
+
+	delete(databases, "db01_dev")
+	require.NoError(t, saveBranchDatabases(fs, databases))
+	loaded, err = loadBranchDatabases(fs)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"db01_feature": {}}, loaded)
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// ExportBinaryLogs copies the binary log files that a Dolt sql-server has written under |fs| into the directory
+// |destDir|, along with a binlog index file listing them, and returns the names of the exported files. The exported
+// files use MySQL's binary log file format, so they can be read with standard MySQL tools, such as mysqlbinlog.
+//
+// Only complete events are exported, so a log file that is still being written to can be exported safely. The files
+// exported are the ones listed in the binlog index file, or every binary log file on disk if there is no index file.
+func ExportBinaryLogs(fs filesys.Filesys, destDir string) ([]string, error) {
+	srcDir, err := fs.Abs(binlogDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	filenames, err := binaryLogFilesToExport(fs)
+	if err != nil {
+		return nil, err
+	}
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("no binary log files found in %s", srcDir)
+	}
+
+	if err = os.MkdirAll(destDir, 0755); err != nil {
+		return nil, err
+	}
+
+	sb := strings.Builder{}
+	for _, filename := range filenames {
+		err = exportBinaryLogFile(filepath.Join(srcDir, filename), filepath.Join(destDir, filename))
+		if err != nil {
+			return nil, fmt.Errorf("unable to export binary log file %s: %w", filename, err)
+		}
+		sb.WriteString("./" + filename + "\n")
+	}
+
+	err = os.WriteFile(filepath.Join(destDir, binlogIndexFilename), []byte(sb.String()), 0644)
+	if err != nil {
+		return nil, err
+	}
+	return filenames, nil
+}
+
+// binaryLogFilesToExport returns the names of the binary log files under |fs| that are listed in the binlog index
+// file, or the names of all binary log files on disk, in order, if there is no index file.
+func binaryLogFilesToExport(fs filesys.Filesys) ([]string, error) {
+	data, err := fs.ReadFile(filepath.Join(binlogDirectory, binlogIndexFilename))
+	if err == nil {
+		var filenames []string
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				filenames = append(filenames, filepath.Base(line))
+			}
+		}
+		return filenames, scanner.Err()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	lm := &logManager{fs: fs}
+	filenames, err := lm.logFilesOnDisk()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filenames)
+	return filenames, nil
+}
+
+// exportBinaryLogFile copies the binary log file |src| to |dest|, stopping at the first incomplete event.
+func exportBinaryLogFile(src, dest string) error {
+	srcFile, err := openBinlogFileForReading(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	destFile, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer destFile.Close()
+
+	if _, err = destFile.Write(binlogFileMagicNumber); err != nil {
+		return err
+	}
+
+	// Unlike readBinlogEventFromFile, read each event with io.ReadFull, so that a partially written
+	// event at the end of the file is detected instead of being read as a complete event.
+	header := make([]byte, 4+1+4+4+4+2)
+	for {
+		if _, err = io.ReadFull(srcFile, header); err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		eventSize := binary.LittleEndian.Uint32(header[9 : 9+4])
+		if eventSize < uint32(len(header)) {
+			return fmt.Errorf("invalid binlog event size: %d", eventSize)
+		}
+		event := make([]byte, eventSize)
+		copy(event, header)
+		if _, err = io.ReadFull(srcFile, event[len(header):]); err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		if _, err = destFile.Write(event); err != nil {
+			return err
+		}
+	}
+
+	return destFile.Close()
+}
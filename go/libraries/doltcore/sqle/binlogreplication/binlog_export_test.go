@@ -0,0 +1,69 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestExportBinaryLogs(t *testing.T) {
+	fs, err := filesys.LocalFilesysWithWorkingDir(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, fs.MkDirs(binlogDirectory))
+
+	format := mysql.NewMySQL56BinlogFormat()
+	meta := mysql.BinlogEventMetadata{ServerID: 1}
+	fde := mysql.NewFormatDescriptionEvent(format, meta).Bytes()
+	xid := mysql.NewXIDEvent(format, meta).Bytes()
+
+	var complete []byte
+	complete = append(complete, binlogFileMagicNumber...)
+	complete = append(complete, fde...)
+	complete = append(complete, xid...)
+	// The second file ends with a partially written event, which is not exported
+	partial := append(append([]byte{}, complete...), xid[:len(xid)-3]...)
+
+	require.NoError(t, fs.WriteFile(filepath.Join(binlogDirectory, formatBinlogFilename("main", 1)), complete, 0644))
+	require.NoError(t, fs.WriteFile(filepath.Join(binlogDirectory, formatBinlogFilename("main", 2)), partial, 0644))
+
+	destDir := filepath.Join(t.TempDir(), "export")
+	filenames, err := ExportBinaryLogs(fs, destDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"binlog-main.000001", "binlog-main.000002"}, filenames)
+
+	for _, filename := range filenames {
+		data, err := os.ReadFile(filepath.Join(destDir, filename))
+		require.NoError(t, err)
+		assert.Equal(t, complete, data)
+	}
+	index, err := os.ReadFile(filepath.Join(destDir, binlogIndexFilename))
+	require.NoError(t, err)
+	assert.Equal(t, "./binlog-main.000001\n./binlog-main.000002\n", string(index))
+
+	// When an index file exists, only the files it lists are exported
+	err = fs.WriteFile(filepath.Join(binlogDirectory, binlogIndexFilename), []byte("./binlog-main.000002\n"), 0644)
+	require.NoError(t, err)
+	filenames, err = ExportBinaryLogs(fs, filepath.Join(t.TempDir(), "export"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"binlog-main.000002"}, filenames)
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +35,10 @@ import (
 
 var binlogDirectory = filepath.Join(".dolt", "binlog")
 
+// binlogIndexFilename is the name of the index file in the binlog directory that lists the binlog files for the
+// branch being logged, in order, using the same format as MySQL's binlog index file.
+const binlogIndexFilename = "binlog.index"
+
 // logManager is responsible for the binary log files on disk, including actually writing events to the log files,
 // rotating the log files, listing the available log files, purging old log files, and keeping track of what GTIDs
 // are available in the log files.
@@ -92,6 +97,11 @@ func NewLogManager(fs filesys.Filesys) (*logManager, error) {
 		return nil, err
 	}
 
+	// List the remaining log files in the index file
+	if err := lm.writeIndexFile(); err != nil {
+		return nil, err
+	}
+
 	// Initialize @@gtid_purged based on the first GTID we see available in the available binary logs
 	// NOTE that we assume that all GTIDs are available after the first GTID we find in the logs. This won't
 	// be true if someone goes directly to the file system and deletes binary log files, but that isn't
@@ -327,7 +337,7 @@ func (lm *logManager) addRotateEventToPreviousLogFile() error {
 	}
 	defer previousLogFile.Close()
 
-	rotateEvent := mysql.NewRotateEvent(lm.binlogFormat, lm.binlogEventMeta, 0, lm.currentBinlogFileName)
+	rotateEvent := mysql.NewRotateEvent(lm.binlogFormat, lm.binlogEventMeta, uint64(len(binlogFileMagicNumber)), lm.currentBinlogFileName)
 	_, err = previousLogFile.Write(rotateEvent.Bytes())
 	return err
 }
@@ -446,7 +456,7 @@ func (lm *logManager) RotateLogFile() error {
 	}
 	logrus.Tracef("Rotating bin log file to: %s", nextLogFile)
 
-	binlogEvent := mysql.NewRotateEvent(lm.binlogFormat, lm.binlogEventMeta, 0, nextLogFile)
+	binlogEvent := mysql.NewRotateEvent(lm.binlogFormat, lm.binlogEventMeta, uint64(len(binlogFileMagicNumber)), nextLogFile)
 	if err = lm.writeEventsHelper(binlogEvent); err != nil {
 		return err
 	}
@@ -458,7 +468,27 @@ func (lm *logManager) RotateLogFile() error {
 
 	// Open and initialize a new binlog file
 	lm.currentBinlogFileName = nextLogFile
-	return lm.initializeCurrentLogFile(lm.binlogFormat, lm.binlogEventMeta)
+	if err = lm.initializeCurrentLogFile(lm.binlogFormat, lm.binlogEventMeta); err != nil {
+		return err
+	}
+	return lm.writeIndexFile()
+}
+
+// writeIndexFile writes the binlog index file, listing every binlog file on disk for the current branch, in order.
+// Each file is listed on its own line, relative to the binlog directory, the same way MySQL lists binlog files in
+// its index file, so that tools that process MySQL's binlog files can find the binlog files Dolt has written.
+func (lm *logManager) writeIndexFile() error {
+	filenames, err := lm.logFilesOnDiskForBranch(BinlogBranch)
+	if err != nil {
+		return err
+	}
+	sort.Strings(filenames)
+
+	sb := strings.Builder{}
+	for _, filename := range filenames {
+		sb.WriteString("./" + filename + "\n")
+	}
+	return lm.fs.WriteFile(filepath.Join(binlogDirectory, binlogIndexFilename), []byte(sb.String()), 0644)
 }
 
 // initializeCurrentLogFile creates and opens the current binlog file for append only writing, writes the first four
@@ -534,6 +564,12 @@ func (lm *logManager) writeEventsHelper(binlogEvents ...mysql.BinlogEvent) error
 		// we change the packet, we must recompute the checksum.
 		nextPosition := lm.currentPosition + len(event.Bytes())
 		binary.LittleEndian.PutUint32(event.Bytes()[13:13+4], uint32(nextPosition))
+		// Events are created with the timestamp of when the producer started, so stamp them with the time they are
+		// logged instead, so that tools reading the log see when each event happened. Like MySQL, Rotate events
+		// keep a zero timestamp.
+		if !event.IsRotate() {
+			binary.LittleEndian.PutUint32(event.Bytes()[0:4], uint32(time.Now().Unix()))
+		}
 		mysql.UpdateChecksum(lm.binlogFormat, event)
 
 		lm.currentPosition = nextPosition
@@ -571,6 +571,44 @@ func TestBinlogPrimary_ChangeReplicationBranch(t *testing.T) {
 	requireReplicaResults(t, "select * from db01.t;", [][]any{{"hundred", "100", "2000"}})
 }
 
+// TestBinlogPrimary_AddExistingBranch asserts that when a branch that already has data is added to
+// @@log_bin_branches, its first update creates its database on the replica with all of its existing data.
+func TestBinlogPrimary_AddExistingBranch(t *testing.T) {
+	defer teardown(t)
+	startSqlServersWithDoltSystemVars(t, doltReplicationPrimarySystemVars)
+	setupForDoltToMySqlReplication()
+	startReplicationAndCreateTestDb(t, doltPort)
+
+	primaryDatabase.MustExec("create table db01.t (pk int primary key, c1 varchar(100));")
+	primaryDatabase.MustExec("call dolt_commit('-Am', 'creating table t');")
+	primaryDatabase.MustExec("call dolt_checkout('-b', 'branch1');")
+	primaryDatabase.MustExec("insert into db01.t values (1, 'one');")
+	primaryDatabase.MustExec("call dolt_commit('-am', 'adding row on branch1');")
+	primaryDatabase.MustExec("call dolt_checkout('main');")
+	waitForReplicaToCatchUp(t)
+	requireReplicaResults(t, "show databases like 'db01_branch1';", [][]any{})
+
+	// Add the existing branch and restart the primary to pick up the change
+	primaryDatabase.MustExec("SET PERSIST log_bin_branches='branch1';")
+	stopDoltSqlServer(t)
+	time.Sleep(2_000 * time.Millisecond)
+	mustRestartDoltPrimaryServer(t)
+	waitForReplicaToReconnect(t)
+
+	// The branch's first update replicates everything on the branch, not just the update
+	primaryDatabase.MustExec("call dolt_checkout('branch1');")
+	primaryDatabase.MustExec("insert into db01.t values (2, 'two');")
+	waitForReplicaToCatchUp(t)
+	requireReplicaResults(t, "show databases like 'db01_branch1';", [][]any{{"db01_branch1"}})
+	requireReplicaResults(t, "select * from db01_branch1.t order by pk;", [][]any{{"1", "one"}, {"2", "two"}})
+
+	// Later updates only replicate what changed
+	primaryDatabase.MustExec("insert into db01.t values (3, 'three');")
+	waitForReplicaToCatchUp(t)
+	requireReplicaResults(t, "select * from db01_branch1.t order by pk;", [][]any{{"1", "one"}, {"2", "two"}, {"3", "three"}})
+	requireReplicaResults(t, "select * from db01.t;", [][]any{})
+}
+
 // TestBinlogPrimary_SimpleSchemaChangesWithAutocommit tests that we can make simple schema changes (e.g. create table,
 // alter table, drop table) and replicate the DDL statements correctly.
 func TestBinlogPrimary_SimpleSchemaChangesWithAutocommit(t *testing.T) {
@@ -41,6 +41,26 @@ import (
 // BinlogBranch specifies the branch used for generating binlog events.
 var BinlogBranch = "main"
 
+// BinlogBranches specifies additional branches used for generating binlog events. Changes on each additional branch
+// are replicated to a separate database, named as described in binlogDatabaseName.
+var BinlogBranches []string
+
+// binlogDatabaseName returns the name of the database that replicas apply the changes on branch |branchName| of
+// the Dolt database |databaseName| to, and false if changes on |branchName| are not replicated. Changes on
+// BinlogBranch are applied to a database with the same name, and changes on any of BinlogBranches are applied to
+// a database named "<databaseName>_<branchName>".
+func binlogDatabaseName(databaseName, branchName string) (string, bool) {
+	if branchName == BinlogBranch {
+		return databaseName, true
+	}
+	for _, branch := range BinlogBranches {
+		if branch == branchName {
+			return databaseName + "_" + branchName, true
+		}
+	}
+	return "", false
+}
+
 // binlogProducer implements the doltdb.DatabaseUpdateListener interface so that it can listen for updates to Dolt
 // databases and generate binlog events describing them. Those binlog events are sent to the binlogStreamerManager,
 // which is responsible for delivering them to each connected replica.
@@ -56,6 +76,10 @@ type binlogProducer struct {
 	gtidPosition *mysql.Position
 	gtidSequence int64
 
+	// branchDatabases holds the names of the databases that have been created on replicas for additional branches
+	fs              filesys.Filesys
+	branchDatabases map[string]struct{}
+
 	logManager *logManager
 }
 
@@ -71,10 +95,17 @@ func NewBinlogProducer(fs filesys.Filesys) (*binlogProducer, error) {
 		return nil, err
 	}
 
+	branchDatabases, err := loadBranchDatabases(fs)
+	if err != nil {
+		return nil, err
+	}
+
 	b := &binlogProducer{
 		binlogEventMeta: *binlogEventMeta,
 		binlogFormat:    binlogFormat,
 		mu:              &sync.Mutex{},
+		fs:              fs,
+		branchDatabases: branchDatabases,
 	}
 
 	if err = b.initializeGtidPosition(fs); err != nil {
@@ -96,22 +127,43 @@ func (b *binlogProducer) LogManager(logManager *logManager) {
 // need to change this so that it writes to a binary log file as the intermediate, and the readers watch that
 // log to stream events back to the connected replicas.
 func (b *binlogProducer) WorkingRootUpdated(ctx *sql.Context, databaseName string, branchName string, before doltdb.RootValue, after doltdb.RootValue) error {
-	// We only support updates to a single branch for binlog events, so ignore all other updates
-	if branchName != BinlogBranch {
+	// Ignore updates to any branch that isn't configured for binlog events
+	binlogDatabase, ok := binlogDatabaseName(databaseName, branchName)
+	if !ok {
 		return nil
 	}
+	databaseName = binlogDatabase
 
 	var binlogEvents []mysql.BinlogEvent
+
+	// Additional branches don't have a database on the replica until the first update the producer sees for them
+	if branchName != BinlogBranch {
+		createDatabaseEvents, bootstrap, err := b.createBranchDatabaseEvents(ctx, databaseName)
+		if err != nil {
+			return err
+		}
+		binlogEvents = append(binlogEvents, createDatabaseEvents...)
+		if bootstrap {
+			// The branch may already have had data before it was configured for binlog events, so the
+			// new database gets the branch's entire schema and data
+			before, err = doltdb.EmptyRootValue(ctx, after.VRW(), after.NodeStore())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	tableDeltas, err := diff.GetTableDeltas(ctx, before, after)
 	if err != nil {
 		return err
 	}
 
 	// Process schema changes first
-	binlogEvents, hasDataChanges, err := b.createSchemaChangeQueryEvents(ctx, databaseName, tableDeltas, after)
+	schemaChangeEvents, hasDataChanges, err := b.createSchemaChangeQueryEvents(ctx, databaseName, tableDeltas, after)
 	if err != nil {
 		return err
 	}
+	binlogEvents = append(binlogEvents, schemaChangeEvents...)
 
 	// Process data changes...
 	if hasDataChanges {
@@ -177,9 +229,65 @@ func (b *binlogProducer) DatabaseDropped(ctx *sql.Context, databaseName string)
 	dropDatabaseStatement := fmt.Sprintf("drop database `%s`;", databaseName)
 	binlogEvents = append(binlogEvents, b.newQueryEvent(databaseName, dropDatabaseStatement))
 
+	// Drop the databases for any additional branches, too
+	for _, branch := range BinlogBranches {
+		branchDatabase, _ := binlogDatabaseName(databaseName, branch)
+		if err = b.forgetBranchDatabase(branchDatabase); err != nil {
+			return err
+		}
+		binlogEvent, err := b.createGtidEvent(ctx)
+		if err != nil {
+			return err
+		}
+		binlogEvents = append(binlogEvents, binlogEvent)
+
+		dropDatabaseStatement = fmt.Sprintf("drop database if exists `%s`;", branchDatabase)
+		binlogEvents = append(binlogEvents, b.newQueryEvent(branchDatabase, dropDatabaseStatement))
+	}
+
 	return b.logManager.WriteEvents(binlogEvents...)
 }
 
+// createBranchDatabaseEvents returns the binlog events that create the database named |databaseName| for an
+// additional branch, and true, if the database hasn't been created on replicas yet. This is the case on the first
+// update to a new branch, and on the first update to an existing branch after it's added to BinlogBranches. The
+// database is recorded as created, so that later updates, including those after a restart, are not treated the same.
+func (b *binlogProducer) createBranchDatabaseEvents(ctx *sql.Context, databaseName string) ([]mysql.BinlogEvent, bool, error) {
+	b.mu.Lock()
+	_, ok := b.branchDatabases[databaseName]
+	if !ok {
+		b.branchDatabases[databaseName] = struct{}{}
+		if err := saveBranchDatabases(b.fs, b.branchDatabases); err != nil {
+			delete(b.branchDatabases, databaseName)
+			b.mu.Unlock()
+			return nil, false, err
+		}
+	}
+	b.mu.Unlock()
+	if ok {
+		return nil, false, nil
+	}
+
+	binlogEvent, err := b.createGtidEvent(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	createDatabaseStatement := fmt.Sprintf("create database if not exists `%s`;", databaseName)
+	return []mysql.BinlogEvent{binlogEvent, b.newQueryEvent(databaseName, createDatabaseStatement)}, true, nil
+}
+
+// forgetBranchDatabase records that the database named |databaseName| for an additional branch has been dropped on
+// replicas, so that it is created again on the branch's next update.
+func (b *binlogProducer) forgetBranchDatabase(databaseName string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.branchDatabases[databaseName]; !ok {
+		return nil
+	}
+	delete(b.branchDatabases, databaseName)
+	return saveBranchDatabases(b.fs, b.branchDatabases)
+}
+
 // initializeGtidPosition loads the persisted GTID position from disk and initializes it
 // in this binlogStreamerManager instance. If the gtidPosition has already been loaded
 // from disk and initialized, this method simply returns. If any problems were encountered
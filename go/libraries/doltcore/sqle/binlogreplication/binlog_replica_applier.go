@@ -407,7 +407,7 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 			ctx.SetSessionVariable(ctx, "unique_checks", 1)
 		}
 
-		ctx.SetCurrentDatabase(query.Database)
+		ctx.SetCurrentDatabase(a.filters.rewriteDatabase(query.Database))
 		executeQueryWithEngine(ctx, engine, query.SQL)
 		createCommit = !strings.EqualFold(query.SQL, "begin")
 
@@ -506,6 +506,7 @@ func (a *binlogReplicaApplier) processBinlogEvent(ctx *sql.Context, engine *gms.
 				ctx.GetLogger().Errorf(msg)
 				DoltBinlogReplicaController.setSqlError(mysql.ERUnknownError, msg)
 			}
+			tableMap.Database = a.filters.rewriteDatabase(tableMap.Database)
 			a.tableMapsById[tableId] = tableMap
 		}
 
@@ -138,6 +138,11 @@ func (d *doltBinlogReplicaController) StartReplica(ctx *sql.Context) error {
 		return err
 	}
 
+	err = d.filters.loadSystemVariables()
+	if err != nil {
+		return err
+	}
+
 	// Set execution context's user to the binlog replication user
 	d.ctx.SetClient(sql.Client{
 		User:    binlogApplierUser,
@@ -16,6 +16,7 @@ package binlogreplication
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 
@@ -29,6 +30,12 @@ type filterConfiguration struct {
 	doTables map[string]map[string]struct{}
 	// ignoreTables holds a map of database name to map of table names, indicating tables that should NOT be replicated.
 	ignoreTables map[string]map[string]struct{}
+	// wildDoTables holds patterns matching qualified table names that SHOULD be replicated.
+	wildDoTables []*tablePattern
+	// wildIgnoreTables holds patterns matching qualified table names that should NOT be replicated.
+	wildIgnoreTables []*tablePattern
+	// rewriteDbs holds a map of database names on the source to the database names they are applied to on the replica.
+	rewriteDbs map[string]string
 	// mu guards against concurrent access to the filter configuration data.
 	mu *sync.Mutex
 }
@@ -38,6 +45,7 @@ func newFilterConfiguration() *filterConfiguration {
 	return &filterConfiguration{
 		doTables:     make(map[string]map[string]struct{}),
 		ignoreTables: make(map[string]map[string]struct{}),
+		rewriteDbs:   make(map[string]string),
 		mu:           &sync.Mutex{},
 	}
 }
@@ -96,6 +104,101 @@ func (fc *filterConfiguration) setIgnoreTables(urts []sql.UnresolvedTable) error
 	return nil
 }
 
+// setWildDoTables sets the table name patterns that are allowed to replicate and returns an error if any of the
+// |patterns| are not qualified with a database name pattern. If any WildDoTables were previously configured, they
+// are cleared out before the new patterns are set.
+func (fc *filterConfiguration) setWildDoTables(patterns []string) error {
+	tablePatterns, err := parseTablePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.wildDoTables = tablePatterns
+	return nil
+}
+
+// setWildIgnoreTables sets the table name patterns that are NOT allowed to replicate and returns an error if any of
+// the |patterns| are not qualified with a database name pattern. If any WildIgnoreTables were previously configured,
+// they are cleared out before the new patterns are set.
+func (fc *filterConfiguration) setWildIgnoreTables(patterns []string) error {
+	tablePatterns, err := parseTablePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.wildIgnoreTables = tablePatterns
+	return nil
+}
+
+// setRewriteDbs sets the database rewrite rules applied to replicated changes and returns an error if any of the
+// |rules| aren't of the form "from_name->to_name". If any RewriteDbs were previously configured, they are cleared
+// out before the new rules are set.
+func (fc *filterConfiguration) setRewriteDbs(rules []string) error {
+	rewriteDbs := make(map[string]string)
+	for _, rule := range rules {
+		from, to, ok := strings.Cut(rule, "->")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return fmt.Errorf("invalid database rewrite rule: %s; expected format 'from_name->to_name'", rule)
+		}
+		rewriteDbs[strings.ToLower(from)] = to
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.rewriteDbs = rewriteDbs
+	return nil
+}
+
+// loadSystemVariables sets the wildcard table filters and the database rewrite rules from the
+// @@replicate_wild_do_table, @@replicate_wild_ignore_table, and @@replicate_rewrite_db system variables. Each system
+// variable holds a comma separated list of values, for example "db1.%,db2.t%" or "db1->db2,db3->db4". Unlike the
+// other filter options, these options can't be set with CHANGE REPLICATION FILTER, so they are configured the same
+// way MySQL configures them at server startup.
+func (fc *filterConfiguration) loadSystemVariables() error {
+	wildDoTables, err := getStringListSystemVariable("replicate_wild_do_table")
+	if err != nil {
+		return err
+	}
+	if err = fc.setWildDoTables(wildDoTables); err != nil {
+		return err
+	}
+
+	wildIgnoreTables, err := getStringListSystemVariable("replicate_wild_ignore_table")
+	if err != nil {
+		return err
+	}
+	if err = fc.setWildIgnoreTables(wildIgnoreTables); err != nil {
+		return err
+	}
+
+	rewriteDbs, err := getStringListSystemVariable("replicate_rewrite_db")
+	if err != nil {
+		return err
+	}
+	return fc.setRewriteDbs(rewriteDbs)
+}
+
+// rewriteDatabase returns the name of the database on this replica that changes to the database named |db| on the
+// source are applied to. Like MySQL, database rewrites are applied before any table filters are evaluated.
+func (fc *filterConfiguration) rewriteDatabase(db string) string {
+	if fc == nil {
+		return db
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if rewritten, ok := fc.rewriteDbs[strings.ToLower(db)]; ok {
+		return rewritten
+	}
+	return db
+}
+
 // isTableFilteredOut returns true if the table identified by |tableMap| has been filtered out on this replica and
 // should not have any updates applied from binlog messages.
 func (fc *filterConfiguration) isTableFilteredOut(ctx *sql.Context, tableMap *mysql.TableMap) bool {
@@ -132,6 +235,22 @@ func (fc *filterConfiguration) isTableFilteredOut(ctx *sql.Context, tableMap *my
 		}
 	}
 
+	// Wildcard options are processed after the doTables and ignoreTables options. A table matching a
+	// wildDoTables pattern is replicated, even if it also matches a wildIgnoreTables pattern.
+	if matchesAnyTablePattern(fc.wildDoTables, db, table) {
+		return false
+	}
+	if matchesAnyTablePattern(fc.wildIgnoreTables, db, table) {
+		ctx.GetLogger().Tracef("skipping table %s.%s (matches wildIgnoreTables)", tableMap.Database, tableMap.Name)
+		return true
+	}
+	if len(fc.wildDoTables) > 0 {
+		if _, ok := fc.doTables[db][table]; !ok {
+			ctx.GetLogger().Tracef("skipping table %s.%s (does not match wildDoTables)", tableMap.Database, tableMap.Name)
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -149,6 +268,20 @@ func (fc *filterConfiguration) getIgnoreTables() []string {
 	return convertFilterMapToStringSlice(fc.ignoreTables)
 }
 
+// getWildDoTables returns a slice of the table name patterns that are configured to be replicated.
+func (fc *filterConfiguration) getWildDoTables() []string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return convertTablePatternsToStringSlice(fc.wildDoTables)
+}
+
+// getWildIgnoreTables returns a slice of the table name patterns that are configured to be filtered out of replication.
+func (fc *filterConfiguration) getWildIgnoreTables() []string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return convertTablePatternsToStringSlice(fc.wildIgnoreTables)
+}
+
 // convertFilterMapToStringSlice converts the specified |filterMap| into a string slice, by iterating over every
 // key in the top level map, which stores a database name, and for each of those keys, iterating over every key
 // in the inner map, which stores a table name. Each table name is qualified with the matching database name and the
@@ -166,3 +299,83 @@ func convertFilterMapToStringSlice(filterMap map[string]map[string]struct{}) []s
 	}
 	return tableNames
 }
+
+// tablePattern matches qualified table names against a "db_pattern.table_pattern" filter, where each pattern may
+// use the '%' and '_' wildcard characters, with the same meaning as in the LIKE operator.
+type tablePattern struct {
+	pattern  string
+	dbRegex  *regexp.Regexp
+	tblRegex *regexp.Regexp
+}
+
+// parseTablePatterns parses each of |patterns| into a tablePattern and returns an error if any of them
+// are not qualified with a database name pattern.
+func parseTablePatterns(patterns []string) ([]*tablePattern, error) {
+	tablePatterns := make([]*tablePattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		dbPattern, tblPattern, ok := strings.Cut(strings.TrimSpace(pattern), ".")
+		if !ok || dbPattern == "" || tblPattern == "" {
+			return nil, fmt.Errorf("no database specified for replication filter pattern %s", pattern)
+		}
+		tablePatterns = append(tablePatterns, &tablePattern{
+			pattern:  strings.TrimSpace(pattern),
+			dbRegex:  likePatternToRegex(dbPattern),
+			tblRegex: likePatternToRegex(tblPattern),
+		})
+	}
+	return tablePatterns, nil
+}
+
+// matches returns true if the table named |table| in the database named |db| matches this pattern.
+func (tp *tablePattern) matches(db, table string) bool {
+	return tp.dbRegex.MatchString(db) && tp.tblRegex.MatchString(table)
+}
+
+// matchesAnyTablePattern returns true if the table named |table| in the database named |db| matches
+// any of |patterns|.
+func matchesAnyTablePattern(patterns []*tablePattern, db, table string) bool {
+	for _, pattern := range patterns {
+		if pattern.matches(db, table) {
+			return true
+		}
+	}
+	return false
+}
+
+// likePatternToRegex converts the LIKE style |pattern| into a case-insensitive regular expression that matches
+// the complete input. A backslash escapes the following character, so that a literal '%' or '_' can be matched.
+func likePatternToRegex(pattern string) *regexp.Regexp {
+	sb := strings.Builder{}
+	sb.WriteString("(?is)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			sb.WriteString(".*")
+		case r == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+// convertTablePatternsToStringSlice returns the original pattern strings from |patterns|.
+func convertTablePatternsToStringSlice(patterns []*tablePattern) []string {
+	if patterns == nil {
+		return nil
+	}
+
+	patternStrings := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		patternStrings = append(patternStrings, pattern.pattern)
+	}
+	return patternStrings
+}
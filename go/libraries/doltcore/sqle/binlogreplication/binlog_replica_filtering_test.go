@@ -0,0 +1,94 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package binlogreplication
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWildTableFilters(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	fc := newFilterConfiguration()
+	require.NoError(t, fc.setWildDoTables([]string{"db1.%", "db%.t\\_1"}))
+	require.NoError(t, fc.setWildIgnoreTables([]string{"db1.secret%", "db3.%"}))
+	assert.Equal(t, []string{"db1.%", "db%.t\\_1"}, fc.getWildDoTables())
+	assert.Equal(t, []string{"db1.secret%", "db3.%"}, fc.getWildIgnoreTables())
+
+	tests := []struct {
+		db, table string
+		filtered  bool
+	}{
+		{"db1", "t", false},
+		{"DB1", "T", false},
+		{"db1", "secrets", false}, // matches a wildDoTables pattern first
+		{"db2", "t_1", false},
+		{"db2", "tx1", true},
+		{"db3", "t_1", false},
+		{"db3", "t2", true},
+		{"other", "t", true},
+	}
+	for _, test := range tests {
+		t.Run(test.db+"."+test.table, func(t *testing.T) {
+			tableMap := &mysql.TableMap{Database: test.db, Name: test.table}
+			assert.Equal(t, test.filtered, fc.isTableFilteredOut(ctx, tableMap))
+		})
+	}
+
+	_, err := parseTablePatterns([]string{"t%"})
+	require.Error(t, err)
+}
+
+func TestWildIgnoreTablesOnly(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	fc := newFilterConfiguration()
+	require.NoError(t, fc.setWildIgnoreTables([]string{"%.tmp\\%"}))
+
+	assert.True(t, fc.isTableFilteredOut(ctx, &mysql.TableMap{Database: "db", Name: "tmp%"}))
+	assert.False(t, fc.isTableFilteredOut(ctx, &mysql.TableMap{Database: "db", Name: "tmp1"}))
+	assert.False(t, fc.isTableFilteredOut(ctx, &mysql.TableMap{Database: "db", Name: "t"}))
+}
+
+func TestRewriteDbs(t *testing.T) {
+	fc := newFilterConfiguration()
+	require.NoError(t, fc.setRewriteDbs([]string{"db1->db2", " Other -> renamed "}))
+	assert.Equal(t, "db2", fc.rewriteDatabase("db1"))
+	assert.Equal(t, "renamed", fc.rewriteDatabase("other"))
+	assert.Equal(t, "db3", fc.rewriteDatabase("db3"))
+	assert.Equal(t, "", fc.rewriteDatabase(""))
+
+	require.Error(t, fc.setRewriteDbs([]string{"db1"}))
+	require.Error(t, fc.setRewriteDbs([]string{"db1->"}))
+}
+
+func TestBinlogDatabaseName(t *testing.T) {
+	defer func(branch string, branches []string) {
+		BinlogBranch, BinlogBranches = branch, branches
+	}(BinlogBranch, BinlogBranches)
+	BinlogBranch, BinlogBranches = "main", []string{"dev"}
+
+	name, ok := binlogDatabaseName("db", "main")
+	assert.True(t, ok)
+	assert.Equal(t, "db", name)
+	name, ok = binlogDatabaseName("db", "dev")
+	assert.True(t, ok)
+	assert.Equal(t, "db_dev", name)
+	_, ok = binlogDatabaseName("db", "other")
+	assert.False(t, ok)
+}
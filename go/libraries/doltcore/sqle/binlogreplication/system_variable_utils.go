@@ -17,6 +17,7 @@ package binlogreplication
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 )
@@ -56,3 +57,26 @@ func getServerUuid(_ context.Context) (string, error) {
 
 	return "", fmt.Errorf("@@server_uuid is not a string – must be set to a valid UUID")
 }
+
+// getStringListSystemVariable returns the values of the comma separated list in the global string system variable
+// named |name|, with surrounding whitespace removed from each value and empty values skipped. If the system variable
+// can't be found, or is not a string, then an error is returned.
+func getStringListSystemVariable(name string) ([]string, error) {
+	_, value, ok := sql.SystemVariables.GetGlobal(name)
+	if !ok {
+		return nil, fmt.Errorf("global variable '%s' not found", name)
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("@@%s is not a string", name)
+	}
+
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,531 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cdc implements a change data capture service, which streams the row changes made by the commits of a
+// branch over gRPC.
+package cdc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cdcapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/cdcapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const (
+	defaultMaxChangesPerResponse = 1000
+	defaultPollInterval          = time.Second
+)
+
+// Server implements cdcapi.ChangeDataCaptureServiceServer for the databases accessible through a sql.Context
+// factory. Changes are computed as they are sent, so a client which reads slowly holds up the diff through gRPC flow
+// control rather than having the changes buffered on the server.
+type Server struct {
+	cdcapi.UnimplementedChangeDataCaptureServiceServer
+
+	ctxFactory func(context.Context) (*sql.Context, error)
+	// pollInterval is how often the branch of a following stream is checked for new commits.
+	pollInterval time.Duration
+}
+
+var _ cdcapi.ChangeDataCaptureServiceServer = (*Server)(nil)
+
+// NewServer returns a Server which streams the changes of the databases available to the sessions created by
+// |ctxFactory|.
+func NewServer(ctxFactory func(context.Context) (*sql.Context, error)) *Server {
+	return &Server{ctxFactory: ctxFactory, pollInterval: defaultPollInterval}
+}
+
+// Register registers the service with |srv|.
+func (s *Server) Register(srv *grpc.Server) {
+	cdcapi.RegisterChangeDataCaptureServiceServer(srv, s)
+}
+
+// resumePoint is where a stream starts. If |table| is empty, the stream starts after |commit|. Otherwise it starts
+// in |commit|, skipping the tables sorted before |table| and the first |offset| changes of |table|.
+type resumePoint struct {
+	commit hash.Hash
+	table  string
+	offset uint64
+}
+
+// StreamChanges implements cdcapi.ChangeDataCaptureServiceServer.
+func (s *Server) StreamChanges(req *cdcapi.StreamChangesRequest, stream cdcapi.ChangeDataCaptureService_StreamChangesServer) error {
+	if req.Database == "" {
+		return status.Error(codes.InvalidArgument, "database is required")
+	}
+	if req.Branch == "" {
+		return status.Error(codes.InvalidArgument, "branch is required")
+	}
+	start, err := startOf(req)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	sqlCtx, err := s.ctxFactory(ctx)
+	if err != nil {
+		return err
+	}
+	sess := dsess.DSessFromSess(sqlCtx.Session)
+	db, err := sess.Provider().Database(sqlCtx, req.Database)
+	if err != nil {
+		if sql.ErrDatabaseNotFound.Is(err) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		return err
+	}
+	sdb, ok := db.(dsess.SqlDatabase)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "database %s does not support change data capture", req.Database)
+	}
+
+	cs := &changeStream{
+		sqlCtx: sqlCtx,
+		ddb:    sdb.DbData().Ddb,
+		branch: ref.NewBranchRef(req.Branch),
+		send:   stream.Send,
+		max:    int(req.MaxChangesPerResponse),
+	}
+	if cs.max == 0 {
+		cs.max = defaultMaxChangesPerResponse
+	}
+	if len(req.Tables) > 0 {
+		cs.tables = make(map[string]bool, len(req.Tables))
+		for _, t := range req.Tables {
+			cs.tables[strings.ToLower(t)] = true
+		}
+	}
+
+	for {
+		start, err = cs.streamFrom(start)
+		if err != nil {
+			return err
+		}
+		if !req.Follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// startOf returns where the stream of |req| starts.
+func startOf(req *cdcapi.StreamChangesRequest) (resumePoint, error) {
+	if req.Cursor != nil {
+		h, ok := hash.MaybeParse(req.Cursor.Commit)
+		if !ok {
+			return resumePoint{}, status.Errorf(codes.InvalidArgument, "invalid cursor commit: %s", req.Cursor.Commit)
+		}
+		return resumePoint{commit: h, table: req.Cursor.Table, offset: req.Cursor.Offset}, nil
+	}
+	if req.StartCommit == "" {
+		return resumePoint{}, nil
+	}
+	h, ok := hash.MaybeParse(req.StartCommit)
+	if !ok {
+		return resumePoint{}, status.Errorf(codes.InvalidArgument, "invalid start commit: %s", req.StartCommit)
+	}
+	return resumePoint{commit: h}, nil
+}
+
+type changeStream struct {
+	sqlCtx *sql.Context
+	ddb    *doltdb.DoltDB
+	branch ref.DoltRef
+	// tables are the lower-cased names of the tables streamed, or nil if every table is streamed.
+	tables map[string]bool
+	send   func(*cdcapi.StreamChangesResponse) error
+	max    int
+}
+
+// streamFrom streams the commits of the branch from |start| up to its current head, and returns the point after the
+// last commit streamed.
+func (cs *changeStream) streamFrom(start resumePoint) (resumePoint, error) {
+	commits, err := cs.commitsFrom(start)
+	if err != nil {
+		return resumePoint{}, err
+	}
+	for i, cm := range commits {
+		var table string
+		var offset uint64
+		if i == 0 {
+			table, offset = start.table, start.offset
+		}
+		h, err := cs.streamCommit(cm, table, offset)
+		if err != nil {
+			return resumePoint{}, err
+		}
+		start = resumePoint{commit: h}
+	}
+	return start, nil
+}
+
+// commitsFrom returns the commits on the first-parent history of the branch which are streamed from |start|, oldest
+// first.
+func (cs *changeStream) commitsFrom(start resumePoint) ([]*doltdb.Commit, error) {
+	ctx := cs.sqlCtx
+	cm, err := cs.ddb.ResolveCommitRef(ctx, cs.branch)
+	if errors.Is(err, doltdb.ErrBranchNotFound) {
+		return nil, status.Errorf(codes.NotFound, "branch not found: %s", cs.branch.GetPath())
+	} else if err != nil {
+		return nil, err
+	}
+
+	var commits []*doltdb.Commit
+	for {
+		h, err := cm.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		if h == start.commit {
+			if start.table != "" {
+				commits = append(commits, cm)
+			}
+			break
+		}
+		commits = append(commits, cm)
+		if cm.NumParents() == 0 {
+			if !start.commit.IsEmpty() {
+				return nil, status.Errorf(codes.FailedPrecondition, "commit %s is not on the first-parent history of branch %s", start.commit.String(), cs.branch.GetPath())
+			}
+			break
+		}
+		optCmt, err := cs.ddb.ResolveParent(ctx, cm, 0)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if cm, ok = optCmt.ToCommit(); !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+	}
+
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// streamCommit streams the changes |cm| made to its first parent, skipping the tables sorted before |table| and the
+// first |offset| changes of |table|. It returns the hash of |cm|.
+func (cs *changeStream) streamCommit(cm *doltdb.Commit, table string, offset uint64) (hash.Hash, error) {
+	ctx := cs.sqlCtx
+	h, err := cm.HashOf()
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	commit, err := commitMessage(ctx, cm, h)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	fromRoot, toRoot, err := cs.roots(cm)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return hash.Hash{}, err
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	resp := &cdcapi.StreamChangesResponse{Commit: commit}
+	for _, td := range deltas {
+		name := td.CurName()
+		if cs.tables != nil && !cs.tables[strings.ToLower(name)] {
+			continue
+		}
+		if table != "" && name < table {
+			continue
+		}
+		var skip uint64
+		if name == table {
+			skip = offset
+		}
+		changed, err := td.HasDataChanged(ctx)
+		if err != nil {
+			return hash.Hash{}, err
+		}
+		if !changed {
+			continue
+		}
+
+		var sent uint64
+		err = cs.diffTable(td, func(change *cdcapi.RowChange) error {
+			sent++
+			if sent <= skip {
+				return nil
+			}
+			resp.Changes = append(resp.Changes, change)
+			if len(resp.Changes) < cs.max {
+				return nil
+			}
+			resp.Cursor = &cdcapi.Cursor{Commit: h.String(), Table: name, Offset: sent}
+			if err := cs.send(resp); err != nil {
+				return err
+			}
+			resp = &cdcapi.StreamChangesResponse{Commit: commit}
+			return nil
+		})
+		if err != nil {
+			return hash.Hash{}, err
+		}
+	}
+
+	resp.CommitComplete = true
+	resp.Cursor = &cdcapi.Cursor{Commit: h.String()}
+	return h, cs.send(resp)
+}
+
+// roots returns the root values of the first parent of |cm| and of |cm|. The first commit of a database is diffed
+// against an empty root value.
+func (cs *changeStream) roots(cm *doltdb.Commit) (from, to doltdb.RootValue, err error) {
+	ctx := cs.sqlCtx
+	to, err = cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cm.NumParents() == 0 {
+		from, err = doltdb.EmptyRootValue(ctx, cs.ddb.ValueReadWriter(), cs.ddb.NodeStore())
+		return from, to, err
+	}
+	optCmt, err := cs.ddb.ResolveParent(ctx, cm, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, nil, doltdb.ErrGhostCommitEncountered
+	}
+	from, err = parent.GetRootValue(ctx)
+	return from, to, err
+}
+
+func commitMessage(ctx context.Context, cm *doltdb.Commit, h hash.Hash) (*cdcapi.Commit, error) {
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commit := &cdcapi.Commit{
+		Hash:            h.String(),
+		AuthorName:      meta.Name,
+		AuthorEmail:     meta.Email,
+		Message:         meta.Description,
+		TimestampMillis: meta.UserTimestamp,
+	}
+	if cm.NumParents() > 0 {
+		parents, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		commit.ParentHash = parents[0].String()
+	}
+	return commit, nil
+}
+
+// diffTable calls |cb| with every row change of |td|, in key order. A table whose primary key changed can't be
+// diffed row by row, so all of its old rows are reported as deleted and all of its new rows as inserted.
+func (cs *changeStream) diffTable(td diff.TableDelta, cb func(*cdcapi.RowChange) error) error {
+	name := td.CurName()
+	from, err := cs.side(td.FromTable, td.FromSch, td.ToTable, td.ToSch)
+	if err != nil {
+		return err
+	}
+	to, err := cs.side(td.ToTable, td.ToSch, td.FromTable, td.FromSch)
+	if err != nil {
+		return err
+	}
+
+	if td.FromTable != nil && td.ToTable != nil && td.HasPrimaryKeySetChanged() {
+		empty, err := cs.emptySide(td.FromTable, td.FromSch)
+		if err != nil {
+			return err
+		}
+		if err = cs.diffMaps(name, from, empty, cb); err != nil {
+			return err
+		}
+		if empty, err = cs.emptySide(td.ToTable, td.ToSch); err != nil {
+			return err
+		}
+		return cs.diffMaps(name, empty, to, cb)
+	}
+	return cs.diffMaps(name, from, to, cb)
+}
+
+// tableSide is the row data and schema of one side of a table diff.
+type tableSide struct {
+	rows prolly.Map
+	sch  schema.Schema
+	ns   tree.NodeStore
+}
+
+// side returns the side of a diff for |tbl|. If the table doesn't exist on this side, the returned side is empty,
+// with the schema of the |other| side.
+func (cs *changeStream) side(tbl *doltdb.Table, sch schema.Schema, other *doltdb.Table, otherSch schema.Schema) (tableSide, error) {
+	if tbl == nil {
+		return cs.emptySide(other, otherSch)
+	}
+	idx, err := tbl.GetRowData(cs.sqlCtx)
+	if err != nil {
+		return tableSide{}, err
+	}
+	return tableSide{rows: durable.ProllyMapFromIndex(idx), sch: sch, ns: tbl.NodeStore()}, nil
+}
+
+func (cs *changeStream) emptySide(tbl *doltdb.Table, sch schema.Schema) (tableSide, error) {
+	idx, err := durable.NewEmptyIndex(cs.sqlCtx, tbl.ValueReadWriter(), tbl.NodeStore(), sch)
+	if err != nil {
+		return tableSide{}, err
+	}
+	return tableSide{rows: durable.ProllyMapFromIndex(idx), sch: sch, ns: tbl.NodeStore()}, nil
+}
+
+func (cs *changeStream) diffMaps(table string, from, to tableSide, cb func(*cdcapi.RowChange) error) error {
+	ctx := cs.sqlCtx
+	err := prolly.DiffMaps(ctx, from.rows, to.rows, false, func(_ context.Context, d tree.Diff) error {
+		change := &cdcapi.RowChange{Table: table}
+		count := uint64(1)
+		switch d.Type {
+		case tree.AddedDiff:
+			change.Type = cdcapi.ChangeType_CHANGE_TYPE_INSERT
+			if schema.IsKeyless(to.sch) {
+				count = cardinality(to.sch, d.To)
+			}
+		case tree.RemovedDiff:
+			change.Type = cdcapi.ChangeType_CHANGE_TYPE_DELETE
+			if schema.IsKeyless(from.sch) {
+				count = cardinality(from.sch, d.From)
+			}
+		case tree.ModifiedDiff:
+			change.Type = cdcapi.ChangeType_CHANGE_TYPE_UPDATE
+			// A keyless row is identified by its contents, so a modification of one is a change in the number of
+			// copies of the row.
+			if schema.IsKeyless(to.sch) {
+				fromCount, toCount := cardinality(from.sch, d.From), cardinality(to.sch, d.To)
+				if toCount > fromCount {
+					change.Type, count = cdcapi.ChangeType_CHANGE_TYPE_INSERT, toCount-fromCount
+				} else {
+					change.Type, count = cdcapi.ChangeType_CHANGE_TYPE_DELETE, fromCount-toCount
+				}
+			}
+		}
+
+		var err error
+		if change.Type != cdcapi.ChangeType_CHANGE_TYPE_INSERT {
+			if change.Before, err = cs.row(from, val.Tuple(d.Key), val.Tuple(d.From)); err != nil {
+				return err
+			}
+		}
+		if change.Type != cdcapi.ChangeType_CHANGE_TYPE_DELETE {
+			if change.After, err = cs.row(to, val.Tuple(d.Key), val.Tuple(d.To)); err != nil {
+				return err
+			}
+		}
+		for i := uint64(0); i < count; i++ {
+			if err = cb(change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// cardinality returns the number of copies of a keyless row, which is the first field of its value tuple.
+func cardinality(sch schema.Schema, value tree.Item) uint64 {
+	count, _ := sch.GetValueDescriptor().GetUint64(0, val.Tuple(value))
+	return count
+}
+
+// row returns the stored columns of the row with |key| and |value| in |side|, formatted as SQL values.
+func (cs *changeStream) row(side tableSide, key, value val.Tuple) (*cdcapi.Row, error) {
+	keyDesc, valDesc := side.sch.GetKeyDescriptor(), side.sch.GetValueDescriptor()
+	fields := make(map[uint64]*cdcapi.Column)
+	if !schema.IsKeyless(side.sch) {
+		for i, col := range side.sch.GetPKCols().GetColumns() {
+			c, err := cs.column(col, keyDesc, i, key, side.ns)
+			if err != nil {
+				return nil, err
+			}
+			fields[col.Tag] = c
+		}
+	}
+
+	// The value tuple of a keyless row starts with its cardinality.
+	valIdx := 0
+	if schema.IsKeyless(side.sch) {
+		valIdx = 1
+	}
+	for _, col := range side.sch.GetNonPKCols().GetColumns() {
+		if col.Virtual {
+			continue
+		}
+		c, err := cs.column(col, valDesc, valIdx, value, side.ns)
+		if err != nil {
+			return nil, err
+		}
+		fields[col.Tag] = c
+		valIdx++
+	}
+
+	row := &cdcapi.Row{}
+	for _, col := range side.sch.GetAllCols().GetColumns() {
+		if c, ok := fields[col.Tag]; ok {
+			row.Columns = append(row.Columns, c)
+		}
+	}
+	return row, nil
+}
+
+func (cs *changeStream) column(col schema.Column, desc val.TupleDesc, i int, tup val.Tuple, ns tree.NodeStore) (*cdcapi.Column, error) {
+	v, err := tree.GetField(cs.sqlCtx, desc, i, tup, ns)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return &cdcapi.Column{Name: col.Name, IsNull: true}, nil
+	}
+	sqlVal, err := col.TypeInfo.ToSqlType().SQL(cs.sqlCtx, nil, v)
+	if err != nil {
+		return nil, err
+	}
+	return &cdcapi.Column{Name: col.Name, Value: sqlVal.ToString()}, nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	cdcapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/cdcapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+type testStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*cdcapi.StreamChangesResponse
+	onSend    func(*cdcapi.StreamChangesResponse)
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testStream) Send(resp *cdcapi.StreamChangesResponse) error {
+	s.responses = append(s.responses, resp)
+	if s.onSend != nil {
+		s.onSend(resp)
+	}
+	return nil
+}
+
+type testDB struct {
+	srv    *Server
+	exec   func(query string)
+	commit func(msg string) string
+}
+
+func newTestDB(t *testing.T) *testDB {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	t.Cleanup(func() { dEnv.DoltDB.Close() })
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	db, err := sqle.NewDatabase(ctx, "dolt", dEnv.DbData(), editor.Options{Deaf: dEnv.DbEaFactory(), Tempdir: tmpDir})
+	require.NoError(t, err)
+	engine, sqlCtx, err := sqle.NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	pro := dsess.DSessFromSess(sqlCtx.Session).Provider()
+
+	tdb := &testDB{}
+	tdb.srv = &Server{
+		ctxFactory: func(ctx context.Context) (*sql.Context, error) {
+			return sqle.NewTestSQLCtxWithProvider(ctx, pro, nil), nil
+		},
+		pollInterval: 10 * time.Millisecond,
+	}
+	tdb.exec = func(query string) {
+		_, iter, _, err := engine.Query(sqlCtx, query)
+		require.NoError(t, err)
+		_, err = sql.RowIterToRows(sqlCtx, iter)
+		require.NoError(t, err)
+	}
+	tdb.commit = func(msg string) string {
+		tdb.exec("call dolt_commit('-Am', '" + msg + "', '--author', 'Test User <test@example.com>')")
+		cm, err := dEnv.DoltDB.ResolveCommitRef(ctx, ref.NewBranchRef("main"))
+		require.NoError(t, err)
+		h, err := cm.HashOf()
+		require.NoError(t, err)
+		return h.String()
+	}
+	return tdb
+}
+
+func (tdb *testDB) stream(req *cdcapi.StreamChangesRequest) ([]*cdcapi.StreamChangesResponse, error) {
+	req.Database, req.Branch = "dolt", "main"
+	stream := &testStream{ctx: context.Background()}
+	err := tdb.srv.StreamChanges(req, stream)
+	return stream.responses, err
+}
+
+func row(kvs ...string) *cdcapi.Row {
+	r := &cdcapi.Row{}
+	for i := 0; i < len(kvs); i += 2 {
+		r.Columns = append(r.Columns, &cdcapi.Column{Name: kvs[i], Value: kvs[i+1]})
+	}
+	return r
+}
+
+// changes returns the row changes of |responses|.
+func changes(responses []*cdcapi.StreamChangesResponse) []*cdcapi.RowChange {
+	var ret []*cdcapi.RowChange
+	for _, resp := range responses {
+		ret = append(ret, resp.Changes...)
+	}
+	return ret
+}
+
+func TestStreamChanges(t *testing.T) {
+	tdb := newTestDB(t)
+	tdb.exec("create table t (pk int primary key, c varchar(10))")
+	tdb.exec("insert into t values (1, 'a'), (2, 'b')")
+	first := tdb.commit("first")
+	tdb.exec("create table k (x int)")
+	tdb.exec("insert into k values (7), (7)")
+	tdb.exec("update t set c = 'z' where pk = 1")
+	tdb.exec("delete from t where pk = 2")
+	tdb.exec("insert into t values (3, null)")
+	second := tdb.commit("second")
+
+	expectedFirst := []*cdcapi.RowChange{
+		{Table: "t", Type: cdcapi.ChangeType_CHANGE_TYPE_INSERT, After: row("pk", "1", "c", "a")},
+		{Table: "t", Type: cdcapi.ChangeType_CHANGE_TYPE_INSERT, After: row("pk", "2", "c", "b")},
+	}
+	expectedSecond := []*cdcapi.RowChange{
+		{Table: "k", Type: cdcapi.ChangeType_CHANGE_TYPE_INSERT, After: row("x", "7")},
+		{Table: "k", Type: cdcapi.ChangeType_CHANGE_TYPE_INSERT, After: row("x", "7")},
+		{Table: "t", Type: cdcapi.ChangeType_CHANGE_TYPE_UPDATE, Before: row("pk", "1", "c", "a"), After: row("pk", "1", "c", "z")},
+		{Table: "t", Type: cdcapi.ChangeType_CHANGE_TYPE_DELETE, Before: row("pk", "2", "c", "b")},
+		{Table: "t", Type: cdcapi.ChangeType_CHANGE_TYPE_INSERT, After: &cdcapi.Row{Columns: []*cdcapi.Column{
+			{Name: "pk", Value: "3"}, {Name: "c", IsNull: true},
+		}}},
+	}
+
+	t.Run("from the first commit", func(t *testing.T) {
+		responses, err := tdb.stream(&cdcapi.StreamChangesRequest{})
+		require.NoError(t, err)
+		require.Len(t, responses, 3)
+		assert.Empty(t, responses[0].Changes)
+		assert.Empty(t, responses[0].Commit.ParentHash)
+		assert.Equal(t, first, responses[1].Commit.Hash)
+		assert.Equal(t, responses[0].Commit.Hash, responses[1].Commit.ParentHash)
+		assert.Equal(t, expectedFirst, responses[1].Changes)
+		assert.Equal(t, second, responses[2].Commit.Hash)
+		assert.Equal(t, "second", responses[2].Commit.Message)
+		assert.Equal(t, expectedSecond, responses[2].Changes)
+		for _, resp := range responses {
+			assert.True(t, resp.CommitComplete)
+			assert.Equal(t, &cdcapi.Cursor{Commit: resp.Commit.Hash}, resp.Cursor)
+		}
+	})
+
+	t.Run("from a start commit", func(t *testing.T) {
+		responses, err := tdb.stream(&cdcapi.StreamChangesRequest{StartCommit: first})
+		require.NoError(t, err)
+		require.Len(t, responses, 1)
+		assert.Equal(t, expectedSecond, responses[0].Changes)
+	})
+
+	t.Run("table filter", func(t *testing.T) {
+		responses, err := tdb.stream(&cdcapi.StreamChangesRequest{StartCommit: first, Tables: []string{"K"}})
+		require.NoError(t, err)
+		require.Len(t, responses, 1)
+		assert.Equal(t, expectedSecond[:2], responses[0].Changes)
+	})
+
+	t.Run("resume from every cursor", func(t *testing.T) {
+		responses, err := tdb.stream(&cdcapi.StreamChangesRequest{StartCommit: first, MaxChangesPerResponse: 2})
+		require.NoError(t, err)
+		require.Len(t, responses, 3)
+		assert.Equal(t, &cdcapi.Cursor{Commit: second, Table: "k", Offset: 2}, responses[0].Cursor)
+		assert.Equal(t, &cdcapi.Cursor{Commit: second, Table: "t", Offset: 2}, responses[1].Cursor)
+		assert.False(t, responses[1].CommitComplete)
+		assert.True(t, responses[2].CommitComplete)
+
+		for i := range responses {
+			resumed, err := tdb.stream(&cdcapi.StreamChangesRequest{Cursor: responses[i].Cursor})
+			require.NoError(t, err)
+			assert.Equal(t, changes(responses[i+1:]), changes(resumed))
+		}
+	})
+
+	t.Run("start commit not on branch", func(t *testing.T) {
+		_, err := tdb.stream(&cdcapi.StreamChangesRequest{StartCommit: "0123456789abcdefghijklmnopqrstuv"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		_, err = tdb.stream(&cdcapi.StreamChangesRequest{StartCommit: "not a hash"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestStreamChangesFollow(t *testing.T) {
+	tdb := newTestDB(t)
+	tdb.exec("create table t (pk int primary key)")
+	start := tdb.commit("create t")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	commits := make(chan *cdcapi.StreamChangesResponse, 8)
+	stream := &testStream{ctx: ctx, onSend: func(resp *cdcapi.StreamChangesResponse) {
+		commits <- resp
+	}}
+	done := make(chan error)
+	go func() {
+		done <- tdb.srv.StreamChanges(&cdcapi.StreamChangesRequest{Database: "dolt", Branch: "main", StartCommit: start, Follow: true}, stream)
+	}()
+
+	tdb.exec("insert into t values (1)")
+	tdb.commit("insert 1")
+	select {
+	case resp := <-commits:
+		assert.Equal(t, "insert 1", resp.Commit.Message)
+		assert.Equal(t, []*cdcapi.RowChange{{Table: "t", Type: cdcapi.ChangeType_CHANGE_TYPE_INSERT, After: row("pk", "1")}}, resp.Changes)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a commit")
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
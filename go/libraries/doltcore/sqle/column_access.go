@@ -0,0 +1,78 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// CheckReadAccess returns an error if the branch control permissions of the current user do not allow for reading the
+// table, along with each of its projected columns.
+func (t *DoltTable) CheckReadAccess(ctx *sql.Context) error {
+	columns := t.Projections()
+	if columns == nil {
+		columns = t.sch.GetAllCols().GetColumnNames()
+	}
+	return dsess.CheckColumnAccessForDb(ctx, t.db, t.tableName, columns, branch_control.Permissions_Read)
+}
+
+// deniedColumn is a column of a table that may not be written by the current user, along with the error to return
+// when it is written.
+type deniedColumn struct {
+	idx int
+	err error
+}
+
+// deniedColumns returns the columns of the table's schema that the branch control permissions of the current user do
+// not allow for the given flags.
+func (t *DoltTable) deniedColumns(ctx *sql.Context, flags branch_control.Permissions) ([]deniedColumn, error) {
+	var denied []deniedColumn
+	for i, col := range t.sqlSch.Schema {
+		err := dsess.CheckColumnAccessForDb(ctx, t.db, t.tableName, []string{col.Name}, flags)
+		if branch_control.ErrIncorrectColumnPermissions.Is(err) {
+			denied = append(denied, deniedColumn{idx: i, err: err})
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return denied, nil
+}
+
+// columnAccessUpdater is a sql.RowUpdater that denies any updates that change the values of columns which may not be
+// written by the current user.
+type columnAccessUpdater struct {
+	dsess.TableWriter
+	sch    sql.Schema
+	denied []deniedColumn
+}
+
+var _ sql.RowUpdater = columnAccessUpdater{}
+
+// Update implements the interface sql.RowUpdater.
+func (u columnAccessUpdater) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	for _, col := range u.denied {
+		cmp, err := u.sch[col.idx].Type.Compare(old[col.idx], new[col.idx])
+		if err != nil {
+			return err
+		}
+		if cmp != 0 {
+			return col.err
+		}
+	}
+	return u.TableWriter.Update(ctx, old, new)
+}
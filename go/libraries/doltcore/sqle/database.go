@@ -331,7 +331,7 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		}
 
 		tableName := tblName[len(doltdb.DoltDiffTablePrefix):]
-		dt, err := dtables.NewDiffTable(ctx, db.Name(), tableName, db.ddb, root, head)
+		dt, err := dtables.NewDiffTable(ctx, db, tableName, db.ddb, root, head)
 		if err != nil {
 			return nil, false, err
 		}
@@ -343,7 +343,7 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		if err != nil {
 			return nil, false, err
 		}
-		dt, err := dtables.NewCommitDiffTable(ctx, db.Name(), suffix, db.ddb, root, ws.StagedRoot())
+		dt, err := dtables.NewCommitDiffTable(ctx, db, suffix, db.ddb, root, ws.StagedRoot())
 		if err != nil {
 			return nil, false, err
 		}
@@ -384,7 +384,7 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		} else if !ok {
 			return nil, false, nil
 		}
-		dt, err := dtables.NewConflictsTable(ctx, suffix, srcTable, root, dtables.RootSetter(db))
+		dt, err := dtables.NewConflictsTable(ctx, db, suffix, srcTable, root, dtables.RootSetter(db))
 		if err != nil {
 			return nil, false, err
 		}
@@ -392,7 +392,7 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 
 	case strings.HasPrefix(lwrName, doltdb.DoltConstViolTablePrefix):
 		suffix := tblName[len(doltdb.DoltConstViolTablePrefix):]
-		dt, err := dtables.NewConstraintViolationsTable(ctx, suffix, root, dtables.RootSetter(db))
+		dt, err := dtables.NewConstraintViolationsTable(ctx, db, suffix, root, dtables.RootSetter(db))
 		if err != nil {
 			return nil, false, err
 		}
@@ -410,7 +410,7 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 
 		userTable := tblName[len(doltdb.DoltWorkspaceTablePrefix):]
 
-		dt, err := dtables.NewWorkspaceTable(ctx, tblName, userTable, head, ws)
+		dt, err := dtables.NewWorkspaceTable(ctx, db, tblName, userTable, head, ws)
 		if err != nil {
 			return nil, false, err
 		}
@@ -483,6 +483,10 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		dt, found = dtables.NewMergeStatusTable(db.RevisionQualifiedName()), true
 	case doltdb.TagsTableName:
 		dt, found = dtables.NewTagsTable(ctx, db.ddb), true
+	case doltdb.StashesTableName:
+		dt, found = dtables.NewStashesTable(ctx, db.ddb), true
+	case doltdb.GCStatusTableName:
+		dt, found = dtables.NewGCStatusTable(db.Name(), db.ddb), true
 	case dtables.AccessTableName:
 		basCtx := branch_control.GetBranchAwareSession(ctx)
 		if basCtx != nil {
@@ -497,6 +501,13 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 				dt, found = dtables.NewBranchNamespaceControlTable(controller.Namespace), true
 			}
 		}
+	case dtables.ProtectionTableName:
+		basCtx := branch_control.GetBranchAwareSession(ctx)
+		if basCtx != nil {
+			if controller := basCtx.GetController(); controller != nil {
+				dt, found = dtables.NewBranchProtectionTable(controller.Protection), true
+			}
+		}
 	case doltdb.IgnoreTableName:
 		backingTable, _, err := db.getTable(ctx, root, doltdb.IgnoreTableName)
 		if err != nil {
@@ -508,6 +519,28 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 			versionableTable := backingTable.(dtables.VersionableTable)
 			dt, found = dtables.NewIgnoreTable(ctx, versionableTable), true
 		}
+	case doltdb.MergeRulesTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.MergeRulesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMergeRulesTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMergeRulesTable(ctx, versionableTable), true
+		}
+	case doltdb.TestsTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.TestsTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyTestsTable(ctx), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewTestsTable(ctx, versionableTable), true
+		}
 	case doltdb.DocTableName:
 		backingTable, _, err := db.getTable(ctx, root, doltdb.DocTableName)
 		if err != nil {
@@ -1016,7 +1049,7 @@ func (db Database) GetHeadRoot(ctx *sql.Context) (doltdb.RootValue, error) {
 // DropTable drops the table with the name given.
 // The planner returns the correct case sensitive name in tableName
 func (db Database) DropTable(ctx *sql.Context, tableName string) error {
-	if err := dsess.CheckAccessForDb(ctx, db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, db, tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	if doltdb.IsNonAlterableSystemTable(tableName) {
@@ -1132,7 +1165,7 @@ func (db Database) removeTableFromAutoIncrementTracker(
 
 // CreateTable creates a table with the name and schema given.
 func (db Database) CreateTable(ctx *sql.Context, tableName string, sch sql.PrimaryKeySchema, collation sql.CollationID, comment string) error {
-	if err := dsess.CheckAccessForDb(ctx, db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, db, tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 
@@ -1153,7 +1186,7 @@ func (db Database) CreateTable(ctx *sql.Context, tableName string, sch sql.Prima
 
 // CreateIndexedTable creates a table with the name and schema given.
 func (db Database) CreateIndexedTable(ctx *sql.Context, tableName string, sch sql.PrimaryKeySchema, idxDef sql.IndexDef, collation sql.CollationID) error {
-	if err := dsess.CheckAccessForDb(ctx, db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, db, tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 
@@ -1473,7 +1506,10 @@ func (db Database) AllSchemas(ctx *sql.Context) ([]sql.DatabaseSchema, error) {
 
 // RenameTable implements sql.TableRenamer
 func (db Database) RenameTable(ctx *sql.Context, oldName, newName string) error {
-	if err := dsess.CheckAccessForDb(ctx, db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, db, oldName, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+	if err := dsess.CheckTableAccessForDb(ctx, db, newName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	root, err := db.GetRoot(ctx)
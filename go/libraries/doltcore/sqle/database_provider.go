@@ -64,8 +64,9 @@ type DoltDatabaseProvider struct {
 	fs            filesys.Filesys
 	remoteDialer  dbfactory.GRPCDialProvider // TODO: why isn't this a method defined on the remote object
 
-	dbFactoryUrl string
-	isStandby    *bool
+	dbFactoryUrl    string
+	isStandby       *bool
+	statementRunner dsess.StatementRunner
 }
 
 var _ sql.DatabaseProvider = (*DoltDatabaseProvider)(nil)
@@ -211,6 +212,17 @@ func (p *DoltDatabaseProvider) SetIsStandby(standby bool) {
 	*p.isStandby = standby
 }
 
+// SetStatementRunner sets the runner that stored procedures use to execute SQL statements on behalf of the user. This
+// is normally the engine that is built on this provider.
+func (p *DoltDatabaseProvider) SetStatementRunner(runner dsess.StatementRunner) {
+	p.statementRunner = runner
+}
+
+// StatementRunner implements the dsess.DoltDatabaseProvider interface
+func (p *DoltDatabaseProvider) StatementRunner() dsess.StatementRunner {
+	return p.statementRunner
+}
+
 // FileSystemForDatabase returns a filesystem, with the working directory set to the root directory
 // of the requested database. If the requested database isn't found, a database not found error
 // is returned.
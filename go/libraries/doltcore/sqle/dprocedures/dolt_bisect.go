@@ -0,0 +1,383 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/bisect"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	bisectStartCmd = "start"
+	bisectBadCmd   = "bad"
+	bisectGoodCmd  = "good"
+	bisectSkipCmd  = "skip"
+	bisectResetCmd = "reset"
+	bisectRunCmd   = "run"
+)
+
+var doltBisectProcedureSchema = []*sql.Column{
+	{
+		Name:     "status",
+		Type:     types.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "message",
+		Type:     types.LongText,
+		Nullable: true,
+	},
+}
+
+// ErrNoBisectInProgress is returned when a bisect subcommand other than start is used without an active bisect.
+var ErrNoBisectInProgress = errors.New("no bisect in progress; start one by calling dolt_bisect('start')")
+
+// ErrBisectInProgress is returned when a bisect is started while another bisect is already active.
+var ErrBisectInProgress = errors.New("a bisect is already in progress; call dolt_bisect('reset') to end it")
+
+// BisectResetMessage is returned when a bisect is reset.
+var BisectResetMessage = "Bisect reset"
+
+// doltBisect is the stored procedure version for the CLI command `dolt bisect`. Bisecting never changes the roots of
+// the current working set. Instead, the commit to test next is reported in the returned message, and can be examined
+// with AS OF queries or through its revision database, or tested automatically by the run subcommand.
+func doltBisect(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, message, err := doDoltBisect(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res), message), nil
+}
+
+func doDoltBisect(ctx *sql.Context, args []string) (int, string, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, "", sql.ErrNoDatabaseSelected.New()
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, "", err
+	}
+
+	apr, err := cli.CreateBisectArgParser().Parse(args)
+	if err != nil {
+		return 1, "", err
+	}
+	if apr.NArg() == 0 {
+		return 1, "", fmt.Errorf("error: missing subcommand, must be one of %s, %s, %s, %s, %s or %s",
+			bisectStartCmd, bisectBadCmd, bisectGoodCmd, bisectSkipCmd, bisectResetCmd, bisectRunCmd)
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return 1, "", fmt.Errorf("Could not load database %s", dbName)
+	}
+	if !dbData.Ddb.Format().UsesFlatbuffers() {
+		return 1, "", fmt.Errorf("bisect is not supported for old storage format")
+	}
+	headRef, err := dSess.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return 1, "", err
+	}
+	workingSet, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return 1, "", err
+	}
+
+	subcommand := strings.ToLower(apr.Arg(0))
+	revs := apr.Args[1:]
+
+	var state *doltdb.BisectState
+	switch subcommand {
+	case bisectStartCmd:
+		if workingSet.BisectActive() {
+			return 1, "", ErrBisectInProgress
+		}
+		state = workingSet.StartBisect().BisectState()
+		if len(revs) > 0 {
+			if state, err = markBisectCommits(ctx, dbData.Ddb, headRef, state, bisectBadCmd, revs[:1]); err != nil {
+				return 1, "", err
+			}
+		}
+		if len(revs) > 1 {
+			if state, err = markBisectCommits(ctx, dbData.Ddb, headRef, state, bisectGoodCmd, revs[1:]); err != nil {
+				return 1, "", err
+			}
+		}
+
+	case bisectBadCmd, bisectGoodCmd, bisectSkipCmd:
+		if !workingSet.BisectActive() {
+			return 1, "", ErrNoBisectInProgress
+		}
+		if subcommand == bisectBadCmd && len(revs) > 1 {
+			return 1, "", fmt.Errorf("error: '%s' takes at most one commit", bisectBadCmd)
+		}
+		state = workingSet.BisectState()
+		if len(revs) == 0 {
+			rev, err := currentBisectCommit(ctx, dbData.Ddb, state)
+			if err != nil {
+				return 1, "", err
+			}
+			revs = []string{rev}
+		}
+		if state, err = markBisectCommits(ctx, dbData.Ddb, headRef, state, subcommand, revs); err != nil {
+			return 1, "", err
+		}
+
+	case bisectResetCmd:
+		if len(revs) > 0 {
+			return 1, "", fmt.Errorf("error: '%s' does not take any arguments", bisectResetCmd)
+		}
+		if err = dSess.SetWorkingSet(ctx, dbName, workingSet.ClearBisect()); err != nil {
+			return 1, "", err
+		}
+		if err = commitTransaction(ctx, dSess, nil); err != nil {
+			return 1, "", err
+		}
+		return 0, BisectResetMessage, nil
+
+	case bisectRunCmd:
+		if !workingSet.BisectActive() {
+			return 1, "", ErrNoBisectInProgress
+		}
+		if len(revs) != 1 {
+			return 1, "", fmt.Errorf("error: '%s' requires exactly one predicate query", bisectRunCmd)
+		}
+		saveState := func(state *doltdb.BisectState) error {
+			ws, err := dSess.WorkingSet(ctx, dbName)
+			if err != nil {
+				return err
+			}
+			if err = dSess.SetWorkingSet(ctx, dbName, ws.WithBisectState(state)); err != nil {
+				return err
+			}
+			return commitTransaction(ctx, dSess, nil)
+		}
+		message, err := runBisect(ctx, dbData.Ddb, dbName, workingSet.BisectState(), revs[0], saveState)
+		if err != nil {
+			return 1, "", err
+		}
+		return 0, message, nil
+
+	default:
+		return 1, "", fmt.Errorf("error: invalid subcommand '%s', must be one of %s, %s, %s, %s, %s or %s", apr.Arg(0),
+			bisectStartCmd, bisectBadCmd, bisectGoodCmd, bisectSkipCmd, bisectResetCmd, bisectRunCmd)
+	}
+
+	message, _, err := bisectStatus(ctx, dbData.Ddb, state)
+	if err != nil {
+		return 1, "", err
+	}
+	if err = dSess.SetWorkingSet(ctx, dbName, workingSet.WithBisectState(state)); err != nil {
+		return 1, "", err
+	}
+	if err = commitTransaction(ctx, dSess, nil); err != nil {
+		return 1, "", err
+	}
+
+	return 0, message, nil
+}
+
+// markBisectCommits resolves each of |revs| and records it in a copy of |state| as bad, good, or skipped, depending
+// on |subcommand|.
+func markBisectCommits(ctx *sql.Context, ddb *doltdb.DoltDB, headRef ref.DoltRef, state *doltdb.BisectState, subcommand string, revs []string) (*doltdb.BisectState, error) {
+	for _, rev := range revs {
+		cs, err := doltdb.NewCommitSpec(rev)
+		if err != nil {
+			return nil, err
+		}
+		optCmt, err := ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return nil, err
+		}
+		commit, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		h, err := commit.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		switch subcommand {
+		case bisectBadCmd:
+			state = state.WithBadCommit(h)
+		case bisectGoodCmd:
+			state = state.WithGoodCommit(h)
+		case bisectSkipCmd:
+			state = state.WithSkippedCommit(h)
+		}
+	}
+	return state, nil
+}
+
+// currentBisectCommit returns the commit that bad, good, and skip apply to when no commit is given. This is the commit
+// that should be tested next, or HEAD if the bisect doesn't know both a good and bad commit yet.
+func currentBisectCommit(ctx *sql.Context, ddb *doltdb.DoltDB, state *doltdb.BisectState) (string, error) {
+	if state.BadCommit().IsEmpty() || len(state.GoodCommits()) == 0 {
+		return "HEAD", nil
+	}
+
+	result, err := bisect.Next(ctx, ddb, state)
+	if err != nil {
+		return "", err
+	}
+	if result.Next == nil {
+		return "", fmt.Errorf("error: the bisect has finished; specify a commit or call dolt_bisect('reset')")
+	}
+
+	h, err := result.Next.HashOf()
+	if err != nil {
+		return "", err
+	}
+	return h.String(), nil
+}
+
+// bisectStatus returns a message describing the state of the bisect, along with the bisect result once both a good
+// and a bad commit are known.
+func bisectStatus(ctx *sql.Context, ddb *doltdb.DoltDB, state *doltdb.BisectState) (string, *bisect.Result, error) {
+	numGood := len(state.GoodCommits())
+	if state.BadCommit().IsEmpty() && numGood == 0 {
+		return "status: waiting for both good and bad commits", nil, nil
+	} else if state.BadCommit().IsEmpty() {
+		return fmt.Sprintf("status: waiting for bad commit, %d good commit(s) known", numGood), nil, nil
+	} else if numGood == 0 {
+		return "status: waiting for good commit(s), bad commit known", nil, nil
+	}
+
+	result, err := bisect.Next(ctx, ddb, state)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch {
+	case result.Next != nil:
+		description, err := describeBisectCommit(ctx, result.Next)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("Bisecting: %d revision(s) left to test after this (roughly %d step(s))\n%s",
+			result.Remaining, result.Steps(), description), result, nil
+
+	case result.FirstBad != nil:
+		h, err := result.FirstBad.HashOf()
+		if err != nil {
+			return "", nil, err
+		}
+		description, err := describeBisectCommit(ctx, result.FirstBad)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("%s is the first bad commit\n%s", h.String(), description), result, nil
+
+	default:
+		sb := strings.Builder{}
+		sb.WriteString("There are only 'skip'ped commits left to test.\nThe first bad commit could be any of:")
+		for _, commit := range result.OnlySkipped {
+			description, err := describeBisectCommit(ctx, commit)
+			if err != nil {
+				return "", nil, err
+			}
+			sb.WriteString("\n")
+			sb.WriteString(description)
+		}
+		return sb.String(), result, nil
+	}
+}
+
+// describeBisectCommit returns a one line description of |commit|, containing its hash and commit message.
+func describeBisectCommit(ctx *sql.Context, commit *doltdb.Commit) (string, error) {
+	h, err := commit.HashOf()
+	if err != nil {
+		return "", err
+	}
+	meta, err := commit.GetCommitMeta(ctx)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("[%s] %s", h.String(), meta.Description), nil
+}
+
+// runBisect automatically tests commits with |query| until the first bad commit is found, or only skipped commits
+// are left to test. The state is passed to |saveState| after every step, so that the progress of the run is kept
+// even if a later step fails. An error from the query ends the run and is returned to the caller. Returns a message
+// describing each step of the run.
+func runBisect(ctx *sql.Context, ddb *doltdb.DoltDB, dbName string, state *doltdb.BisectState, query string, saveState func(*doltdb.BisectState) error) (string, error) {
+	if state.BadCommit().IsEmpty() || len(state.GoodCommits()) == 0 {
+		return "", fmt.Errorf("error: bisect run requires a good and a bad commit to be marked first")
+	}
+
+	var lines []string
+	for {
+		message, result, err := bisectStatus(ctx, ddb, state)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, message)
+		if result.Next == nil {
+			return strings.Join(lines, "\n"), nil
+		}
+
+		h, err := result.Next.HashOf()
+		if err != nil {
+			return "", err
+		}
+		isBad, err := evalBisectPredicate(ctx, dbName, h, query)
+		if err != nil {
+			return "", err
+		}
+		if isBad {
+			state = state.WithBadCommit(h)
+			lines = append(lines, fmt.Sprintf("%s is bad", h.String()))
+		} else {
+			state = state.WithGoodCommit(h)
+			lines = append(lines, fmt.Sprintf("%s is good", h.String()))
+		}
+		if err = saveState(state); err != nil {
+			return "", err
+		}
+	}
+}
+
+// evalBisectPredicate runs |query| against the revision database for |commit| and returns whether the commit is bad.
+// The query runs in the caller's session, with the caller's privileges. The commit is bad if the first column of the
+// first row returned by the query is true. A query that returns no rows, or a false or NULL value, marks the commit as
+// good.
+func evalBisectPredicate(ctx *sql.Context, dbName string, commit hash.Hash, query string) (bool, error) {
+	baseName, _ := dsess.SplitRevisionDbName(dbName)
+	ctx.SetCurrentDatabase(dsess.RevisionDbName(baseName, commit.String()))
+	defer ctx.SetCurrentDatabase(dbName)
+
+	_, rows, err := runUserStatement(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	if len(rows) == 0 || len(rows[0]) == 0 || rows[0][0] == nil {
+		return false, nil
+	}
+	return sql.ConvertToBool(ctx, rows[0][0])
+}
@@ -125,6 +125,9 @@ func renameBranch(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParseRe
 	if err := branch_control.CanCreateBranch(ctx, newBranchName); err != nil {
 		return err
 	}
+	if err := branch_control.CheckProtection(ctx, oldBranchName, branch_control.ProtectedOperation_Rename); err != nil {
+		return err
+	}
 	force := apr.Contains(cli.ForceFlag)
 
 	if !force {
@@ -150,6 +153,11 @@ func renameBranch(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParseRe
 		// destination branch doesn't exist. An unauthorized user could simply rerun the command without the force flag.
 		return err
 	}
+	if force {
+		if err := branch_control.CheckProtection(ctx, newBranchName, branch_control.ProtectedOperation_HistoryRewrite); err != nil {
+			return err
+		}
+	}
 
 	headRef, err := dbData.Rsr.CWBHeadRef()
 	if err != nil {
@@ -223,6 +231,9 @@ func deleteBranches(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgParse
 		if err = branch_control.CanDeleteBranch(ctx, branchName); err != nil {
 			return err
 		}
+		if err = branch_control.CheckProtection(ctx, branchName, branch_control.ProtectedOperation_Delete); err != nil {
+			return err
+		}
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
@@ -405,6 +416,13 @@ func createNewBranch(ctx *sql.Context, dbData env.DbData, apr *argparser.ArgPars
 	if err != nil {
 		return err
 	}
+	// Forcibly creating a branch will overwrite an existing branch of the same name
+	if apr.Contains(cli.ForceFlag) {
+		err = branch_control.CheckProtection(ctx, branchName, branch_control.ProtectedOperation_HistoryRewrite)
+		if err != nil {
+			return err
+		}
+	}
 
 	err = actions.CreateBranchWithStartPt(ctx, dbData, branchName, startPt, apr.Contains(cli.ForceFlag), rsc)
 	if err != nil {
@@ -451,6 +469,9 @@ func copyABranch(ctx *sql.Context, dbData env.DbData, srcBr string, destBr strin
 		if err := branch_control.CanDeleteBranch(ctx, destBr); err != nil {
 			return err
 		}
+		if err := branch_control.CheckProtection(ctx, destBr, branch_control.ProtectedOperation_HistoryRewrite); err != nil {
+			return err
+		}
 	}
 	err := actions.CopyBranchOnDB(ctx, dbData.Ddb, srcBr, destBr, force, rsc)
 	if err != nil {
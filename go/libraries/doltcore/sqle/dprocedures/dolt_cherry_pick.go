@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/cherry_pick"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 )
 
 var ErrEmptyCherryPick = errors.New("cannot cherry-pick empty string")
@@ -96,6 +97,14 @@ func doDoltCherryPick(ctx *sql.Context, args []string) (string, int, int, int, e
 		return "", 0, 0, 0, ErrEmptyCherryPick
 	}
 
+	headRef, err := dsess.DSessFromSess(ctx.Session).CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return "", 0, 0, 0, err
+	}
+	if err = branch_control.CheckProtection(ctx, headRef.GetPath(), branch_control.ProtectedOperation_DirectCommit); err != nil {
+		return "", 0, 0, 0, err
+	}
+
 	cherryPickOptions := cherry_pick.NewCherryPickOptions()
 
 	// If --allow-empty is specified, then empty commits are allowed to be cherry-picked
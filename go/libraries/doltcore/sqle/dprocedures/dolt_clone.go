@@ -16,6 +16,7 @@ package dprocedures
 
 import (
 	"path"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -56,6 +57,11 @@ func doltClone(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	if user, hasUser := apr.GetValue(cli.UserFlag); hasUser {
 		remoteParms[dbfactory.GRPCUsernameAuthParam] = user
 	}
+	filter, err := cli.ParseTableFilter(apr)
+	if err != nil {
+		return nil, err
+	}
+	env.AddTableFilterParams(remoteParms, filter)
 
 	depth, ok := apr.GetInt(cli.DepthFlag)
 	if !ok {
@@ -89,7 +95,7 @@ func getDirectoryAndUrlString(apr *argparser.ArgParseResults) (string, string, e
 	if apr.NArg() == 2 {
 		dir = apr.Arg(1)
 	} else {
-		dir = path.Base(urlStr)
+		dir = strings.TrimSuffix(path.Base(urlStr), dbfactory.BundleExt)
 		if dir == "." {
 			dir = path.Dir(urlStr)
 		} else if dir == "/" {
@@ -63,6 +63,12 @@ func doltCommitHashOut(ctx *sql.Context, outHash *string, args ...string) (sql.R
 // of the new commit (or the empty string if the commit was skipped), a boolean that indicates if creating the commit
 // was skipped (e.g. due to --skip-empty), and an error describing any error encountered.
 func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
+	return doDoltCommitWithMerge(ctx, args, false)
+}
+
+// doDoltCommitWithMerge is the same as doDoltCommit, except that |fromMerge| indicates that the commit concludes a
+// call to dolt_merge, which is allowed on branches that are protected by a rule requiring merges.
+func doDoltCommitWithMerge(ctx *sql.Context, args []string, fromMerge bool) (string, bool, error) {
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
 		return "", false, err
 	}
@@ -118,6 +124,9 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 	}
 
 	amend := apr.Contains(cli.AmendFlag)
+	if err = dSess.CheckCommitProtection(ctx, dbName, amend, fromMerge); err != nil {
+		return "", false, err
+	}
 
 	msg, msgOk := apr.GetValue(cli.MessageArg)
 	if !msgOk {
@@ -183,6 +192,11 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 		return "", false, errors.New("nothing to commit")
 	}
 
+	err = runTestsOnRoot(ctx, dbName, pendingCommit.Roots.Staged, dsess.DoltPreCommitTestGroups, "commit")
+	if err != nil {
+		return "", false, err
+	}
+
 	if apr.Contains(cli.SignFlag) || shouldSign {
 		keyId := apr.GetValueOrDefault(cli.SignFlag, "")
 
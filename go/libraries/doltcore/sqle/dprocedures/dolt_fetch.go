@@ -79,6 +79,13 @@ func doDoltFetch(ctx *sql.Context, args []string) (int, error) {
 			dbfactory.GRPCUsernameAuthParam: user,
 		})
 	}
+	if apr.Contains(cli.TablesFlag) || apr.Contains(cli.ExcludeTablesFlag) {
+		filter, err := cli.ParseTableFilter(apr)
+		if err != nil {
+			return cmdFailure, err
+		}
+		remote = remote.WithTableFilter(filter)
+	}
 
 	srcDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote, false)
 	if err != nil {
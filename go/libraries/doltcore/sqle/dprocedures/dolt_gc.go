@@ -15,18 +15,20 @@
 package dprocedures
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
-	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 const (
@@ -54,8 +56,6 @@ func doltGC(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	return rowToIter(int64(res)), nil
 }
 
-var ErrServerPerformedGC = errors.New("this connection was established when this server performed an online garbage collection. this connection can no longer be used. please reconnect.")
-
 func doDoltGC(ctx *sql.Context, args []string) (int, error) {
 	dbName := ctx.GetCurrentDatabase()
 
@@ -75,96 +75,133 @@ func doDoltGC(ctx *sql.Context, args []string) (int, error) {
 		return cmdFailure, InvalidArgErr
 	}
 
+	err = RunGC(ctx, dbName, apr.Contains(cli.ShallowFlag), dsess.GCTriggerUser)
+	if err != nil {
+		return cmdFailure, err
+	}
+
+	return cmdSuccess, nil
+}
+
+// RunGC garbage collects the database named |dbName| in the session of |ctx|, as dolt_gc() does, without checking the
+// permissions of the session. The GC is recorded in the status of the database with |trigger|.
+func RunGC(ctx *sql.Context, dbName string, shallow bool, trigger string) (err error) {
 	dSess := dsess.DSessFromSess(ctx.Session)
 	ddb, ok := dSess.GetDoltDB(ctx, dbName)
 	if !ok {
-		return cmdFailure, fmt.Errorf("Could not load database %s", dbName)
+		return fmt.Errorf("Could not load database %s", dbName)
 	}
 
-	if apr.Contains(cli.ShallowFlag) {
-		err = ddb.ShallowGC(ctx)
-		if err != nil {
-			return cmdFailure, err
-		}
-	} else {
-		// Currently, if this server is involved in cluster
-		// replication, a full GC is only safe to run on the primary.
-		// We assert that we are the primary here before we begin, and
-		// we assert again that we are the primary at the same epoch as
-		// we establish the safepoint.
-
-		origepoch := -1
-		if _, role, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleVariable); ok {
-			// TODO: magic constant...
-			if role.(string) != "primary" {
-				return cmdFailure, fmt.Errorf("cannot run a full dolt_gc() while cluster replication is enabled and role is %s; must be the primary", role.(string))
-			}
-			_, epoch, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleEpochVariable)
-			if !ok {
-				return cmdFailure, fmt.Errorf("internal error: cannot run a full dolt_gc(); cluster replication is enabled but could not read %s", dsess.DoltClusterRoleEpochVariable)
+	controller := dSess.GCSafepointController()
+	setPhase := func(string) {}
+	if controller != nil {
+		statuses := controller.Statuses()
+		statuses.Begin(dbName, shallow, trigger)
+		defer func() {
+			if errors.Is(err, chunks.ErrNothingToCollect) {
+				statuses.End(dbName, nil)
+			} else {
+				statuses.End(dbName, err)
 			}
-			origepoch = epoch.(int)
+		}()
+		setPhase = func(phase string) {
+			statuses.SetPhase(dbName, phase)
 		}
+	}
 
-		// TODO: If we got a callback at the beginning and an
-		// (allowed-to-block) callback at the end, we could more
-		// gracefully tear things down.
-		err = ddb.GC(ctx, func() error {
-			if origepoch != -1 {
-				// Here we need to sanity check role and epoch.
-				if _, role, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleVariable); ok {
-					if role.(string) != "primary" {
-						return fmt.Errorf("dolt_gc failed: when we began we were a primary in a cluster, but now our role is %s", role.(string))
-					}
-					_, epoch, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleEpochVariable)
-					if !ok {
-						return fmt.Errorf("dolt_gc failed: when we began we were a primary in a cluster, but we can no longer read the cluster role epoch.")
-					}
-					if origepoch != epoch.(int) {
-						return fmt.Errorf("dolt_gc failed: when we began we were primary in the cluster at epoch %d, but now we are at epoch %d. for gc to safely finalize, our role and epoch must not change throughout the gc.", origepoch, epoch.(int))
-					}
-				} else {
-					return fmt.Errorf("dolt_gc failed: when we began we were a primary in a cluster, but we can no longer read the cluster role.")
-				}
-			}
+	if shallow {
+		setPhase(dsess.GCPhaseCollecting)
+		return ddb.ShallowGC(ctx)
+	}
 
-			killed := make(map[uint32]struct{})
-			processes := ctx.ProcessList.Processes()
-			for _, p := range processes {
-				if p.Connection != ctx.Session.ID() {
-					// Kill any inflight query.
-					ctx.ProcessList.Kill(p.Connection)
-					// Tear down the connection itself.
-					ctx.KillConnection(p.Connection)
-					killed[p.Connection] = struct{}{}
-				}
-			}
+	// Currently, if this server is involved in cluster
+	// replication, a full GC is only safe to run on the primary.
+	// We assert that we are the primary here before we begin, and
+	// we assert again that we are the primary at the same epoch as
+	// we establish the safepoint.
+
+	origepoch := -1
+	if _, role, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleVariable); ok {
+		// TODO: magic constant...
+		if role.(string) != "primary" {
+			return fmt.Errorf("cannot run a full dolt_gc() while cluster replication is enabled and role is %s; must be the primary", role.(string))
+		}
+		_, epoch, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleEpochVariable)
+		if !ok {
+			return fmt.Errorf("internal error: cannot run a full dolt_gc(); cluster replication is enabled but could not read %s", dsess.DoltClusterRoleEpochVariable)
+		}
+		origepoch = epoch.(int)
+	}
 
-			// Look in processes until the connections are actually gone.
-			params := backoff.NewExponentialBackOff()
-			params.InitialInterval = 1 * time.Millisecond
-			params.MaxInterval = 25 * time.Millisecond
-			params.MaxElapsedTime = 3 * time.Second
-			err := backoff.Retry(func() error {
-				processes := ctx.ProcessList.Processes()
-				for _, p := range processes {
-					if _, ok := killed[p.Connection]; ok {
-						return errors.New("unable to establish safepoint.")
-					}
-				}
-				return nil
-			}, params)
-			if err != nil {
-				return err
-			}
-			ctx.Session.SetTransaction(nil)
-			dsess.DSessFromSess(ctx.Session).SetValidateErr(ErrServerPerformedGC)
-			return nil
-		})
+	if controller != nil {
+		endGC, err := controller.BeginGC(ctx, dSess)
 		if err != nil {
-			return cmdFailure, err
+			return err
 		}
+		defer endGC()
 	}
 
-	return cmdSuccess, nil
+	setPhase(dsess.GCPhaseCollecting)
+	return ddb.GC(ctx, &sessionAwareSafepoint{
+		ctx:        ctx,
+		sess:       dSess,
+		controller: controller,
+		dbName:     dbName,
+		origEpoch:  origepoch,
+		setPhase:   setPhase,
+	})
+}
+
+// sessionAwareSafepoint is the types.GCSafepointController for a GC run by dolt_gc(). It keeps the chunks referenced
+// by the sessions of the server, so that they keep working after the GC. The sessions are held at the safepoint until
+// the GC is over.
+type sessionAwareSafepoint struct {
+	ctx        *sql.Context
+	sess       *dsess.DoltSession
+	controller *dsess.GCSafepointController
+	dbName     string
+	origEpoch  int
+	setPhase   func(string)
+}
+
+var _ types.GCSafepointController = (*sessionAwareSafepoint)(nil)
+
+func (sp *sessionAwareSafepoint) EstablishPreFinalizeSafepoint(ctx context.Context, keep func(hash.Hash) bool) error {
+	keepErr := func(h hash.Hash) error {
+		if keep(h) {
+			return errors.New("dolt_gc failed: a session root was kept after the gc was finalized")
+		}
+		return nil
+	}
+	visit := func(sess *dsess.DoltSession) error {
+		return sess.VisitGCRoots(ctx, sp.dbName, keepErr)
+	}
+	sp.setPhase(dsess.GCPhaseSafepoint)
+	if sp.controller == nil {
+		return visit(sp.sess)
+	}
+	return sp.controller.EstablishSafepoint(sp.ctx, sp.sess, visit)
+}
+
+func (sp *sessionAwareSafepoint) EstablishPostFinalizeSafepoint(ctx context.Context) error {
+	sp.setPhase(dsess.GCPhaseFinalizing)
+	if sp.origEpoch == -1 {
+		return nil
+	}
+	// Here we need to sanity check role and epoch.
+	if _, role, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleVariable); ok {
+		if role.(string) != "primary" {
+			return fmt.Errorf("dolt_gc failed: when we began we were a primary in a cluster, but now our role is %s", role.(string))
+		}
+		_, epoch, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleEpochVariable)
+		if !ok {
+			return fmt.Errorf("dolt_gc failed: when we began we were a primary in a cluster, but we can no longer read the cluster role epoch.")
+		}
+		if sp.origEpoch != epoch.(int) {
+			return fmt.Errorf("dolt_gc failed: when we began we were primary in the cluster at epoch %d, but now we are at epoch %d. for gc to safely finalize, our role and epoch must not change throughout the gc.", sp.origEpoch, epoch.(int))
+		}
+	} else {
+		return fmt.Errorf("dolt_gc failed: when we began we were a primary in a cluster, but we can no longer read the cluster role.")
+	}
+	return nil
 }
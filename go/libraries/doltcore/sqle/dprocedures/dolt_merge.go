@@ -226,6 +226,15 @@ func performMerge(
 	}
 
 	if canFF {
+		mergeRoot, err := spec.MergeC.GetRootValue(ctx)
+		if err != nil {
+			return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+		err = runTestsOnRoot(ctx, dbName, mergeRoot, dsess.DoltPreMergeTestGroups, "merge")
+		if err != nil {
+			return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+		}
+
 		if spec.NoFF {
 			var commit *doltdb.Commit
 			ws, commit, err = executeNoFFMerge(ctx, sess, spec, msg, dbName, ws, noCommit)
@@ -286,6 +295,11 @@ func performMerge(
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", err
 	}
 
+	err = runTestsOnRoot(ctx, dbName, ws.StagedRoot(), dsess.DoltPreMergeTestGroups, "merge")
+	if err != nil {
+		return ws, "", noConflictsOrViolations, threeWayMerge, "", err
+	}
+
 	err = sess.SetWorkingSet(ctx, dbName, ws)
 	if err != nil {
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", err
@@ -298,7 +312,7 @@ func performMerge(
 		if spec.Force {
 			args = append(args, "--force")
 		}
-		commit, _, err = doDoltCommit(ctx, args)
+		commit, _, err = doDoltCommitWithMerge(ctx, args, true)
 		if err != nil {
 			return ws, commit, noConflictsOrViolations, threeWayMerge, "", err
 		}
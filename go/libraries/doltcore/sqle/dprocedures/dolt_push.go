@@ -82,6 +82,13 @@ func doDoltPush(ctx *sql.Context, args []string) (int, string, error) {
 	if err != nil {
 		return cmdFailure, "", err
 	}
+	for _, target := range targets {
+		if target.Mode.Force {
+			if err = branch_control.CheckProtection(ctx, target.DestRef.GetPath(), branch_control.ProtectedOperation_ForcePush); err != nil {
+				return cmdFailure, "", err
+			}
+		}
+	}
 
 	if user, hasUser := apr.GetValue(cli.UserFlag); hasUser {
 		rmt := (*remote).WithParams(map[string]string{
@@ -24,6 +24,7 @@ import (
 	goerrors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/cherry_pick"
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -53,7 +54,10 @@ var RebaseActionEnumType = types.MustCreateEnumType([]string{
 	rebase.RebaseActionPick,
 	rebase.RebaseActionReword,
 	rebase.RebaseActionSquash,
-	rebase.RebaseActionFixup}, sql.Collation_Default)
+	rebase.RebaseActionFixup,
+	rebase.RebaseActionEdit,
+	rebase.RebaseActionBreak,
+	rebase.RebaseActionExec}, sql.Collation_Default)
 
 var DoltRebaseSystemTableSchema = []*sql.Column{
 	{
@@ -117,6 +121,20 @@ var ErrRebaseConflictWithAbortError = goerrors.NewKind(
 	"merge conflict detected while rebasing commit %s. " +
 		"attempted to abort rebase operation, but encountered error: %w")
 
+// ErrRebaseExecFailed is used when the SQL statement for an exec step in the rebase plan returns an error.
+var ErrRebaseExecFailed = goerrors.NewKind(
+	"exec statement failed: %s; the rebase has been automatically aborted")
+
+// ErrRebaseUncommittedChangesAfterStop is used when a rebase is continued after stopping at a break or exec
+// step, but there are uncommitted changes in the working set.
+var ErrRebaseUncommittedChangesAfterStop = goerrors.NewKind(
+	"cannot continue a rebase with uncommitted changes after a break or exec step; " +
+		"commit the changes with dolt_commit() and then continue the rebase by calling dolt_rebase('--continue')")
+
+// RebaseStoppedMessage is used when a rebase stops at an edit or break step in the rebase plan, or at an exec
+// step that left uncommitted changes, so that the caller can make changes before continuing the rebase.
+var RebaseStoppedMessage = "Stopped at "
+
 // SuccessfulRebaseMessage is used when a rebase finishes successfully. The branch that was rebased should be appended
 // to the end of the message.
 var SuccessfulRebaseMessage = "Successfully rebased and updated refs/heads/"
@@ -151,11 +169,23 @@ func doDoltRebase(ctx *sql.Context, args []string) (int, string, error) {
 		}
 
 	case apr.Contains(cli.ContinueFlag):
-		rebaseBranch, err := continueRebase(ctx)
+		conflictStrategy, err := processConflictStrategyParam(apr)
+		if err != nil {
+			return 1, "", err
+		}
+
+		// A strategy given when continuing replaces the one the rebase was started with
+		if conflictStrategy != doltdb.RebaseConflictStrategyNone {
+			if err = recordConflictStrategy(ctx, conflictStrategy); err != nil {
+				return 1, "", err
+			}
+		}
+
+		message, err := continueRebase(ctx)
 		if err != nil {
 			return 1, "", err
 		} else {
-			return 0, SuccessfulRebaseMessage + rebaseBranch, nil
+			return 0, message, nil
 		}
 
 	default:
@@ -164,6 +194,11 @@ func doDoltRebase(ctx *sql.Context, args []string) (int, string, error) {
 			return 1, "", err
 		}
 
+		conflictStrategy, err := processConflictStrategyParam(apr)
+		if err != nil {
+			return 1, "", err
+		}
+
 		// The default, in rebase, for handling commits that start off empty is to keep them
 		// TODO: Add support for --keep-empty and --no-keep-empty flags
 		emptyCommitHandling := doltdb.EmptyCommitHandling(doltdb.KeepEmptyCommit)
@@ -173,14 +208,23 @@ func doDoltRebase(ctx *sql.Context, args []string) (int, string, error) {
 		} else if apr.NArg() > 1 {
 			return 1, "", fmt.Errorf("too many args")
 		}
-		if !apr.Contains(cli.InteractiveFlag) {
-			return 1, "", fmt.Errorf("non-interactive rebases not currently supported")
+		if apr.Contains(cli.InteractiveFlag) && conflictStrategy != doltdb.RebaseConflictStrategyNone {
+			return 1, "", fmt.Errorf("the --strategy option can only be used with a non-interactive rebase or with --continue")
 		}
-		err = startRebase(ctx, apr.Arg(0), commitBecomesEmptyHandling, emptyCommitHandling)
+		err = startRebase(ctx, apr.Arg(0), commitBecomesEmptyHandling, emptyCommitHandling, conflictStrategy)
 		if err != nil {
 			return 1, "", err
 		}
 
+		// A non-interactive rebase executes the default rebase plan right away
+		if !apr.Contains(cli.InteractiveFlag) {
+			message, err := continueRebase(ctx)
+			if err != nil {
+				return 1, "", err
+			}
+			return 0, message, nil
+		}
+
 		currentBranch, err := currentBranch(ctx)
 		if err != nil {
 			return 1, "", err
@@ -215,10 +259,29 @@ func processCommitBecomesEmptyParams(apr *argparser.ArgParseResults) (doltdb.Emp
 	}
 }
 
+// processConflictStrategyParam examines the parsed arguments in |apr| for the "strategy" arg and returns the
+// strategy to use for data conflicts. If an invalid argument value is encountered, an error is returned.
+func processConflictStrategyParam(apr *argparser.ArgParseResults) (doltdb.RebaseConflictStrategy, error) {
+	strategyParam, isStrategySpecified := apr.GetValue(cli.StrategyParam)
+	if !isStrategySpecified {
+		return doltdb.RebaseConflictStrategyNone, nil
+	}
+
+	if strings.EqualFold(strategyParam, "ours") {
+		return doltdb.RebaseConflictStrategyOurs, nil
+	} else if strings.EqualFold(strategyParam, "theirs") {
+		return doltdb.RebaseConflictStrategyTheirs, nil
+	} else {
+		return doltdb.RebaseConflictStrategyNone, fmt.Errorf("unsupported option for the strategy flag (%s); "+
+			"only 'ours' or 'theirs' are allowed", strategyParam)
+	}
+}
+
 // startRebase starts a new interactive rebase operation. |upstreamPoint| specifies the commit where the new rebased
 // commits will be based off of, |commitBecomesEmptyHandling| specifies how to  handle commits that are not empty, but
-// do not produce any changes when applied, and |emptyCommitHandling| specifies how to handle empty commits.
-func startRebase(ctx *sql.Context, upstreamPoint string, commitBecomesEmptyHandling doltdb.EmptyCommitHandling, emptyCommitHandling doltdb.EmptyCommitHandling) error {
+// do not produce any changes when applied, |emptyCommitHandling| specifies how to handle empty commits, and
+// |conflictStrategy| specifies how to resolve data conflicts.
+func startRebase(ctx *sql.Context, upstreamPoint string, commitBecomesEmptyHandling doltdb.EmptyCommitHandling, emptyCommitHandling doltdb.EmptyCommitHandling, conflictStrategy doltdb.RebaseConflictStrategy) error {
 	if upstreamPoint == "" {
 		return fmt.Errorf("no upstream branch specified")
 	}
@@ -233,6 +296,9 @@ func startRebase(ctx *sql.Context, upstreamPoint string, commitBecomesEmptyHandl
 	if err != nil {
 		return err
 	}
+	if err = branch_control.CheckProtection(ctx, headRef.GetPath(), branch_control.ProtectedOperation_HistoryRewrite); err != nil {
+		return err
+	}
 	dbData, ok := doltSession.GetDbData(ctx, ctx.GetCurrentDatabase())
 	if !ok {
 		return fmt.Errorf("unable to find database %s", ctx.GetCurrentDatabase())
@@ -306,7 +372,7 @@ func startRebase(ctx *sql.Context, upstreamPoint string, commitBecomesEmptyHandl
 	}
 
 	newWorkingSet, err := workingSet.StartRebase(ctx, upstreamCommit, rebaseBranch, branchRoots.Working,
-		commitBecomesEmptyHandling, emptyCommitHandling)
+		commitBecomesEmptyHandling, emptyCommitHandling, conflictStrategy)
 	if err != nil {
 		return err
 	}
@@ -546,6 +612,23 @@ func workingSetStatus(ctx *sql.Context) (stagedChanges, unstagedChanges bool, er
 	return len(stagedTables) > 0, len(unstagedTables) > 0, nil
 }
 
+// recordConflictStrategy records |conflictStrategy| in the active rebase's state, so that it is used to resolve data
+// conflicts for the rest of the rebase plan.
+func recordConflictStrategy(ctx *sql.Context, conflictStrategy doltdb.RebaseConflictStrategy) error {
+	if err := validateActiveRebase(ctx); err != nil {
+		return err
+	}
+
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	workingSet, err := doltSession.WorkingSet(ctx, ctx.GetCurrentDatabase())
+	if err != nil {
+		return err
+	}
+
+	newWorkingSet := workingSet.WithRebaseState(workingSet.RebaseState().WithConflictStrategy(conflictStrategy))
+	return doltSession.SetWorkingSet(ctx, ctx.GetCurrentDatabase(), newWorkingSet)
+}
+
 // recordCurrentStep updates working set metadata to record the current rebase plan step number as well
 // as the rebase started flag indicating that execution of the rebase plan has been started. This
 // information is all stored in the RebaseState of the WorkingSet.
@@ -583,6 +666,11 @@ func recordCurrentStep(ctx *sql.Context, step rebase.RebasePlanStep) error {
 	return nil
 }
 
+// continueRebase executes the steps in the rebase plan that haven't been executed yet. Data conflicts are
+// resolved automatically according to the conflict strategy recorded in the rebase state. If all steps
+// complete, the rebased branch is updated and a message describing the successful rebase is returned. If
+// the rebase stops at an edit or break step, a message describing how to continue the rebase is returned
+// instead.
 func continueRebase(ctx *sql.Context) (string, error) {
 	// Validate that we are in an interactive rebase
 	if err := validateActiveRebase(ctx); err != nil {
@@ -638,18 +726,39 @@ func continueRebase(ctx *sql.Context) (string, error) {
 			continue
 		}
 
+		// Break and exec steps don't have a commit to record manual changes in, so after stopping at one of
+		// them, any changes need to be committed by the caller before the rebase can be continued.
+		if rebasingStarted && rebaseStepOrder == lastAttemptedStep && !step.AppliesCommit() &&
+			(hasStagedChanges || hasUnstagedChanges) {
+			return "", ErrRebaseUncommittedChangesAfterStop.New()
+		}
+
 		// If the rebase is continued, but not all working set changes are staged, then tell the user
 		// they need to explicitly stage the tables before the rebase can be continued.
 		if hasUnstagedChanges {
 			return "", ErrRebaseUnstagedChanges.New()
 		}
 
+		// If the rebase stopped cleanly at an edit step, any staged changes are amended into the commit
+		// that was picked for the step, the same as if the caller had amended it with dolt_commit('--amend').
+		if rebasingStarted && rebaseStepOrder == lastAttemptedStep && hasStagedChanges &&
+			step.Action == rebase.RebaseActionEdit && !workingSet.MergeActive() {
+			if err = amendCommitForEditStep(ctx); err != nil {
+				return "", err
+			}
+			continue
+		}
+
 		// If we've already executed this step, but the working set has staged changes,
 		// then we need to make the commit for the manual changes made for this step.
 		if rebasingStarted && rebaseStepOrder == lastAttemptedStep && hasStagedChanges {
 			if err = commitManuallyStagedChangesForStep(ctx, step); err != nil {
 				return "", err
 			}
+			// An edit step still stops once its commit has been made, so that it can be amended
+			if step.Action == rebase.RebaseActionEdit {
+				return rebaseStoppedMessage(&step), nil
+			}
 			continue
 		}
 
@@ -668,10 +777,26 @@ func continueRebase(ctx *sql.Context) (string, error) {
 
 			err = processRebasePlanStep(ctx, &step,
 				workingSet.RebaseState().CommitBecomesEmptyHandling(),
-				workingSet.RebaseState().EmptyCommitHandling())
+				workingSet.RebaseState().EmptyCommitHandling(), workingSet.RebaseState().ConflictStrategy())
 			if err != nil {
 				return "", err
 			}
+
+			// Edit and break steps stop the rebase, so the caller can make changes before continuing
+			if step.Action == rebase.RebaseActionEdit || step.Action == rebase.RebaseActionBreak {
+				return rebaseStoppedMessage(&step), nil
+			}
+
+			// An exec step that leaves uncommitted changes stops the rebase, so the caller can commit them
+			if step.Action == rebase.RebaseActionExec {
+				hasStagedChanges, hasUnstagedChanges, err := workingSetStatus(ctx)
+				if err != nil {
+					return "", err
+				}
+				if hasStagedChanges || hasUnstagedChanges {
+					return rebaseStoppedMessage(&step), nil
+				}
+			}
 		}
 
 		// Ensure a transaction has been started, so that the session is in sync with the latest changes
@@ -733,9 +858,64 @@ func continueRebase(ctx *sql.Context) (string, error) {
 	if !ok {
 		return "", fmt.Errorf("unable to lookup dbdata")
 	}
-	return rebaseBranch, actions.DeleteBranch(ctx, dbData, rebaseWorkingBranch, actions.DeleteOptions{
+	err = actions.DeleteBranch(ctx, dbData, rebaseWorkingBranch, actions.DeleteOptions{
 		Force: true,
 	}, doltSession.Provider(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return SuccessfulRebaseMessage + rebaseBranch, nil
+}
+
+// rebaseStoppedMessage returns the message describing why the rebase stopped at the edit or break step |step|
+// and how to continue the rebase.
+func rebaseStoppedMessage(step *rebase.RebasePlanStep) string {
+	switch step.Action {
+	case rebase.RebaseActionEdit:
+		return fmt.Sprintf("%s%s (%s); amend the commit with dolt_commit('--amend') if needed, then "+
+			"continue rebasing by calling dolt_rebase('--continue')", RebaseStoppedMessage, step.CommitHash, step.CommitMsg)
+	case rebase.RebaseActionExec:
+		return fmt.Sprintf("%srebase step %s (exec %s); the statement left uncommitted changes, commit them with "+
+			"dolt_commit() and then continue rebasing by calling dolt_rebase('--continue')",
+			RebaseStoppedMessage, step.RebaseOrder.String(), step.CommitMsg)
+	}
+	return fmt.Sprintf("%srebase step %s (break); continue rebasing by calling dolt_rebase('--continue')",
+		RebaseStoppedMessage, step.RebaseOrder.String())
+}
+
+// amendCommitForEditStep amends the commit picked for an edit step with the changes the caller staged after the
+// rebase stopped at the step. The commit keeps its message.
+func amendCommitForEditStep(ctx *sql.Context) error {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	roots, ok := doltSession.GetRoots(ctx, ctx.GetCurrentDatabase())
+	if !ok {
+		return fmt.Errorf("unable to get roots for current session")
+	}
+
+	// Leaving the message empty makes the amend commit codepath use the message of the commit being amended
+	commitProps := actions.CommitStagedProps{
+		Date:  ctx.QueryTime(),
+		Name:  ctx.Client().User,
+		Email: fmt.Sprintf("%s@%s", ctx.Client().User, ctx.Client().Address),
+		Amend: true,
+	}
+	pendingCommit, err := doltSession.NewPendingCommit(ctx, ctx.GetCurrentDatabase(), roots, commitProps)
+	if err != nil {
+		return err
+	}
+	if pendingCommit == nil {
+		return nil
+	}
+
+	// Ensure a SQL transaction is set in the session
+	if doltSession.GetTransaction() == nil {
+		if _, err = doltSession.StartTransaction(ctx, sql.ReadWrite); err != nil {
+			return err
+		}
+	}
+	_, err = doltSession.DoltCommit(ctx, ctx.GetCurrentDatabase(), doltSession.GetTransaction(), pendingCommit)
+	return err
 }
 
 // commitManuallyStagedChangesForStep handles committing staged changes after a conflict has been manually
@@ -771,6 +951,14 @@ func commitManuallyStagedChangesForStep(ctx *sql.Context, step rebase.RebasePlan
 	if err != nil {
 		return err
 	}
+	if pendingCommit == nil {
+		// Nothing is staged after automatically resolving conflicts, so the commit became empty and is dropped
+		workingSet, err = doltSession.WorkingSet(ctx, ctx.GetCurrentDatabase())
+		if err != nil {
+			return err
+		}
+		return doltSession.SetWorkingSet(ctx, ctx.GetCurrentDatabase(), workingSet.ClearMerge())
+	}
 
 	// Ensure a SQL transaction is set in the session
 	if doltSession.GetTransaction() == nil {
@@ -783,7 +971,8 @@ func commitManuallyStagedChangesForStep(ctx *sql.Context, step rebase.RebasePlan
 }
 
 func processRebasePlanStep(ctx *sql.Context, planStep *rebase.RebasePlanStep,
-	commitBecomesEmptyHandling doltdb.EmptyCommitHandling, emptyCommitHandling doltdb.EmptyCommitHandling) error {
+	commitBecomesEmptyHandling doltdb.EmptyCommitHandling, emptyCommitHandling doltdb.EmptyCommitHandling,
+	conflictStrategy doltdb.RebaseConflictStrategy) error {
 	// Make sure we have a transaction opened for the session
 	// NOTE: After our first call to cherry-pick, the tx is committed, so a new tx needs to be started
 	//       as we process additional rebase actions.
@@ -795,9 +984,12 @@ func processRebasePlanStep(ctx *sql.Context, planStep *rebase.RebasePlanStep,
 		}
 	}
 
-	// If the action is "drop", then we don't need to do anything
-	if planStep.Action == rebase.RebaseActionDrop {
+	switch planStep.Action {
+	case rebase.RebaseActionDrop, rebase.RebaseActionBreak:
+		// If the action is "drop" or "break", then we don't need to do anything
 		return nil
+	case rebase.RebaseActionExec:
+		return execRebaseStatement(ctx, planStep.CommitMsg)
 	}
 
 	options, err := createCherryPickOptionsForRebaseStep(ctx, planStep, commitBecomesEmptyHandling, emptyCommitHandling)
@@ -805,7 +997,7 @@ func processRebasePlanStep(ctx *sql.Context, planStep *rebase.RebasePlanStep,
 		return err
 	}
 
-	return handleRebaseCherryPick(ctx, planStep, *options)
+	return handleRebaseCherryPick(ctx, planStep, *options, conflictStrategy)
 }
 
 func createCherryPickOptionsForRebaseStep(ctx *sql.Context, planStep *rebase.RebasePlanStep, commitBecomesEmptyHandling doltdb.EmptyCommitHandling, emptyCommitHandling doltdb.EmptyCommitHandling) (*cherry_pick.CherryPickOptions, error) {
@@ -816,9 +1008,9 @@ func createCherryPickOptionsForRebaseStep(ctx *sql.Context, planStep *rebase.Reb
 	options.EmptyCommitHandling = emptyCommitHandling
 
 	switch planStep.Action {
-	case rebase.RebaseActionDrop, rebase.RebaseActionPick:
-		// Nothing to do – the drop action doesn't result in a cherry pick and the pick action
-		// doesn't require any special options (i.e. no amend, no custom commit message).
+	case rebase.RebaseActionDrop, rebase.RebaseActionPick, rebase.RebaseActionEdit:
+		// Nothing to do – the drop action doesn't result in a cherry pick and the pick and edit
+		// actions don't require any special options (i.e. no amend, no custom commit message).
 
 	case rebase.RebaseActionReword:
 		options.CommitMessage = planStep.CommitMsg
@@ -841,11 +1033,29 @@ func createCherryPickOptionsForRebaseStep(ctx *sql.Context, planStep *rebase.Reb
 	return &options, nil
 }
 
+// execRebaseStatement runs the SQL |statement| from an exec step in the rebase plan on the rebase working branch,
+// in the caller's session and with the caller's privileges. If the statement returns an error, the rebase is aborted.
+// Any changes the statement leaves in the working set are kept, and the caller stops the rebase so that they can be
+// committed before continuing.
+func execRebaseStatement(ctx *sql.Context, statement string) error {
+	_, _, err := runUserStatement(ctx, statement)
+	if err != nil {
+		if abortErr := abortRebase(ctx); abortErr != nil {
+			return fmt.Errorf("%s: unable to cleanly abort rebase: %s", err.Error(), abortErr.Error())
+		}
+		return ErrRebaseExecFailed.New(err.Error())
+	}
+
+	return nil
+}
+
 // handleRebaseCherryPick runs a cherry-pick for the specified |commitHash|, using the specified
-// cherry-pick |options| and checks the results for any errors or merge conflicts. If a data conflict
+// cherry-pick |options| and checks the results for any errors or merge conflicts. Data conflicts are
+// resolved automatically if |conflictStrategy| specifies a strategy; otherwise, if a data conflict
 // is detected, then the ErrRebaseDataConflict error is returned. If a schema conflict is detected,
 // then the ErrRebaseSchemaConflict error is returned.
-func handleRebaseCherryPick(ctx *sql.Context, planStep *rebase.RebasePlanStep, options cherry_pick.CherryPickOptions) error {
+func handleRebaseCherryPick(ctx *sql.Context, planStep *rebase.RebasePlanStep, options cherry_pick.CherryPickOptions,
+	conflictStrategy doltdb.RebaseConflictStrategy) error {
 	_, mergeResult, err := cherry_pick.CherryPick(ctx, planStep.CommitHash, options)
 
 	// TODO: rebase doesn't support schema conflict resolution yet. Ideally, when a schema conflict
@@ -861,6 +1071,13 @@ func handleRebaseCherryPick(ctx *sql.Context, planStep *rebase.RebasePlanStep, o
 
 	doltSession := dsess.DSessFromSess(ctx.Session)
 	if mergeResult != nil && mergeResult.HasMergeArtifacts() {
+		if conflictStrategy != doltdb.RebaseConflictStrategyNone {
+			resolved, err := resolveRebaseDataConflicts(ctx, planStep, conflictStrategy)
+			if err != nil || resolved {
+				return err
+			}
+		}
+
 		if err := validateConflictsCanBeResolved(ctx, planStep); err != nil {
 			return err
 		}
@@ -894,6 +1111,55 @@ func handleRebaseCherryPick(ctx *sql.Context, planStep *rebase.RebasePlanStep, o
 	return err
 }
 
+// resolveRebaseDataConflicts resolves the data conflicts from applying |planStep| using |conflictStrategy|, with the
+// same code that dolt_conflicts_resolve (and so the CLI's conflicts resolve command) uses, and then commits the result
+// for the step. Returns false, without making a commit, if there are merge artifacts, such as constraint violations,
+// that can't be resolved automatically.
+func resolveRebaseDataConflicts(ctx *sql.Context, planStep *rebase.RebasePlanStep, conflictStrategy doltdb.RebaseConflictStrategy) (bool, error) {
+	doltSession := dsess.DSessFromSess(ctx.Session)
+	dbName := ctx.GetCurrentDatabase()
+	workingSet, err := doltSession.WorkingSet(ctx, dbName)
+	if err != nil {
+		return false, err
+	}
+
+	tablesWithDataConflicts, err := doltdb.TablesWithDataConflicts(ctx, workingSet.WorkingRoot())
+	if err != nil {
+		return false, err
+	}
+	if len(tablesWithDataConflicts) == 0 {
+		return false, nil
+	}
+
+	resolveOurs := conflictStrategy == doltdb.RebaseConflictStrategyOurs
+	err = ResolveDataConflicts(ctx, doltSession, workingSet.WorkingRoot(), dbName, resolveOurs, tablesWithDataConflicts)
+	if err != nil {
+		return false, err
+	}
+
+	roots, ok := doltSession.GetRoots(ctx, dbName)
+	if !ok {
+		return false, fmt.Errorf("unable to get roots for database %s", dbName)
+	}
+	tablesWithViolations, err := doltdb.TablesWithConstraintViolations(ctx, roots.Working)
+	if err != nil {
+		return false, err
+	}
+	if len(tablesWithViolations) > 0 {
+		return false, nil
+	}
+
+	roots, err = actions.StageTables(ctx, roots, tablesWithDataConflicts, true)
+	if err != nil {
+		return false, err
+	}
+	if err = doltSession.SetRoots(ctx, dbName, roots); err != nil {
+		return false, err
+	}
+
+	return true, commitManuallyStagedChangesForStep(ctx, *planStep)
+}
+
 // squashCommitMessage looks up the commit at HEAD and the commit identified by |nextCommitHash| and squashes their two
 // commit messages together.
 func squashCommitMessage(ctx *sql.Context, nextCommitHash string) (string, error) {
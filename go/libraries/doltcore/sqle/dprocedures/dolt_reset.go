@@ -134,6 +134,9 @@ func resetSoftToRef(
 	dSess *dsess.DoltSession,
 	dbName string,
 ) error {
+	if err := checkResetProtection(ctx, dbData, firstArg); err != nil {
+		return err
+	}
 	roots, err := actions.ResetSoftToRef(ctx, dbData, firstArg)
 	if err != nil {
 		return err
@@ -215,6 +218,9 @@ func resetSoft(
 
 	// If ref is "" that means HEAD, which makes reset --soft a no-op
 	if arg != "" {
+		if err := checkResetProtection(ctx, dbData, arg); err != nil {
+			return err
+		}
 		roots, err := actions.ResetSoftToRef(ctx, dbData, arg)
 		if err != nil {
 			return err
@@ -249,6 +255,10 @@ func resetHard(
 		arg = apr.Arg(0)
 	}
 
+	if err := checkResetProtection(ctx, dbData, arg); err != nil {
+		return err
+	}
+
 	var newHead *doltdb.Commit
 	newHead, roots, err := actions.ResetHardTables(ctx, dbData, arg, roots)
 
@@ -283,3 +293,44 @@ func resetHard(
 
 	return nil
 }
+
+// checkResetProtection returns an error if resetting to the given commit would move the HEAD of a protected branch.
+// Resetting to the current HEAD only affects the working set, so it is always allowed. Any commit that can't be
+// resolved fails the check, rather than letting the reset through.
+func checkResetProtection(ctx *sql.Context, dbData env.DbData, cSpecStr string) error {
+	if cSpecStr == "" {
+		return nil
+	}
+	cs, err := doltdb.NewCommitSpec(cSpecStr)
+	if err != nil {
+		return err
+	}
+	headRef, err := dbData.Rsr.CWBHeadRef()
+	if err != nil {
+		return err
+	}
+	optCmt, err := dbData.Ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return err
+	}
+	newHead, ok := optCmt.ToCommit()
+	if !ok {
+		return doltdb.ErrGhostCommitEncountered
+	}
+	headCommit, err := dbData.Ddb.ResolveCommitRef(ctx, headRef)
+	if err != nil {
+		return err
+	}
+	newHash, err := newHead.HashOf()
+	if err != nil {
+		return err
+	}
+	headHash, err := headCommit.HashOf()
+	if err != nil {
+		return err
+	}
+	if newHash == headHash {
+		return nil
+	}
+	return branch_control.CheckProtection(ctx, headRef.GetPath(), branch_control.ProtectedOperation_HistoryRewrite)
+}
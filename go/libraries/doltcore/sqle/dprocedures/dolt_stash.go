@@ -0,0 +1,242 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	stashPushCmd  = "push"
+	stashPopCmd   = "pop"
+	stashApplyCmd = "apply"
+	stashDropCmd  = "drop"
+	stashClearCmd = "clear"
+)
+
+var ErrStashNotSupportedForOldFormat = errors.New("stash is not supported for old storage format")
+
+// doltStash is the stored procedure version for the CLI command `dolt stash` and its subcommands.
+func doltStash(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := doDoltStash(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+// doDoltStash is used as sql dolt_stash command for pushing, popping, applying and removing stash entries, not
+// listing them. To list stashes, the dolt_stashes system table is used. As with the `dolt stash` CLI command, the
+// stash list is shared by all branches of a database; the dolt_stashes table records the branch of each entry.
+func doDoltStash(ctx *sql.Context, args []string) (int, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return 1, fmt.Errorf("Could not load database %s", dbName)
+	}
+	if !dbData.Ddb.Format().UsesFlatbuffers() {
+		return 1, ErrStashNotSupportedForOldFormat
+	}
+
+	apr, err := cli.CreateStashArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	if apr.NArg() == 0 {
+		return 1, fmt.Errorf("error: invalid argument, use 'dolt_stashes' system table to list stashes")
+	}
+
+	isReadOnly, err := isReadOnlyDatabase(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+	if isReadOnly {
+		return 1, fmt.Errorf("unable to stash changes in read-only databases")
+	}
+
+	subcommand := strings.ToLower(apr.Arg(0))
+	if subcommand != stashPushCmd && (apr.Contains(cli.IncludeUntrackedFlag) || apr.Contains(cli.AllFlag)) {
+		return 1, fmt.Errorf("error: --%s and --%s can only be used with '%s'", cli.IncludeUntrackedFlag, cli.AllFlag, stashPushCmd)
+	}
+
+	if subcommand == stashPushCmd || subcommand == stashPopCmd || subcommand == stashApplyCmd {
+		ws, err := dSess.WorkingSet(ctx, dbName)
+		if err != nil {
+			return 1, err
+		}
+		if ws.MergeActive() {
+			return 1, fmt.Errorf("error: unable to %s stash while a merge is in progress – commit or abort the current merge before proceeding", subcommand)
+		}
+		if ws.RebaseActive() {
+			return 1, fmt.Errorf("error: unable to %s stash while a rebase is in progress – continue or abort the current rebase before proceeding", subcommand)
+		}
+	}
+
+	// popped stash entries are only dropped once the changes they hold have been committed to the working set.
+	dropIdx := -1
+
+	switch subcommand {
+	case stashPushCmd:
+		if apr.NArg() > 1 {
+			return 1, fmt.Errorf("error: '%s' does not take a stash reference", stashPushCmd)
+		}
+		err = stashPush(ctx, dSess, dbName, apr)
+	case stashPopCmd, stashApplyCmd:
+		var idx int
+		idx, err = parseStashIndex(apr)
+		if err != nil {
+			return 1, err
+		}
+		err = stashApply(ctx, dSess, dbName, idx)
+		if subcommand == stashPopCmd {
+			dropIdx = idx
+		}
+	case stashDropCmd:
+		var idx int
+		idx, err = parseStashIndex(apr)
+		if err != nil {
+			return 1, err
+		}
+		err = dbData.Ddb.RemoveStashAtIdx(ctx, idx)
+	case stashClearCmd:
+		if apr.NArg() > 1 {
+			return 1, fmt.Errorf("error: '%s' does not take a stash reference", stashClearCmd)
+		}
+		err = dbData.Ddb.RemoveAllStashes(ctx)
+	default:
+		err = fmt.Errorf("error: invalid subcommand '%s', must be one of %s, %s, %s, %s or %s",
+			apr.Arg(0), stashPushCmd, stashPopCmd, stashApplyCmd, stashDropCmd, stashClearCmd)
+	}
+	if err != nil {
+		return 1, err
+	}
+
+	if err = commitTransaction(ctx, dSess, nil); err != nil {
+		return 1, err
+	}
+
+	if dropIdx >= 0 {
+		if err = dbData.Ddb.RemoveStashAtIdx(ctx, dropIdx); err != nil {
+			return 1, err
+		}
+	}
+
+	return 0, nil
+}
+
+// parseStashIndex returns the index of the stash entry named by the second positional argument, which may be given
+// either as a bare index or as a stash reference like stash@{1}. The most recent stash, at index 0, is the default.
+func parseStashIndex(apr *argparser.ArgParseResults) (int, error) {
+	if apr.NArg() < 2 {
+		return 0, nil
+	}
+
+	stashName := strings.TrimSuffix(strings.TrimPrefix(apr.Arg(1), "stash@{"), "}")
+	idx, err := strconv.Atoi(stashName)
+	if err != nil || idx < 0 {
+		return 0, fmt.Errorf("error: %s is not a valid reference", apr.Arg(1))
+	}
+	return idx, nil
+}
+
+// stashPush saves the staged and unstaged changes of the current branch as a new stash entry and resets the working
+// set back to HEAD. Untracked tables are only included with --include-untracked, ignored tables only with --all.
+func stashPush(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, apr *argparser.ArgParseResults) error {
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	includeUntracked, all := apr.Contains(cli.IncludeUntrackedFlag), apr.Contains(cli.AllFlag)
+	hasChanges, err := actions.HasLocalChanges(ctx, roots, includeUntracked, all)
+	if err != nil {
+		return err
+	}
+	if !hasChanges {
+		return fmt.Errorf("no local changes to save")
+	}
+
+	headRef, err := dSess.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	headCommit, err := dSess.GetHeadCommit(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	roots, err = actions.StashChanges(ctx, ddb, roots, headRef, headCommit, includeUntracked, all)
+	if err != nil {
+		return err
+	}
+	return dSess.SetRoots(ctx, dbName, roots)
+}
+
+// stashApply merges the stash entry at |idx| into the current working set. If applying the stash results in
+// conflicts, the working set is left untouched and an error is returned, so that the stash entry is never dropped
+// without its changes having been applied.
+func stashApply(ctx *sql.Context, dSess *dsess.DoltSession, dbName string, idx int) error {
+	ddb, ok := dSess.GetDoltDB(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("Could not load database %s", dbName)
+	}
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("Could not load database %s", dbName)
+	}
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	roots, tablesWithConflict, err := merge.ApplyStash(ctx, ddb, roots, idx, dbState.EditOpts())
+	if err != nil {
+		return err
+	}
+	if len(tablesWithConflict) > 0 {
+		return fmt.Errorf("error: Your local changes to the following tables would be overwritten by applying stash %d:\n"+
+			"\t{'%s'}\n"+
+			"Please commit your changes or stash them before you merge.\nAborting", idx, strings.Join(tablesWithConflict, "', '"))
+	}
+
+	return dSess.SetRoots(ctx, dbName, roots)
+}
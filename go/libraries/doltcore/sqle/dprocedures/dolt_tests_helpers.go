@@ -0,0 +1,237 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"strings"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	goerrors "gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// ErrTestsFailed is returned when the tests that must pass before a commit, merge or push lands have failed.
+var ErrTestsFailed = goerrors.NewKind("%s rejected, as the following tests in dolt_tests failed:\n%s")
+
+// ErrTestQueryNotReadOnly is returned for a test whose query is not a SELECT statement. Tests run as a side effect of
+// commits and merges, so they may only read data.
+var ErrTestQueryNotReadOnly = goerrors.NewKind("test queries must be SELECT statements without INTO, got '%s'")
+
+// allTestGroups is the test group that selects every test in the dolt_tests table.
+const allTestGroups = "*"
+
+func init() {
+	// a transaction commit that creates a dolt commit runs the same tests as dolt_commit
+	dsess.RunPreCommitTests = func(ctx *sql.Context, dbName string, root doltdb.RootValue) error {
+		return runTestsOnRoot(ctx, dbName, root, dsess.DoltPreCommitTestGroups, "commit")
+	}
+}
+
+// TestGroups returns the groups of tests named by the global system variable |groupsVar|. Returns nil if no tests
+// need to be run.
+func TestGroups(groupsVar string) []string {
+	_, val, ok := sql.SystemVariables.GetGlobal(groupsVar)
+	if !ok {
+		return nil
+	}
+	s, _ := val.(string)
+	var groups []string
+	for _, group := range strings.Split(s, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// RunTests runs the tests in the groups named by the global system variable |groupsVar| against the current database,
+// which is what a push will result in. The tests are read from the dolt_tests table of the database named |testsDb|
+// rather than from the data being validated, so that a push can't remove or weaken the tests it must pass. The tests
+// run on a read-only engine, as there's no user to check their privileges against. If any of them fail, returns
+// ErrTestsFailed for the |operation| being validated.
+func RunTests(ctx *sql.Context, testsDb string, groupsVar string, operation string) error {
+	groups := TestGroups(groupsVar)
+	if len(groups) == 0 {
+		return nil
+	}
+	engine := newReadOnlyEngine(ctx)
+	return runTests(ctx, testsDb, groups, operation, func(ctx *sql.Context, query string) (sql.Schema, []sql.Row, error) {
+		return runQuery(ctx, engine, query)
+	})
+}
+
+// runTestsOnRoot runs the tests named by |groupsVar| against |root|, which is what a commit or merge on the database
+// |dbName| will result in. The tests are read from the head of the database's current branch, as it was last
+// committed, and run with the privileges of the session's user. Only SELECT statements are run. They see |root| as both
+// the working and staged root of the session, which is restored once the tests have completed.
+func runTestsOnRoot(ctx *sql.Context, dbName string, root doltdb.RootValue, groupsVar string, operation string) error {
+	groups := TestGroups(groupsVar)
+	if len(groups) == 0 {
+		return nil
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	testsDb, err := branchHeadDb(ctx, dSess, dbName)
+	if err != nil {
+		return err
+	}
+
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
+	testRoots := roots
+	testRoots.Working = root
+	testRoots.Staged = root
+	if err = dSess.SetRoots(ctx, dbName, testRoots); err != nil {
+		return err
+	}
+
+	err = runTests(ctx, testsDb, groups, operation, runUserSelect)
+	if restoreErr := dSess.SetRoots(ctx, dbName, roots); restoreErr != nil && err == nil {
+		err = restoreErr
+	}
+	return err
+}
+
+// runUserSelect runs the test query |query| in the session of |ctx| with the privileges of the session's user, if it
+// is a SELECT statement that only reads data. Otherwise returns ErrTestQueryNotReadOnly.
+func runUserSelect(ctx *sql.Context, query string) (sql.Schema, []sql.Row, error) {
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sel, ok := stmt.(sqlparser.SelectStatement); !ok || sel.GetInto() != nil {
+		return nil, nil, ErrTestQueryNotReadOnly.New(query)
+	}
+	return runUserStatement(ctx, query)
+}
+
+// branchHeadDb returns the name of the revision database for the commit at the head of the current branch of the
+// database |dbName|, as it is stored in the database rather than as the session sees it.
+func branchHeadDb(ctx *sql.Context, dSess *dsess.DoltSession, dbName string) (string, error) {
+	headRef, err := dSess.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return "", err
+	}
+	dbData, ok := dSess.GetDbData(ctx, dbName)
+	if !ok {
+		return "", sql.ErrDatabaseNotFound.New(dbName)
+	}
+	head, err := dbData.Ddb.ResolveCommitRef(ctx, headRef)
+	if err != nil {
+		return "", err
+	}
+	h, err := head.HashOf()
+	if err != nil {
+		return "", err
+	}
+	baseName, _ := dsess.SplitRevisionDbName(dbName)
+	return baseName + dsess.DbRevisionDelimiter + h.String(), nil
+}
+
+// runTests loads the tests in the given groups from the database named |testsDb|, and runs each of their queries with
+// |runQuery|. If any of them fail, returns ErrTestsFailed for the |operation| being validated.
+func runTests(ctx *sql.Context, testsDb string, groups []string, operation string, runQuery func(*sql.Context, string) (sql.Schema, []sql.Row, error)) error {
+	tests, err := loadTests(ctx, testsDb, groups)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, test := range tests {
+		sch, rows, err := runQuery(ctx, test.Query)
+		if err == nil {
+			err = test.Check(sch, rows)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", test.Name, err.Error()))
+		}
+	}
+	if len(failures) > 0 {
+		return ErrTestsFailed.New(operation, strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// loadTests returns the tests in the dolt_tests table of the database named |testsDb| that belong to any of the given
+// groups, ordered by name. A database without a dolt_tests table has no tests.
+func loadTests(ctx *sql.Context, testsDb string, groups []string) ([]doltdb.DoltTest, error) {
+	query := fmt.Sprintf("SELECT test_name, test_group, test_query, assertion_type, assertion_comparator, assertion_value FROM %s.%s ORDER BY test_name",
+		sql.QuoteIdentifier(testsDb), doltdb.TestsTableName)
+	_, rows, err := runQuery(ctx, newReadOnlyEngine(ctx), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var tests []doltdb.DoltTest
+	for _, row := range rows {
+		group, _ := row[1].(string)
+		if !inTestGroups(group, groups) {
+			continue
+		}
+		test := doltdb.DoltTest{
+			Name:          row[0].(string),
+			Group:         group,
+			Query:         row[2].(string),
+			AssertionType: doltdb.TestAssertionType(row[3].(string)),
+			Comparator:    row[4].(string),
+		}
+		if value, ok := row[5].(string); ok {
+			test.Value = &value
+		}
+		tests = append(tests, test)
+	}
+	return tests, nil
+}
+
+// newReadOnlyEngine returns an engine over the databases of the session's provider that rejects any query that
+// modifies them. It doesn't check the privileges of the session's user.
+func newReadOnlyEngine(ctx *sql.Context) *gms.Engine {
+	pro := dsess.DSessFromSess(ctx.Session).Provider()
+	return gms.New(analyzer.NewDefaultWithVersion(pro), &gms.Config{IsReadOnly: true})
+}
+
+// runQuery runs |query| on |engine| as part of the caller's transaction, so it is never committed when it finishes.
+func runQuery(ctx *sql.Context, engine *gms.Engine, query string) (sql.Schema, []sql.Row, error) {
+	ignoreAutoCommit := ctx.GetIgnoreAutoCommit()
+	ctx.SetIgnoreAutoCommit(true)
+	defer ctx.SetIgnoreAutoCommit(ignoreAutoCommit)
+
+	sch, iter, _, err := engine.Query(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := sql.RowIterToRows(ctx, iter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sch, rows, nil
+}
+
+func inTestGroups(group string, groups []string) bool {
+	for _, g := range groups {
+		if g == allTestGroups || strings.EqualFold(g, group) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// ErrNoStatementRunner is returned when a procedure needs to run a user supplied SQL statement, but the session's
+// database provider has no statement runner to run it with.
+var ErrNoStatementRunner = errors.New("unable to run statement: no statement runner is configured for this server")
+
+// runUserStatement runs the user supplied SQL |statement| in the session of |ctx|, with the privileges of the session's
+// user, and returns its schema and rows. The statement runs as part of the caller's transaction, so it is never
+// committed when it finishes.
+func runUserStatement(ctx *sql.Context, statement string) (sql.Schema, []sql.Row, error) {
+	runner := dsess.DSessFromSess(ctx.Session).Provider().StatementRunner()
+	if runner == nil {
+		return nil, nil, ErrNoStatementRunner
+	}
+
+	ignoreAutoCommit := ctx.GetIgnoreAutoCommit()
+	ctx.SetIgnoreAutoCommit(true)
+	defer ctx.SetIgnoreAutoCommit(ignoreAutoCommit)
+
+	sch, iter, _, err := runner.Query(ctx, statement)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := sql.RowIterToRows(ctx, iter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sch, rows, nil
+}
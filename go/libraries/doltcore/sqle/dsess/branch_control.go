@@ -17,6 +17,8 @@ package dsess
 import (
 	"context"
 
+	"github.com/dolthub/go-mysql-server/sql"
+
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 )
 
@@ -24,6 +26,21 @@ import (
 // This has to live here, rather than in the branch_control package, to prevent a dependency cycle with that package.
 // We could also avoid this by defining branchController as an interface used by dsess.
 func CheckAccessForDb(ctx context.Context, db SqlDatabase, flags branch_control.Permissions) error {
+	return CheckColumnAccessForDb(ctx, db, "", nil, flags)
+}
+
+// CheckTableAccessForDb checks whether the current user has the given permissions on the table named |tableName| of
+// the given database. Entries in the branch control table that are scoped to tables take precedence over those that
+// apply to the entire branch.
+func CheckTableAccessForDb(ctx context.Context, db SqlDatabase, tableName string, flags branch_control.Permissions) error {
+	return CheckColumnAccessForDb(ctx, db, tableName, nil, flags)
+}
+
+// CheckColumnAccessForDb checks whether the current user has the given permissions on the table named |tableName| of
+// the given database, along with each of the given columns of the table. Entries in the branch control table that are
+// scoped to columns take precedence over those scoped to tables, which take precedence over those that apply to the
+// entire branch. An empty table name checks the permissions for the entire branch.
+func CheckColumnAccessForDb(ctx context.Context, db SqlDatabase, tableName string, columns []string, flags branch_control.Permissions) error {
 	branchAwareSession := branch_control.GetBranchAwareSession(ctx)
 	// A nil session means we're not in the SQL context, so we allow all operations
 	if branchAwareSession == nil {
@@ -49,11 +66,52 @@ func CheckAccessForDb(ctx context.Context, db SqlDatabase, flags branch_control.
 
 	dbName, branch := SplitRevisionDbName(db.RevisionQualifiedName())
 
-	// Get the permissions for the branch, user, and host combination
-	_, perms := controller.Access.Match(dbName, branch, user, host)
-	// If either the flags match or the user is an admin for this branch, then we allow access
-	if (perms&flags == flags) || (perms&branch_control.Permissions_Admin == branch_control.Permissions_Admin) {
+	// Without any entries that are scoped to tables or columns, every table and column has the permissions of the
+	// branch, which always allow reading
+	if !controller.Access.HasScopedRows() {
+		if flags == branch_control.Permissions_Read {
+			return nil
+		}
+		tableName, columns = "", nil
+	}
+
+	// Get the permissions for the branch, user, host, and table combination
+	_, perms, scope := controller.Access.MatchTable(dbName, branch, user, host, tableName, "")
+	if !perms.Allows(flags, scope) {
+		if len(tableName) == 0 {
+			return branch_control.ErrIncorrectPermissions.New(user, host, branch)
+		}
+		return branch_control.ErrIncorrectTablePermissions.New(user, host, tableName, branch)
+	}
+	for _, column := range columns {
+		_, perms, scope = controller.Access.MatchTable(dbName, branch, user, host, tableName, column)
+		if !perms.Allows(flags, scope) {
+			return branch_control.ErrIncorrectColumnPermissions.New(user, host, column, tableName, branch)
+		}
+	}
+	return nil
+}
+
+// CheckCommitProtection returns an error if a commit is not allowed on the current branch of the database named due to
+// its protection rule. Amending rewrites the branch's history, while any other commit is a direct commit unless it
+// concludes a merge. Cherry-picks record their state as a merge, but they still count as direct commits.
+func (d *DoltSession) CheckCommitProtection(ctx *sql.Context, dbName string, amend bool, fromMerge bool) error {
+	headRef, err := d.CWBHeadRef(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	if amend {
+		return branch_control.CheckProtection(ctx, headRef.GetPath(), branch_control.ProtectedOperation_HistoryRewrite)
+	}
+	if fromMerge {
+		return nil
+	}
+	ws, err := d.WorkingSet(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	if ws.MergeActive() && !ws.MergeState().IsCherryPick() {
 		return nil
 	}
-	return branch_control.ErrIncorrectPermissions.New(user, host, branch)
+	return branch_control.CheckProtection(ctx, headRef.GetPath(), branch_control.ProtectedOperation_DirectCommit)
 }
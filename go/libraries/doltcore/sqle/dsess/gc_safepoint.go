@@ -0,0 +1,393 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// GCSafepointWaitTimeout is how long a GC waits for the queries running on other sessions to complete before it gives
+// up on establishing a safepoint.
+var GCSafepointWaitTimeout = 10 * time.Second
+
+// minGCSessionsPrune is the number of registered sessions at which a GCSafepointController first prunes the sessions
+// whose connections are closed.
+const minGCSessionsPrune = 64
+
+var ErrGCSafepointTimeout = errors.New("unable to establish a safepoint for garbage collection: timed out waiting for running queries to complete")
+
+// GCRootsVisitor is implemented by objects held in a session, such as temporary tables, which reference chunks that
+// are not reachable from the refs of their database.
+type GCRootsVisitor interface {
+	// VisitGCRoots calls |keep| with the address of every chunk the object references.
+	VisitGCRoots(ctx context.Context, keep func(hash.Hash) error) error
+}
+
+// GCSafepointController coordinates an online garbage collection with the sessions of a server, so that the sessions
+// survive the collection. To establish a safepoint, a GC blocks new queries from starting, waits for the queries which
+// are already running to complete, and then visits every session to keep the chunks the session references. The
+// sessions are released when the GC is over.
+type GCSafepointController struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	// sessions are the sessions registered with this controller. Sessions which are not used by a connection, such as
+	// the sessions of background work, can share their ids with connections, so sessions are not keyed by id.
+	sessions map[*DoltSession]*gcSession
+	// pruneAt is the number of registered sessions at which the controller next prunes closed sessions
+	pruneAt int
+	// collecting is true while a GC is running. Only one GC runs at a time.
+	collecting bool
+	// owner is the session running the current GC
+	owner *DoltSession
+	// held is true once the current GC has begun establishing its safepoint. New queries wait for the GC to end.
+	held bool
+	// lastQuery is the time the last query began in a session, or the time the controller was created
+	lastQuery time.Time
+	// statuses are the statuses of the GCs of each database
+	statuses *GCStatuses
+}
+
+type gcSession struct {
+	sess *DoltSession
+	// queryPid is the pid of the last query which began in the session
+	queryPid uint64
+	// parked is true while the session is waiting for a GC to end
+	parked bool
+}
+
+// NewGCSafepointController returns a new GCSafepointController with no sessions registered.
+func NewGCSafepointController() *GCSafepointController {
+	c := &GCSafepointController{
+		sessions:  make(map[*DoltSession]*gcSession),
+		pruneAt:   minGCSessionsPrune,
+		lastQuery: time.Now(),
+		statuses:  NewGCStatuses(),
+	}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// addSession registers |sess| with this controller.
+func (c *GCSafepointController) addSession(sess *DoltSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions[sess] = &gcSession{sess: sess}
+}
+
+// SessionCommandBegin is called when a query begins in |sess|. If a GC is establishing a safepoint, it blocks until
+// the GC ends.
+func (c *GCSafepointController) SessionCommandBegin(ctx *sql.Context, sess *DoltSession) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.sessions) >= c.pruneAt {
+		c.pruneSessions(ctx.ProcessList.Processes())
+		c.pruneAt = max(2*len(c.sessions), minGCSessionsPrune)
+	}
+	s, ok := c.sessions[sess]
+	if !ok {
+		s = &gcSession{sess: sess}
+		c.sessions[sess] = s
+	}
+	c.lastQuery = time.Now()
+
+	// Queries run by a query in the same session, as by a stored procedure, are part of the running query.
+	nested := s.queryPid == ctx.Pid()
+	s.queryPid = ctx.Pid()
+	if nested || !c.held || c.owner == sess {
+		return nil
+	}
+
+	s.parked = true
+	defer func() {
+		s.parked = false
+	}()
+	return c.wait(ctx, func() bool {
+		return c.held
+	})
+}
+
+// LastQueryTime returns the time the last query began in a session registered with this controller, or the time the
+// controller was created if no query has begun.
+func (c *GCSafepointController) LastQueryTime() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastQuery
+}
+
+// Statuses returns the statuses of the GCs of the databases of the server.
+func (c *GCSafepointController) Statuses() *GCStatuses {
+	return c.statuses
+}
+
+// BeginGC is called when a GC begins in |sess|. It waits for any other GC to end, and returns a function which must
+// be called when the GC ends.
+func (c *GCSafepointController) BeginGC(ctx *sql.Context, sess *DoltSession) (func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// While it waits, the session is at a safepoint for the other GC.
+	s, ok := c.sessions[sess]
+	if ok {
+		s.parked = true
+		defer func() {
+			s.parked = false
+		}()
+	}
+	err := c.wait(ctx, func() bool {
+		return c.collecting
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.collecting = true
+	c.owner = sess
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.collecting = false
+		c.held = false
+		c.owner = nil
+		c.cond.Broadcast()
+	}, nil
+}
+
+// EstablishSafepoint is called by the GC running in |sess| when it needs the chunks referenced by every session to
+// be kept. It blocks new queries until the GC ends, waits for running queries to complete, and then calls |visit| for
+// |sess| and every other open session. Returns ErrGCSafepointTimeout if running queries don't complete in time.
+func (c *GCSafepointController) EstablishSafepoint(ctx *sql.Context, sess *DoltSession, visit func(*DoltSession) error) error {
+	c.mu.Lock()
+	c.held = true
+	c.mu.Unlock()
+
+	// The queries of the GC's own connection are part of the GC. Sessions which are not used by a connection can share
+	// their ids with connections, so the GC's session is only known to be a connection if the GC's query is listed.
+	ownConnection := false
+	for _, p := range ctx.ProcessList.Processes() {
+		if ctx.Pid() != 0 && p.QueryPid == ctx.Pid() && p.Connection == sess.ID() {
+			ownConnection = true
+		}
+	}
+
+	params := backoff.NewExponentialBackOff()
+	params.InitialInterval = 1 * time.Millisecond
+	params.MaxInterval = 25 * time.Millisecond
+	params.MaxElapsedTime = GCSafepointWaitTimeout
+	var processes []sql.Process
+	err := backoff.Retry(func() error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		processes = ctx.ProcessList.Processes()
+		parked := make(map[uint32]struct{})
+		for _, s := range c.sessions {
+			if s.parked {
+				parked[s.sess.ID()] = struct{}{}
+			}
+		}
+		for _, p := range processes {
+			if p.Command != sql.ProcessCommandQuery || (ownConnection && p.Connection == sess.ID()) {
+				continue
+			}
+			if _, ok := parked[p.Connection]; ok {
+				continue
+			}
+			return ErrGCSafepointTimeout
+		}
+		return nil
+	}, backoff.WithContext(params, ctx))
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pruneSessions(processes)
+	if _, ok := c.sessions[sess]; !ok {
+		if err = visit(sess); err != nil {
+			return err
+		}
+	}
+	for _, s := range c.sessions {
+		if err = visit(s.sess); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneSessions removes the sessions which don't have a connection in |processes|. Sessions are only pruned when the
+// process list tracks connections, since sessions which are not used by a server have no connection.
+func (c *GCSafepointController) pruneSessions(processes []sql.Process) {
+	if len(processes) == 0 {
+		return
+	}
+	open := make(map[uint32]struct{}, len(processes))
+	for _, p := range processes {
+		open[p.Connection] = struct{}{}
+	}
+	for sess := range c.sessions {
+		if _, ok := open[sess.ID()]; !ok {
+			delete(c.sessions, sess)
+		}
+	}
+}
+
+// wait blocks until |blocked| returns false or |ctx| is canceled. Must be called with c.mu held.
+func (c *GCSafepointController) wait(ctx context.Context, blocked func() bool) error {
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.mu.Lock()
+			c.cond.Broadcast()
+			c.mu.Unlock()
+		case <-stop:
+		}
+	}()
+	for blocked() && ctx.Err() == nil {
+		c.cond.Wait()
+	}
+	return ctx.Err()
+}
+
+// VisitGCRoots calls |keep| with the addresses of the chunks of the database named |dbName| which this session
+// references and which might not be reachable from the refs of the database: its heads and working sets, the start
+// point of its transaction, its savepoints and its temporary tables. Root values which only exist in memory are
+// written to the database so that they can be kept. Must only be called while no query is running in the session.
+func (d *DoltSession) VisitGCRoots(ctx context.Context, dbName string, keep func(hash.Hash) error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dbName, _ = SplitRevisionDbName(strings.ToLower(dbName))
+	if dbState, ok := d.dbStates[dbName]; ok {
+		for _, bs := range dbState.heads {
+			ddb := bs.dbData.Ddb
+			if err := keepCommit(keep, bs.headCommit); err != nil {
+				return err
+			}
+			if err := keepRoot(ctx, ddb, keep, bs.headRoot); err != nil {
+				return err
+			}
+			if err := keepWorkingSet(ctx, ddb, keep, bs.workingSet); err != nil {
+				return err
+			}
+			if bs.writeSession != nil {
+				if err := keepWorkingSet(ctx, ddb, keep, bs.writeSession.GetWorkingSet()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if tx, ok := d.Session.GetTransaction().(*DoltTransaction); ok && tx != nil {
+		if startPoint, ok := tx.dbStartPoints[dbName]; ok {
+			if err := keep(startPoint.rootHash); err != nil {
+				return err
+			}
+			for _, sp := range tx.savepoints {
+				if err := keepRoot(ctx, startPoint.db, keep, sp.roots[dbName]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for db, tables := range d.tempTables {
+		if baseName, _ := SplitRevisionDbName(db); baseName != dbName {
+			continue
+		}
+		for _, tbl := range tables {
+			if v, ok := tbl.(GCRootsVisitor); ok {
+				if err := v.VisitGCRoots(ctx, keep); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func keepCommit(keep func(hash.Hash) error, cm *doltdb.Commit) error {
+	if cm == nil {
+		return nil
+	}
+	h, err := cm.HashOf()
+	if err != nil {
+		return err
+	}
+	return keep(h)
+}
+
+func keepRoot(ctx context.Context, ddb *doltdb.DoltDB, keep func(hash.Hash) error, root doltdb.RootValue) error {
+	if root == nil {
+		return nil
+	}
+	_, h, err := ddb.WriteRootValue(ctx, root)
+	if err != nil {
+		return fmt.Errorf("unable to write session root for garbage collection: %w", err)
+	}
+	return keep(h)
+}
+
+func keepWorkingSet(ctx context.Context, ddb *doltdb.DoltDB, keep func(hash.Hash) error, ws *doltdb.WorkingSet) error {
+	if ws == nil {
+		return nil
+	}
+	h, err := ws.HashOf()
+	if err != nil {
+		return err
+	}
+	if !h.IsEmpty() {
+		if err = keep(h); err != nil {
+			return err
+		}
+	}
+	for _, root := range []doltdb.RootValue{ws.WorkingRoot(), ws.StagedRoot()} {
+		if err = keepRoot(ctx, ddb, keep, root); err != nil {
+			return err
+		}
+	}
+	if ms := ws.MergeState(); ms != nil {
+		if err = keepCommit(keep, ms.Commit()); err != nil {
+			return err
+		}
+		if err = keepRoot(ctx, ddb, keep, ms.PreMergeWorkingRoot()); err != nil {
+			return err
+		}
+	}
+	if rs := ws.RebaseState(); rs != nil {
+		if err = keepCommit(keep, rs.OntoCommit()); err != nil {
+			return err
+		}
+		if err = keepRoot(ctx, ddb, keep, rs.PreRebaseWorkingRoot()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
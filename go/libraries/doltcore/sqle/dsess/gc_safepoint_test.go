@@ -0,0 +1,167 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/variables"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	variables.InitStatusVariables()
+}
+
+type gcTestConn struct {
+	sess *DoltSession
+	pl   *gms.ProcessList
+	pid  uint64
+}
+
+func newGCTestConn(pl *gms.ProcessList, c *GCSafepointController, id uint32) *gcTestConn {
+	sess := DefaultSession(emptyDatabaseProvider(), nil)
+	sess.Session.(*sql.BaseSession).SetConnectionId(id)
+	sess.SetGCSafepointController(c)
+	pl.AddConnection(id, "")
+	pl.ConnectionReady(sess)
+	return &gcTestConn{sess: sess, pl: pl, pid: uint64(id) * 1000}
+}
+
+// beginQuery begins a query in the connection, as the server does, and returns its context, along with a channel
+// which receives the result of ValidateSession for the query.
+func (c *gcTestConn) beginQuery(t *testing.T) (*sql.Context, chan error) {
+	c.pid++
+	ctx := sql.NewContext(context.Background(), sql.WithSession(c.sess), sql.WithPid(c.pid), sql.WithProcessList(c.pl))
+	ctx, err := c.pl.BeginQuery(ctx, "select 1")
+	require.NoError(t, err)
+	validated := make(chan error, 1)
+	go func() {
+		validated <- c.sess.ValidateSession(ctx)
+		close(validated)
+	}()
+	return ctx, validated
+}
+
+func requireBlocked[T any](t *testing.T, ch chan T) {
+	select {
+	case <-ch:
+		require.FailNow(t, "expected channel to block")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestGCSafepointController(t *testing.T) {
+	pl := gms.NewProcessList()
+	c := NewGCSafepointController()
+	gcConn := newGCTestConn(pl, c, 1)
+	running := newGCTestConn(pl, c, 2)
+	idle := newGCTestConn(pl, c, 3)
+
+	gcCtx, validated := gcConn.beginQuery(t)
+	require.NoError(t, <-validated)
+	runningCtx, validated := running.beginQuery(t)
+	require.NoError(t, <-validated)
+
+	endGC, err := c.BeginGC(gcCtx, gcConn.sess)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var visited []uint32
+	established := make(chan error, 1)
+	go func() {
+		established <- c.EstablishSafepoint(gcCtx, gcConn.sess, func(sess *DoltSession) error {
+			mu.Lock()
+			defer mu.Unlock()
+			visited = append(visited, sess.ID())
+			return nil
+		})
+	}()
+
+	// The safepoint waits for the running query to complete.
+	requireBlocked(t, established)
+
+	// New queries wait for the GC to end, but the GC's own session can run queries.
+	_, idleValidated := idle.beginQuery(t)
+	requireBlocked(t, idleValidated)
+	_, validated = gcConn.beginQuery(t)
+	require.NoError(t, <-validated)
+
+	pl.EndQuery(runningCtx)
+	require.NoError(t, <-established)
+	assert.ElementsMatch(t, []uint32{1, 2, 3}, visited)
+
+	requireBlocked(t, idleValidated)
+	endGC()
+	require.NoError(t, <-idleValidated)
+}
+
+func TestGCSafepointControllerTimeout(t *testing.T) {
+	defer func(timeout time.Duration) {
+		GCSafepointWaitTimeout = timeout
+	}(GCSafepointWaitTimeout)
+	GCSafepointWaitTimeout = 50 * time.Millisecond
+
+	pl := gms.NewProcessList()
+	c := NewGCSafepointController()
+	gcConn := newGCTestConn(pl, c, 1)
+	running := newGCTestConn(pl, c, 2)
+
+	gcCtx, validated := gcConn.beginQuery(t)
+	require.NoError(t, <-validated)
+	_, validated = running.beginQuery(t)
+	require.NoError(t, <-validated)
+
+	endGC, err := c.BeginGC(gcCtx, gcConn.sess)
+	require.NoError(t, err)
+	err = c.EstablishSafepoint(gcCtx, gcConn.sess, func(*DoltSession) error {
+		return nil
+	})
+	require.ErrorIs(t, err, ErrGCSafepointTimeout)
+	endGC()
+
+	// Once the GC ends, queries run again.
+	_, validated = running.beginQuery(t)
+	require.NoError(t, <-validated)
+}
+
+func TestGCSafepointControllerPrunesClosedSessions(t *testing.T) {
+	pl := gms.NewProcessList()
+	c := NewGCSafepointController()
+	gcConn := newGCTestConn(pl, c, 1)
+	closed := newGCTestConn(pl, c, 2)
+	pl.RemoveConnection(closed.sess.ID())
+
+	gcCtx, validated := gcConn.beginQuery(t)
+	require.NoError(t, <-validated)
+	endGC, err := c.BeginGC(gcCtx, gcConn.sess)
+	require.NoError(t, err)
+	defer endGC()
+
+	var visited []uint32
+	err = c.EstablishSafepoint(gcCtx, gcConn.sess, func(sess *DoltSession) error {
+		visited = append(visited, sess.ID())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1}, visited)
+	assert.Len(t, c.sessions, 1)
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// The phases of a garbage collection, as reported by GCStatus.
+const (
+	// GCPhaseWaiting is the phase of a GC waiting for another GC to end
+	GCPhaseWaiting = "waiting"
+	// GCPhaseCollecting is the phase of a GC copying the reachable chunks of its database
+	GCPhaseCollecting = "collecting"
+	// GCPhaseSafepoint is the phase of a GC holding the sessions of the server at a safepoint
+	GCPhaseSafepoint = "safepoint"
+	// GCPhaseFinalizing is the phase of a GC swapping the collected chunks in for the old ones
+	GCPhaseFinalizing = "finalizing"
+	// GCPhaseDone is the phase of a GC which completed
+	GCPhaseDone = "done"
+	// GCPhaseFailed is the phase of a GC which failed
+	GCPhaseFailed = "failed"
+)
+
+// GCTriggerUser is the GCStatus trigger of the garbage collections run by calls to dolt_gc().
+const GCTriggerUser = "dolt_gc"
+
+// GCStatus is the status of the garbage collections of a database.
+type GCStatus struct {
+	// Running is true while a GC of the database is running.
+	Running bool
+	// Phase is the phase of the running GC, or of the last GC if none is running.
+	Phase string
+	// Shallow is true if the running or last GC is a shallow GC.
+	Shallow bool
+	// Trigger is what started the running or last GC.
+	Trigger string
+	// Started and Finished are the times the running or last GC started and finished.
+	Started  time.Time
+	Finished time.Time
+	// Err is the error the last GC failed with, if any.
+	Err error
+	// Runs is the number of GCs of the database which have started.
+	Runs uint64
+}
+
+// GCStatuses records the GCStatus of each database of a server.
+type GCStatuses struct {
+	mu       sync.Mutex
+	statuses map[string]*GCStatus
+}
+
+// NewGCStatuses returns a new GCStatuses with no statuses recorded.
+func NewGCStatuses() *GCStatuses {
+	return &GCStatuses{statuses: make(map[string]*GCStatus)}
+}
+
+// Get returns the GCStatus of the database named |dbName|, and false if it has never been garbage collected.
+func (s *GCStatuses) Get(dbName string) (GCStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.statuses[gcStatusKey(dbName)]
+	if !ok {
+		return GCStatus{}, false
+	}
+	return *st, true
+}
+
+// Begin records the start of a GC of the database named |dbName|.
+func (s *GCStatuses) Begin(dbName string, shallow bool, trigger string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := gcStatusKey(dbName)
+	st, ok := s.statuses[key]
+	if !ok {
+		st = &GCStatus{}
+		s.statuses[key] = st
+	}
+	*st = GCStatus{
+		Running: true,
+		Phase:   GCPhaseWaiting,
+		Shallow: shallow,
+		Trigger: trigger,
+		Started: time.Now(),
+		Runs:    st.Runs + 1,
+	}
+}
+
+// SetPhase records the phase of the running GC of the database named |dbName|.
+func (s *GCStatuses) SetPhase(dbName string, phase string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if st, ok := s.statuses[gcStatusKey(dbName)]; ok && st.Running {
+		st.Phase = phase
+	}
+}
+
+// End records the end of the running GC of the database named |dbName|, which failed if |err| is not nil.
+func (s *GCStatuses) End(dbName string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.statuses[gcStatusKey(dbName)]
+	if !ok || !st.Running {
+		return
+	}
+	st.Running = false
+	st.Finished = time.Now()
+	st.Err = err
+	if err != nil {
+		st.Phase = GCPhaseFailed
+	} else {
+		st.Phase = GCPhaseDone
+	}
+}
+
+func gcStatusKey(dbName string) string {
+	dbName, _ = SplitRevisionDbName(strings.ToLower(dbName))
+	return dbName
+}
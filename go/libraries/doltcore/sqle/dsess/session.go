@@ -67,6 +67,10 @@ type DoltSession struct {
 	// If non-nil, this will be returned from ValidateSession.
 	// Used by sqle/cluster to put a session into a terminal err state.
 	validateErr error
+
+	// If non-nil, queries in this session wait in ValidateSession
+	// while an online GC is establishing its safepoint.
+	gcSafepointController *GCSafepointController
 }
 
 var _ sql.Session = (*DoltSession)(nil)
@@ -318,7 +322,25 @@ func (d *DoltSession) SetValidateErr(err error) {
 // If there is no sessionState or its current working set not defined, then no need for validation,
 // so no error is returned.
 func (d *DoltSession) ValidateSession(ctx *sql.Context) error {
-	return d.validateErr
+	if d.validateErr != nil {
+		return d.validateErr
+	}
+	if d.gcSafepointController != nil {
+		return d.gcSafepointController.SessionCommandBegin(ctx, d)
+	}
+	return nil
+}
+
+// SetGCSafepointController registers this session with the GCSafepointController given, which coordinates the
+// session with online garbage collection.
+func (d *DoltSession) SetGCSafepointController(controller *GCSafepointController) {
+	d.gcSafepointController = controller
+	controller.addSession(d)
+}
+
+// GCSafepointController returns the GCSafepointController this session is registered with, or nil if there is none.
+func (d *DoltSession) GCSafepointController() *GCSafepointController {
+	return d.gcSafepointController
 }
 
 // StartTransaction refreshes the state of this session and starts a new transaction.
@@ -509,6 +531,20 @@ func (d *DoltSession) CommitTransaction(ctx *sql.Context, tx sql.Transaction) (e
 			return d.commitWorkingSet(ctx, dirtyBranchState, tx)
 		}
 
+		// a transaction commit is a direct commit, so it's subject to the same protection rules as dolt_commit
+		err = d.CheckCommitProtection(ctx, ctx.GetCurrentDatabase(), false, false)
+		if err != nil {
+			return err
+		}
+
+		// and it must pass the same pre-commit tests
+		if RunPreCommitTests != nil {
+			err = RunPreCommitTests(ctx, ctx.GetCurrentDatabase(), pendingCommit.Roots.Staged)
+			if err != nil {
+				return err
+			}
+		}
+
 		_, err = d.DoltCommit(ctx, ctx.GetCurrentDatabase(), tx, pendingCommit)
 		return err
 	} else {
@@ -547,6 +583,10 @@ func (d *DoltSession) validateDoltCommit(ctx *sql.Context, dirtyBranchState *bra
 	return nil
 }
 
+// RunPreCommitTests runs the tests named by @@dolt_pre_commit_test_groups against |root|, which a commit on the
+// database named will result in. The tests are run by the dprocedures package, which sets it.
+var RunPreCommitTests func(ctx *sql.Context, dbName string, root doltdb.RootValue) error
+
 var ErrDirtyWorkingSets = errors.New("Cannot commit changes on more than one branch / database")
 
 // dirtyWorkingSets returns all dirty working sets for this session
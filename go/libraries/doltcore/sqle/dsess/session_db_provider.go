@@ -111,6 +111,17 @@ type DoltDatabaseProvider interface {
 	// PurgeDroppedDatabases permanently deletes any dropped databases that are being held in temporary storage
 	// in case they need to be restored. This operation is not reversible, so use with caution!
 	PurgeDroppedDatabases(ctx *sql.Context) error
+	// StatementRunner returns the runner for SQL statements that stored procedures execute on behalf of the user, or
+	// nil if none has been set.
+	StatementRunner() StatementRunner
+}
+
+// StatementRunner runs SQL statements in the session of the given context. Stored procedures that run SQL supplied by
+// the user, such as the predicate of dolt_bisect('run'), use it so that the statements are checked against the
+// privileges of the session's user, the same as the statements the user runs directly. It is normally the engine that
+// serves the session.
+type StatementRunner interface {
+	Query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, *sql.QueryFlags, error)
 }
 
 type SessionDatabaseBranchSpec struct {
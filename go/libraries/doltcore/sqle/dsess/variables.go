@@ -65,6 +65,9 @@ const (
 	DoltStatsAutoRefreshInterval  = "dolt_stats_auto_refresh_interval"
 	DoltStatsMemoryOnly           = "dolt_stats_memory_only"
 	DoltStatsBranches             = "dolt_stats_branches"
+
+	DoltPreCommitTestGroups = "dolt_pre_commit_test_groups"
+	DoltPreMergeTestGroups  = "dolt_pre_merge_test_groups"
 )
 
 const URLTemplateDatabasePlaceholder = "{database}"
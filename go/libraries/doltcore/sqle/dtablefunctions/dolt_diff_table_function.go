@@ -179,6 +179,14 @@ func (dtf *DiffTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter,
 		return nil, fmt.Errorf("unable to get dolt database")
 	}
 
+	tableName := dtf.tableDelta.ToName.Name
+	if dtf.tableDelta.ToTable == nil {
+		tableName = dtf.tableDelta.FromName.Name
+	}
+	if err = dtables.CheckReadAccess(ctx, sqledb, tableName, dtf.tableDelta.FromSch, dtf.tableDelta.ToSch); err != nil {
+		return nil, err
+	}
+
 	fromCommitStr, toCommitStr, err := loadCommitStrings(ctx, fromCommitVal, toCommitVal, dotCommitVal, sqledb)
 	if err != nil {
 		return nil, err
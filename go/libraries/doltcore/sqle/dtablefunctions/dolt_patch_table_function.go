@@ -167,6 +167,21 @@ func (p *PatchTableFunction) PartitionRows(ctx *sql.Context, partition sql.Parti
 	includeSchemaDiff := bytes.Equal(partition.Key(), schemaAndDataChangePartitionKey) || bytes.Equal(partition.Key(), schemaChangePartitionKey)
 	includeDataDiff := bytes.Equal(partition.Key(), schemaAndDataChangePartitionKey) || bytes.Equal(partition.Key(), dataChangePartitionKey)
 
+	if includeDataDiff {
+		for _, td := range tableDeltas {
+			if td.FromTable == nil && td.ToTable == nil {
+				continue
+			}
+			tblName := td.ToName
+			if td.IsDrop() {
+				tblName = td.FromName
+			}
+			if err = dtables.CheckReadAccess(ctx, sqledb, tblName.Name, td.FromSch, td.ToSch); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	patches, err := getPatchNodes(ctx, sqledb.DbData(), tableDeltas, fromRefDetails, toRefDetails, includeSchemaDiff, includeDataDiff)
 	if err != nil {
 		return nil, err
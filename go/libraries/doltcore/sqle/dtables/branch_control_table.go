@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/dolthub/vitess/go/sqltypes"
 
@@ -35,6 +36,9 @@ const (
 // strings should exactly match the order of the branch_control.Permissions according to their flag value.
 var PermissionsStrings = []string{"admin", "write", "read"}
 
+// accessPermissionsIndex is the index of the "permissions" column in the "dolt_branch_control" table.
+const accessPermissionsIndex = 6
+
 // accessSchema is the schema for the "dolt_branch_control" table.
 var accessSchema = sql.Schema{
 	&sql.Column{
@@ -61,6 +65,20 @@ var accessSchema = sql.Schema{
 		Source:     AccessTableName,
 		PrimaryKey: true,
 	},
+	&sql.Column{
+		Name:       "table",
+		Type:       types.MustCreateString(sqltypes.VarChar, 16383, sql.Collation_utf8mb4_0900_ai_ci),
+		Default:    matchAllDefault,
+		Source:     AccessTableName,
+		PrimaryKey: true,
+	},
+	&sql.Column{
+		Name:       "column",
+		Type:       types.MustCreateString(sqltypes.VarChar, 16383, sql.Collation_utf8mb4_0900_ai_ci),
+		Default:    matchAllDefault,
+		Source:     AccessTableName,
+		PrimaryKey: true,
+	},
 	&sql.Column{
 		Name:       "permissions",
 		Type:       types.MustCreateSetType(PermissionsStrings, sql.Collation_utf8mb4_0900_ai_ci),
@@ -69,6 +87,16 @@ var accessSchema = sql.Schema{
 	},
 }
 
+// matchAllDefault is the default for the "table" and "column" columns, which matches every table and column.
+var matchAllDefault = func() *sql.ColumnDefaultValue {
+	typ := types.MustCreateString(sqltypes.VarChar, 16383, sql.Collation_utf8mb4_0900_ai_ci)
+	def, err := sql.NewColumnDefaultValue(expression.NewLiteral("%", typ), typ, true, false, false)
+	if err != nil {
+		panic(err)
+	}
+	return def
+}()
+
 // BranchControlTable provides a layer over the branch_control.Access structure, exposing it as a system table.
 type BranchControlTable struct {
 	*branch_control.Access
@@ -127,6 +155,8 @@ func (tbl BranchControlTable) PartitionRows(context *sql.Context, partition sql.
 			value.Branch,
 			value.User,
 			value.Host,
+			value.Table,
+			value.Column,
 			uint64(value.Permissions),
 		})
 	}
@@ -175,16 +205,19 @@ func (tbl BranchControlTable) Insert(ctx *sql.Context, row sql.Row) error {
 	tbl.RWMutex.Lock()
 	defer tbl.RWMutex.Unlock()
 
-	// Database, Branch, and Host are case-insensitive, while user is case-sensitive
+	// Database, Branch, Host, Table, and Column are case-insensitive, while user is case-sensitive
 	database := strings.ToLower(branch_control.FoldExpression(row[0].(string)))
 	branch := strings.ToLower(branch_control.FoldExpression(row[1].(string)))
 	user := branch_control.FoldExpression(row[2].(string))
 	host := strings.ToLower(branch_control.FoldExpression(row[3].(string)))
-	perms := branch_control.Permissions(row[4].(uint64))
+	table := strings.ToLower(branch_control.FoldExpression(row[4].(string)))
+	column := strings.ToLower(branch_control.FoldExpression(row[5].(string)))
+	perms := branch_control.Permissions(row[accessPermissionsIndex].(uint64))
 
 	// Verify that the lengths of each expression fit within an uint16
-	if len(database) > math.MaxUint16 || len(branch) > math.MaxUint16 || len(user) > math.MaxUint16 || len(host) > math.MaxUint16 {
-		return branch_control.ErrExpressionsTooLong.New(database, branch, user, host)
+	if len(database) > math.MaxUint16 || len(branch) > math.MaxUint16 || len(user) > math.MaxUint16 || len(host) > math.MaxUint16 ||
+		len(table) > math.MaxUint16 || len(column) > math.MaxUint16 {
+		return branch_control.ErrAccessExpressionsTooLong.New(database, branch, user, host, table, column)
 	}
 
 	// A nil session means we're not in the SQL context, so we allow the insertion in such a case
@@ -197,23 +230,24 @@ func (tbl BranchControlTable) Insert(ctx *sql.Context, row sql.Row) error {
 		// determine if the user attempting the insertion has permission to perform the insertion.
 		_, modPerms := tbl.Match(database, branch, insertUser, insertHost)
 		if modPerms&branch_control.Permissions_Admin != branch_control.Permissions_Admin {
-			permStr, _ := accessSchema[4].Type.(sql.SetType).BitsToString(uint64(perms))
-			return branch_control.ErrInsertingAccessRow.New(insertUser, insertHost, database, branch, user, host, permStr)
+			permStr, _ := accessSchema[accessPermissionsIndex].Type.(sql.SetType).BitsToString(uint64(perms))
+			return branch_control.ErrInsertingAccessRow.New(insertUser, insertHost, database, branch, user, host, table, column, permStr)
 		}
 	}
 
-	// We check if we're inserting a subset of an already-existing row. If we are, we deny the insertion as the existing
-	// row will already match against ALL possible values for this row.
-	if ok, modPerms := tbl.Match(database, branch, user, host); ok {
+	// We check if we're inserting a subset of an already-existing row of the same scope. If we are, we deny the
+	// insertion as the existing row will already match against ALL possible values for this row. Rows of a narrower
+	// scope may be inserted, as they take precedence over the rows of a wider scope.
+	if ok, modPerms := tbl.Covers(database, branch, user, host, table, column); ok {
 		permBits := uint64(modPerms)
-		permStr, _ := accessSchema[4].Type.(sql.SetType).BitsToString(permBits)
+		permStr, _ := accessSchema[accessPermissionsIndex].Type.(sql.SetType).BitsToString(permBits)
 		return sql.NewUniqueKeyErr(
-			fmt.Sprintf(`[%q, %q, %q, %q, %q]`, database, branch, user, host, permStr),
+			fmt.Sprintf(`[%q, %q, %q, %q, %q, %q, %q]`, database, branch, user, host, table, column, permStr),
 			true,
-			sql.Row{database, branch, user, host, permBits})
+			sql.Row{database, branch, user, host, table, column, permBits})
 	}
 
-	tbl.Access.Insert(database, branch, user, host, perms)
+	tbl.Access.Insert(database, branch, user, host, table, column, perms)
 	return nil
 }
 
@@ -222,31 +256,37 @@ func (tbl BranchControlTable) Update(ctx *sql.Context, old sql.Row, new sql.Row)
 	tbl.RWMutex.Lock()
 	defer tbl.RWMutex.Unlock()
 
-	// Database, Branch, and Host are case-insensitive, while User is case-sensitive
+	// Database, Branch, Host, Table, and Column are case-insensitive, while User is case-sensitive
 	oldDatabase := strings.ToLower(branch_control.FoldExpression(old[0].(string)))
 	oldBranch := strings.ToLower(branch_control.FoldExpression(old[1].(string)))
 	oldUser := branch_control.FoldExpression(old[2].(string))
 	oldHost := strings.ToLower(branch_control.FoldExpression(old[3].(string)))
+	oldTable := strings.ToLower(branch_control.FoldExpression(old[4].(string)))
+	oldColumn := strings.ToLower(branch_control.FoldExpression(old[5].(string)))
 	newDatabase := strings.ToLower(branch_control.FoldExpression(new[0].(string)))
 	newBranch := strings.ToLower(branch_control.FoldExpression(new[1].(string)))
 	newUser := branch_control.FoldExpression(new[2].(string))
 	newHost := strings.ToLower(branch_control.FoldExpression(new[3].(string)))
-	newPerms := branch_control.Permissions(new[4].(uint64))
+	newTable := strings.ToLower(branch_control.FoldExpression(new[4].(string)))
+	newColumn := strings.ToLower(branch_control.FoldExpression(new[5].(string)))
+	newPerms := branch_control.Permissions(new[accessPermissionsIndex].(uint64))
 
 	// Verify that the lengths of each expression fit within an uint16
-	if len(newDatabase) > math.MaxUint16 || len(newBranch) > math.MaxUint16 || len(newUser) > math.MaxUint16 || len(newHost) > math.MaxUint16 {
-		return branch_control.ErrExpressionsTooLong.New(newDatabase, newBranch, newUser, newHost)
+	if len(newDatabase) > math.MaxUint16 || len(newBranch) > math.MaxUint16 || len(newUser) > math.MaxUint16 || len(newHost) > math.MaxUint16 ||
+		len(newTable) > math.MaxUint16 || len(newColumn) > math.MaxUint16 {
+		return branch_control.ErrAccessExpressionsTooLong.New(newDatabase, newBranch, newUser, newHost, newTable, newColumn)
 	}
 
 	// If we're not updating the same row, then we check for a row violation
-	if oldDatabase != newDatabase || oldBranch != newBranch || oldUser != newUser || oldHost != newHost {
-		if ok, modPerms := tbl.Match(newDatabase, newBranch, newUser, newHost); ok {
+	if oldDatabase != newDatabase || oldBranch != newBranch || oldUser != newUser || oldHost != newHost ||
+		oldTable != newTable || oldColumn != newColumn {
+		if ok, modPerms := tbl.Covers(newDatabase, newBranch, newUser, newHost, newTable, newColumn); ok {
 			permBits := uint64(modPerms)
-			permStr, _ := accessSchema[4].Type.(sql.SetType).BitsToString(permBits)
+			permStr, _ := accessSchema[accessPermissionsIndex].Type.(sql.SetType).BitsToString(permBits)
 			return sql.NewUniqueKeyErr(
-				fmt.Sprintf(`[%q, %q, %q, %q, %q]`, newDatabase, newBranch, newUser, newHost, permStr),
+				fmt.Sprintf(`[%q, %q, %q, %q, %q, %q, %q]`, newDatabase, newBranch, newUser, newHost, newTable, newColumn, permStr),
 				true,
-				sql.Row{newDatabase, newBranch, newUser, newHost, permBits})
+				sql.Row{newDatabase, newBranch, newUser, newHost, newTable, newColumn, permBits})
 		}
 	}
 
@@ -272,8 +312,8 @@ func (tbl BranchControlTable) Update(ctx *sql.Context, old sql.Row, new sql.Row)
 		}
 	}
 
-	tbl.Access.Delete(oldDatabase, oldBranch, oldUser, oldHost)
-	tbl.Access.Insert(newDatabase, newBranch, newUser, newHost, newPerms)
+	tbl.Access.Delete(oldDatabase, oldBranch, oldUser, oldHost, oldTable, oldColumn)
+	tbl.Access.Insert(newDatabase, newBranch, newUser, newHost, newTable, newColumn, newPerms)
 	return nil
 }
 
@@ -282,11 +322,13 @@ func (tbl BranchControlTable) Delete(ctx *sql.Context, row sql.Row) error {
 	tbl.RWMutex.Lock()
 	defer tbl.RWMutex.Unlock()
 
-	// Database, Branch, and Host are case-insensitive, while User is case-sensitive
+	// Database, Branch, Host, Table, and Column are case-insensitive, while User is case-sensitive
 	database := strings.ToLower(branch_control.FoldExpression(row[0].(string)))
 	branch := strings.ToLower(branch_control.FoldExpression(row[1].(string)))
 	user := branch_control.FoldExpression(row[2].(string))
 	host := strings.ToLower(branch_control.FoldExpression(row[3].(string)))
+	table := strings.ToLower(branch_control.FoldExpression(row[4].(string)))
+	column := strings.ToLower(branch_control.FoldExpression(row[5].(string)))
 
 	// A nil session means we're not in the SQL context, so we allow the deletion in such a case
 	if branchAwareSession := branch_control.GetBranchAwareSession(ctx); branchAwareSession != nil &&
@@ -302,7 +344,7 @@ func (tbl BranchControlTable) Delete(ctx *sql.Context, row sql.Row) error {
 		}
 	}
 
-	tbl.Access.Delete(database, branch, user, host)
+	tbl.Access.Delete(database, branch, user, host, table, column)
 	return nil
 }
 
@@ -306,7 +306,7 @@ func (tbl BranchNamespaceControlTable) insert(ctx context.Context, database, bra
 	}
 
 	// Add an entry to the binlog
-	tbl.GetBinlog().Insert(database, branch, user, host, 0)
+	tbl.GetBinlog().Insert(database, branch, user, host, "", "", 0)
 	// Add the expressions to their respective slices
 	databaseExpr := branch_control.ParseExpression(database, sql.Collation_utf8mb4_0900_ai_ci)
 	branchExpr := branch_control.ParseExpression(branch, sql.Collation_utf8mb4_0900_ai_ci)
@@ -337,7 +337,7 @@ func (tbl BranchNamespaceControlTable) delete(ctx context.Context, database, bra
 
 	endIndex := len(tbl.Values) - 1
 	// Add an entry to the binlog
-	tbl.GetBinlog().Delete(database, branch, user, host, 0)
+	tbl.GetBinlog().Delete(database, branch, user, host, "", "", 0)
 	// Remove the matching row from all slices by first swapping with the last element
 	tbl.Databases[tblIndex], tbl.Databases[endIndex] = tbl.Databases[endIndex], tbl.Databases[tblIndex]
 	tbl.Branches[tblIndex], tbl.Branches[endIndex] = tbl.Branches[endIndex], tbl.Branches[tblIndex]
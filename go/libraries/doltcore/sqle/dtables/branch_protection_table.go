@@ -0,0 +1,276 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+const (
+	ProtectionTableName = "dolt_branch_protection"
+)
+
+// protectionSchema is the schema for the "dolt_branch_protection" table.
+var protectionSchema = sql.Schema{
+	&sql.Column{
+		Name:       "database",
+		Type:       types.MustCreateString(sqltypes.VarChar, 16383, sql.Collation_utf8mb4_0900_ai_ci),
+		Source:     ProtectionTableName,
+		PrimaryKey: true,
+	},
+	&sql.Column{
+		Name:       "branch",
+		Type:       types.MustCreateString(sqltypes.VarChar, 16383, sql.Collation_utf8mb4_0900_ai_ci),
+		Source:     ProtectionTableName,
+		PrimaryKey: true,
+	},
+	&sql.Column{
+		Name:       "require_merge",
+		Type:       types.Boolean,
+		Default:    falseDefault,
+		Source:     ProtectionTableName,
+		PrimaryKey: false,
+	},
+}
+
+// falseDefault is the default for the "require_merge" column.
+var falseDefault = func() *sql.ColumnDefaultValue {
+	def, err := sql.NewColumnDefaultValue(expression.NewLiteral(int8(0), types.Boolean), types.Boolean, true, false, false)
+	if err != nil {
+		panic(err)
+	}
+	return def
+}()
+
+// BranchProtectionTable provides a layer over the branch_control.Protection structure, exposing it as a system table.
+type BranchProtectionTable struct {
+	*branch_control.Protection
+}
+
+var _ sql.Table = BranchProtectionTable{}
+var _ sql.InsertableTable = BranchProtectionTable{}
+var _ sql.ReplaceableTable = BranchProtectionTable{}
+var _ sql.UpdatableTable = BranchProtectionTable{}
+var _ sql.DeletableTable = BranchProtectionTable{}
+var _ sql.RowInserter = BranchProtectionTable{}
+var _ sql.RowReplacer = BranchProtectionTable{}
+var _ sql.RowUpdater = BranchProtectionTable{}
+var _ sql.RowDeleter = BranchProtectionTable{}
+
+// NewBranchProtectionTable returns a new BranchProtectionTable.
+func NewBranchProtectionTable(protection *branch_control.Protection) BranchProtectionTable {
+	return BranchProtectionTable{protection}
+}
+
+// Name implements the interface sql.Table.
+func (tbl BranchProtectionTable) Name() string {
+	return ProtectionTableName
+}
+
+// String implements the interface sql.Table.
+func (tbl BranchProtectionTable) String() string {
+	return ProtectionTableName
+}
+
+// Schema implements the interface sql.Table.
+func (tbl BranchProtectionTable) Schema() sql.Schema {
+	return protectionSchema
+}
+
+// Collation implements the interface sql.Table.
+func (tbl BranchProtectionTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions implements the interface sql.Table.
+func (tbl BranchProtectionTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows implements the interface sql.Table.
+func (tbl BranchProtectionTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	tbl.RWMutex.RLock()
+	defer tbl.RWMutex.RUnlock()
+
+	var rows []sql.Row
+	for _, value := range tbl.Values {
+		requireMerge := int8(0)
+		if value.RequireMerge {
+			requireMerge = 1
+		}
+		rows = append(rows, sql.Row{
+			value.Database,
+			value.Branch,
+			requireMerge,
+		})
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// Inserter implements the interface sql.InsertableTable.
+func (tbl BranchProtectionTable) Inserter(context *sql.Context) sql.RowInserter {
+	return tbl
+}
+
+// Replacer implements the interface sql.ReplaceableTable.
+func (tbl BranchProtectionTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return tbl
+}
+
+// Updater implements the interface sql.UpdatableTable.
+func (tbl BranchProtectionTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return tbl
+}
+
+// Deleter implements the interface sql.DeletableTable.
+func (tbl BranchProtectionTable) Deleter(context *sql.Context) sql.RowDeleter {
+	return tbl
+}
+
+// StatementBegin implements the interface sql.TableEditor.
+func (tbl BranchProtectionTable) StatementBegin(ctx *sql.Context) {}
+
+// DiscardChanges implements the interface sql.TableEditor.
+func (tbl BranchProtectionTable) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	return nil
+}
+
+// StatementComplete implements the interface sql.TableEditor.
+func (tbl BranchProtectionTable) StatementComplete(ctx *sql.Context) error {
+	return nil
+}
+
+// Insert implements the interface sql.RowInserter.
+func (tbl BranchProtectionTable) Insert(ctx *sql.Context, row sql.Row) error {
+	tbl.RWMutex.Lock()
+	defer tbl.RWMutex.Unlock()
+
+	database, branch, requireMerge, err := protectionRowValues(ctx, row)
+	if err != nil {
+		return err
+	}
+	if err = tbl.checkPrivileges(ctx, database, branch, branch_control.ErrInsertingProtectionRow); err != nil {
+		return err
+	}
+	// If we already have this in the table, then we return a duplicate PK error
+	if tbl.GetIndex(database, branch) != -1 {
+		return sql.NewUniqueKeyErr(fmt.Sprintf(`[%q, %q]`, database, branch), true, sql.Row{database, branch})
+	}
+	tbl.Protection.Insert(database, branch, requireMerge)
+	return nil
+}
+
+// Update implements the interface sql.RowUpdater.
+func (tbl BranchProtectionTable) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	tbl.RWMutex.Lock()
+	defer tbl.RWMutex.Unlock()
+
+	oldDatabase, oldBranch, _, err := protectionRowValues(ctx, old)
+	if err != nil {
+		return err
+	}
+	newDatabase, newBranch, requireMerge, err := protectionRowValues(ctx, new)
+	if err != nil {
+		return err
+	}
+
+	// If we're not updating the same row, then we pre-emptively check for a row violation
+	if oldDatabase != newDatabase || oldBranch != newBranch {
+		if tbl.GetIndex(newDatabase, newBranch) != -1 {
+			return sql.NewUniqueKeyErr(fmt.Sprintf(`[%q, %q]`, newDatabase, newBranch), true, sql.Row{newDatabase, newBranch})
+		}
+	}
+	if err = tbl.checkPrivileges(ctx, oldDatabase, oldBranch, branch_control.ErrUpdatingProtectionRow); err != nil {
+		return err
+	}
+	if err = tbl.checkPrivileges(ctx, newDatabase, newBranch, branch_control.ErrUpdatingProtectionRow); err != nil {
+		return err
+	}
+	tbl.Protection.Delete(oldDatabase, oldBranch)
+	tbl.Protection.Insert(newDatabase, newBranch, requireMerge)
+	return nil
+}
+
+// Delete implements the interface sql.RowDeleter.
+func (tbl BranchProtectionTable) Delete(ctx *sql.Context, row sql.Row) error {
+	tbl.RWMutex.Lock()
+	defer tbl.RWMutex.Unlock()
+
+	database, branch, _, err := protectionRowValues(ctx, row)
+	if err != nil {
+		return err
+	}
+	if err = tbl.checkPrivileges(ctx, database, branch, branch_control.ErrDeletingProtectionRow); err != nil {
+		return err
+	}
+	tbl.Protection.Delete(database, branch)
+	return nil
+}
+
+// Close implements the interface sql.Closer.
+func (tbl BranchProtectionTable) Close(context *sql.Context) error {
+	return branch_control.SaveData(context)
+}
+
+// checkPrivileges returns an error of the given kind if the user cannot modify rules for the given database and branch
+// expressions. Users that have the correct database privileges, or that are admins of the branch expression, may modify
+// the rules. A nil session means we're not in the SQL context, so the modification is allowed.
+func (tbl BranchProtectionTable) checkPrivileges(ctx *sql.Context, database string, branch string, errKind *errors.Kind) error {
+	branchAwareSession := branch_control.GetBranchAwareSession(ctx)
+	if branchAwareSession == nil || branch_control.HasDatabasePrivileges(branchAwareSession, database) {
+		return nil
+	}
+	// tbl.Access() shares a lock with the protection table. No need to acquire its lock.
+	user := branchAwareSession.GetUser()
+	host := branchAwareSession.GetHost()
+	// As we've folded the branch expression, we can use it directly as though it were a normal branch name to
+	// determine if the user attempting the modification has permission to perform it.
+	_, modPerms := tbl.Access().Match(database, branch, user, host)
+	if modPerms&branch_control.Permissions_Admin != branch_control.Permissions_Admin {
+		return errKind.New(user, host, database, branch)
+	}
+	return nil
+}
+
+// protectionRowValues returns the folded database and branch expressions of the given row, along with whether it
+// requires merges.
+func protectionRowValues(ctx *sql.Context, row sql.Row) (string, string, bool, error) {
+	// Database and Branch are case-insensitive
+	database := strings.ToLower(branch_control.FoldExpression(row[0].(string)))
+	branch := strings.ToLower(branch_control.FoldExpression(row[1].(string)))
+	// Verify that the lengths of each expression fit within an uint16
+	if len(database) > math.MaxUint16 || len(branch) > math.MaxUint16 {
+		return "", "", false, branch_control.ErrProtectionExpressionsTooLong.New(database, branch)
+	}
+	requireMerge := false
+	if row[2] != nil {
+		var err error
+		if requireMerge, err = sql.ConvertToBool(ctx, row[2]); err != nil {
+			return "", "", false, err
+		}
+	}
+	return database, branch, requireMerge, nil
+}
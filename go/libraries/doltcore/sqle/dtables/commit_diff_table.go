@@ -26,6 +26,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
@@ -40,7 +41,7 @@ var ErrInvalidCommitDiffTableArgs = errors.New("commit_diff_<table> requires one
 
 type CommitDiffTable struct {
 	name        string
-	dbName      string
+	db          dsess.SqlDatabase
 	ddb         *doltdb.DoltDB
 	joiner      *rowconv.Joiner
 	sqlSch      sql.PrimaryKeySchema
@@ -58,7 +59,7 @@ var _ sql.Table = (*CommitDiffTable)(nil)
 var _ sql.IndexAddressable = (*CommitDiffTable)(nil)
 var _ sql.StatisticsTable = (*CommitDiffTable)(nil)
 
-func NewCommitDiffTable(ctx *sql.Context, dbName, tblName string, ddb *doltdb.DoltDB, wRoot, sRoot doltdb.RootValue) (sql.Table, error) {
+func NewCommitDiffTable(ctx *sql.Context, db dsess.SqlDatabase, tblName string, ddb *doltdb.DoltDB, wRoot, sRoot doltdb.RootValue) (sql.Table, error) {
 	diffTblName := doltdb.DoltCommitDiffTablePrefix + tblName
 
 	_, table, tableExists, err := resolve.Table(ctx, wRoot, tblName)
@@ -79,13 +80,13 @@ func NewCommitDiffTable(ctx *sql.Context, dbName, tblName string, ddb *doltdb.Do
 		return nil, err
 	}
 
-	sqlSch, err := sqlutil.FromDoltSchema(dbName, diffTblName, diffTableSchema)
+	sqlSch, err := sqlutil.FromDoltSchema(db.Name(), diffTblName, diffTableSchema)
 	if err != nil {
 		return nil, err
 	}
 
 	return &CommitDiffTable{
-		dbName:       dbName,
+		db:           db,
 		name:         tblName,
 		ddb:          ddb,
 		workingRoot:  wRoot,
@@ -304,6 +305,9 @@ func (dt *CommitDiffTable) rootValForHash(ctx *sql.Context, hashStr string) (dol
 }
 
 func (dt *CommitDiffTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	if err := CheckReadAccess(ctx, dt.db, dt.name, dt.targetSchema); err != nil {
+		return nil, err
+	}
 	dp := part.(DiffPartition)
 	return dp.GetRowIter(ctx, dt.ddb, dt.joiner, sql.IndexLookup{})
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
@@ -29,7 +30,7 @@ import (
 )
 
 // NewConflictsTable returns a new ConflictsTable instance
-func NewConflictsTable(ctx *sql.Context, tblName string, srcTbl sql.Table, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
+func NewConflictsTable(ctx *sql.Context, db dsess.SqlDatabase, tblName string, srcTbl sql.Table, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
 	resolvedTableName, tbl, ok, err := resolve.Table(ctx, root, tblName)
 	if err != nil {
 		return nil, err
@@ -42,13 +43,13 @@ func NewConflictsTable(ctx *sql.Context, tblName string, srcTbl sql.Table, root
 		if !ok {
 			return nil, fmt.Errorf("%s can not have conflicts because it is not updateable", tblName)
 		}
-		return newProllyConflictsTable(ctx, tbl, upd, resolvedTableName, root, rs)
+		return newProllyConflictsTable(ctx, db, tbl, upd, resolvedTableName, root, rs)
 	}
 
-	return newNomsConflictsTable(ctx, tbl, resolvedTableName.Name, root, rs)
+	return newNomsConflictsTable(ctx, db, tbl, resolvedTableName.Name, root, rs)
 }
 
-func newNomsConflictsTable(ctx *sql.Context, tbl *doltdb.Table, tblName string, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
+func newNomsConflictsTable(ctx *sql.Context, db dsess.SqlDatabase, tbl *doltdb.Table, tblName string, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
 	rd, err := merge.NewConflictReader(ctx, tbl, doltdb.TableName{Name: tblName})
 	if err != nil {
 		return nil, err
@@ -61,6 +62,7 @@ func newNomsConflictsTable(ctx *sql.Context, tbl *doltdb.Table, tblName string,
 	}
 
 	return ConflictsTable{
+		db:      db,
 		tblName: tblName,
 		sqlSch:  sqlSch,
 		root:    root,
@@ -75,6 +77,7 @@ var _ sql.DeletableTable = ConflictsTable{}
 
 // ConflictsTable is a sql.Table implementation that provides access to the conflicts that exist for a user table
 type ConflictsTable struct {
+	db      dsess.SqlDatabase
 	tblName string
 	sqlSch  sql.PrimaryKeySchema
 	root    doltdb.RootValue
@@ -114,6 +117,13 @@ func (ct ConflictsTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error)
 
 // PartitionRows returns a RowIter for the given partition
 func (ct ConflictsTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	sch, err := ct.tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = CheckReadAccess(ctx, ct.db, ct.tblName, sch); err != nil {
+		return nil, err
+	}
 	// conflict reader must be reset each time partitionRows is called.
 	// TODO: schema name
 	rd, err := merge.NewConflictReader(ctx, ct.tbl, doltdb.TableName{Name: ct.tblName})
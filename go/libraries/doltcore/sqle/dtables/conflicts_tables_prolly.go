@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -37,6 +38,7 @@ import (
 
 func newProllyConflictsTable(
 	ctx *sql.Context,
+	db dsess.SqlDatabase,
 	tbl *doltdb.Table,
 	sourceUpdatableTbl sql.UpdatableTable,
 	tblName doltdb.TableName,
@@ -63,6 +65,7 @@ func newProllyConflictsTable(
 	}
 
 	return ProllyConflictsTable{
+		db:              db,
 		tblName:         tblName,
 		sqlSch:          sqlSch,
 		baseSch:         baseSch,
@@ -80,6 +83,7 @@ func newProllyConflictsTable(
 // ProllyConflictsTable is a sql.Table implementation that uses the merge
 // artifacts table to persist and read conflicts.
 type ProllyConflictsTable struct {
+	db                        dsess.SqlDatabase
 	tblName                   doltdb.TableName
 	sqlSch                    sql.PrimaryKeySchema
 	baseSch, ourSch, theirSch schema.Schema
@@ -115,6 +119,9 @@ func (ct ProllyConflictsTable) Partitions(ctx *sql.Context) (sql.PartitionIter,
 }
 
 func (ct ProllyConflictsTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	if err := CheckReadAccess(ctx, ct.db, ct.tblName.Name, ct.baseSch, ct.ourSch, ct.theirSch); err != nil {
+		return nil, err
+	}
 	return newProllyConflictRowIter(ctx, ct)
 }
 
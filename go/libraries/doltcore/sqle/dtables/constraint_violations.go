@@ -22,20 +22,21 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
 // NewConstraintViolationsTable returns a sql.Table that lists constraint violations.
-func NewConstraintViolationsTable(ctx *sql.Context, tblName string, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
+func NewConstraintViolationsTable(ctx *sql.Context, db dsess.SqlDatabase, tblName string, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
 	if root.VRW().Format() == types.Format_DOLT {
-		return newProllyCVTable(ctx, tblName, root, rs)
+		return newProllyCVTable(ctx, db, tblName, root, rs)
 	}
 
-	return newNomsCVTable(ctx, tblName, root, rs)
+	return newNomsCVTable(ctx, db, tblName, root, rs)
 }
 
-func newNomsCVTable(ctx *sql.Context, tblName string, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
+func newNomsCVTable(ctx *sql.Context, db dsess.SqlDatabase, tblName string, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
 	tbl, tblName, ok, err := doltdb.GetTableInsensitive(ctx, root, doltdb.TableName{Name: tblName})
 	if err != nil {
 		return nil, err
@@ -52,6 +53,7 @@ func newNomsCVTable(ctx *sql.Context, tblName string, root doltdb.RootValue, rs
 	}
 
 	return &constraintViolationsTable{
+		db:      db,
 		tblName: tblName,
 		root:    root,
 		cvSch:   cvSch,
@@ -64,6 +66,7 @@ func newNomsCVTable(ctx *sql.Context, tblName string, root doltdb.RootValue, rs
 // constraintViolationsTable is a sql.Table implementation that provides access to the constraint violations that exist
 // for a user table for the old format.
 type constraintViolationsTable struct {
+	db      dsess.SqlDatabase
 	tblName string
 	root    doltdb.RootValue
 	cvSch   schema.Schema
@@ -102,6 +105,13 @@ func (cvt *constraintViolationsTable) Partitions(ctx *sql.Context) (sql.Partitio
 
 // PartitionRows implements the interface sql.Table.
 func (cvt *constraintViolationsTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	sch, err := cvt.tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = CheckReadAccess(ctx, cvt.db, cvt.tblName, sch); err != nil {
+		return nil, err
+	}
 	cvMap, err := cvt.tbl.GetConstraintViolations(ctx)
 	if err != nil {
 		return nil, err
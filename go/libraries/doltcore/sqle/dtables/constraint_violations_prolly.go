@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
@@ -33,7 +34,7 @@ import (
 	"github.com/dolthub/dolt/go/store/val"
 )
 
-func newProllyCVTable(ctx *sql.Context, tblName string, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
+func newProllyCVTable(ctx *sql.Context, db dsess.SqlDatabase, tblName string, root doltdb.RootValue, rs RootSetter) (sql.Table, error) {
 	resolvedName, tbl, ok, err := resolve.Table(ctx, root, tblName)
 	if err != nil {
 		return nil, err
@@ -55,6 +56,7 @@ func newProllyCVTable(ctx *sql.Context, tblName string, root doltdb.RootValue, r
 	}
 	m := durable.ProllyMapFromArtifactIndex(arts)
 	return &prollyConstraintViolationsTable{
+		db:      db,
 		tblName: resolvedName,
 		root:    root,
 		sqlSch:  sqlSch,
@@ -67,6 +69,7 @@ func newProllyCVTable(ctx *sql.Context, tblName string, root doltdb.RootValue, r
 // prollyConstraintViolationsTable is a sql.Table implementation that provides access to the constraint violations that exist
 // for a user table for the v1 format.
 type prollyConstraintViolationsTable struct {
+	db      dsess.SqlDatabase
 	tblName doltdb.TableName
 	root    doltdb.RootValue
 	sqlSch  sql.PrimaryKeySchema
@@ -104,16 +107,19 @@ func (cvt *prollyConstraintViolationsTable) Partitions(ctx *sql.Context) (sql.Pa
 }
 
 func (cvt *prollyConstraintViolationsTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
-	idx, err := cvt.tbl.GetArtifacts(ctx)
+	sch, err := cvt.tbl.GetSchema(ctx)
 	if err != nil {
 		return nil, err
 	}
-	m := durable.ProllyMapFromArtifactIndex(idx)
-	itr, err := m.IterAllCVs(ctx)
+	if err = CheckReadAccess(ctx, cvt.db, cvt.tblName.Name, sch); err != nil {
+		return nil, err
+	}
+	idx, err := cvt.tbl.GetArtifacts(ctx)
 	if err != nil {
 		return nil, err
 	}
-	sch, err := cvt.tbl.GetSchema(ctx)
+	m := durable.ProllyMapFromArtifactIndex(idx)
+	itr, err := m.IterAllCVs(ctx)
 	if err != nil {
 		return nil, err
 	}
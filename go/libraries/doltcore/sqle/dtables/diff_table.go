@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expreval"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
@@ -60,6 +61,7 @@ var _ sql.StatisticsTable = (*DiffTable)(nil)
 
 type DiffTable struct {
 	name        string
+	db          dsess.SqlDatabase
 	ddb         *doltdb.DoltDB
 	workingRoot doltdb.RootValue
 	head        *doltdb.Commit
@@ -88,7 +90,7 @@ var PrimaryKeyChangeWarning = "cannot render full diff between commits %s and %s
 
 const PrimaryKeyChangeWarningCode int = 1105 // Since this is our own custom warning we'll use 1105, the code for an unknown error
 
-func NewDiffTable(ctx *sql.Context, dbName, tblName string, ddb *doltdb.DoltDB, root doltdb.RootValue, head *doltdb.Commit) (sql.Table, error) {
+func NewDiffTable(ctx *sql.Context, db dsess.SqlDatabase, tblName string, ddb *doltdb.DoltDB, root doltdb.RootValue, head *doltdb.Commit) (sql.Table, error) {
 	diffTblName := doltdb.DoltDiffTablePrefix + tblName
 
 	resolvedTableName, table, tableExists, err := resolve.Table(ctx, root, tblName)
@@ -108,13 +110,14 @@ func NewDiffTable(ctx *sql.Context, dbName, tblName string, ddb *doltdb.DoltDB,
 		return nil, err
 	}
 
-	sqlSch, err := sqlutil.FromDoltSchema(dbName, diffTblName, diffTableSchema)
+	sqlSch, err := sqlutil.FromDoltSchema(db.Name(), diffTblName, diffTableSchema)
 	if err != nil {
 		return nil, err
 	}
 
 	return &DiffTable{
 		name:             resolvedTableName.Name,
+		db:               db,
 		ddb:              ddb,
 		workingRoot:      root,
 		head:             head,
@@ -242,6 +245,9 @@ func (dt *DiffTable) HeadHash() (hash.Hash, error) {
 }
 
 func (dt *DiffTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	if err := CheckReadAccess(ctx, dt.db, dt.name, dt.targetSch); err != nil {
+		return nil, err
+	}
 	dp := part.(DiffPartition)
 	return dp.GetRowIter(ctx, dt.ddb, dt.joiner, dt.lookup)
 }
@@ -0,0 +1,103 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// GCStatusTable is a sql.Table implementation that implements a system table which shows the status of the garbage
+// collections of a database, along with the storage which grows between them.
+type GCStatusTable struct {
+	dbName string
+	ddb    *doltdb.DoltDB
+}
+
+var _ sql.Table = (*GCStatusTable)(nil)
+
+// NewGCStatusTable creates a GCStatusTable
+func NewGCStatusTable(dbName string, ddb *doltdb.DoltDB) sql.Table {
+	return &GCStatusTable{dbName: dbName, ddb: ddb}
+}
+
+func (t *GCStatusTable) Name() string {
+	return doltdb.GCStatusTableName
+}
+
+func (t *GCStatusTable) String() string {
+	return doltdb.GCStatusTableName
+}
+
+func (t *GCStatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "running", Type: types.Boolean, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "phase", Type: types.Text, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: true, DatabaseSource: t.dbName},
+		{Name: "kind", Type: types.Text, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: true, DatabaseSource: t.dbName},
+		{Name: "reason", Type: types.Text, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: true, DatabaseSource: t.dbName},
+		{Name: "started", Type: types.Datetime, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: true, DatabaseSource: t.dbName},
+		{Name: "finished", Type: types.Datetime, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: true, DatabaseSource: t.dbName},
+		{Name: "error", Type: types.Text, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: true, DatabaseSource: t.dbName},
+		{Name: "runs", Type: types.Uint64, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "journal_size", Type: types.Uint64, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+		{Name: "table_files", Type: types.Int64, Source: doltdb.GCStatusTableName, PrimaryKey: false, Nullable: false, DatabaseSource: t.dbName},
+	}
+}
+
+func (t *GCStatusTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (t *GCStatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (t *GCStatusTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	var status dsess.GCStatus
+	var ok bool
+	if controller := dsess.DSessFromSess(ctx.Session).GCSafepointController(); controller != nil {
+		status, ok = controller.Statuses().Get(t.dbName)
+	}
+
+	stats := t.ddb.StorageStats()
+	row := sql.NewRow(status.Running, nil, nil, nil, nil, nil, nil, status.Runs, stats.JournalSize, int64(stats.TableFiles))
+	if ok {
+		row[1] = status.Phase
+		row[2] = "full"
+		if status.Shallow {
+			row[2] = "shallow"
+		}
+		row[3] = status.Trigger
+		row[4] = status.Started
+		row[5] = nullableTime(status.Finished)
+		if status.Err != nil {
+			row[6] = status.Err.Error()
+		}
+	}
+	return sql.RowsToRowIter(row), nil
+}
+
+func nullableTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
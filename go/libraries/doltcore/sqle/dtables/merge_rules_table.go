@@ -0,0 +1,375 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*MergeRulesTable)(nil)
+var _ sql.UpdatableTable = (*MergeRulesTable)(nil)
+var _ sql.DeletableTable = (*MergeRulesTable)(nil)
+var _ sql.InsertableTable = (*MergeRulesTable)(nil)
+var _ sql.ReplaceableTable = (*MergeRulesTable)(nil)
+var _ sql.IndexAddressableTable = (*MergeRulesTable)(nil)
+
+// MergeRulesTable is the system table that declares how merges resolve conflicting changes to the same cell.
+type MergeRulesTable struct {
+	backingTable VersionableTable
+}
+
+func (mt *MergeRulesTable) Name() string {
+	return doltdb.MergeRulesTableName
+}
+
+func (mt *MergeRulesTable) String() string {
+	return doltdb.MergeRulesTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the dolt_merge_rules system table.
+func (mt *MergeRulesTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: typeinfo.StringDefaultType.ToSqlType(), Source: doltdb.MergeRulesTableName, PrimaryKey: true},
+		{Name: "column_name", Type: typeinfo.StringDefaultType.ToSqlType(), Source: doltdb.MergeRulesTableName, PrimaryKey: true},
+		{Name: "strategy", Type: typeinfo.StringDefaultType.ToSqlType(), Source: doltdb.MergeRulesTableName, PrimaryKey: false, Nullable: false},
+	}
+}
+
+func (mt *MergeRulesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (mt *MergeRulesTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	if mt.backingTable == nil {
+		// no backing table; return an empty iter.
+		return index.SinglePartitionIterFromNomsMap(nil), nil
+	}
+	return mt.backingTable.Partitions(context)
+}
+
+func (mt *MergeRulesTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if mt.backingTable == nil {
+		// no backing table; return an empty iter.
+		return sql.RowsToRowIter(), nil
+	}
+
+	return mt.backingTable.PartitionRows(context, partition)
+}
+
+// NewMergeRulesTable creates a MergeRulesTable
+func NewMergeRulesTable(_ *sql.Context, backingTable VersionableTable) sql.Table {
+	return &MergeRulesTable{backingTable: backingTable}
+}
+
+// NewEmptyMergeRulesTable creates a MergeRulesTable
+func NewEmptyMergeRulesTable(_ *sql.Context) sql.Table {
+	return &MergeRulesTable{}
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (mt *MergeRulesTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return newMergeRulesWriter(mt)
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (mt *MergeRulesTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return newMergeRulesWriter(mt)
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (mt *MergeRulesTable) Inserter(*sql.Context) sql.RowInserter {
+	return newMergeRulesWriter(mt)
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (mt *MergeRulesTable) Deleter(*sql.Context) sql.RowDeleter {
+	return newMergeRulesWriter(mt)
+}
+
+func (mt *MergeRulesTable) LockedToRoot(ctx *sql.Context, root doltdb.RootValue) (sql.IndexAddressableTable, error) {
+	if mt.backingTable == nil {
+		return mt, nil
+	}
+	return mt.backingTable.LockedToRoot(ctx, root)
+}
+
+// IndexedAccess implements IndexAddressableTable, but MergeRulesTable has no indexes.
+// Thus, this should never be called.
+func (mt *MergeRulesTable) IndexedAccess(lookup sql.IndexLookup) sql.IndexedTable {
+	panic("Unreachable")
+}
+
+// GetIndexes implements IndexAddressableTable, but MergeRulesTable has no indexes.
+func (mt *MergeRulesTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
+	return nil, nil
+}
+
+func (mt *MergeRulesTable) PreciseMatch() bool {
+	return true
+}
+
+var _ sql.RowReplacer = (*mergeRulesWriter)(nil)
+var _ sql.RowUpdater = (*mergeRulesWriter)(nil)
+var _ sql.RowInserter = (*mergeRulesWriter)(nil)
+var _ sql.RowDeleter = (*mergeRulesWriter)(nil)
+
+type mergeRulesWriter struct {
+	mt                      *MergeRulesTable
+	errDuringStatementBegin error
+	prevHash                *hash.Hash
+	tableWriter             dsess.TableWriter
+	// workingRoot is the working root at the start of the statement, used to validate the tables and columns that
+	// rules refer to.
+	workingRoot doltdb.RootValue
+}
+
+func newMergeRulesWriter(mt *MergeRulesTable) *mergeRulesWriter {
+	return &mergeRulesWriter{mt: mt}
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called.
+func (mw *mergeRulesWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	if err := mw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	if err := mw.validateMergeRule(ctx, r); err != nil {
+		return err
+	}
+	return mw.tableWriter.Insert(ctx, r)
+}
+
+// Update the given row. Provides both the old and new rows.
+func (mw *mergeRulesWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	if err := mw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	if err := mw.validateMergeRule(ctx, new); err != nil {
+		return err
+	}
+	return mw.tableWriter.Update(ctx, old, new)
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called.
+func (mw *mergeRulesWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	if err := mw.errDuringStatementBegin; err != nil {
+		return err
+	}
+	return mw.tableWriter.Delete(ctx, r)
+}
+
+// validateMergeRule returns an error if |r| doesn't name a valid merge rule strategy, or if the rule refers to a table
+// or column that doesn't exist in the working set or can't be merged with the strategy.
+func (mw *mergeRulesWriter) validateMergeRule(ctx *sql.Context, r sql.Row) error {
+	tableName, _ := r[0].(string)
+	columnName, _ := r[1].(string)
+	s, ok := r[2].(string)
+	if !ok {
+		return fmt.Errorf("the strategy of a merge rule must be one of ours, theirs, sum, max, or min")
+	}
+	strategy, err := doltdb.ParseMergeRuleStrategy(s)
+	if err != nil {
+		return err
+	}
+
+	tbl, _, ok, err := doltdb.GetTableInsensitive(ctx, mw.workingRoot, doltdb.TableName{Name: tableName})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("cannot add a merge rule for table '%s'; table not found", tableName)
+	}
+
+	if columnName == doltdb.MergeRuleAllColumns {
+		if strategy == doltdb.MergeRuleSum {
+			return fmt.Errorf("the merge rule 'sum' cannot be applied to all columns of a table; declare it for each numeric column")
+		}
+		return nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	col, ok := sch.GetAllCols().GetByNameCaseInsensitive(columnName)
+	if !ok {
+		return fmt.Errorf("cannot add a merge rule for column '%s'; column not found in table '%s'", columnName, tableName)
+	}
+	if strategy == doltdb.MergeRuleSum && !merge.IsSummable(col.TypeInfo.ToSqlType()) {
+		return fmt.Errorf("cannot apply the merge rule '%s' to column %s.%s of type %s; it is only supported for numeric columns",
+			strategy, tableName, col.Name, col.TypeInfo.ToSqlType().String())
+	}
+	return nil
+}
+
+// StatementBegin is called before the first operation of a statement. Integrators should mark the state of the data
+// in some way that it may be returned to in the case of an error.
+func (mw *mergeRulesWriter) StatementBegin(ctx *sql.Context) {
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+
+	roots, _ := dSess.GetRoots(ctx, dbName)
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		mw.errDuringStatementBegin = err
+		return
+	}
+	if !ok {
+		mw.errDuringStatementBegin = fmt.Errorf("no root value found in session")
+		return
+	}
+
+	prevHash, err := roots.Working.HashOf()
+	if err != nil {
+		mw.errDuringStatementBegin = err
+		return
+	}
+
+	mw.prevHash = &prevHash
+	mw.workingRoot = roots.Working
+
+	found, err := roots.Working.HasTable(ctx, doltdb.TableName{Name: doltdb.MergeRulesTableName})
+
+	if err != nil {
+		mw.errDuringStatementBegin = err
+		return
+	}
+
+	if !found {
+		// the persisted schema must agree with MergeRulesTable.Schema, including the NOT NULL strategy column.
+		colCollection := schema.NewColCollection(
+			schema.Column{
+				Name:          "table_name",
+				Tag:           schema.DoltMergeRulesTableNameTag,
+				Kind:          types.StringKind,
+				IsPartOfPK:    true,
+				TypeInfo:      typeinfo.FromKind(types.StringKind),
+				Default:       "",
+				AutoIncrement: false,
+				Comment:       "",
+				Constraints:   nil,
+			},
+			schema.Column{
+				Name:          "column_name",
+				Tag:           schema.DoltMergeRulesColumnNameTag,
+				Kind:          types.StringKind,
+				IsPartOfPK:    true,
+				TypeInfo:      typeinfo.FromKind(types.StringKind),
+				Default:       "",
+				AutoIncrement: false,
+				Comment:       "",
+				Constraints:   nil,
+			},
+			schema.Column{
+				Name:          "strategy",
+				Tag:           schema.DoltMergeRulesStrategyTag,
+				Kind:          types.StringKind,
+				IsPartOfPK:    false,
+				TypeInfo:      typeinfo.FromKind(types.StringKind),
+				Default:       "",
+				AutoIncrement: false,
+				Comment:       "",
+				Constraints:   []schema.ColConstraint{schema.NotNullConstraint{}},
+			},
+		)
+
+		newSchema, err := schema.NewSchema(colCollection, nil, schema.Collation_Default, nil, nil)
+		if err != nil {
+			mw.errDuringStatementBegin = err
+			return
+		}
+
+		// underlying table doesn't exist. Record this, then create the table.
+		newRootValue, err := doltdb.CreateEmptyTable(ctx, roots.Working, doltdb.TableName{Name: doltdb.MergeRulesTableName}, newSchema)
+
+		if err != nil {
+			mw.errDuringStatementBegin = err
+			return
+		}
+
+		if dbState.WorkingSet() == nil {
+			mw.errDuringStatementBegin = doltdb.ErrOperationNotSupportedInDetachedHead
+			return
+		}
+
+		// We use WriteSession.SetWorkingSet instead of DoltSession.SetWorkingRoot because we want to avoid modifying the root
+		// until the end of the transaction, but we still want the WriteSession to be able to find the newly
+		// created table.
+		if ws := dbState.WriteSession(); ws != nil {
+			err = ws.SetWorkingSet(ctx, dbState.WorkingSet().WithWorkingRoot(newRootValue))
+			if err != nil {
+				mw.errDuringStatementBegin = err
+				return
+			}
+		}
+
+		dSess.SetWorkingRoot(ctx, dbName, newRootValue)
+	}
+
+	if ws := dbState.WriteSession(); ws != nil {
+		tableWriter, err := ws.GetTableWriter(ctx, doltdb.TableName{Name: doltdb.MergeRulesTableName}, dbName, dSess.SetWorkingRoot, false)
+		if err != nil {
+			mw.errDuringStatementBegin = err
+			return
+		}
+		mw.tableWriter = tableWriter
+		tableWriter.StatementBegin(ctx)
+	}
+}
+
+// DiscardChanges is called if a statement encounters an error, and all current changes since the statement beginning
+// should be discarded.
+func (mw *mergeRulesWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	if mw.tableWriter != nil {
+		return mw.tableWriter.DiscardChanges(ctx, errorEncountered)
+	}
+	return nil
+}
+
+// StatementComplete is called after the last operation of the statement, indicating that it has successfully completed.
+// The mark set in StatementBegin may be removed, and a new one should be created on the next StatementBegin.
+func (mw *mergeRulesWriter) StatementComplete(ctx *sql.Context) error {
+	if mw.tableWriter != nil {
+		return mw.tableWriter.StatementComplete(ctx)
+	}
+	return nil
+}
+
+// Close finalizes the write operation, persisting the result.
+func (mw mergeRulesWriter) Close(ctx *sql.Context) error {
+	if mw.tableWriter != nil {
+		return mw.tableWriter.Close(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// CheckReadAccess returns an error if the branch control permissions of the current user do not allow for reading the
+// table named |tableName| of |db|, along with each column of |schemas|. System tables and table functions that return
+// the rows of a user table don't project the table's columns, so every column they may return is checked.
+func CheckReadAccess(ctx *sql.Context, db dsess.SqlDatabase, tableName string, schemas ...schema.Schema) error {
+	var columns []string
+	for _, sch := range schemas {
+		if sch != nil {
+			columns = append(columns, sch.GetAllCols().GetColumnNames()...)
+		}
+	}
+	return dsess.CheckColumnAccessForDb(ctx, db, tableName, columns, branch_control.Permissions_Read)
+}
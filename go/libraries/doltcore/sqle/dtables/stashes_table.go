@@ -0,0 +1,160 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var _ sql.Table = (*StashesTable)(nil)
+var _ sql.StatisticsTable = (*StashesTable)(nil)
+
+// StashesTable is a sql.Table implementation that implements a system table which shows the dolt stash entries
+type StashesTable struct {
+	ddb *doltdb.DoltDB
+}
+
+// NewStashesTable creates a StashesTable
+func NewStashesTable(_ *sql.Context, ddb *doltdb.DoltDB) sql.Table {
+	return &StashesTable{ddb: ddb}
+}
+
+func (st *StashesTable) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(st.Schema())
+	numRows, _, err := st.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (st *StashesTable) RowCount(ctx *sql.Context) (uint64, bool, error) {
+	if !st.ddb.Format().UsesFlatbuffers() {
+		return 0, true, nil
+	}
+	stashes, err := st.ddb.GetStashes(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	return uint64(len(stashes)), true, nil
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StashesTableName
+func (st *StashesTable) Name() string {
+	return doltdb.StashesTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// StashesTableName
+func (st *StashesTable) String() string {
+	return doltdb.StashesTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the stashes system table.
+func (st *StashesTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "name", Type: types.Text, Source: doltdb.StashesTableName, PrimaryKey: true, Nullable: false},
+		{Name: "stash_id", Type: types.Text, Source: doltdb.StashesTableName, PrimaryKey: false, Nullable: false},
+		{Name: "branch", Type: types.Text, Source: doltdb.StashesTableName, PrimaryKey: false, Nullable: false},
+		{Name: "hash", Type: types.Text, Source: doltdb.StashesTableName, PrimaryKey: false, Nullable: false},
+		{Name: "commit_message", Type: types.Text, Source: doltdb.StashesTableName, PrimaryKey: false, Nullable: true},
+	}
+}
+
+// Collation implements the sql.Table interface.
+func (st *StashesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently, the data is unpartitioned.
+func (st *StashesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (st *StashesTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	return NewStashItr(ctx, st.ddb)
+}
+
+// StashItr is a sql.RowItr implementation which iterates over each stash entry as if it's a row in the table.
+type StashItr struct {
+	stashes     []*doltdb.Stash
+	stashHashes []hash.Hash
+	idx         int
+}
+
+// NewStashItr creates a StashItr from the stash list of the database given.
+func NewStashItr(ctx *sql.Context, ddb *doltdb.DoltDB) (*StashItr, error) {
+	if !ddb.Format().UsesFlatbuffers() {
+		return &StashItr{}, nil
+	}
+
+	stashes, err := ddb.GetStashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stashHashes := make([]hash.Hash, len(stashes))
+	for i := range stashes {
+		stashHashes[i], err = ddb.GetStashHashAtIdx(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &StashItr{stashes: stashes, stashHashes: stashHashes}, nil
+}
+
+// Next retrieves the next row. It will return io.EOF if it's the last row.
+// After retrieving the last row, Close will be automatically closed.
+func (itr *StashItr) Next(*sql.Context) (sql.Row, error) {
+	if itr.idx >= len(itr.stashes) {
+		return nil, io.EOF
+	}
+
+	defer func() {
+		itr.idx++
+	}()
+
+	stash := itr.stashes[itr.idx]
+	commitHash, err := stash.HeadCommit.HashOf()
+	if err != nil {
+		return nil, err
+	}
+
+	branch := stash.BranchName
+	if ref.IsRef(branch) {
+		if dref, err := ref.Parse(branch); err == nil {
+			branch = dref.GetPath()
+		}
+	}
+
+	return sql.NewRow(stash.Name, itr.stashHashes[itr.idx].String(), branch, commitHash.String(), stash.Description), nil
+}
+
+// Close closes the iterator.
+func (itr *StashItr) Close(*sql.Context) error {
+	return nil
+}
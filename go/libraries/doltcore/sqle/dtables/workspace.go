@@ -52,6 +52,7 @@ import (
 const stagedColumnIdx = 1
 
 type WorkspaceTable struct {
+	db          dsess.SqlDatabase
 	userTblName string
 	ws          *doltdb.WorkingSet
 	head        doltdb.RootValue
@@ -62,6 +63,8 @@ type WorkspaceTable struct {
 
 	// headSchema is the schema of the table that is being modified.
 	headSchema schema.Schema
+	// toSchema is the schema of the table in the staged or working root.
+	toSchema schema.Schema
 }
 
 type WorkspaceTableModifier struct {
@@ -344,7 +347,7 @@ var _ sql.Table = (*WorkspaceTable)(nil)
 var _ sql.UpdatableTable = (*WorkspaceTable)(nil)
 var _ sql.DeletableTable = (*WorkspaceTable)(nil)
 
-func NewWorkspaceTable(ctx *sql.Context, workspaceName, userName string, head doltdb.RootValue, ws *doltdb.WorkingSet) (sql.Table, error) {
+func NewWorkspaceTable(ctx *sql.Context, db dsess.SqlDatabase, workspaceName, userName string, head doltdb.RootValue, ws *doltdb.WorkingSet) (sql.Table, error) {
 	stageDlt, err := diff.GetTableDeltas(ctx, head, ws.StagedRoot())
 	if err != nil {
 		return nil, err
@@ -417,6 +420,7 @@ func NewWorkspaceTable(ctx *sql.Context, workspaceName, userName string, head do
 	}
 
 	return &WorkspaceTable{
+		db:            db,
 		ws:            ws,
 		head:          head,
 		userTblName:   userName,
@@ -424,6 +428,7 @@ func NewWorkspaceTable(ctx *sql.Context, workspaceName, userName string, head do
 		stagedDeltas:  stgDel,
 		workingDeltas: wkDel,
 		headSchema:    fromSch,
+		toSchema:      toSch,
 	}, nil
 }
 
@@ -575,6 +580,9 @@ func (wt *WorkspaceTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error
 }
 
 func (wt *WorkspaceTable) PartitionRows(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
+	if err := CheckReadAccess(ctx, wt.db, wt.userTblName, wt.headSchema, wt.toSchema); err != nil {
+		return nil, err
+	}
 	wp, ok := part.(*WorkspacePartition)
 	if !ok {
 		return nil, fmt.Errorf("Runtime Exception: expected a WorkspacePartition, got %T", part)
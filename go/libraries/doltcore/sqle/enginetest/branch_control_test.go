@@ -64,7 +64,7 @@ type BranchControlBlockTest struct {
 // "other".
 var TestUserSetUpScripts = []string{
 	"DELETE FROM dolt_branch_control WHERE user = '%';",
-	"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+	"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
 	"CREATE USER testuser@localhost;",
 	"GRANT ALL ON *.* TO testuser@localhost;",
 	"REVOKE SUPER ON *.* FROM testuser@localhost;",
@@ -326,7 +326,7 @@ var BranchControlBlockTests = []BranchControlBlockTest{
 	{
 		Name: "DOLT_BRANCH Force Move",
 		SetUpScript: []string{
-			"INSERT INTO dolt_branch_control VALUES ('%', 'newother', 'testuser', 'localhost', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', 'newother', 'testuser', 'localhost', '%', '%', 'write');",
 		},
 		Query:       "CALL DOLT_BRANCH('-f', '-m', 'other', 'newother');",
 		ExpectedErr: branch_control.ErrCannotDeleteBranch,
@@ -603,7 +603,7 @@ var BranchControlTests = []BranchControlTest{
 		Name: "Namespace entries block",
 		SetUpScript: []string{
 			"DELETE FROM dolt_branch_control WHERE user = '%';",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
 			"CREATE USER testuser@localhost;",
 			"GRANT ALL ON *.* TO testuser@localhost;",
 		},
@@ -682,14 +682,14 @@ var BranchControlTests = []BranchControlTest{
 		Name: "Require admin to modify tables",
 		SetUpScript: []string{
 			"DELETE FROM dolt_branch_control WHERE user = '%';",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
 			"CREATE USER a@localhost;",
 			"CREATE USER b@localhost;",
 			"GRANT ALL ON *.* TO a@localhost;",
 			"REVOKE SUPER ON *.* FROM a@localhost;",
 			"GRANT ALL ON *.* TO b@localhost;",
 			"REVOKE SUPER ON *.* FROM b@localhost;",
-			"INSERT INTO dolt_branch_control VALUES ('%', 'other', 'a', 'localhost', 'write'), ('%', 'prefix%', 'a', 'localhost', 'admin')",
+			"INSERT INTO dolt_branch_control VALUES ('%', 'other', 'a', 'localhost', '%', '%', 'write'), ('%', 'prefix%', 'a', 'localhost', '%', '%', 'admin')",
 		},
 		Assertions: []BranchControlTestAssertion{
 			{
@@ -713,7 +713,7 @@ var BranchControlTests = []BranchControlTest{
 			{
 				User:  "a",
 				Host:  "localhost",
-				Query: "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1%', 'b', 'localhost', 'write');",
+				Query: "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1%', 'b', 'localhost', '%', '%', 'write');",
 				Expected: []sql.Row{
 					{types.NewOkResult(1)},
 				},
@@ -755,7 +755,7 @@ var BranchControlTests = []BranchControlTest{
 			{
 				User:        "b",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1%', 'b', 'localhost', 'admin');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1%', 'b', 'localhost', '%', '%', 'admin');",
 				ExpectedErr: branch_control.ErrInsertingAccessRow,
 			},
 			{ // Since "a" has admin on "prefix%", they can also insert into the namespace table
@@ -804,16 +804,16 @@ var BranchControlTests = []BranchControlTest{
 		Name: "Deleting entries works",
 		SetUpScript: []string{
 			"DELETE FROM dolt_branch_control WHERE user = '%';",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
 			"CREATE TABLE test (pk BIGINT PRIMARY KEY);",
 			"CREATE USER testuser@localhost;",
 			"GRANT ALL ON *.* TO testuser@localhost;",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_1', 'write');",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_2', 'write');",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost', 'write');",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_3', 'write');",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_4', 'write');",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_5', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_1', '%', '%', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_2', '%', '%', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost', '%', '%', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_3', '%', '%', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_4', '%', '%', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'testuser', 'localhost_5', '%', '%', 'write');",
 			"DELETE FROM dolt_branch_control WHERE host IN ('localhost_2', 'localhost_3');",
 		},
 		Assertions: []BranchControlTestAssertion{
@@ -822,10 +822,10 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"%", "%", "testuser", "localhost_1", "write"},
-					{"%", "%", "testuser", "localhost", "write"},
-					{"%", "%", "testuser", "localhost_4", "write"},
-					{"%", "%", "testuser", "localhost_5", "write"},
+					{"%", "%", "testuser", "localhost_1", "%", "%", "write"},
+					{"%", "%", "testuser", "localhost", "%", "%", "write"},
+					{"%", "%", "testuser", "localhost_4", "%", "%", "write"},
+					{"%", "%", "testuser", "localhost_5", "%", "%", "write"},
 				},
 			},
 			{
@@ -849,9 +849,9 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"%", "%", "testuser", "localhost_1", "write"},
-					{"%", "%", "testuser", "localhost", "write"},
-					{"%", "%", "testuser", "localhost_4", "write"},
+					{"%", "%", "testuser", "localhost_1", "%", "%", "write"},
+					{"%", "%", "testuser", "localhost", "%", "%", "write"},
+					{"%", "%", "testuser", "localhost_4", "%", "%", "write"},
 				},
 			},
 			{
@@ -875,8 +875,8 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"%", "%", "testuser", "localhost", "write"},
-					{"%", "%", "testuser", "localhost_4", "write"},
+					{"%", "%", "testuser", "localhost", "%", "%", "write"},
+					{"%", "%", "testuser", "localhost_4", "%", "%", "write"},
 				},
 			},
 			{
@@ -900,7 +900,7 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"%", "%", "testuser", "localhost", "write"},
+					{"%", "%", "testuser", "localhost", "%", "%", "write"},
 				},
 			},
 			{
@@ -948,7 +948,7 @@ var BranchControlTests = []BranchControlTest{
 			{
 				User:  "root",
 				Host:  "localhost",
-				Query: "INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', '%', 'admin');",
+				Query: "INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', '%', '%', '%', 'admin');",
 				Expected: []sql.Row{
 					{types.NewOkResult(1)},
 				},
@@ -958,7 +958,7 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control;",
 				Expected: []sql.Row{
-					{"%", "%", "root", "%", "admin"},
+					{"%", "%", "root", "%", "%", "%", "admin"},
 				},
 			},
 		},
@@ -967,31 +967,31 @@ var BranchControlTests = []BranchControlTest{
 		Name: "Subset entries count as duplicates",
 		SetUpScript: []string{
 			"DELETE FROM dolt_branch_control WHERE user = '%';",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
 			"CREATE USER testuser@localhost;",
 			"GRANT ALL ON *.* TO testuser@localhost;",
-			"INSERT INTO dolt_branch_control VALUES ('%', 'prefix', 'testuser', 'localhost', 'admin');",
-			"INSERT INTO dolt_branch_control VALUES ('%', 'prefix1%', 'testuser', 'localhost', 'admin');",
-			"INSERT INTO dolt_branch_control VALUES ('%', 'prefix2_', 'testuser', 'localhost', 'admin');",
-			"INSERT INTO dolt_branch_control VALUES ('%', 'prefix3_', 'testuser', 'localhost', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', 'prefix', 'testuser', 'localhost', '%', '%', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', 'prefix1%', 'testuser', 'localhost', '%', '%', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', 'prefix2_', 'testuser', 'localhost', '%', '%', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', 'prefix3_', 'testuser', 'localhost', '%', '%', 'admin');",
 		},
 		Assertions: []BranchControlTestAssertion{
 			{ // The pre-existing "prefix1%" entry will cover ALL possible matches of "prefix1sub%", so we treat it as a duplicate
 				User:        "testuser",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1sub%', 'testuser', 'localhost', 'admin');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1sub%', 'testuser', 'localhost', '%', '%', 'admin');",
 				ExpectedErr: sql.ErrPrimaryKeyViolation,
 			},
 			{ // The ending "%" fully covers "_", so we also treat it as a duplicate
 				User:        "testuser",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1_', 'testuser', 'localhost', 'admin');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1_', 'testuser', 'localhost', '%', '%', 'admin');",
 				ExpectedErr: sql.ErrPrimaryKeyViolation,
 			},
 			{ // This is the reverse of the above case, so this is NOT a duplicate (although the original is now a subset)
 				User:  "root",
 				Host:  "localhost",
-				Query: "INSERT INTO dolt_branch_control VALUES ('%', 'prefix2%', 'testuser', 'localhost', 'admin');",
+				Query: "INSERT INTO dolt_branch_control VALUES ('%', 'prefix2%', 'testuser', 'localhost', '%', '%', 'admin');",
 				Expected: []sql.Row{
 					{types.NewOkResult(1)},
 				},
@@ -1001,29 +1001,29 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"%", "prefix", "testuser", "localhost", "admin"},
-					{"%", "prefix1%", "testuser", "localhost", "admin"},
-					{"%", "prefix2_", "testuser", "localhost", "admin"},
-					{"%", "prefix2%", "testuser", "localhost", "admin"},
-					{"%", "prefix3_", "testuser", "localhost", "admin"},
+					{"%", "prefix", "testuser", "localhost", "%", "%", "admin"},
+					{"%", "prefix1%", "testuser", "localhost", "%", "%", "admin"},
+					{"%", "prefix2_", "testuser", "localhost", "%", "%", "admin"},
+					{"%", "prefix2%", "testuser", "localhost", "%", "%", "admin"},
+					{"%", "prefix3_", "testuser", "localhost", "%", "%", "admin"},
 				},
 			},
 			{ // Sanity checks to ensure that straight-up duplicates are also caught
 				User:        "testuser",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix', 'testuser', 'localhost', 'admin');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix', 'testuser', 'localhost', '%', '%', 'admin');",
 				ExpectedErr: sql.ErrPrimaryKeyViolation,
 			},
 			{
 				User:        "testuser",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1%', 'testuser', 'localhost', 'admin');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix1%', 'testuser', 'localhost', '%', '%', 'admin');",
 				ExpectedErr: sql.ErrPrimaryKeyViolation,
 			},
 			{
 				User:        "testuser",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix3_', 'testuser', 'localhost', 'admin');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'prefix3_', 'testuser', 'localhost', '%', '%', 'admin');",
 				ExpectedErr: sql.ErrPrimaryKeyViolation,
 			},
 			{ // Verify that creating branches also skips adding an entry if it would be a subset
@@ -1031,7 +1031,7 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'root';",
 				Expected: []sql.Row{
-					{"%", "%", "root", "localhost", "admin"},
+					{"%", "%", "root", "localhost", "%", "%", "admin"},
 				},
 			},
 			{
@@ -1045,7 +1045,7 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'root';",
 				Expected: []sql.Row{
-					{"%", "%", "root", "localhost", "admin"},
+					{"%", "%", "root", "localhost", "%", "%", "admin"},
 				},
 			},
 		},
@@ -1054,7 +1054,7 @@ var BranchControlTests = []BranchControlTest{
 		Name: "Creating branch creates new entry",
 		SetUpScript: []string{
 			"DELETE FROM dolt_branch_control WHERE user = '%';",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
 			"CREATE USER testuser@localhost;",
 			"GRANT ALL ON *.* TO testuser@localhost;",
 		},
@@ -1076,7 +1076,7 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"mydb", "otherbranch", "testuser", "localhost", "admin"},
+					{"mydb", "otherbranch", "testuser", "localhost", "%", "%", "admin"},
 				},
 			},
 		},
@@ -1085,11 +1085,11 @@ var BranchControlTests = []BranchControlTest{
 		Name: "Renaming branch creates new entry",
 		SetUpScript: []string{
 			"DELETE FROM dolt_branch_control WHERE user = '%';",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
 			"CREATE USER testuser@localhost;",
 			"GRANT ALL ON *.* TO testuser@localhost;",
 			"CALL DOLT_BRANCH('otherbranch');",
-			"INSERT INTO dolt_branch_control VALUES ('%', 'otherbranch', 'testuser', 'localhost', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', 'otherbranch', 'testuser', 'localhost', '%', '%', 'write');",
 		},
 		Assertions: []BranchControlTestAssertion{
 			{
@@ -1097,7 +1097,7 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"%", "otherbranch", "testuser", "localhost", "write"},
+					{"%", "otherbranch", "testuser", "localhost", "%", "%", "write"},
 				},
 			},
 			{
@@ -1117,8 +1117,8 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"%", "otherbranch", "testuser", "localhost", "write"},  // Original entry remains
-					{"mydb", "newbranch", "testuser", "localhost", "admin"}, // New entry is scoped specifically to db
+					{"%", "otherbranch", "testuser", "localhost", "%", "%", "write"},  // Original entry remains
+					{"mydb", "newbranch", "testuser", "localhost", "%", "%", "admin"}, // New entry is scoped specifically to db
 				},
 			},
 		},
@@ -1127,7 +1127,7 @@ var BranchControlTests = []BranchControlTest{
 		Name: "Copying branch creates new entry",
 		SetUpScript: []string{
 			"DELETE FROM dolt_branch_control WHERE user = '%';",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
 			"CREATE USER testuser@localhost;",
 			"GRANT ALL ON *.* TO testuser@localhost;",
 			"CALL DOLT_BRANCH('otherbranch');",
@@ -1156,7 +1156,7 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"mydb", "newbranch", "testuser", "localhost", "admin"},
+					{"mydb", "newbranch", "testuser", "localhost", "%", "%", "admin"},
 				},
 			},
 		},
@@ -1165,8 +1165,8 @@ var BranchControlTests = []BranchControlTest{
 		Name: "Proper database scoping",
 		SetUpScript: []string{
 			"DELETE FROM dolt_branch_control WHERE user = '%';",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin')," +
-				"('dba', 'main', 'testuser', 'localhost', 'write'), ('dbb', 'other', 'testuser', 'localhost', 'write');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin')," +
+				"('dba', 'main', 'testuser', 'localhost', '%', '%', 'write'), ('dbb', 'other', 'testuser', 'localhost', '%', '%', 'write');",
 			"CREATE DATABASE dba;", // Implicitly creates "main" branch
 			"CREATE DATABASE dbb;", // Implicitly creates "main" branch
 			"CREATE USER testuser@localhost;",
@@ -1271,7 +1271,7 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control WHERE user = 'testuser';",
 				Expected: []sql.Row{
-					{"mydb", "newbranch", "testuser", "localhost", "admin"},
+					{"mydb", "newbranch", "testuser", "localhost", "%", "%", "admin"},
 				},
 			},
 		},
@@ -1280,7 +1280,7 @@ var BranchControlTests = []BranchControlTest{
 		Name: "Database-level admin privileges allow scoped table modifications",
 		SetUpScript: []string{
 			"DELETE FROM dolt_branch_control WHERE user = '%';",
-			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', 'admin');",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
 			"CREATE DATABASE dba;",
 			"CREATE DATABASE dbb;",
 			"CREATE USER a@localhost;",
@@ -1295,7 +1295,7 @@ var BranchControlTests = []BranchControlTest{
 			{
 				User:  "a",
 				Host:  "localhost",
-				Query: "INSERT INTO dolt_branch_control VALUES ('dba', 'dummy1', '%', '%', 'write');",
+				Query: "INSERT INTO dolt_branch_control VALUES ('dba', 'dummy1', '%', '%', '%', '%', 'write');",
 				Expected: []sql.Row{
 					{types.NewOkResult(1)},
 				},
@@ -1303,31 +1303,31 @@ var BranchControlTests = []BranchControlTest{
 			{
 				User:        "a",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('db_', 'dummy2', '%', '%', 'write');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('db_', 'dummy2', '%', '%', '%', '%', 'write');",
 				ExpectedErr: branch_control.ErrInsertingAccessRow,
 			},
 			{
 				User:        "a",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('dbb', 'dummy3', '%', '%', 'write');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('dbb', 'dummy3', '%', '%', '%', '%', 'write');",
 				ExpectedErr: branch_control.ErrInsertingAccessRow,
 			},
 			{
 				User:        "b",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('dba', 'dummy4', '%', '%', 'write');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('dba', 'dummy4', '%', '%', '%', '%', 'write');",
 				ExpectedErr: branch_control.ErrInsertingAccessRow,
 			},
 			{
 				User:        "b",
 				Host:        "localhost",
-				Query:       "INSERT INTO dolt_branch_control VALUES ('db_', 'dummy5', '%', '%', 'write');",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('db_', 'dummy5', '%', '%', '%', '%', 'write');",
 				ExpectedErr: branch_control.ErrInsertingAccessRow,
 			},
 			{
 				User:  "b",
 				Host:  "localhost",
-				Query: "INSERT INTO dolt_branch_control VALUES ('dbb', 'dummy6', '%', '%', 'write');",
+				Query: "INSERT INTO dolt_branch_control VALUES ('dbb', 'dummy6', '%', '%', '%', '%', 'write');",
 				Expected: []sql.Row{
 					{types.NewOkResult(1)},
 				},
@@ -1343,7 +1343,7 @@ var BranchControlTests = []BranchControlTest{
 			{
 				User:  "a",
 				Host:  "localhost",
-				Query: "INSERT INTO dolt_branch_control VALUES ('db_', 'dummy7', '%', '%', 'write');",
+				Query: "INSERT INTO dolt_branch_control VALUES ('db_', 'dummy7', '%', '%', '%', '%', 'write');",
 				Expected: []sql.Row{
 					{types.NewOkResult(1)},
 				},
@@ -1353,14 +1353,481 @@ var BranchControlTests = []BranchControlTest{
 				Host:  "localhost",
 				Query: "SELECT * FROM dolt_branch_control;",
 				Expected: []sql.Row{
-					{"%", "%", "root", "localhost", "admin"},
-					{"dba", "dummy1", "%", "%", "write"},
-					{"dbb", "dummy6", "%", "%", "write"},
-					{"db_", "dummy7", "%", "%", "write"},
+					{"%", "%", "root", "localhost", "%", "%", "admin"},
+					{"dba", "dummy1", "%", "%", "%", "%", "write"},
+					{"dbb", "dummy6", "%", "%", "%", "%", "write"},
+					{"db_", "dummy7", "%", "%", "%", "%", "write"},
 				},
 			},
 		},
 	},
+	{
+		Name: "Table and column scoped entries",
+		SetUpScript: []string{
+			"DELETE FROM dolt_branch_control WHERE user = '%';",
+			"INSERT INTO dolt_branch_control VALUES ('%', '%', 'root', 'localhost', '%', '%', 'admin');",
+			"CREATE USER testuser@localhost;",
+			"GRANT ALL ON *.* TO testuser@localhost;",
+			"CREATE TABLE orders_1 (pk BIGINT PRIMARY KEY, v BIGINT);",
+			"CREATE TABLE customers (pk BIGINT PRIMARY KEY, name VARCHAR(20), ssn VARCHAR(20));",
+			"INSERT INTO orders_1 VALUES (1, 1);",
+			"INSERT INTO customers VALUES (1, 'alice', '123-45-6789');",
+			"CALL DOLT_COMMIT('-Am', 'init');",
+			"CALL DOLT_BRANCH('release1');",
+			"CREATE TABLE `mydb/release1`.employees (pk BIGINT PRIMARY KEY, ssn VARCHAR(20));",
+			"INSERT INTO `mydb/release1`.employees VALUES (1, '111-11-1111');",
+			"INSERT INTO dolt_branch_control VALUES ('%', 'main', 'testuser', 'localhost', '%', '%', 'read')," +
+				"('%', 'main', 'testuser', 'localhost', 'orders_%', '%', 'write')," +
+				"('%', 'release%', '%', '%', '%', 'ssn', '');",
+		},
+		Assertions: []BranchControlTestAssertion{
+			{ // Table entries take precedence over the branch entry
+				User:  "testuser",
+				Host:  "localhost",
+				Query: "INSERT INTO orders_1 VALUES (2, 2);",
+				Expected: []sql.Row{
+					{types.NewOkResult(1)},
+				},
+			},
+			{
+				User:  "testuser",
+				Host:  "localhost",
+				Query: "UPDATE orders_1 SET v = 3 WHERE pk = 2;",
+				Expected: []sql.Row{
+					{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}},
+				},
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "INSERT INTO customers VALUES (2, 'bob', '987-65-4321');",
+				ExpectedErr: branch_control.ErrIncorrectTablePermissions,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "UPDATE customers SET name = 'bob';",
+				ExpectedErr: branch_control.ErrIncorrectTablePermissions,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "DROP TABLE customers;",
+				ExpectedErr: branch_control.ErrIncorrectTablePermissions,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "CALL DOLT_COMMIT('-Am', 'orders');",
+				ExpectedErr: branch_control.ErrIncorrectPermissions,
+			},
+			{ // The ssn column is only hidden on release branches
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT * FROM customers;",
+				Expected: []sql.Row{{1, "alice", "123-45-6789"}},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT pk, name FROM `mydb/release1`.customers;",
+				Expected: []sql.Row{{1, "alice"}},
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT * FROM `mydb/release1`.customers;",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT name FROM `mydb/release1`.customers WHERE ssn = '123-45-6789';",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "SELECT ssn FROM `mydb/release1`.customers WHERE pk = 1;",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "UPDATE `mydb/release1`.customers SET ssn = NULL;",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{ // Hidden columns may not be read through the system tables and table functions that return rows
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT to_ssn FROM `mydb/release1`.dolt_diff_customers;",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT to_ssn FROM `mydb/release1`.dolt_commit_diff_customers WHERE from_commit = HASHOF('main~') AND to_commit = HASHOF('main');",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT ssn FROM `mydb/release1`.dolt_history_customers;",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT pk, name FROM `mydb/release1`.dolt_history_customers;",
+				Expected: []sql.Row{{1, "alice"}},
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT to_ssn FROM `mydb/release1`.dolt_workspace_employees;",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "USE `mydb/release1`;",
+				Expected: []sql.Row{},
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT to_ssn FROM dolt_diff('main~', 'main', 'customers');",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "SELECT statement FROM dolt_patch('main~', 'main', 'customers');",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "USE mydb;",
+				Expected: []sql.Row{},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT to_ssn FROM dolt_diff_customers;",
+				Expected: []sql.Row{{"123-45-6789"}},
+			},
+			{ // Entries of a narrower scope may be added alongside those of a wider scope
+				User:  "root",
+				Host:  "localhost",
+				Query: "INSERT INTO dolt_branch_control (`database`, branch, user, host, `table`, permissions) VALUES ('%', 'main', 'testuser', 'localhost', 'customers', 'write');",
+				Expected: []sql.Row{
+					{types.NewOkResult(1)},
+				},
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_branch_control VALUES ('%', 'main', 'testuser', 'localhost', 'orders_1', '%', 'write');",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				User:  "testuser",
+				Host:  "localhost",
+				Query: "INSERT INTO customers VALUES (2, 'bob', '987-65-4321');",
+				Expected: []sql.Row{
+					{types.NewOkResult(1)},
+				},
+			},
+			{ // Columns may be made read-only
+				User:  "root",
+				Host:  "localhost",
+				Query: "INSERT INTO dolt_branch_control VALUES ('%', 'main', 'testuser', 'localhost', 'customers', 'ssn', 'read');",
+				Expected: []sql.Row{
+					{types.NewOkResult(1)},
+				},
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "INSERT INTO customers VALUES (3, 'carol', '555-55-5555');",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "UPDATE customers SET ssn = NULL WHERE pk = 2;",
+				ExpectedErr: branch_control.ErrIncorrectColumnPermissions,
+			},
+			{ // Updates that don't change the read-only column are allowed
+				User:  "testuser",
+				Host:  "localhost",
+				Query: "UPDATE customers SET name = 'dave' WHERE pk = 2;",
+				Expected: []sql.Row{
+					{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}},
+				},
+			},
+			{
+				User:     "testuser",
+				Host:     "localhost",
+				Query:    "SELECT * FROM customers ORDER BY pk;",
+				Expected: []sql.Row{{1, "alice", "123-45-6789"}, {2, "dave", "987-65-4321"}},
+			},
+			{
+				User:  "root",
+				Host:  "localhost",
+				Query: "SELECT * FROM dolt_branch_control WHERE user <> 'root';",
+				Expected: []sql.Row{
+					{"%", "main", "testuser", "localhost", "%", "%", "read"},
+					{"%", "main", "testuser", "localhost", "orders_%", "%", "write"},
+					{"%", "release%", "%", "%", "%", "ssn", ""},
+					{"%", "main", "testuser", "localhost", "customers", "%", "write"},
+					{"%", "main", "testuser", "localhost", "customers", "ssn", "read"},
+				},
+			},
+		},
+	},
+	{
+		Name: "Branch protection",
+		SetUpScript: []string{
+			"CREATE USER testuser@localhost;",
+			"GRANT ALL ON *.* TO testuser@localhost;",
+			"CREATE TABLE test (pk BIGINT PRIMARY KEY);",
+			"INSERT INTO test VALUES (1);",
+			"CALL DOLT_COMMIT('-Am', 'init');",
+			"CALL DOLT_BRANCH('feature');",
+			"CALL DOLT_BRANCH('other');",
+			"INSERT INTO dolt_branch_protection VALUES ('%', 'main', true), ('%', 'release%', false);",
+			"CALL DOLT_CHECKOUT('feature');",
+			"INSERT INTO test VALUES (2);",
+			"CALL DOLT_COMMIT('-am', 'feature');",
+			"CALL DOLT_CHECKOUT('main');",
+			"CALL DOLT_MERGE('feature', '--no-ff', '-m', 'merge feature');",
+			"CALL DOLT_RESET('--hard');",
+			"CALL DOLT_BRANCH('release1');",
+		},
+		Assertions: []BranchControlTestAssertion{
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SELECT message FROM dolt_log LIMIT 2;",
+				Expected: []sql.Row{{"merge feature"}, {"feature"}},
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "CALL DOLT_RESET('--hard', 'HEAD~1');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_RESET('--soft', 'HEAD~1');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_RESET('HEAD~1');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{ // Commits that can't be resolved fail the protection check
+				User:           "root",
+				Host:           "localhost",
+				Query:          "CALL DOLT_RESET('--hard', 'HEAD~10');",
+				ExpectedErrStr: "invalid ancestor spec",
+			},
+			{ // Resetting to the current commit only affects the working set
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CALL DOLT_RESET('--hard', 'HEAD');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_REBASE('-i', 'other');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_COMMIT('--amend', '-m', 'amended');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:  "root",
+				Host:  "localhost",
+				Query: "INSERT INTO test VALUES (3);",
+				Expected: []sql.Row{
+					{types.NewOkResult(1)},
+				},
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_COMMIT('-am', 'direct');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SET @@dolt_transaction_commit = 1;",
+				Expected: []sql.Row{{}},
+			},
+			{ // Committing the transaction would create a commit directly on the branch
+				User:        "root",
+				Host:        "localhost",
+				Query:       "INSERT INTO test VALUES (5);",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "SET @@dolt_transaction_commit = 0;",
+				Expected: []sql.Row{{}},
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_CHERRY_PICK('feature');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_BRANCH('-D', 'main');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_BRANCH('-m', 'main', 'renamed');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_BRANCH('-f', 'main', 'other');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_BRANCH('-c', '-f', 'other', 'main');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_BRANCH('-m', '-f', 'other', 'main');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_CHECKOUT('-B', 'main', 'other');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{ // Rules that don't require merges still allow direct commits
+				User:  "root",
+				Host:  "localhost",
+				Query: "CALL DOLT_CHECKOUT('release1');",
+				Expected: []sql.Row{
+					{0, "Switched to branch 'release1'"},
+				},
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_COMMIT('--amend', '-m', 'amended');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{
+				User:  "root",
+				Host:  "localhost",
+				Query: "INSERT INTO test VALUES (4);",
+				Expected: []sql.Row{
+					{types.NewOkResult(1)},
+				},
+			},
+			{
+				User:  "root",
+				Host:  "localhost",
+				Query: "CALL DOLT_COMMIT('-am', 'direct');",
+				Expected: []sql.Row{
+					{doltCommit},
+				},
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "CALL DOLT_BRANCH('-D', 'release1');",
+				ExpectedErr: branch_control.ErrProtectedBranch,
+			},
+			{ // Unprotected branches are unaffected
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CALL DOLT_BRANCH('-D', 'other');",
+				Expected: []sql.Row{{0}},
+			},
+			{ // Only admins may modify the rules
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "DELETE FROM dolt_branch_protection;",
+				ExpectedErr: branch_control.ErrDeletingProtectionRow,
+			},
+			{
+				User:        "testuser",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_branch_protection VALUES ('%', 'feature', false);",
+				ExpectedErr: branch_control.ErrInsertingProtectionRow,
+			},
+			{
+				User:        "root",
+				Host:        "localhost",
+				Query:       "INSERT INTO dolt_branch_protection (`database`, branch) VALUES ('%', 'MAIN');",
+				ExpectedErr: sql.ErrPrimaryKeyViolation,
+			},
+			{
+				User:  "root",
+				Host:  "localhost",
+				Query: "SELECT * FROM dolt_branch_protection ORDER BY branch;",
+				Expected: []sql.Row{
+					{"%", "main", 1},
+					{"%", "release%", 0},
+				},
+			},
+			{
+				User:  "root",
+				Host:  "localhost",
+				Query: "DELETE FROM dolt_branch_protection WHERE branch = 'main';",
+				Expected: []sql.Row{
+					{types.NewOkResult(1)},
+				},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CALL DOLT_BRANCH('-D', 'feature');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				User:  "root",
+				Host:  "localhost",
+				Query: "CALL DOLT_CHECKOUT('main');",
+				Expected: []sql.Row{
+					{0, "Switched to branch 'main'"},
+				},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "CALL DOLT_RESET('--hard', 'HEAD~1');",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
 }
 
 func TestBranchControl(t *testing.T) {
@@ -1448,7 +1915,7 @@ func TestBranchControlBlocks(t *testing.T) {
 			})
 			enginetest.AssertErrWithCtx(t, engine, harness, userCtx, test.Query, nil, test.ExpectedErr)
 
-			addUserQuery := "INSERT INTO dolt_branch_control VALUES ('%', 'main', 'testuser', 'localhost', 'write'), ('%', 'other', 'testuser', 'localhost', 'write');"
+			addUserQuery := "INSERT INTO dolt_branch_control VALUES ('%', 'main', 'testuser', 'localhost', '%', '%', 'write'), ('%', 'other', 'testuser', 'localhost', '%', '%', 'write');"
 			addUserQueryResults := []sql.Row{{types.NewOkResult(2)}}
 			enginetest.TestQueryWithContext(t, rootCtx, engine, harness, addUserQuery, addUserQueryResults, nil, nil, nil)
 
@@ -1486,7 +1953,7 @@ func TestBranchControlBlocks(t *testing.T) {
 				enginetest.RunQueryWithContext(t, engine, harness, rootCtx, statement)
 			}
 
-			addUserQuery := "INSERT INTO dolt_branch_control VALUES ('%', 'main', 'testuser', 'localhost', 'write');"
+			addUserQuery := "INSERT INTO dolt_branch_control VALUES ('%', 'main', 'testuser', 'localhost', '%', '%', 'write');"
 			addUserQueryResults := []sql.Row{{types.NewOkResult(1)}}
 			enginetest.TestQueryWithContext(t, rootCtx, engine, harness, addUserQuery, addUserQueryResults, nil, nil, nil)
 
@@ -1496,7 +1963,7 @@ func TestBranchControlBlocks(t *testing.T) {
 			})
 			enginetest.AssertErrWithCtx(t, engine, harness, userCtx, test.Query, nil, test.ExpectedErr)
 
-			addUserQuery = "INSERT INTO dolt_branch_control VALUES ('%', 'other', 'testuser', 'localhost', 'write');"
+			addUserQuery = "INSERT INTO dolt_branch_control VALUES ('%', 'other', 'testuser', 'localhost', '%', '%', 'write');"
 			addUserQueryResults = []sql.Row{{types.NewOkResult(1)}}
 			enginetest.TestQueryWithContext(t, rootCtx, engine, harness, addUserQuery, addUserQueryResults, nil, nil, nil)
 
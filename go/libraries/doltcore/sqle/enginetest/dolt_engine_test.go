@@ -2048,7 +2048,22 @@ func TestStatsAutoRefreshConcurrency(t *testing.T) {
 	}
 }
 
+func TestDoltBisect(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunDoltBisectTests(t, harness)
+}
+
+func TestDoltStash(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunDoltStashTests(t, harness)
+}
+
 func TestDoltWorkspace(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	RunDoltWorkspaceTests(t, harness)
 }
+
+func TestDoltTestsTable(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunDoltTestsTableTests(t, harness)
+}
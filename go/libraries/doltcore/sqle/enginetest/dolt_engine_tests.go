@@ -1972,6 +1972,26 @@ func RunDoltReflogTestsPrepared(t *testing.T, h DoltEnginetestHarness) {
 	}
 }
 
+func RunDoltBisectTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range DoltBisectTests {
+		func() {
+			h = h.NewHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
+func RunDoltStashTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range DoltStashTests {
+		func() {
+			h = h.NewHarness(t)
+			defer h.Close()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
+
 func RunDoltWorkspaceTests(t *testing.T, h DoltEnginetestHarness) {
 	for _, script := range DoltWorkspaceScriptTests {
 		func() {
@@ -1981,3 +2001,17 @@ func RunDoltWorkspaceTests(t *testing.T, h DoltEnginetestHarness) {
 		}()
 	}
 }
+
+func RunDoltTestsTableTests(t *testing.T, h DoltEnginetestHarness) {
+	for _, script := range DoltTestsTableTests {
+		func() {
+			h = h.NewHarness(t)
+			defer h.Close()
+			defer func() {
+				require.NoError(t, sql.SystemVariables.SetGlobal(dsess.DoltPreCommitTestGroups, ""))
+				require.NoError(t, sql.SystemVariables.SetGlobal(dsess.DoltPreMergeTestGroups, ""))
+			}()
+			enginetest.TestScript(t, h, script)
+		}()
+	}
+}
@@ -1874,6 +1874,86 @@ var DoltUserPrivTests = []queries.UserPrivilegeTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_rebase exec statements are checked against the caller's privileges",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"create table secret (pk int primary key);",
+			"call dolt_commit('-Am', 'creating tables');",
+			"call dolt_branch('branch1');",
+			"call dolt_checkout('branch1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'inserting row 1');",
+			"call dolt_rebase('-i', 'main');",
+			"insert into dolt_rebase values (1.5, 'exec', '', 'insert into t select * from secret');",
+			"set @@GLOBAL.mydb_default_branch = 'dolt_rebase_branch1';",
+			"CREATE USER tester@localhost;",
+			"GRANT SELECT, INSERT, UPDATE, DELETE ON mydb.t TO tester@localhost;",
+			"GRANT SELECT, INSERT, UPDATE, DELETE ON mydb.dolt_rebase TO tester@localhost;",
+			"GRANT EXECUTE ON mydb.* TO tester@localhost;",
+		},
+		Assertions: []queries.UserPrivilegeTestAssertion{
+			{
+				// tester can't read the secret table, so the exec step fails and the rebase is aborted
+				User:           "tester",
+				Host:           "localhost",
+				Query:          "call dolt_rebase('--continue');",
+				ExpectedErrStr: "exec statement failed: command denied to user 'tester'@'localhost'; the rebase has been automatically aborted",
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "set @@GLOBAL.mydb_default_branch = 'main';",
+				Expected: []sql.Row{{}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "select name from dolt_branches order by name;",
+				Expected: []sql.Row{{"branch1"}, {"main"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_tests queries are checked against the caller's privileges",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"create table secret (pk int primary key);",
+			"insert into dolt_tests values ('no secrets', null, 'select pk from secret', 'expected_rows', '==', '0');",
+			"call dolt_commit('-Am', 'creating tables');",
+			"set global dolt_pre_commit_test_groups = '*';",
+			"CREATE USER tester@localhost;",
+			"GRANT SELECT, INSERT, UPDATE, DELETE ON mydb.t TO tester@localhost;",
+			"GRANT EXECUTE ON mydb.* TO tester@localhost;",
+		},
+		Assertions: []queries.UserPrivilegeTestAssertion{
+			{
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "insert into t values (1);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				// tester can't read the secret table, so the test fails
+				User:           "tester",
+				Host:           "localhost",
+				Query:          "call dolt_commit('-am', 'inserting row 1');",
+				ExpectedErrStr: "commit rejected, as the following tests in dolt_tests failed:\nno secrets: command denied to user 'tester'@'localhost'",
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "call dolt_commit('-am', 'inserting row 1');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				User:     "root",
+				Host:     "localhost",
+				Query:    "set global dolt_pre_commit_test_groups = '';",
+				Expected: []sql.Row{{}},
+			},
+		},
+	},
 }
 
 // HistorySystemTableScriptTests contains working tests for both prepared and non-prepared
@@ -5275,10 +5355,8 @@ var DoltReflogTestScripts = []queries.ScriptTest{
 				Expected: []sql.Row{{0}},
 			},
 			{
-				// Calling dolt_gc() invalidates the session, so we have to ask this assertion to create a new session
-				NewSession: true,
-				Query:      "select ref, commit_hash, commit_message from dolt_reflog('main')",
-				Expected:   []sql.Row{},
+				Query:    "select ref, commit_hash, commit_message from dolt_reflog('main')",
+				Expected: []sql.Row{},
 			},
 		},
 	},
@@ -5308,10 +5386,8 @@ var DoltReflogTestScripts = []queries.ScriptTest{
 				Expected: []sql.Row{{0}},
 			},
 			{
-				// Calling dolt_gc() invalidates the session, so we have to force this test to create a new session
-				NewSession: true,
-				Query:      "select ref, commit_hash, commit_message from dolt_reflog('main')",
-				Expected:   []sql.Row{},
+				Query:    "select ref, commit_hash, commit_message from dolt_reflog('main')",
+				Expected: []sql.Row{},
 			},
 		},
 	},
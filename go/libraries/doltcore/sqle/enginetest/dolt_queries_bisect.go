@@ -0,0 +1,199 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+)
+
+var bisectSetUpScript = []string{
+	"create table t (pk int primary key, c int);",
+	"call dolt_commit('-Am', 'c1');",
+	"insert into t values (1, 1);",
+	"call dolt_commit('-am', 'c2');",
+	"insert into t values (2, 2);",
+	"call dolt_commit('-am', 'c3');",
+	"insert into t values (3, -1);",
+	"call dolt_commit('-am', 'c4');",
+	"insert into t values (4, 4);",
+	"call dolt_commit('-am', 'c5');",
+	"insert into t values (5, 5);",
+	"call dolt_commit('-am', 'c6');",
+}
+
+var DoltBisectTests = []queries.ScriptTest{
+	{
+		Name:        "dolt_bisect: marking commits manually",
+		SetUpScript: bisectSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_bisect('start');",
+				Expected: []sql.Row{{0, "status: waiting for both good and bad commits"}},
+			},
+			{
+				Query:    "call dolt_bisect('bad');",
+				Expected: []sql.Row{{0, "status: waiting for good commit(s), bad commit known"}},
+			},
+			{
+				Query:    "call dolt_bisect('good', 'HEAD~5');",
+				Expected: []sql.Row{{0, hashMessage("Bisecting: 2 revision(s) left to test after this (roughly 2 step(s))\n[HASH] c4")}},
+			},
+			{
+				Query:    "call dolt_bisect('bad');",
+				Expected: []sql.Row{{0, hashMessage("Bisecting: 1 revision(s) left to test after this (roughly 1 step(s))\n[HASH] c3")}},
+			},
+			{
+				Query:    "call dolt_bisect('good');",
+				Expected: []sql.Row{{0, hashMessage("HASH is the first bad commit\n[HASH] c4")}},
+			},
+			{
+				// bisecting doesn't change the working set
+				Query:    "select count(*) from t;",
+				Expected: []sql.Row{{5}},
+			},
+			{
+				Query:    "call dolt_bisect('reset');",
+				Expected: []sql.Row{{0, dprocedures.BisectResetMessage}},
+			},
+			{
+				Query:          "call dolt_bisect('good');",
+				ExpectedErrStr: dprocedures.ErrNoBisectInProgress.Error(),
+			},
+		},
+	},
+	{
+		Name:        "dolt_bisect: run with a predicate query",
+		SetUpScript: bisectSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_bisect('start', 'HEAD', 'HEAD~5');",
+				Expected: []sql.Row{{0, hashMessage("Bisecting: 2 revision(s) left to test after this (roughly 2 step(s))\n[HASH] c4")}},
+			},
+			{
+				Query: "call dolt_bisect('run', 'select count(*) > 0 from t where c < 0');",
+				Expected: []sql.Row{{0, hashMessage(
+					"Bisecting: 2 revision(s) left to test after this (roughly 2 step(s))\n[HASH] c4\n" +
+						"HASH is bad\n" +
+						"Bisecting: 1 revision(s) left to test after this (roughly 1 step(s))\n[HASH] c3\n" +
+						"HASH is good\n" +
+						"HASH is the first bad commit\n[HASH] c4")}},
+			},
+			{
+				Query:    "call dolt_bisect('reset');",
+				Expected: []sql.Row{{0, dprocedures.BisectResetMessage}},
+			},
+		},
+	},
+	{
+		Name: "dolt_bisect: run stops at a commit the predicate query fails against",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'c1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'c2');",
+			"insert into t values (2);",
+			"call dolt_commit('-am', 'c3');",
+			"create table u (pk int primary key);",
+			"insert into u values (1);",
+			"call dolt_commit('-Am', 'c4');",
+			"insert into t values (3);",
+			"call dolt_commit('-am', 'c5');",
+			"insert into t values (4);",
+			"call dolt_commit('-am', 'c6');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_bisect('start', 'HEAD', 'HEAD~5');",
+				Expected: []sql.Row{{0, hashMessage("Bisecting: 2 revision(s) left to test after this (roughly 2 step(s))\n[HASH] c4")}},
+			},
+			{
+				Query:       "call dolt_bisect('run', 'select count(*) > 0 from u');",
+				ExpectedErr: sql.ErrTableNotFound,
+			},
+			{
+				// the progress of the run was saved up to the commit the query failed against
+				Query:    "call dolt_bisect('skip');",
+				Expected: []sql.Row{{0, hashMessage("Bisecting: 1 revision(s) left to test after this (roughly 1 step(s))\n[HASH] c2")}},
+			},
+			{
+				Query:    "call dolt_bisect('reset');",
+				Expected: []sql.Row{{0, dprocedures.BisectResetMessage}},
+			},
+		},
+	},
+	{
+		Name:        "dolt_bisect: skipped commits",
+		SetUpScript: bisectSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_bisect('start', 'HEAD~3', 'HEAD~5');",
+				Expected: []sql.Row{{0, hashMessage("Bisecting: 0 revision(s) left to test after this (roughly 0 step(s))\n[HASH] c2")}},
+			},
+			{
+				Query:    "call dolt_bisect('skip');",
+				Expected: []sql.Row{{0, hashMessage("There are only 'skip'ped commits left to test.\nThe first bad commit could be any of:\n[HASH] c3\n[HASH] c2")}},
+			},
+			{
+				Query:          "call dolt_bisect('good');",
+				ExpectedErrStr: "error: the bisect has finished; specify a commit or call dolt_bisect('reset')",
+			},
+		},
+	},
+	{
+		Name:        "dolt_bisect: invalid arguments",
+		SetUpScript: bisectSetUpScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_bisect();",
+				ExpectedErrStr: "error: missing subcommand, must be one of start, bad, good, skip, reset or run",
+			},
+			{
+				Query:          "call dolt_bisect('visualize');",
+				ExpectedErrStr: "error: invalid subcommand 'visualize', must be one of start, bad, good, skip, reset or run",
+			},
+			{
+				Query:          "call dolt_bisect('run', 'select 1');",
+				ExpectedErrStr: dprocedures.ErrNoBisectInProgress.Error(),
+			},
+			{
+				Query:    "call dolt_bisect('start');",
+				Expected: []sql.Row{{0, "status: waiting for both good and bad commits"}},
+			},
+			{
+				Query:          "call dolt_bisect('start');",
+				ExpectedErrStr: dprocedures.ErrBisectInProgress.Error(),
+			},
+			{
+				Query:          "call dolt_bisect('bad', 'HEAD', 'HEAD~1');",
+				ExpectedErrStr: "error: 'bad' takes at most one commit",
+			},
+			{
+				Query:          "call dolt_bisect('run', 'select 1');",
+				ExpectedErrStr: "error: bisect run requires a good and a bad commit to be marked first",
+			},
+			{
+				Query:    "call dolt_bisect('good', 'HEAD');",
+				Expected: []sql.Row{{0, "status: waiting for bad commit, 1 good commit(s) known"}},
+			},
+			{
+				Query:          "call dolt_bisect('bad', 'HEAD~1');",
+				ExpectedErrStr: "error: the bad commit is an ancestor of a good commit",
+			},
+		},
+	},
+}
@@ -2518,6 +2518,284 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_merge_rules resolve conflicting changes to the same cell",
+		SetUpScript: []string{
+			"create table counters (id int primary key, hits int, last_seen datetime, note varchar(20), price decimal(10, 2), ratio double);",
+			"insert into counters values (1, 10, '2024-01-01', 'base', 1.00, 0.5);",
+			"insert into dolt_merge_rules values ('counters', 'hits', 'sum'), ('counters', 'last_seen', 'max'), ('counters', 'note', 'theirs'), ('counters', 'price', 'sum'), ('counters', 'ratio', 'min');",
+			"call dolt_commit('-Am', 'setup');",
+			"call dolt_branch('other');",
+			"update counters set hits = hits + 5, last_seen = '2024-03-01', note = 'ours', price = price + 2.50, ratio = 0.7;",
+			"insert into counters values (2, 1, '2024-01-01', 'ours', 0.00, 0);",
+			"call dolt_commit('-am', 'main changes');",
+			"call dolt_checkout('other');",
+			"update counters set hits = hits + 3, last_seen = '2024-02-01', note = 'theirs', price = price + 1.00, ratio = 0.2;",
+			"insert into counters values (2, 2, '2024-02-01', 'theirs', 1.00, 1);",
+			"call dolt_commit('-am', 'other changes');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query: "select id, hits, date_format(last_seen, '%Y-%m-%d'), note, cast(price as char), ratio from counters order by id;",
+				Expected: []sql.Row{
+					{1, 18, "2024-03-01", "theirs", "4.50", 0.2},
+					{2, 3, "2024-02-01", "theirs", "1.00", 0.0},
+				},
+			},
+			{
+				Query:    "select count(*) from dolt_conflicts;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_rules with table-wide rules and columns without rules",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on;",
+			"create table t (pk int primary key, c1 int, c2 int);",
+			"create table u (pk int primary key, c1 int);",
+			"insert into t values (1, 1, 1);",
+			"insert into u values (1, 1);",
+			"insert into dolt_merge_rules values ('t', '*', 'ours'), ('t', 'c2', 'theirs');",
+			"call dolt_commit('-Am', 'setup');",
+			"call dolt_branch('other');",
+			"update t set c1 = 10, c2 = 10;",
+			"update u set c1 = 10;",
+			"call dolt_commit('-am', 'main changes');",
+			"call dolt_checkout('other');",
+			"update t set c1 = 20, c2 = 20;",
+			"update u set c1 = 20;",
+			"call dolt_commit('-am', 'other changes');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 10, 20}},
+			},
+			{
+				Query:    "select `table`, num_conflicts from dolt_conflicts;",
+				Expected: []sql.Row{{"u", uint64(1)}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_rules: sum overflow is a conflict",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on;",
+			"create table t (pk int primary key, c tinyint);",
+			"insert into t values (1, 100);",
+			"insert into dolt_merge_rules values ('t', 'c', 'sum');",
+			"call dolt_commit('-Am', 'setup');",
+			"call dolt_branch('other');",
+			"update t set c = 120;",
+			"call dolt_commit('-am', 'main changes');",
+			"call dolt_checkout('other');",
+			"update t set c = 110;",
+			"call dolt_commit('-am', 'other changes');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select base_c, our_c, their_c from dolt_conflicts_t;",
+				Expected: []sql.Row{{100, 120, 110}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_rules: sum rule for a column that is no longer numeric is ignored",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on;",
+			"create table t (pk int primary key, c int, d int);",
+			"insert into t values (1, 10, 10);",
+			"insert into dolt_merge_rules values ('t', 'c', 'sum'), ('t', 'd', 'sum');",
+			"alter table t modify column c varchar(20);",
+			"call dolt_commit('-Am', 'setup');",
+			"call dolt_branch('other');",
+			"update t set c = 'main', d = 15;",
+			"call dolt_commit('-am', 'main changes');",
+			"call dolt_checkout('other');",
+			"update t set c = 'other', d = 11;",
+			"call dolt_commit('-am', 'other changes');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select base_c, our_c, their_c from dolt_conflicts_t;",
+				Expected: []sql.Row{{"10", "main", "other"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_rules: sum rule for a column that is no longer numeric doesn't affect other columns",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int, d int);",
+			"insert into t values (1, 10, 10);",
+			"insert into dolt_merge_rules values ('t', 'c', 'sum'), ('t', 'd', 'sum');",
+			"alter table t modify column c varchar(20);",
+			"call dolt_commit('-Am', 'setup');",
+			"call dolt_branch('other');",
+			"update t set d = 15;",
+			"call dolt_commit('-am', 'main changes');",
+			"call dolt_checkout('other');",
+			"update t set d = 11;",
+			"call dolt_commit('-am', 'other changes');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "10", 16}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_rules: invalid rules",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c varchar(20));",
+			"insert into t values (1, 'a');",
+			"call dolt_commit('-Am', 'setup');",
+			"call dolt_branch('other');",
+			"update t set c = 'b';",
+			"call dolt_commit('-am', 'main changes');",
+			"call dolt_checkout('other');",
+			"update t set c = 'c';",
+			"call dolt_commit('-am', 'other changes');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "insert into dolt_merge_rules values ('t', 'c', 'average');",
+				ExpectedErrStr: "invalid merge rule strategy 'average'; valid strategies are ours, theirs, sum, max, and min",
+			},
+			{
+				Query:          "insert into dolt_merge_rules values ('t', 'c', 'sum');",
+				ExpectedErrStr: "cannot apply the merge rule 'sum' to column t.c of type varchar(20); it is only supported for numeric columns",
+			},
+			{
+				Query:          "insert into dolt_merge_rules values ('t', '*', 'sum');",
+				ExpectedErrStr: "the merge rule 'sum' cannot be applied to all columns of a table; declare it for each numeric column",
+			},
+			{
+				Query:          "insert into dolt_merge_rules values ('missing', 'c', 'max');",
+				ExpectedErrStr: "cannot add a merge rule for table 'missing'; table not found",
+			},
+			{
+				Query:          "insert into dolt_merge_rules values ('t', 'missing', 'max');",
+				ExpectedErrStr: "cannot add a merge rule for column 'missing'; column not found in table 't'",
+			},
+			{
+				Query:    "insert into dolt_merge_rules values ('t', 'c', 'ours');",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:          "update dolt_merge_rules set strategy = 'sum';",
+				ExpectedErrStr: "cannot apply the merge rule 'sum' to column t.c of type varchar(20); it is only supported for numeric columns",
+			},
+			{
+				Query:          "insert into dolt_merge_rules (table_name, column_name) values ('t', 'pk');",
+				ExpectedErrStr: "Field 'strategy' doesn't have a default value",
+			},
+			{
+				Query:    "update dolt_merge_rules set strategy = 'MAX';",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: uint64(1), Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:            "call dolt_commit('-Am', 'add merge rule');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "c"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_rules on a table with a virtual column",
+		SetUpScript: []string{
+			"create table t (pk int primary key, a varchar(20), v varchar(20) as (concat(a, 'x')) virtual, c decimal(10, 2), d int);",
+			"insert into t (pk, a, c, d) values (1, 'a', 1.50, 10);",
+			"insert into dolt_merge_rules values ('t', 'c', 'sum'), ('t', 'd', 'sum');",
+			"call dolt_commit('-Am', 'setup');",
+			"call dolt_branch('other');",
+			"update t set c = 2.00, d = 15;",
+			"call dolt_commit('-am', 'main changes');",
+			"call dolt_checkout('other');",
+			"update t set c = 3.25, d = 11;",
+			"call dolt_commit('-am', 'other changes');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select pk, a, v, cast(c as char), d from t;",
+				Expected: []sql.Row{{1, "a", "ax", "3.75", 16}},
+			},
+		},
+	},
+	{
+		Name: "dolt_merge_rules: our rules are used when the two sides of a merge disagree",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on;",
+			"create table t (pk int primary key, c int);",
+			"insert into t values (1, 1);",
+			"insert into dolt_merge_rules values ('t', 'c', 'max');",
+			"call dolt_commit('-Am', 'setup');",
+			"call dolt_branch('other');",
+			"update dolt_merge_rules set strategy = 'ours';",
+			"update t set c = 10;",
+			"call dolt_commit('-am', 'main changes');",
+			"call dolt_checkout('other');",
+			"update dolt_merge_rules set strategy = 'theirs';",
+			"update t set c = 20;",
+			"call dolt_commit('-am', 'other changes');",
+			"call dolt_checkout('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				// the conflicting rule is reported as a conflict in dolt_merge_rules, but the rule from our side of
+				// the merge was used to resolve the conflict in t
+				Query:    "select `table` from dolt_conflicts;",
+				Expected: []sql.Row{{"dolt_merge_rules"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 10}},
+			},
+		},
+	},
 }
 
 var KeylessMergeCVsAndConflictsScripts = []queries.ScriptTest{
@@ -4466,6 +4744,36 @@ var GeneratedColumnMergeTestScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "concurrent changes to a column after a virtual column conflict",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on",
+			"create table t1 (id bigint primary key, v1 bigint as (id * 2) virtual, v2 bigint)",
+			"insert into t1 (id, v2) values (1, 1)",
+			"call dolt_commit('-Am', 'first commit')",
+			"call dolt_branch('branch1')",
+			"update t1 set v2 = 2",
+			"call dolt_commit('-am', 'main commit')",
+			"call dolt_checkout('branch1')",
+			"update t1 set v2 = 3",
+			"call dolt_commit('-am', 'branch1 commit')",
+			"call dolt_checkout('main')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('branch1')",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select * from dolt_conflicts",
+				Expected: []sql.Row{{"t1", uint64(1)}},
+			},
+			{
+				Query:    "select * from t1",
+				Expected: []sql.Row{{1, 2, 2}},
+			},
+		},
+	},
 }
 
 // convertMergeScriptTest converts a MergeScriptTest into a standard ScriptTest. If flipSides is true, then the
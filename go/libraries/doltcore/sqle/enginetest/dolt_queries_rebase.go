@@ -36,8 +36,11 @@ var DoltRebaseScriptTests = []queries.ScriptTest{
 				Query:          "call dolt_rebase('--continue');",
 				ExpectedErrStr: "no rebase in progress",
 			}, {
-				Query:          "call dolt_rebase('main');",
-				ExpectedErrStr: "non-interactive rebases not currently supported",
+				Query:          "call dolt_rebase('--strategy=mine', 'main');",
+				ExpectedErrStr: "unsupported option for the strategy flag (mine); only 'ours' or 'theirs' are allowed",
+			}, {
+				Query:          "call dolt_rebase('-i', '--strategy=ours', 'main');",
+				ExpectedErrStr: "the --strategy option can only be used with a non-interactive rebase or with --continue",
 			}, {
 				Query:          "call dolt_rebase('-i');",
 				ExpectedErrStr: "not enough args",
@@ -710,6 +713,562 @@ var DoltRebaseScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_rebase: edit and break actions",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (0);",
+			"call dolt_commit('-am', 'inserting row 0');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'inserting row 1');",
+			"insert into t values (10);",
+			"call dolt_commit('-am', 'inserting row 10');",
+			"insert into t values (100);",
+			"call dolt_commit('-am', 'inserting row 100');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_rebase('-i', 'main');",
+				Expected: []sql.Row{{0, "interactive rebase started on branch dolt_rebase_branch1; " +
+					"adjust the rebase plan in the dolt_rebase table, then " +
+					"continue rebasing by calling dolt_rebase('--continue')"}},
+			},
+			{
+				Query: "update dolt_rebase set action='edit' where rebase_order = 1;",
+				Expected: []sql.Row{{gmstypes.OkResult{RowsAffected: uint64(1), Info: plan.UpdateInfo{
+					Matched: 1,
+					Updated: 1,
+				}}}},
+			},
+			{
+				Query:    "insert into dolt_rebase values (2.5, 'break', '', '');",
+				Expected: []sql.Row{{gmstypes.NewOkResult(1)}},
+			},
+			{
+				Query: "call dolt_rebase('--continue');",
+				Expected: []sql.Row{{0, hashMessage("Stopped at HASH (inserting row 1); amend the commit with " +
+					"dolt_commit('--amend') if needed, then continue rebasing by calling dolt_rebase('--continue')")}},
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"dolt_rebase_branch1"}},
+			},
+			{
+				Query:    "insert into t values (2);",
+				Expected: []sql.Row{{gmstypes.NewOkResult(1)}},
+			},
+			{
+				Query:    "call dolt_commit('-a', '--amend', '-m', 'inserting rows 1 and 2');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query: "call dolt_rebase('--continue');",
+				Expected: []sql.Row{{0, "Stopped at rebase step 2.5 (break); " +
+					"continue rebasing by calling dolt_rebase('--continue')"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{0}, {1}, {2}, {10}},
+			},
+			{
+				Query:    "call dolt_rebase('--continue');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"branch1"}},
+			},
+			{
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"inserting row 100"},
+					{"inserting row 10"},
+					{"inserting rows 1 and 2"},
+					{"inserting row 0"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{0}, {1}, {2}, {10}, {100}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: edit action amends staged changes on continue",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (0);",
+			"call dolt_commit('-am', 'inserting row 0');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'inserting row 1');",
+			"insert into t values (10);",
+			"call dolt_commit('-am', 'inserting row 10');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_rebase('-i', 'main');",
+				Expected: []sql.Row{{0, "interactive rebase started on branch dolt_rebase_branch1; " +
+					"adjust the rebase plan in the dolt_rebase table, then " +
+					"continue rebasing by calling dolt_rebase('--continue')"}},
+			},
+			{
+				Query: "update dolt_rebase set action='edit' where rebase_order = 1;",
+				Expected: []sql.Row{{gmstypes.OkResult{RowsAffected: uint64(1), Info: plan.UpdateInfo{
+					Matched: 1,
+					Updated: 1,
+				}}}},
+			},
+			{
+				Query: "call dolt_rebase('--continue');",
+				Expected: []sql.Row{{0, hashMessage("Stopped at HASH (inserting row 1); amend the commit with " +
+					"dolt_commit('--amend') if needed, then continue rebasing by calling dolt_rebase('--continue')")}},
+			},
+			{
+				Query:    "insert into t values (2);",
+				Expected: []sql.Row{{gmstypes.NewOkResult(1)}},
+			},
+			{
+				Query:    "call dolt_add('t');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_rebase('--continue');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"inserting row 10"},
+					{"inserting row 1"},
+					{"inserting row 0"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+			{
+				Query:    "select * from t as of 'HEAD~1';",
+				Expected: []sql.Row{{0}, {1}, {2}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{0}, {1}, {2}, {10}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: exec action",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (0);",
+			"call dolt_commit('-am', 'inserting row 0');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'inserting row 1');",
+			"insert into t values (10);",
+			"call dolt_commit('-am', 'inserting row 10');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_rebase('-i', 'main');",
+				Expected: []sql.Row{{0, "interactive rebase started on branch dolt_rebase_branch1; " +
+					"adjust the rebase plan in the dolt_rebase table, then " +
+					"continue rebasing by calling dolt_rebase('--continue')"}},
+			},
+			{
+				Query:    "insert into dolt_rebase values (1.5, 'exec', '', 'insert into t values (5)');",
+				Expected: []sql.Row{{gmstypes.NewOkResult(1)}},
+			},
+			{
+				Query: "select * from dolt_rebase order by rebase_order ASC;",
+				Expected: []sql.Row{
+					{"1", "pick", doltCommit, "inserting row 1"},
+					{"1.50", "exec", "", "insert into t values (5)"},
+					{"2", "pick", doltCommit, "inserting row 10"},
+				},
+			},
+			{
+				Query: "call dolt_rebase('--continue');",
+				Expected: []sql.Row{{0, "Stopped at rebase step 1.5 (exec insert into t values (5)); the statement " +
+					"left uncommitted changes, commit them with dolt_commit() and then continue rebasing by " +
+					"calling dolt_rebase('--continue')"}},
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"dolt_rebase_branch1"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{0}, {1}, {5}},
+			},
+			{
+				Query:          "call dolt_rebase('--continue');",
+				ExpectedErrStr: dprocedures.ErrRebaseUncommittedChangesAfterStop.New().Error(),
+			},
+			{
+				Query:    "call dolt_commit('-am', 'inserting row 5');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "call dolt_rebase('--continue');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"inserting row 10"},
+					{"inserting row 5"},
+					{"inserting row 1"},
+					{"inserting row 0"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{0}, {1}, {5}, {10}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase errors: exec action",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (0);",
+			"call dolt_commit('-am', 'inserting row 0');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'inserting row 1');",
+			"insert into t values (10);",
+			"call dolt_commit('-am', 'inserting row 10');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_rebase('-i', 'main');",
+				Expected: []sql.Row{{0, "interactive rebase started on branch dolt_rebase_branch1; " +
+					"adjust the rebase plan in the dolt_rebase table, then " +
+					"continue rebasing by calling dolt_rebase('--continue')"}},
+			},
+			{
+				Query:    "insert into dolt_rebase values (1.5, 'exec', '', '');",
+				Expected: []sql.Row{{gmstypes.NewOkResult(1)}},
+			},
+			{
+				Query:          "call dolt_rebase('--continue');",
+				ExpectedErrStr: rebase.ErrInvalidRebasePlanExecWithoutStatement.Error(),
+			},
+			{
+				Query: "update dolt_rebase set commit_message='select * from doesnotexist' where rebase_order = 1.5;",
+				Expected: []sql.Row{{gmstypes.OkResult{RowsAffected: uint64(1), Info: plan.UpdateInfo{
+					Matched: 1,
+					Updated: 1,
+				}}}},
+			},
+			{
+				Query:          "call dolt_rebase('--continue');",
+				ExpectedErrStr: "exec statement failed: table not found: doesnotexist; the rebase has been automatically aborted",
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"branch1"}},
+			},
+			{
+				Query:    "select name from dolt_branches",
+				Expected: []sql.Row{{"main"}, {"branch1"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1}, {10}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: non-interactive rebase",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (0);",
+			"call dolt_commit('-am', 'inserting row 0');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1);",
+			"call dolt_commit('-am', 'inserting row 1');",
+			"insert into t values (10);",
+			"call dolt_commit('-am', 'inserting row 10');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_rebase('main');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"branch1"}},
+			},
+			{
+				Query:    "select name from dolt_branches",
+				Expected: []sql.Row{{"main"}, {"branch1"}},
+			},
+			{
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"inserting row 10"},
+					{"inserting row 1"},
+					{"inserting row 0"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{0}, {1}, {10}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: non-interactive rebase with data conflicts and no strategy",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(100));",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (1, 'main');",
+			"call dolt_commit('-am', 'inserting row 1 on main');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1, 'branch1');",
+			"call dolt_commit('-am', 'inserting row 1 on branch1');",
+			"insert into t values (2, 'two');",
+			"call dolt_commit('-am', 'inserting row 2 on branch1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "call dolt_rebase('main');",
+				ExpectedErr: dprocedures.ErrRebaseDataConflictsCantBeResolved,
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"branch1"}},
+			},
+			{
+				Query:    "select name from dolt_branches",
+				Expected: []sql.Row{{"main"}, {"branch1"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "branch1"}, {2, "two"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: non-interactive rebase with --strategy=theirs",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(100));",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (1, 'main');",
+			"call dolt_commit('-am', 'inserting row 1 on main');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1, 'branch1');",
+			"call dolt_commit('-am', 'inserting row 1 on branch1');",
+			"insert into t values (2, 'two');",
+			"call dolt_commit('-am', 'inserting row 2 on branch1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_rebase('--strategy=theirs', 'main');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				Query:    "select active_branch();",
+				Expected: []sql.Row{{"branch1"}},
+			},
+			{
+				Query:    "select * from dolt_conflicts;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"inserting row 2 on branch1"},
+					{"inserting row 1 on branch1"},
+					{"inserting row 1 on main"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "branch1"}, {2, "two"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: non-interactive rebase with --strategy=ours",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(100));",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (1, 'main');",
+			"call dolt_commit('-am', 'inserting row 1 on main');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1, 'branch1');",
+			"call dolt_commit('-am', 'inserting row 1 on branch1');",
+			"insert into t values (2, 'two');",
+			"call dolt_commit('-am', 'inserting row 2 on branch1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_rebase('--strategy=ours', 'main');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				// The commit for row 1 becomes empty after keeping the upstream row, so it is dropped
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"inserting row 2 on branch1"},
+					{"inserting row 1 on main"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "main"}, {2, "two"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: continue an interactive rebase with --strategy",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(100));",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (1, 'main');",
+			"call dolt_commit('-am', 'inserting row 1 on main');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1, 'branch1');",
+			"call dolt_commit('-am', 'inserting row 1 on branch1');",
+			"insert into t values (2, 'two');",
+			"call dolt_commit('-am', 'inserting row 2 on branch1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_rebase('-i', 'main');",
+				Expected: []sql.Row{{0, "interactive rebase started on branch dolt_rebase_branch1; " +
+					"adjust the rebase plan in the dolt_rebase table, then " +
+					"continue rebasing by calling dolt_rebase('--continue')"}},
+			},
+			{
+				Query: "update dolt_rebase set action='reword', commit_message='row 1 from branch1' where rebase_order = 1;",
+				Expected: []sql.Row{{gmstypes.OkResult{RowsAffected: uint64(1), Info: plan.UpdateInfo{
+					Matched: 1,
+					Updated: 1,
+				}}}},
+			},
+			{
+				Query:    "call dolt_rebase('--continue', '--strategy=theirs');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"inserting row 2 on branch1"},
+					{"row 1 from branch1"},
+					{"inserting row 1 on main"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "branch1"}, {2, "two"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_rebase: --strategy still applies after a stopped rebase is continued",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(100));",
+			"call dolt_commit('-Am', 'creating table t');",
+			"call dolt_branch('branch1');",
+
+			"insert into t values (1, 'main'), (2, 'main');",
+			"call dolt_commit('-am', 'inserting rows 1 and 2 on main');",
+
+			"call dolt_checkout('branch1');",
+			"insert into t values (1, 'branch1');",
+			"call dolt_commit('-am', 'inserting row 1 on branch1');",
+			"insert into t values (2, 'branch1');",
+			"call dolt_commit('-am', 'inserting row 2 on branch1');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_rebase('-i', 'main');",
+				Expected: []sql.Row{{0, "interactive rebase started on branch dolt_rebase_branch1; " +
+					"adjust the rebase plan in the dolt_rebase table, then " +
+					"continue rebasing by calling dolt_rebase('--continue')"}},
+			},
+			{
+				Query:    "insert into dolt_rebase values (1.5, 'break', '', '');",
+				Expected: []sql.Row{{gmstypes.NewOkResult(1)}},
+			},
+			{
+				Query: "call dolt_rebase('--continue', '--strategy=theirs');",
+				Expected: []sql.Row{{0, "Stopped at rebase step 1.5 (break); " +
+					"continue rebasing by calling dolt_rebase('--continue')"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "branch1"}, {2, "main"}},
+			},
+			{
+				Query:    "call dolt_rebase('--continue');",
+				Expected: []sql.Row{{0, "Successfully rebased and updated refs/heads/branch1"}},
+			},
+			{
+				Query: "select message from dolt_log;",
+				Expected: []sql.Row{
+					{"inserting row 2 on branch1"},
+					{"inserting row 1 on branch1"},
+					{"inserting rows 1 and 2 on main"},
+					{"creating table t"},
+					{"Initialize data repository"},
+				},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, "branch1"}, {2, "branch1"}},
+			},
+		},
+	},
 	{
 		Name: "dolt_rebase: negative rebase order",
 		SetUpScript: []string{
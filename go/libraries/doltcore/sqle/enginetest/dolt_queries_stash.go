@@ -0,0 +1,259 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+var DoltStashTests = []queries.ScriptTest{
+	{
+		Name: "dolt_stash: push and pop",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'created table t');",
+			"insert into t values (1, 1);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_stash('push');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select name, branch, commit_message from dolt_stashes;",
+				Expected: []sql.Row{{"stash@{0}", "main", "created table t"}},
+			},
+			{
+				Query:    "select count(*) from dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_stash('pop');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "select count(*) from dolt_stashes;",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_stash: apply keeps the stash entry",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'created table t');",
+			"insert into t values (1, 1);",
+			"call dolt_stash('push');",
+			"insert into t values (2, 2);",
+			"call dolt_stash('push');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select name from dolt_stashes;",
+				Expected: []sql.Row{{"stash@{0}"}, {"stash@{1}"}},
+			},
+			{
+				Query:    "call dolt_stash('apply', 'stash@{1}');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "select count(*) from dolt_stashes;",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "dolt_stash: staged new tables are staged again when popped",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+			"call dolt_add('t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_stash('push');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "select * from t;",
+				ExpectedErrStr: "table not found: t",
+			},
+			{
+				Query:    "call dolt_stash('pop');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select table_name, staged, status from dolt_status;",
+				Expected: []sql.Row{{"t", true, "new table"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_stash: untracked tables",
+		SetUpScript: []string{
+			"create table t (pk int primary key);",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_stash('push');",
+				ExpectedErrStr: "no local changes to save",
+			},
+			{
+				Query:    "call dolt_stash('push', '--include-untracked');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_status;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_stash('pop');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select table_name, staged, status from dolt_status;",
+				Expected: []sql.Row{{"t", false, "new table"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_stash: pop with conflicts keeps the stash entry",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-Am', 'created table t');",
+			"update t set c = 2 where pk = 1;",
+			"call dolt_stash('push');",
+			"update t set c = 3 where pk = 1;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_stash('pop');",
+				ExpectedErrStr: "error: Your local changes to the following tables would be overwritten by applying stash 0:\n\t{'t'}\nPlease commit your changes or stash them before you merge.\nAborting",
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 3}},
+			},
+			{
+				Query:    "select count(*) from dolt_stashes;",
+				Expected: []sql.Row{{1}},
+			},
+		},
+	},
+	{
+		Name: "dolt_stash: drop and clear",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'created table t');",
+			"insert into t values (1, 1);",
+			"call dolt_stash('push');",
+			"insert into t values (2, 2);",
+			"call dolt_stash('push');",
+			"insert into t values (3, 3);",
+			"call dolt_stash('push');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_stash('drop', 'stash@{1}');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "call dolt_stash('apply', '1');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "call dolt_stash('clear');",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select count(*) from dolt_stashes;",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:          "call dolt_stash('drop');",
+				ExpectedErrStr: "No stash entries found.",
+			},
+		},
+	},
+	{
+		Name: "dolt_stash: invalid arguments",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c int);",
+			"call dolt_commit('-Am', 'created table t');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_stash();",
+				ExpectedErrStr: "error: invalid argument, use 'dolt_stashes' system table to list stashes",
+			},
+			{
+				Query:          "call dolt_stash('list');",
+				ExpectedErrStr: "error: invalid subcommand 'list', must be one of push, pop, apply, drop or clear",
+			},
+			{
+				Query:          "call dolt_stash('pop', 'stash@{foo}');",
+				ExpectedErrStr: "error: stash@{foo} is not a valid reference",
+			},
+			{
+				Query:          "call dolt_stash('pop', '-u');",
+				ExpectedErrStr: "error: --include-untracked and --all can only be used with 'push'",
+			},
+		},
+	},
+	{
+		Name: "dolt_stash: not allowed during a merge",
+		SetUpScript: []string{
+			"set dolt_allow_commit_conflicts = on;",
+			"create table t (pk int primary key, c int);",
+			"insert into t values (1, 1);",
+			"call dolt_commit('-Am', 'created table t');",
+			"call dolt_branch('other');",
+			"update t set c = 2;",
+			"call dolt_commit('-am', 'main change');",
+			"call dolt_checkout('other');",
+			"update t set c = 3;",
+			"call dolt_commit('-am', 'other change');",
+			"call dolt_checkout('main');",
+			"call dolt_merge('other');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_stash('push');",
+				ExpectedErrStr: "error: unable to push stash while a merge is in progress – commit or abort the current merge before proceeding",
+			},
+			{
+				Query:          "call dolt_stash('pop');",
+				ExpectedErrStr: "error: unable to pop stash while a merge is in progress – commit or abort the current merge before proceeding",
+			},
+		},
+	},
+}
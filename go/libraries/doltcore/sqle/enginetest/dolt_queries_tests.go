@@ -0,0 +1,264 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"github.com/dolthub/go-mysql-server/enginetest/queries"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
+)
+
+// DoltTestsTableTests set the global test group variables, which RunDoltTestsTableTests resets after each script.
+var DoltTestsTableTests = []queries.ScriptTest{
+	{
+		Name: "dolt_tests: validating tests",
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "insert into dolt_tests values ('t1', null, 'select 1', 'expected_value', '==', '1');",
+				ExpectedErrStr: "invalid test 't1': invalid assertion type 'expected_value'; valid types are expected_rows, expected_columns, and expected_single_value",
+			},
+			{
+				Query:          "insert into dolt_tests values ('t1', null, 'select 1', 'expected_single_value', '~', '1');",
+				ExpectedErrStr: "invalid test 't1': invalid assertion comparator '~'; valid comparators are ==, !=, <, <=, >, and >=",
+			},
+			{
+				Query:          "insert into dolt_tests values ('t1', null, 'select 1', 'expected_rows', '==', 'one');",
+				ExpectedErrStr: "invalid test 't1': the assertion value for expected_rows must be an integer, got 'one'",
+			},
+			{
+				Query:    "insert into dolt_tests values ('t1', null, 'select 1', 'EXPECTED_ROWS', '=', '1'), ('t2', 'g', 'select 1', 'expected_single_value', '<>', null);",
+				Expected: []sql.Row{{types.NewOkResult(2)}},
+			},
+			{
+				Query:    "select * from dolt_tests;",
+				Expected: []sql.Row{{"t1", nil, "select 1", "expected_rows", "==", "1"}, {"t2", "g", "select 1", "expected_single_value", "!=", nil}},
+			},
+			{
+				Query:    "select * from dolt_status;",
+				Expected: []sql.Row{{"dolt_tests", false, "new table"}},
+			},
+		},
+	},
+	{
+		Name: "dolt_tests: tests run before commits",
+		SetUpScript: []string{
+			"create table orders (id int primary key, total int);",
+			"insert into orders values (1, 10);",
+			"insert into dolt_tests values " +
+				"('no negative totals', 'orders', 'select count(*) from orders where total < 0', 'expected_single_value', '==', '0'), " +
+				"('has orders', 'orders', 'select * from orders', 'expected_rows', '>', '0'), " +
+				"('always fails', 'other', 'select 1', 'expected_single_value', '==', '2');",
+			"call dolt_commit('-Am', 'init');",
+			"set global dolt_pre_commit_test_groups = 'orders';",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "insert into orders values (2, -5);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:       "call dolt_commit('-am', 'negative total');",
+				ExpectedErr: dprocedures.ErrTestsFailed,
+			},
+			{
+				Query:          "call dolt_commit('-am', 'negative total');",
+				ExpectedErrStr: "commit rejected, as the following tests in dolt_tests failed:\nno negative totals: expected value == '0', but got '1'",
+			},
+			{
+				// The working set is unchanged by the tests
+				Query:    "select * from orders order by id;",
+				Expected: []sql.Row{{1, 10}, {2, -5}},
+			},
+			{
+				// Unstaged changes are not tested
+				Query:    "call dolt_commit('--allow-empty', '-m', 'empty');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "update orders set total = 5 where id = 2;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "call dolt_commit('-am', 'positive total');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "set global dolt_pre_commit_test_groups = '*';",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "insert into orders values (3, 3);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:          "call dolt_commit('-am', 'all tests');",
+				ExpectedErrStr: "commit rejected, as the following tests in dolt_tests failed:\nalways fails: expected value == '2', but got '1'",
+			},
+			{
+				Query:    "delete from dolt_tests where test_name = 'always fails';",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				// The tests are read from HEAD, so removing a failing test doesn't get the commit through
+				Query:          "call dolt_commit('-am', 'all tests');",
+				ExpectedErrStr: "commit rejected, as the following tests in dolt_tests failed:\nalways fails: expected value == '2', but got '1'",
+			},
+			{
+				Query:    "set global dolt_pre_commit_test_groups = 'orders';",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "call dolt_commit('-am', 'remove failing test');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "set global dolt_pre_commit_test_groups = '*';",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "insert into orders values (4, 4);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "call dolt_commit('-am', 'all tests');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+		},
+	},
+	{
+		Name: "dolt_tests: tests run before commits may only read data",
+		SetUpScript: []string{
+			"create table orders (id int primary key, total int);",
+			"create table audit (id int primary key);",
+			"insert into dolt_tests values " +
+				"('a writes', 'writes', 'insert into audit values (1)', 'expected_rows', '==', '0'), " +
+				"('b calls', 'writes', 'call dolt_branch(\\'sneaky\\')', 'expected_rows', '==', '0'), " +
+				"('c selects into', 'writes', 'select 1 into @x', 'expected_rows', '==', '0');",
+			"call dolt_commit('-Am', 'init');",
+			"set global dolt_pre_commit_test_groups = 'writes';",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query: "call dolt_commit('--allow-empty', '-m', 'writes');",
+				ExpectedErrStr: "commit rejected, as the following tests in dolt_tests failed:\n" +
+					"a writes: test queries must be SELECT statements without INTO, got 'insert into audit values (1)'\n" +
+					"b calls: test queries must be SELECT statements without INTO, got 'call dolt_branch('sneaky')'\n" +
+					"c selects into: test queries must be SELECT statements without INTO, got 'select 1 into @x'",
+			},
+			{
+				Query:    "select * from audit;",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select count(*) from dolt_branches where name = 'sneaky';",
+				Expected: []sql.Row{{0}},
+			},
+		},
+	},
+	{
+		Name: "dolt_tests: tests run before transaction commits that create dolt commits",
+		SetUpScript: []string{
+			"create table orders (id int primary key, total int);",
+			"insert into orders values (1, 10);",
+			"insert into dolt_tests values ('no negative totals', 'orders', 'select count(*) from orders where total < 0', 'expected_single_value', '==', '0');",
+			"call dolt_commit('-Am', 'init');",
+			"set global dolt_pre_commit_test_groups = 'orders';",
+			"set @@dolt_transaction_commit = 1;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "insert into orders values (2, -5);",
+				ExpectedErrStr: "commit rejected, as the following tests in dolt_tests failed:\nno negative totals: expected value == '0', but got '1'",
+			},
+			{
+				// the rejected row stays in the session's working set until it's fixed
+				Query:    "update orders set total = 5 where id = 2;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "select message from dolt_log limit 2;",
+				Expected: []sql.Row{{"Transaction commit"}, {"init"}},
+			},
+			{
+				Query:    "select * from orders as of 'HEAD' order by id;",
+				Expected: []sql.Row{{1, 10}, {2, 5}},
+			},
+		},
+	},
+	{
+		Name: "dolt_tests: tests run before merges",
+		SetUpScript: []string{
+			"create table orders (id int primary key, total int);",
+			"insert into orders values (1, 10);",
+			"insert into dolt_tests values ('no negative totals', 'orders', 'select count(*) from orders where total < 0', 'expected_single_value', '==', '0');",
+			"call dolt_commit('-Am', 'init');",
+			"call dolt_branch('feature');",
+			"call dolt_checkout('feature');",
+			"insert into orders values (2, -5);",
+			"call dolt_commit('-am', 'negative total');",
+			"call dolt_checkout('main');",
+			"set global dolt_pre_merge_test_groups = 'orders';",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "call dolt_merge('feature');",
+				ExpectedErrStr: "merge rejected, as the following tests in dolt_tests failed:\nno negative totals: expected value == '0', but got '1'",
+			},
+			{
+				Query:    "insert into orders values (3, 3);",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "call dolt_commit('-am', 'main change');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:       "call dolt_merge('feature');",
+				ExpectedErr: dprocedures.ErrTestsFailed,
+			},
+			{
+				Query:    "select * from orders order by id;",
+				Expected: []sql.Row{{1, 10}, {3, 3}},
+			},
+			{
+				Query:    "call dolt_checkout('feature');",
+				Expected: []sql.Row{{0, "Switched to branch 'feature'"}},
+			},
+			{
+				Query:    "update orders set total = 5 where id = 2;",
+				Expected: []sql.Row{{types.OkResult{RowsAffected: 1, Info: plan.UpdateInfo{Matched: 1, Updated: 1}}}},
+			},
+			{
+				Query:    "call dolt_commit('-am', 'positive total');",
+				Expected: []sql.Row{{doltCommit}},
+			},
+			{
+				Query:    "call dolt_checkout('main');",
+				Expected: []sql.Row{{0, "Switched to branch 'main'"}},
+			},
+			{
+				Query:    "call dolt_merge('feature', '-m', 'merge feature');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from orders order by id;",
+				Expected: []sql.Row{{1, 10}, {2, 5}, {3, 3}},
+			},
+		},
+	},
+}
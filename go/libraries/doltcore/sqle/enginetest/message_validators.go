@@ -0,0 +1,43 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enginetest
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/enginetest"
+)
+
+// hashMessageValidator validates a procedure message, such as a dolt_bisect or dolt_rebase message, against a pattern,
+// where each HASH in the pattern matches any commit hash.
+type hashMessageValidator struct {
+	re *regexp.Regexp
+}
+
+var _ enginetest.CustomValueValidator = &hashMessageValidator{}
+
+func hashMessage(pattern string) *hashMessageValidator {
+	pattern = strings.ReplaceAll(regexp.QuoteMeta(pattern), "HASH", "[0-9a-v]{32}")
+	return &hashMessageValidator{re: regexp.MustCompile("(?s)^" + pattern + "$")}
+}
+
+func (v *hashMessageValidator) Validate(val interface{}) (bool, error) {
+	message, ok := val.(string)
+	if !ok {
+		return false, nil
+	}
+	return v.re.MatchString(message), nil
+}
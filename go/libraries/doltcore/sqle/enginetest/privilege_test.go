@@ -969,6 +969,46 @@ var DoltOnlyRevisionDbPrivilegeTests = []queries.UserPrivilegeTest{
 			},
 		},
 	},
+	{
+		Name: "dolt_bisect run predicate is checked against the caller's privileges",
+		SetUpScript: []string{
+			"CREATE TABLE pub (pk BIGINT PRIMARY KEY);",
+			"CREATE TABLE secret (pk BIGINT PRIMARY KEY);",
+			"call dolt_commit('-Am', 'c1');",
+			"INSERT INTO pub VALUES (1);",
+			"INSERT INTO secret VALUES (1);",
+			"call dolt_commit('-Am', 'c2');",
+			"INSERT INTO pub VALUES (2);",
+			"call dolt_commit('-Am', 'c3');",
+			"call dolt_branch('b1')",
+			"CREATE USER tester@localhost;",
+			"GRANT SELECT, INSERT, UPDATE, DELETE ON mydb.pub TO tester@localhost;",
+			"GRANT EXECUTE ON *.* TO tester@localhost;",
+		},
+		Assertions: []queries.UserPrivilegeTestAssertion{
+			{
+				User:     "tester",
+				Host:     "localhost",
+				Query:    "call dolt_bisect('start', 'HEAD', 'HEAD~2');",
+				Expected: []sql.Row{{0, hashMessage("Bisecting: 0 revision(s) left to test after this (roughly 0 step(s))\n[HASH] c2")}},
+			},
+			{
+				User:        "tester",
+				Host:        "localhost",
+				Query:       "call dolt_bisect('run', 'select pk = 1 from secret');",
+				ExpectedErr: sql.ErrPrivilegeCheckFailed,
+			},
+			{
+				User:  "tester",
+				Host:  "localhost",
+				Query: "call dolt_bisect('run', 'select count(*) > 1 from pub');",
+				Expected: []sql.Row{{0, hashMessage(
+					"Bisecting: 0 revision(s) left to test after this (roughly 0 step(s))\n[HASH] c2\n" +
+						"HASH is good\n" +
+						"HASH is the first bad commit\n[HASH] c3")}},
+			},
+		},
+	},
 }
 
 func TestDoltOnlyRevisionDatabasePrivileges(t *testing.T) {
@@ -58,6 +58,9 @@ func (t *IndexedDoltTable) LookupBuilder(ctx *sql.Context) (index.IndexScanBuild
 		return nil, err
 	}
 	if t.lb == nil || !canCache || t.lb.Key() != key {
+		if err = t.CheckReadAccess(ctx); err != nil {
+			return nil, err
+		}
 		return index.NewIndexReaderBuilder(ctx, t.DoltTable, t.idx, key, t.DoltTable.projectedCols, t.DoltTable.sqlSch, t.isDoltFormat)
 	}
 	return t.lb, nil
@@ -80,6 +83,9 @@ func (idt *IndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Partition)
 	}
 
 	if idt.lb == nil || !canCache || idt.lb.Key() != key {
+		if err = idt.CheckReadAccess(ctx); err != nil {
+			return nil, err
+		}
 		idt.lb, err = index.NewIndexReaderBuilder(ctx, idt.DoltTable, idt.idx, key, idt.DoltTable.projectedCols, idt.DoltTable.sqlSch, idt.isDoltFormat)
 		if err != nil {
 			return nil, err
@@ -97,6 +103,9 @@ func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition
 		return nil, err
 	}
 	if idt.lb == nil || !canCache || idt.lb.Key() != key {
+		if err = idt.CheckReadAccess(ctx); err != nil {
+			return nil, err
+		}
 		idt.lb, err = index.NewIndexReaderBuilder(ctx, idt.DoltTable, idt.idx, key, idt.DoltTable.projectedCols, idt.DoltTable.sqlSch, idt.isDoltFormat)
 		if err != nil {
 			return nil, err
@@ -142,6 +151,9 @@ func (t *WritableIndexedDoltTable) LookupBuilder(ctx *sql.Context) (index.IndexS
 		return nil, err
 	}
 	if t.lb == nil || !canCache || t.lb.Key() != key {
+		if err = t.CheckReadAccess(ctx); err != nil {
+			return nil, err
+		}
 		return index.NewIndexReaderBuilder(ctx, t.DoltTable, t.idx, key, t.DoltTable.projectedCols, t.DoltTable.sqlSch, t.isDoltFormat)
 	}
 	return t.lb, nil
@@ -163,6 +175,9 @@ func (t *WritableIndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Part
 		return nil, err
 	}
 	if t.lb == nil || !canCache || t.lb.Key() != key {
+		if err = t.CheckReadAccess(ctx); err != nil {
+			return nil, err
+		}
 		t.lb, err = index.NewIndexReaderBuilder(ctx, t.DoltTable, t.idx, key, t.projectedCols, t.sqlSch, t.isDoltFormat)
 		if err != nil {
 			return nil, err
@@ -337,19 +337,22 @@ func getSourceKv(ctx *sql.Context, n sql.Node, isSrc bool) (prolly.Map, prolly.M
 		}
 
 	case *plan.ResolvedTable:
-		switch dt := n.UnderlyingTable().(type) {
+		var dt *sqle.DoltTable
+		switch t := n.UnderlyingTable().(type) {
 		case *sqle.WritableDoltTable:
-			tags = dt.ProjectedTags()
-			table, err = dt.DoltTable.DoltTable(ctx)
+			dt = t.DoltTable
 		case *sqle.AlterableDoltTable:
-			tags = dt.ProjectedTags()
-			table, err = dt.DoltTable.DoltTable(ctx)
+			dt = t.DoltTable
 		case *sqle.DoltTable:
-			tags = dt.ProjectedTags()
-			table, err = dt.DoltTable(ctx)
+			dt = t
 		default:
 			return prolly.Map{}, nil, nil, nil, nil, nil, nil
 		}
+		if err = dt.CheckReadAccess(ctx); err != nil {
+			return prolly.Map{}, nil, nil, nil, nil, nil, err
+		}
+		tags = dt.ProjectedTags()
+		table, err = dt.DoltTable(ctx)
 		if err != nil {
 			return prolly.Map{}, nil, nil, nil, nil, nil, err
 		}
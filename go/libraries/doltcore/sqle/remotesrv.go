@@ -16,14 +16,21 @@ package sqle
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 type remotesrvStore struct {
@@ -94,6 +101,152 @@ func RemoteSrvFSAndDBCache(ctxFactory func(context.Context) (*sql.Context, error
 	return fs, dbcache, nil
 }
 
+// RemoteSrvCommitValidator returns a |remotesrv.CommitValidator| which
+// rejects pushes that force update a protected branch, and which runs the
+// tests in the groups named by @@dolt_pre_merge_test_groups against every
+// branch that a push updates, rejecting the push if any of them fail. The
+// tests are read from the head of each branch before the push, so that the
+// push can't change them.
+func RemoteSrvCommitValidator(ctxFactory func(context.Context) (*sql.Context, error)) remotesrv.CommitValidator {
+	return func(ctx context.Context, repoPath string, current, last hash.Hash) error {
+		sqlCtx, err := ctxFactory(ctx)
+		if err != nil {
+			return err
+		}
+		sess := dsess.DSessFromSess(sqlCtx.Session)
+		db, err := sess.Provider().Database(sqlCtx, repoPath)
+		if err != nil {
+			return err
+		}
+		sdb, ok := db.(dsess.SqlDatabase)
+		if !ok {
+			return remotesrv.ErrUnimplemented
+		}
+		ddb := sdb.DbData().Ddb
+		datasdb := doltdb.HackDatasDatabaseFromDoltDB(ddb)
+
+		lastHeads, err := branchHeadsAtRoot(ctx, datasdb, last)
+		if err != nil {
+			return err
+		}
+		currentHeads, err := branchHeadsAtRoot(ctx, datasdb, current)
+		if err != nil {
+			return err
+		}
+		branches := make([]string, 0, len(currentHeads))
+		for branch, addr := range currentHeads {
+			if lastHeads[branch] != addr {
+				branches = append(branches, branch)
+			}
+		}
+		sort.Strings(branches)
+
+		// The protection rules are matched against the database being pushed to
+		sqlCtx.SetCurrentDatabase(repoPath)
+		for _, branch := range branches {
+			lastHead, ok := lastHeads[branch]
+			if !ok {
+				continue
+			}
+			isFastForward, err := isFastForward(ctx, ddb, lastHead, currentHeads[branch])
+			if err != nil {
+				return err
+			}
+			if !isFastForward {
+				err = branch_control.CheckProtection(sqlCtx, branch, branch_control.ProtectedOperation_ForcePush)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(dprocedures.TestGroups(dsess.DoltPreMergeTestGroups)) == 0 {
+			return nil
+		}
+		defaultBranch, err := dsess.DefaultHead(repoPath, sdb)
+		if err != nil {
+			return err
+		}
+		for _, branch := range branches {
+			// The tests come from the branch as it is on the server, or from the default branch for new branches
+			testsHead, ok := lastHeads[branch]
+			if !ok {
+				testsHead, ok = lastHeads[defaultBranch]
+			}
+			if !ok {
+				continue
+			}
+			testsDb := repoPath + dsess.DbRevisionDelimiter + testsHead.String()
+			sqlCtx.SetCurrentDatabase(repoPath + dsess.DbRevisionDelimiter + currentHeads[branch].String())
+			err = dprocedures.RunTests(sqlCtx, testsDb, dsess.DoltPreMergeTestGroups, fmt.Sprintf("push to branch '%s'", branch))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// isFastForward returns whether the commit |to| descends from the commit
+// |from|, so that moving a branch from one to the other keeps its history.
+func isFastForward(ctx context.Context, ddb *doltdb.DoltDB, from, to hash.Hash) (bool, error) {
+	fromCommit, err := readCommit(ctx, ddb, from)
+	if err != nil {
+		return false, err
+	}
+	toCommit, err := readCommit(ctx, ddb, to)
+	if err != nil {
+		return false, err
+	}
+	ancestor, err := doltdb.GetCommitAncestor(ctx, fromCommit, toCommit)
+	if errors.Is(err, doltdb.ErrNoCommonAncestor) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return ancestor.Addr == from, nil
+}
+
+// readCommit reads the commit at |addr|, which must not be a ghost commit.
+func readCommit(ctx context.Context, ddb *doltdb.DoltDB, addr hash.Hash) (*doltdb.Commit, error) {
+	optCmt, err := ddb.ReadCommit(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
+
+// branchHeadsAtRoot returns the commit addresses of the branches in the
+// store root |rootHash|, keyed by branch name.
+func branchHeadsAtRoot(ctx context.Context, db datas.Database, rootHash hash.Hash) (map[string]hash.Hash, error) {
+	datasets, err := db.DatasetsByRootHash(ctx, rootHash)
+	if err != nil {
+		return nil, err
+	}
+	heads := make(map[string]hash.Hash)
+	err = datasets.IterAll(ctx, func(id string, addr hash.Hash) error {
+		if !ref.IsRef(id) {
+			return nil
+		}
+		dref, err := ref.Parse(id)
+		if err != nil {
+			return err
+		}
+		if dref.GetType() == ref.BranchRefType {
+			heads[dref.GetPath()] = addr
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return heads, nil
+}
+
 func WithUserPasswordAuth(args remotesrv.ServerArgs, authnz remotesrv.AccessControl) remotesrv.ServerArgs {
 	si := remotesrv.ServerInterceptor{
 		Lgr:              args.Logger,
@@ -38,6 +38,38 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemStringType("log_bin_branch"),
 		Default:           "main",
 	},
+	&sql.MysqlSystemVariable{
+		Name:              "log_bin_branches",
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType("log_bin_branches"),
+		Default:           "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              "replicate_wild_do_table",
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType("replicate_wild_do_table"),
+		Default:           "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              "replicate_wild_ignore_table",
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType("replicate_wild_ignore_table"),
+		Default:           "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              "replicate_rewrite_db",
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType("replicate_rewrite_db"),
+		Default:           "",
+	},
 	&sql.MysqlSystemVariable{
 		Name:              dsess.DoltOverrideSchema,
 		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -253,6 +285,20 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemStringType(dsess.DoltStatsBranches),
 		Default: "",
 	},
+	&sql.MysqlSystemVariable{ // Comma-separated groups of dolt_tests that must pass before a commit lands, or * for all tests.
+		Name:    dsess.DoltPreCommitTestGroups,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemStringType(dsess.DoltPreCommitTestGroups),
+		Default: "",
+	},
+	&sql.MysqlSystemVariable{ // Comma-separated groups of dolt_tests that must pass before a merge or push lands, or * for all tests.
+		Name:    dsess.DoltPreMergeTestGroups,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemStringType(dsess.DoltPreMergeTestGroups),
+		Default: "",
+	},
 }
 
 func AddDoltSystemVariables() {
@@ -265,6 +311,38 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemStringType("log_bin_branch"),
 			Default:           "main",
 		},
+		&sql.MysqlSystemVariable{
+			Name:              "log_bin_branches",
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType("log_bin_branches"),
+			Default:           "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              "replicate_wild_do_table",
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType("replicate_wild_do_table"),
+			Default:           "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              "replicate_wild_ignore_table",
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType("replicate_wild_ignore_table"),
+			Default:           "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              "replicate_rewrite_db",
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Persist),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType("replicate_rewrite_db"),
+			Default:           "",
+		},
 		&sql.MysqlSystemVariable{
 			Name:              dsess.DoltOverrideSchema,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -480,6 +558,20 @@ func AddDoltSystemVariables() {
 			Type:    types.NewSystemStringType(dsess.DoltStatsBranches),
 			Default: "",
 		},
+		&sql.MysqlSystemVariable{ // Comma-separated groups of dolt_tests that must pass before a commit lands, or * for all tests.
+			Name:    dsess.DoltPreCommitTestGroups,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemStringType(dsess.DoltPreCommitTestGroups),
+			Default: "",
+		},
+		&sql.MysqlSystemVariable{ // Comma-separated groups of dolt_tests that must pass before a merge or push lands, or * for all tests.
+			Name:    dsess.DoltPreMergeTestGroups,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemStringType(dsess.DoltPreMergeTestGroups),
+			Default: "",
+		},
 		&sql.MysqlSystemVariable{
 			Name:    "signingkey",
 			Dynamic: true,
@@ -598,6 +598,9 @@ func (t *DoltTable) PrimaryKeySchema() sql.PrimaryKeySchema {
 
 // PartitionRows returns the table rows for the partition given
 func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if err := t.CheckReadAccess(ctx); err != nil {
+		return nil, err
+	}
 	table, err := t.DoltTable(ctx)
 	if err != nil {
 		return nil, err
@@ -692,7 +695,7 @@ func (t *WritableDoltTable) WithProjections(colNames []string) sql.Table {
 
 // Inserter implements sql.InsertableTable
 func (t *WritableDoltTable) Inserter(ctx *sql.Context) sql.RowInserter {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckColumnAccessForDb(ctx, t.db, t.tableName, t.sch.GetAllCols().GetColumnNames(), branch_control.Permissions_Write); err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
 	te, err := t.getTableEditor(ctx)
@@ -952,7 +955,7 @@ func emptyFulltextTable(
 
 // Deleter implements sql.DeletableTable
 func (t *WritableDoltTable) Deleter(ctx *sql.Context) sql.RowDeleter {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckColumnAccessForDb(ctx, t.db, t.tableName, t.sch.GetAllCols().GetColumnNames(), branch_control.Permissions_Write); err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
 	te, err := t.getTableEditor(ctx)
@@ -964,7 +967,7 @@ func (t *WritableDoltTable) Deleter(ctx *sql.Context) sql.RowDeleter {
 
 // Replacer implements sql.ReplaceableTable
 func (t *WritableDoltTable) Replacer(ctx *sql.Context) sql.RowReplacer {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckColumnAccessForDb(ctx, t.db, t.tableName, t.sch.GetAllCols().GetColumnNames(), branch_control.Permissions_Write); err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
 	te, err := t.getTableEditor(ctx)
@@ -976,7 +979,7 @@ func (t *WritableDoltTable) Replacer(ctx *sql.Context) sql.RowReplacer {
 
 // Truncate implements sql.TruncateableTable
 func (t *WritableDoltTable) Truncate(ctx *sql.Context) (int, error) {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckColumnAccessForDb(ctx, t.db, t.tableName, t.sch.GetAllCols().GetColumnNames(), branch_control.Permissions_Write); err != nil {
 		return 0, err
 	}
 	table, err := t.DoltTable.DoltTable(ctx)
@@ -1118,19 +1121,27 @@ func copyConstraintViolationsAndConflicts(ctx context.Context, from, to *doltdb.
 
 // Updater implements sql.UpdatableTable
 func (t *WritableDoltTable) Updater(ctx *sql.Context) sql.RowUpdater {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
 	te, err := t.getTableEditor(ctx)
 	if err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
+	// Updates only write the columns whose values change, so we check the columns that may not be written per row
+	denied, err := t.deniedColumns(ctx, branch_control.Permissions_Write)
+	if err != nil {
+		return sqlutil.NewStaticErrorEditor(err)
+	}
+	if len(denied) > 0 {
+		return columnAccessUpdater{TableWriter: te, sch: t.sqlSch.Schema, denied: denied}
+	}
 	return te
 }
 
 // AutoIncrementSetter implements sql.AutoIncrementTable
 func (t *WritableDoltTable) AutoIncrementSetter(ctx *sql.Context) sql.AutoIncrementSetter {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return sqlutil.NewStaticErrorEditor(err)
 	}
 	te, err := t.getTableEditor(ctx)
@@ -1577,7 +1588,7 @@ func (t *AlterableDoltTable) WithProjections(colNames []string) sql.Table {
 
 // AddColumn implements sql.AlterableTable
 func (t *AlterableDoltTable) AddColumn(ctx *sql.Context, column *sql.Column, order *sql.ColumnOrder) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckColumnAccessForDb(ctx, t.db, t.tableName, []string{column.Name}, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	root, err := t.getRoot(ctx)
@@ -1714,7 +1725,7 @@ func (t *AlterableDoltTable) RewriteInserter(
 	newColumn *sql.Column,
 	idxCols []sql.IndexColumn,
 ) (sql.RowInserter, error) {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckColumnAccessForDb(ctx, t.db, t.tableName, t.sch.GetAllCols().GetColumnNames(), branch_control.Permissions_Write); err != nil {
 		return nil, err
 	}
 	err := validateSchemaChange(t.Name(), oldSchema, newSchema, oldColumn, newColumn, idxCols)
@@ -2276,7 +2287,7 @@ func (t *AlterableDoltTable) dropColumnData(ctx *sql.Context, updatedTable *dolt
 // ModifyColumn implements sql.AlterableTable. ModifyColumn operations are only used for operations that change only
 // the schema of a table, not the data. For those operations, |RewriteInserter| is used.
 func (t *AlterableDoltTable) ModifyColumn(ctx *sql.Context, columnName string, column *sql.Column, order *sql.ColumnOrder) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckColumnAccessForDb(ctx, t.db, t.tableName, []string{columnName}, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	ws, err := t.db.GetWorkingSet(ctx)
@@ -2449,7 +2460,7 @@ func allocatePrefixLengths(idxCols []sql.IndexColumn) []uint16 {
 
 // CreateIndex implements sql.IndexAlterableTable
 func (t *AlterableDoltTable) CreateIndex(ctx *sql.Context, idx sql.IndexDef) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	if idx.Constraint != sql.IndexConstraint_None && idx.Constraint != sql.IndexConstraint_Unique && idx.Constraint != sql.IndexConstraint_Spatial {
@@ -2461,7 +2472,7 @@ func (t *AlterableDoltTable) CreateIndex(ctx *sql.Context, idx sql.IndexDef) err
 
 // DropIndex implements sql.IndexAlterableTable
 func (t *AlterableDoltTable) DropIndex(ctx *sql.Context, indexName string) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	// We disallow removing internal dolt_ tables from SQL directly
@@ -2489,7 +2500,7 @@ func (t *AlterableDoltTable) DropIndex(ctx *sql.Context, indexName string) error
 
 // RenameIndex implements sql.IndexAlterableTable
 func (t *AlterableDoltTable) RenameIndex(ctx *sql.Context, fromIndexName string, toIndexName string) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	// RenameIndex will error if there is a name collision or an index does not exist
@@ -2533,7 +2544,7 @@ func (t *AlterableDoltTable) CreateFulltextIndex(ctx *sql.Context, idx sql.Index
 	if !types.IsFormat_DOLT(t.Format()) {
 		return fmt.Errorf("FULLTEXT is not supported on storage format %s. Run `dolt migrate` to upgrade to the latest storage format.", t.Format().VersionString())
 	}
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	if !idx.IsFullText() {
@@ -2724,7 +2735,7 @@ func (t *WritableDoltTable) createForeignKey(
 
 // AddForeignKey implements sql.ForeignKeyTable
 func (t *AlterableDoltTable) AddForeignKey(ctx *sql.Context, sqlFk sql.ForeignKeyConstraint) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	// empty string foreign key names are replaced with a generated name elsewhere
@@ -2780,7 +2791,7 @@ func (t *AlterableDoltTable) AddForeignKey(ctx *sql.Context, sqlFk sql.ForeignKe
 
 // DropForeignKey implements sql.ForeignKeyTable
 func (t *AlterableDoltTable) DropForeignKey(ctx *sql.Context, fkName string) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	root, err := t.getRoot(ctx)
@@ -2814,7 +2825,7 @@ func (t *AlterableDoltTable) DropForeignKey(ctx *sql.Context, fkName string) err
 // an update statement (including a no-op write statement) has the side-effect of causing a schema change.
 // TODO: get rid of explicit IsResolved tracking
 func (t *WritableDoltTable) UpdateForeignKey(ctx *sql.Context, fkName string, sqlFk sql.ForeignKeyConstraint) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	root, err := t.getRoot(ctx)
@@ -3089,7 +3100,7 @@ func (t *WritableDoltTable) updateFromRoot(ctx *sql.Context, root doltdb.RootVal
 }
 
 func (t *AlterableDoltTable) CreateCheck(ctx *sql.Context, check *sql.CheckDefinition) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	root, err := t.getRoot(ctx)
@@ -3145,7 +3156,7 @@ func (t *AlterableDoltTable) CreateCheck(ctx *sql.Context, check *sql.CheckDefin
 }
 
 func (t *AlterableDoltTable) DropCheck(ctx *sql.Context, chName string) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	root, err := t.getRoot(ctx)
@@ -3196,7 +3207,7 @@ func (t *AlterableDoltTable) ModifyStoredCollation(ctx *sql.Context, collation s
 }
 
 func (t *AlterableDoltTable) ModifyDefaultCollation(ctx *sql.Context, collation sql.CollationID) error {
-	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+	if err := dsess.CheckTableAccessForDb(ctx, t.db, t.tableName, branch_control.Permissions_Write); err != nil {
 		return err
 	}
 	root, err := t.getRoot(ctx)
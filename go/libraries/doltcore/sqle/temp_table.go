@@ -32,6 +32,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -45,8 +46,10 @@ type TempTable struct {
 
 	lookup sql.IndexLookup
 
-	ed   dsess.TableWriter
-	opts editor.Options
+	ddb          *doltdb.DoltDB
+	writeSession dsess.WriteSession
+	ed           dsess.TableWriter
+	opts         editor.Options
 }
 
 var _ sql.TemporaryTable = &TempTable{}
@@ -59,6 +62,7 @@ var _ sql.CheckTable = &TempTable{}
 var _ sql.CheckAlterableTable = &TempTable{}
 var _ sql.StatisticsTable = &TempTable{}
 var _ sql.AutoIncrementTable = &TempTable{}
+var _ dsess.GCRootsVisitor = &TempTable{}
 
 func NewTempTable(
 	ctx *sql.Context,
@@ -138,12 +142,14 @@ func NewTempTable(
 	writeSession := writer.NewWriteSession(tbl.Format(), newWs, ait, opts)
 
 	tempTable := &TempTable{
-		tableName: name,
-		dbName:    db,
-		pkSch:     pkSch,
-		table:     tbl,
-		sch:       sch,
-		opts:      opts,
+		tableName:    name,
+		dbName:       db,
+		pkSch:        pkSch,
+		table:        tbl,
+		sch:          sch,
+		ddb:          ddb,
+		writeSession: writeSession,
+		opts:         opts,
 	}
 
 	tempTable.ed, err = writeSession.GetTableWriter(ctx, doltdb.TableName{Name: name}, db, setTempTableRoot(tempTable), false)
@@ -185,8 +191,8 @@ func setTempTableRoot(t *TempTable) func(ctx *sql.Context, dbName string, newRoo
 			return err
 		}
 
-		writeSession := writer.NewWriteSession(newTable.Format(), newWs, ait, t.opts)
-		t.ed, err = writeSession.GetTableWriter(ctx, doltdb.TableName{Name: t.tableName}, t.dbName, setTempTableRoot(t), false)
+		t.writeSession = writer.NewWriteSession(newTable.Format(), newWs, ait, t.opts)
+		t.ed, err = t.writeSession.GetTableWriter(ctx, doltdb.TableName{Name: t.tableName}, t.dbName, setTempTableRoot(t), false)
 		if err != nil {
 			return err
 		}
@@ -456,6 +462,16 @@ func (t *TempTable) StatementComplete(ctx *sql.Context) error {
 	return nil
 }
 
+// VisitGCRoots implements dsess.GCRootsVisitor. The root of the table's write session holds the table, along with the
+// working root of its database when the table was last written.
+func (t *TempTable) VisitGCRoots(ctx context.Context, keep func(hash.Hash) error) error {
+	_, h, err := t.ddb.WriteRootValue(ctx, t.writeSession.GetWorkingSet().WorkingRoot())
+	if err != nil {
+		return err
+	}
+	return keep(h)
+}
+
 func (t *TempTable) Close(ctx *sql.Context) error {
 	err := t.ed.Close(ctx)
 
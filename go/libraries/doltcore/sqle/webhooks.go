@@ -0,0 +1,88 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// WebhookEndpointsFunc returns the webhook endpoints which are notified when the branches and tags of the database
+// named |name| move.
+type WebhookEndpointsFunc func(name string) []doltdb.WebhookEndpoint
+
+// ChangedTables returns the names of the tables which differ between |from| and |to|. It implements
+// doltdb.ChangedTablesFunc.
+func ChangedTables(ctx context.Context, from, to doltdb.RootValue) ([]string, error) {
+	deltas, err := diff.GetTableDeltas(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for _, td := range deltas {
+		changed, err := td.HasChanges()
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			tables = append(tables, td.CurName())
+		}
+	}
+	return tables, nil
+}
+
+// ApplyWebhookConfig adds a webhook commit hook to each of |dbs| which has endpoints configured.
+func ApplyWebhookConfig(ctx context.Context, bThreads *sql.BackgroundThreads, mrEnv *env.MultiRepoEnv, endpoints WebhookEndpointsFunc, logger io.Writer, dbs ...dsess.SqlDatabase) error {
+	for _, db := range dbs {
+		dEnv := mrEnv.GetEnv(db.Name())
+		if dEnv == nil {
+			continue
+		}
+		err := addWebhookHook(ctx, bThreads, dEnv, db.Name(), endpoints, logger)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewWebhookInitDatabaseHook returns an InitDatabaseHook which adds a webhook commit hook to newly created databases
+// which have endpoints configured.
+func NewWebhookInitDatabaseHook(bThreads *sql.BackgroundThreads, endpoints WebhookEndpointsFunc, logger io.Writer) InitDatabaseHook {
+	return func(ctx *sql.Context, _ *DoltDatabaseProvider, name string, dEnv *env.DoltEnv, _ dsess.SqlDatabase) error {
+		return addWebhookHook(ctx, bThreads, dEnv, name, endpoints, logger)
+	}
+}
+
+func addWebhookHook(ctx context.Context, bThreads *sql.BackgroundThreads, dEnv *env.DoltEnv, name string, endpoints WebhookEndpointsFunc, logger io.Writer) error {
+	dbEndpoints := endpoints(name)
+	if len(dbEndpoints) == 0 {
+		return nil
+	}
+	hook, err := doltdb.NewWebhookHook(ctx, bThreads, dEnv.DoltDB, name, dbEndpoints, ChangedTables)
+	if err != nil {
+		return err
+	}
+	_ = hook.SetLogger(ctx, logger)
+	dEnv.DoltDB.PrependCommitHook(ctx, hook)
+	return nil
+}
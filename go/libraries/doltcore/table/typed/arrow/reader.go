@@ -0,0 +1,313 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// recordReader reads record batches in one of the arrow IPC formats. It returns io.EOF after the last batch.
+type recordReader interface {
+	Read() (arrow.Record, error)
+	Schema() *arrow.Schema
+}
+
+// ArrowReader implements TableReader. It reads rows from an arrow IPC file or stream, a record batch at a time. Only
+// the fields of the file that are columns of the reader's schema are read.
+type ArrowReader struct {
+	rd     recordReader
+	closer io.Closer
+	sch    schema.Schema
+
+	// fieldIdxs are the indexes of the fields that are read, in the order of the columns of |sch|
+	fieldIdxs []int
+	rec       arrow.Record
+	recIdx    int
+}
+
+var _ table.SqlTableReader = (*ArrowReader)(nil)
+
+// OpenArrowReader opens a reader for the arrow file at |path|, which can be in either the IPC file or stream format.
+// The columns of |sch| that are not in the file are left out of the reader's schema.
+func OpenArrowReader(path string, fs filesys.ReadableFS, sch schema.Schema) (*ArrowReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewArrowReader(r, sch)
+}
+
+// OpenArrowReaderWithInferredSchema opens a reader for the arrow file at |path|, whose schema is inferred from the
+// fields of the file. See InferColumns.
+func OpenArrowReaderWithInferredSchema(path string, fs filesys.ReadableFS) (*ArrowReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewArrowReader(r, nil)
+}
+
+// InferColumnsFromFile returns the columns of the arrow file at |path|. See InferColumns.
+func InferColumnsFromFile(path string, fs filesys.ReadableFS) (*schema.ColCollection, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	rd, err := newRecordReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return InferColumns(rd.Schema())
+}
+
+// NewArrowReader creates an ArrowReader that reads rows from |r| with the schema |sch|. If |sch| is nil, the schema
+// of the reader is inferred from the fields of the file.
+func NewArrowReader(r io.ReadCloser, sch schema.Schema) (*ArrowReader, error) {
+	rd, err := newRecordReader(r)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	if sch == nil {
+		cols, err := InferColumns(rd.Schema())
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		sch, err = schema.SchemaFromCols(cols)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+	}
+
+	fieldIdxs, sch, err := projectColumns(rd.Schema(), sch)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	return &ArrowReader{rd: rd, closer: r, sch: sch, fieldIdxs: fieldIdxs}, nil
+}
+
+// newRecordReader returns a reader for the record batches of |r|. Files in the IPC file format begin with a magic
+// string, and can only be read when |r| can be seeked, as the file format is read from its footer. Anything else is
+// read as an IPC stream.
+func newRecordReader(r io.Reader) (recordReader, error) {
+	if rs, ok := r.(ipc.ReadAtSeeker); ok {
+		magic := make([]byte, len(ipc.Magic))
+		n, err := io.ReadFull(rs, magic)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return nil, err
+		}
+		if _, err := rs.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if bytes.Equal(magic[:n], ipc.Magic) {
+			return ipc.NewFileReader(rs)
+		}
+		return ipc.NewReader(rs)
+	}
+
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(ipc.Magic))
+	if bytes.Equal(magic, ipc.Magic) {
+		return nil, errors.New("arrow files can only be read from a file, use the arrow stream format instead")
+	}
+	return ipc.NewReader(br)
+}
+
+// projectColumns returns the indexes of the fields of |as| that are columns of |sch|, and the schema of the rows read
+// from them.
+func projectColumns(as *arrow.Schema, sch schema.Schema) ([]int, schema.Schema, error) {
+	var fieldIdxs []int
+	var cols []schema.Column
+	for _, col := range sch.GetAllCols().GetColumns() {
+		idxs := as.FieldIndices(col.Name)
+		if len(idxs) == 0 {
+			continue
+		}
+		f := as.Field(idxs[0])
+		if _, err := typeInfo(f); err != nil {
+			return nil, nil, fmt.Errorf("cannot read column: %s: %w", col.Name, err)
+		}
+
+		fieldIdxs = append(fieldIdxs, idxs[0])
+		cols = append(cols, col)
+	}
+
+	if len(cols) == 0 {
+		return nil, nil, errors.New("none of the columns of the arrow file are in the schema")
+	}
+
+	if len(cols) == sch.GetAllCols().Size() {
+		return fieldIdxs, sch, nil
+	}
+
+	projected, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, nil, err
+	}
+	return fieldIdxs, projected, nil
+}
+
+func (ar *ArrowReader) ReadRow(ctx context.Context) (row.Row, error) {
+	panic("deprecated")
+}
+
+func (ar *ArrowReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	for ar.rec == nil || ar.recIdx >= int(ar.rec.NumRows()) {
+		rec, err := ar.rd.Read()
+		if err != nil {
+			return nil, err
+		}
+		ar.rec = rec
+		ar.recIdx = 0
+	}
+
+	r := make(sql.Row, len(ar.fieldIdxs))
+	for i, fieldIdx := range ar.fieldIdxs {
+		v, err := value(ar.rec.Column(fieldIdx), ar.recIdx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read column: %s: %w", ar.rec.ColumnName(fieldIdx), err)
+		}
+		r[i] = v
+	}
+	ar.recIdx++
+
+	return r, nil
+}
+
+// value returns the value at index |i| of the array |arr|, converted to a value of the sql type of the array's field.
+// See typeInfo.
+func value(arr arrow.Array, i int) (interface{}, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		return arr.Value(i), nil
+	case *array.Int8:
+		return arr.Value(i), nil
+	case *array.Int16:
+		return arr.Value(i), nil
+	case *array.Int32:
+		return arr.Value(i), nil
+	case *array.Int64:
+		return arr.Value(i), nil
+	case *array.Uint8:
+		return arr.Value(i), nil
+	case *array.Uint16:
+		return arr.Value(i), nil
+	case *array.Uint32:
+		return arr.Value(i), nil
+	case *array.Uint64:
+		return arr.Value(i), nil
+	case *array.Float16:
+		return arr.Value(i).Float32(), nil
+	case *array.Float32:
+		return arr.Value(i), nil
+	case *array.Float64:
+		return arr.Value(i), nil
+	case *array.Decimal128:
+		return numToDecimal(arr.Value(i), arr.DataType().(*arrow.Decimal128Type).Scale), nil
+	case *array.Date32:
+		return time.Unix(int64(arr.Value(i))*24*60*60, 0).UTC(), nil
+	case *array.Date64:
+		return time.UnixMilli(int64(arr.Value(i))).UTC(), nil
+	case *array.Timestamp:
+		return unitTime(int64(arr.Value(i)), arr.DataType().(*arrow.TimestampType).Unit), nil
+	case *array.Time32:
+		return unitTimespan(int64(arr.Value(i)), arr.DataType().(*arrow.Time32Type).Unit), nil
+	case *array.Time64:
+		return unitTimespan(int64(arr.Value(i)), arr.DataType().(*arrow.Time64Type).Unit), nil
+	case *array.Duration:
+		return unitTimespan(int64(arr.Value(i)), arr.DataType().(*arrow.DurationType).Unit), nil
+	case *array.String:
+		return arr.Value(i), nil
+	case *array.Binary:
+		// the value points into the record batch, which is released once it has been read
+		return append([]byte(nil), arr.Value(i)...), nil
+	case *array.FixedSizeBinary:
+		return append([]byte(nil), arr.Value(i)...), nil
+	default:
+		return nil, fmt.Errorf("arrow type %s is not supported", arr.DataType())
+	}
+}
+
+// unitTime returns the UTC time that is |n| |unit|s after the unix epoch.
+func unitTime(n int64, unit arrow.TimeUnit) time.Time {
+	switch unit {
+	case arrow.Second:
+		return time.Unix(n, 0).UTC()
+	case arrow.Millisecond:
+		return time.UnixMilli(n).UTC()
+	case arrow.Microsecond:
+		return time.UnixMicro(n).UTC()
+	default:
+		return time.Unix(0, n).UTC()
+	}
+}
+
+// unitTimespan returns the time of |n| |unit|s.
+func unitTimespan(n int64, unit arrow.TimeUnit) gmstypes.Timespan {
+	switch unit {
+	case arrow.Second:
+		n *= 1000000
+	case arrow.Millisecond:
+		n *= 1000
+	case arrow.Nanosecond:
+		n /= 1000
+	}
+	return gmstypes.Time.MicrosecondsToTimespan(n)
+}
+
+func (ar *ArrowReader) GetSchema() schema.Schema {
+	return ar.sch
+}
+
+// Close should release resources being held
+func (ar *ArrowReader) Close(ctx context.Context) error {
+	if ar.closer == nil {
+		return errors.New("Already closed.")
+	}
+
+	err := ar.closer.Close()
+	ar.closer = nil
+	return err
+}
@@ -0,0 +1,214 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/decimal128"
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+const (
+	// extensionNameKey is the field metadata key that holds the name of a field's extension type.
+	extensionNameKey = "ARROW:extension:name"
+	// jsonExtensionName is the name of the canonical extension type for JSON values, which are stored as strings.
+	jsonExtensionName = "arrow.json"
+
+	// maxDecimalPrecision is the largest precision of a decimal128 value.
+	maxDecimalPrecision = 38
+)
+
+// ArrowSchema returns the arrow schema of rows with the sql schema |sch|.
+func ArrowSchema(sch sql.Schema) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, len(sch))
+	for i, col := range sch {
+		dt, err := arrowType(col.Type)
+		if err != nil {
+			return nil, fmt.Errorf("column '%s': %w", col.Name, err)
+		}
+
+		fields[i] = arrow.Field{Name: col.Name, Type: dt, Nullable: col.Nullable}
+		if col.Type.Type() == query.Type_JSON {
+			fields[i].Metadata = arrow.NewMetadata([]string{extensionNameKey}, []string{jsonExtensionName})
+		}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// arrowType returns the arrow type of the values of the sql type |t|. Decimals too wide for a decimal128 are written
+// as strings, and TIME values, which can be negative and longer than a day, are written as durations.
+func arrowType(t sql.Type) (arrow.DataType, error) {
+	switch t.Type() {
+	case query.Type_INT8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case query.Type_INT16, query.Type_YEAR:
+		return arrow.PrimitiveTypes.Int16, nil
+	case query.Type_INT24, query.Type_INT32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case query.Type_INT64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case query.Type_UINT8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case query.Type_UINT16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case query.Type_UINT24, query.Type_UINT32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case query.Type_UINT64, query.Type_BIT:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case query.Type_FLOAT32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case query.Type_FLOAT64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case query.Type_DECIMAL:
+		dt := t.(sql.DecimalType)
+		if dt.Precision() > maxDecimalPrecision {
+			return arrow.BinaryTypes.String, nil
+		}
+		return &arrow.Decimal128Type{Precision: int32(dt.Precision()), Scale: int32(dt.Scale())}, nil
+	case query.Type_DATE:
+		return arrow.FixedWidthTypes.Date32, nil
+	case query.Type_DATETIME:
+		return &arrow.TimestampType{Unit: arrow.Microsecond}, nil
+	case query.Type_TIMESTAMP:
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, nil
+	case query.Type_TIME:
+		return &arrow.DurationType{Unit: arrow.Microsecond}, nil
+	case query.Type_CHAR, query.Type_VARCHAR, query.Type_TEXT, query.Type_ENUM, query.Type_SET, query.Type_JSON:
+		return arrow.BinaryTypes.String, nil
+	case query.Type_BINARY, query.Type_VARBINARY, query.Type_BLOB, query.Type_GEOMETRY:
+		return arrow.BinaryTypes.Binary, nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %v", t.Type())
+	}
+}
+
+// InferColumns returns a column for each field of the arrow schema |as|, with a type derived from the field's type.
+// Fields that aren't nullable are given a NOT NULL constraint. Columns are tagged in field order, starting at
+// schema.ReservedTagMin, and none of them are part of the primary key.
+func InferColumns(as *arrow.Schema) (*schema.ColCollection, error) {
+	var cols []schema.Column
+	for i, f := range as.Fields() {
+		ti, err := typeInfo(f)
+		if err != nil {
+			return nil, fmt.Errorf("column '%s': %w", f.Name, err)
+		}
+
+		var constraints []schema.ColConstraint
+		if !f.Nullable {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+
+		col, err := schema.NewColumnWithTypeInfo(f.Name, schema.ReservedTagMin+uint64(i), ti, false, "", false, "", constraints...)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+
+	return schema.NewColCollection(cols...), nil
+}
+
+// typeInfo returns the type of the column that holds the values of the arrow field |f|.
+func typeInfo(f arrow.Field) (typeinfo.TypeInfo, error) {
+	switch dt := f.Type.(type) {
+	case *arrow.BooleanType:
+		return typeinfo.FromSqlType(gmstypes.Boolean)
+	case *arrow.Int8Type:
+		return typeinfo.Int8Type, nil
+	case *arrow.Int16Type:
+		return typeinfo.Int16Type, nil
+	case *arrow.Int32Type:
+		return typeinfo.Int32Type, nil
+	case *arrow.Int64Type:
+		return typeinfo.Int64Type, nil
+	case *arrow.Uint8Type:
+		return typeinfo.Uint8Type, nil
+	case *arrow.Uint16Type:
+		return typeinfo.Uint16Type, nil
+	case *arrow.Uint32Type:
+		return typeinfo.Uint32Type, nil
+	case *arrow.Uint64Type:
+		return typeinfo.Uint64Type, nil
+	case *arrow.Float16Type, *arrow.Float32Type:
+		return typeinfo.Float32Type, nil
+	case *arrow.Float64Type:
+		return typeinfo.Float64Type, nil
+	case *arrow.Decimal128Type:
+		decType, err := gmstypes.CreateDecimalType(uint8(dt.Precision), uint8(dt.Scale))
+		if err != nil {
+			return nil, err
+		}
+		return typeinfo.FromSqlType(decType)
+	case *arrow.Date32Type, *arrow.Date64Type:
+		return typeinfo.DateType, nil
+	case *arrow.TimestampType:
+		return typeinfo.DatetimeType, nil
+	case *arrow.Time32Type, *arrow.Time64Type, *arrow.DurationType:
+		return typeinfo.TimeType, nil
+	case *arrow.StringType:
+		if isJSON(f) {
+			return typeinfo.JSONType, nil
+		}
+		return typeinfo.StringDefaultType, nil
+	case *arrow.BinaryType:
+		return typeinfo.BlobType, nil
+	case *arrow.FixedSizeBinaryType:
+		if dt.ByteWidth <= 255 {
+			return typeinfo.FromSqlType(gmstypes.MustCreateBinary(sqltypes.Binary, int64(dt.ByteWidth)))
+		}
+		return typeinfo.BlobType, nil
+	default:
+		return nil, fmt.Errorf("arrow type %s is not supported", f.Type)
+	}
+}
+
+// isJSON returns whether the field |f| holds JSON values.
+func isJSON(f arrow.Field) bool {
+	i := f.Metadata.FindKey(extensionNameKey)
+	return i >= 0 && f.Metadata.Values()[i] == jsonExtensionName
+}
+
+// decimalToNum returns the decimal128 value of |d| with the scale |scale|.
+func decimalToNum(d decimal.Decimal, scale int32) (decimal128.Num, error) {
+	b := d.Shift(scale).BigInt()
+	if b.BitLen() > 127 {
+		return decimal128.Num{}, fmt.Errorf("%s does not fit in a decimal128", d.String())
+	}
+
+	if b.Sign() < 0 {
+		// two's complement
+		b.Add(b, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	lo := new(big.Int).And(b, new(big.Int).SetUint64(^uint64(0))).Uint64()
+	hi := new(big.Int).Rsh(b, 64).Uint64()
+	return decimal128.New(int64(hi), lo), nil
+}
+
+// numToDecimal returns the decimal value of the decimal128 |n| with the scale |scale|.
+func numToDecimal(n decimal128.Num, scale int32) decimal.Decimal {
+	b := new(big.Int).Lsh(big.NewInt(n.HighBits()), 64)
+	b.Add(b, new(big.Int).SetUint64(n.LowBits()))
+	return decimal.NewFromBigInt(b, -scale)
+}
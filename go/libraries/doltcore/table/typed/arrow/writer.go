@@ -0,0 +1,267 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+const (
+	// writeBatchSize is the number of rows written in each record batch.
+	writeBatchSize = 64 * 1024
+)
+
+// recordWriter writes record batches in one of the arrow IPC formats.
+type recordWriter interface {
+	Write(rec arrow.Record) error
+	Close() error
+}
+
+// ArrowWriter implements TableWriter. It writes rows to an arrow IPC file or stream, in record batches of
+// writeBatchSize rows.
+type ArrowWriter struct {
+	wr      recordWriter
+	closer  io.Closer
+	sch     sql.Schema
+	builder *array.RecordBuilder
+	numRows int
+}
+
+var _ table.SqlRowWriter = (*ArrowWriter)(nil)
+
+// NewArrowFileWriter returns an ArrowWriter that writes rows with the schema |outSch| to |wr| in the arrow IPC file
+// format, which is the format of .arrow and .feather (v2) files. The file format has a footer that points back to
+// the record batches, so |wr| must be seekable.
+func NewArrowFileWriter(wr io.WriteCloser, outSch schema.Schema) (*ArrowWriter, error) {
+	ws, ok := wr.(io.WriteSeeker)
+	if !ok {
+		return nil, errors.New("arrow files can only be written to a file, use the arrow stream format instead")
+	}
+
+	as, sch, err := arrowSchema(outSch)
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := ipc.NewFileWriter(ws, ipc.WithSchema(as))
+	if err != nil {
+		return nil, err
+	}
+	return newArrowWriter(fw, wr, as, sch), nil
+}
+
+// NewArrowStreamWriter returns an ArrowWriter that writes rows with the schema |outSch| to |wr| in the arrow IPC
+// stream format.
+func NewArrowStreamWriter(wr io.WriteCloser, outSch schema.Schema) (*ArrowWriter, error) {
+	as, sch, err := arrowSchema(outSch)
+	if err != nil {
+		return nil, err
+	}
+
+	return newArrowWriter(ipc.NewWriter(wr, ipc.WithSchema(as)), wr, as, sch), nil
+}
+
+func arrowSchema(outSch schema.Schema) (*arrow.Schema, sql.Schema, error) {
+	pkSch, err := sqlutil.FromDoltSchema("", "", outSch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	as, err := ArrowSchema(pkSch.Schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	return as, pkSch.Schema, nil
+}
+
+func newArrowWriter(wr recordWriter, closer io.Closer, as *arrow.Schema, sch sql.Schema) *ArrowWriter {
+	return &ArrowWriter{
+		wr:      wr,
+		closer:  closer,
+		sch:     sch,
+		builder: array.NewRecordBuilder(memory.NewGoAllocator(), as),
+	}
+}
+
+func (w *ArrowWriter) WriteSqlRow(ctx context.Context, r sql.Row) error {
+	for i, v := range r {
+		if err := appendValue(w.builder.Field(i), w.sch[i].Type, v); err != nil {
+			return fmt.Errorf("column '%s': %w", w.sch[i].Name, err)
+		}
+	}
+
+	w.numRows++
+	if w.numRows == writeBatchSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// flush writes the rows that have been appended to the builder as a record batch.
+func (w *ArrowWriter) flush() error {
+	rec := w.builder.NewRecord()
+	defer rec.Release()
+	w.numRows = 0
+	return w.wr.Write(rec)
+}
+
+// Close should flush all writes, release resources being held
+func (w *ArrowWriter) Close(ctx context.Context) error {
+	if w.closer == nil {
+		return errors.New("Already closed.")
+	}
+
+	var err error
+	if w.numRows > 0 {
+		err = w.flush()
+	}
+	w.builder.Release()
+	if err == nil {
+		err = w.wr.Close()
+	}
+
+	errCl := w.closer.Close()
+	w.closer = nil
+	if err != nil {
+		return err
+	}
+	return errCl
+}
+
+// appendValue appends |v|, a value of the sql type |t|, to the builder |b|, which was created for the arrow type of |t|.
+func appendValue(b array.Builder, t sql.Type, v interface{}) error {
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch b := b.(type) {
+	case *array.Int8Builder, *array.Int16Builder, *array.Int32Builder, *array.Int64Builder:
+		n, _, err := gmstypes.Int64.Convert(v)
+		if err != nil {
+			return err
+		}
+		switch b := b.(type) {
+		case *array.Int8Builder:
+			b.Append(int8(n.(int64)))
+		case *array.Int16Builder:
+			b.Append(int16(n.(int64)))
+		case *array.Int32Builder:
+			b.Append(int32(n.(int64)))
+		case *array.Int64Builder:
+			b.Append(n.(int64))
+		}
+	case *array.Uint8Builder, *array.Uint16Builder, *array.Uint32Builder, *array.Uint64Builder:
+		n, _, err := gmstypes.Uint64.Convert(v)
+		if err != nil {
+			return err
+		}
+		switch b := b.(type) {
+		case *array.Uint8Builder:
+			b.Append(uint8(n.(uint64)))
+		case *array.Uint16Builder:
+			b.Append(uint16(n.(uint64)))
+		case *array.Uint32Builder:
+			b.Append(uint32(n.(uint64)))
+		case *array.Uint64Builder:
+			b.Append(n.(uint64))
+		}
+	case *array.Float32Builder:
+		f, _, err := gmstypes.Float32.Convert(v)
+		if err != nil {
+			return err
+		}
+		b.Append(f.(float32))
+	case *array.Float64Builder:
+		f, _, err := gmstypes.Float64.Convert(v)
+		if err != nil {
+			return err
+		}
+		b.Append(f.(float64))
+	case *array.Decimal128Builder:
+		d, _, err := t.Convert(v)
+		if err != nil {
+			return err
+		}
+		n, err := decimalToNum(d.(decimal.Decimal), int32(t.(sql.DecimalType).Scale()))
+		if err != nil {
+			return err
+		}
+		b.Append(n)
+	case *array.Date32Builder:
+		tm, err := toTime(t, v)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Date32(tm.Unix() / (24 * 60 * 60)))
+	case *array.TimestampBuilder:
+		tm, err := toTime(t, v)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Timestamp(tm.UnixMicro()))
+	case *array.DurationBuilder:
+		ts, err := gmstypes.Time.ConvertToTimespan(v)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Duration(ts.AsMicroseconds()))
+	case *array.StringBuilder:
+		s, err := sqlutil.SqlColToStr(t, v)
+		if err != nil {
+			return err
+		}
+		b.Append(s)
+	case *array.BinaryBuilder:
+		val, err := t.SQL(sql.NewEmptyContext(), nil, v)
+		if err != nil {
+			return err
+		}
+		b.Append(val.Raw())
+	default:
+		return fmt.Errorf("unsupported arrow builder %T", b)
+	}
+
+	return nil
+}
+
+// toTime returns the time of the date, datetime or timestamp value |v| of the type |t|.
+func toTime(t sql.Type, v interface{}) (time.Time, error) {
+	if tm, ok := v.(time.Time); ok {
+		return tm.UTC(), nil
+	}
+
+	tm, _, err := t.Convert(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tm.(time.Time).UTC(), nil
+}
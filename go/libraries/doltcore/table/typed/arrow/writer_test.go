@@ -0,0 +1,184 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+func testSchema(t *testing.T) schema.Schema {
+	decType, err := typeinfo.FromSqlType(gmstypes.MustCreateDecimalType(10, 2))
+	require.NoError(t, err)
+
+	id, err := schema.NewColumnWithTypeInfo("id", 0, typeinfo.Int64Type, true, "", false, "", schema.NotNullConstraint{})
+	require.NoError(t, err)
+	cols := []schema.Column{id}
+	for i, c := range []struct {
+		name string
+		ti   typeinfo.TypeInfo
+	}{
+		{"price", decType},
+		{"created", typeinfo.DatetimeType},
+		{"doc", typeinfo.JSONType},
+		{"name", typeinfo.StringDefaultType},
+		{"dur", typeinfo.TimeType},
+		{"data", typeinfo.BlobType},
+	} {
+		col, err := schema.NewColumnWithTypeInfo(c.name, uint64(i+1), c.ti, false, "", false, "")
+		require.NoError(t, err)
+		cols = append(cols, col)
+	}
+	return schema.MustSchemaFromCols(schema.NewColCollection(cols...))
+}
+
+func testRow(i int) sql.Row {
+	var name interface{}
+	if i%3 != 0 {
+		name = "row"
+	}
+	return sql.Row{
+		int64(i),
+		decimal.New(int64(i*100-5), -2),
+		time.Date(2024, 3, 5, 13, 14, 15, 123456000, time.UTC),
+		gmstypes.MustJSON(`{"a": [1, 2]}`),
+		name,
+		gmstypes.Time.MicrosecondsToTimespan(int64(-i) * 1000001),
+		[]byte{0, byte(i)},
+	}
+}
+
+func writeRows(t *testing.T, wr *ArrowWriter, n int) {
+	ctx := context.Background()
+	for i := 0; i < n; i++ {
+		require.NoError(t, wr.WriteSqlRow(ctx, testRow(i)))
+	}
+	require.NoError(t, wr.Close(ctx))
+	assert.Error(t, wr.Close(ctx))
+}
+
+func readRows(t *testing.T, rd *ArrowReader, n int) {
+	ctx := context.Background()
+	for i := 0; i < n; i++ {
+		r, err := rd.ReadSqlRow(ctx)
+		require.NoError(t, err)
+
+		exp := testRow(i)
+		require.Len(t, r, len(exp))
+		assert.Equal(t, exp[0], r[0])
+		assert.True(t, exp[1].(decimal.Decimal).Equal(r[1].(decimal.Decimal)), "%v != %v", exp[1], r[1])
+		assert.Equal(t, exp[2], r[2])
+		assert.JSONEq(t, `{"a": [1, 2]}`, r[3].(string))
+		assert.Equal(t, exp[4], r[4])
+		assert.Equal(t, exp[5], r[5])
+		assert.Equal(t, exp[6], r[6])
+	}
+	_, err := rd.ReadSqlRow(ctx)
+	assert.Equal(t, io.EOF, err)
+	require.NoError(t, rd.Close(ctx))
+}
+
+func TestArrowFileRoundTrip(t *testing.T) {
+	sch := testSchema(t)
+	p := filepath.Join(t.TempDir(), "test.arrow")
+	f, err := filesys.LocalFS.OpenForWrite(p, os.ModePerm)
+	require.NoError(t, err)
+
+	// enough rows for more than one record batch
+	n := writeBatchSize + 10
+	wr, err := NewArrowFileWriter(f, sch)
+	require.NoError(t, err)
+	writeRows(t, wr, n)
+
+	rd, err := OpenArrowReader(p, filesys.LocalFS, sch)
+	require.NoError(t, err)
+	readRows(t, rd, n)
+
+	rd, err = OpenArrowReaderWithInferredSchema(p, filesys.LocalFS)
+	require.NoError(t, err)
+	cols := rd.GetSchema().GetAllCols()
+	assert.Equal(t, []string{"id", "price", "created", "doc", "name", "dur", "data"}, cols.GetColumnNames())
+	assert.Equal(t, "decimal(10,2)", cols.GetByIndex(1).TypeInfo.ToSqlType().String())
+	assert.Equal(t, typeinfo.JSONType, cols.GetByIndex(3).TypeInfo)
+	assert.Equal(t, typeinfo.TimeType, cols.GetByIndex(5).TypeInfo)
+	require.NoError(t, rd.Close(context.Background()))
+}
+
+func TestArrowStreamRoundTrip(t *testing.T) {
+	sch := testSchema(t)
+	buf := &bytes.Buffer{}
+
+	_, err := NewArrowFileWriter(iohelp.NopWrCloser(buf), sch)
+	assert.Error(t, err)
+
+	wr, err := NewArrowStreamWriter(iohelp.NopWrCloser(buf), sch)
+	require.NoError(t, err)
+	writeRows(t, wr, 100)
+
+	rd, err := NewArrowReader(io.NopCloser(bytes.NewReader(buf.Bytes())), sch)
+	require.NoError(t, err)
+	readRows(t, rd, 100)
+
+	// streams can be read when they can't be seeked, such as from stdin
+	rd, err = NewArrowReader(io.NopCloser(bytes.NewBuffer(buf.Bytes())), sch)
+	require.NoError(t, err)
+	readRows(t, rd, 100)
+}
+
+func TestArrowSchema(t *testing.T) {
+	as, err := ArrowSchema(sql.Schema{
+		{Name: "id", Type: gmstypes.Int64, Nullable: false},
+		{Name: "wide", Type: gmstypes.MustCreateDecimalType(65, 30), Nullable: true},
+		{Name: "ts", Type: gmstypes.Timestamp, Nullable: true},
+		{Name: "doc", Type: gmstypes.JSON, Nullable: true},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, arrow.PrimitiveTypes.Int64, as.Field(0).Type)
+	assert.False(t, as.Field(0).Nullable)
+	// decimals that don't fit in a decimal128 are written as strings
+	assert.Equal(t, arrow.BinaryTypes.String, as.Field(1).Type)
+	assert.Equal(t, &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, as.Field(2).Type)
+	assert.True(t, isJSON(as.Field(3)))
+}
+
+func TestDecimalNum(t *testing.T) {
+	for _, s := range []string{"0", "1.5", "-1.5", "12345678.91", "-99999999999999999999999999999999.999999"} {
+		d := decimal.RequireFromString(s)
+		n, err := decimalToNum(d, 6)
+		require.NoError(t, err)
+		assert.True(t, d.Equal(numToDecimal(n, 6)), s)
+	}
+
+	_, err := decimalToNum(decimal.RequireFromString("1e40"), 0)
+	assert.Error(t, err)
+}
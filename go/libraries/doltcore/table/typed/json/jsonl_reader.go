@@ -0,0 +1,328 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// JSONLReader reads newline delimited JSON, where each line of the input is a JSON object holding a single row.
+// Unlike the JSONReader, it only ever holds a single line in memory. Blank lines are skipped.
+type JSONLReader struct {
+	closer    io.Closer
+	bRd       *bufio.Reader
+	sch       schema.Schema
+	nbf       *types.NomsBinFormat
+	lineNum   int
+	isDone    bool
+	sampleRow sql.Row
+
+	// untyped is true when the reader returns every value as a string, for schema inference
+	untyped bool
+}
+
+var _ table.SqlTableReader = (*JSONLReader)(nil)
+
+// OpenJSONLReader opens the file at |path| and returns a reader for its rows, which are converted to |sch|.
+func OpenJSONLReader(path string, fs filesys.ReadableFS, sch schema.Schema) (*JSONLReader, error) {
+	r, err := fs.OpenForRead(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewJSONLReader(r, sch)
+}
+
+// NewJSONLReader returns a reader for the rows in |r|, which are converted to |sch|. The bytes of the supplied reader
+// are treated as UTF-8, unless there is a UTF8, UTF16LE or UTF16BE BOM at the first bytes read.
+func NewJSONLReader(r io.ReadCloser, sch schema.Schema) (*JSONLReader, error) {
+	if sch == nil {
+		return nil, errors.New("schema must be provided to JSONLReader")
+	}
+
+	return &JSONLReader{closer: r, bRd: newJSONLBufReader(r), sch: sch}, nil
+}
+
+// NewUntypedJSONLReader returns a reader for the rows in |r| whose schema has a string column for each of |colNames|.
+// Every value is returned as the string form of its JSON value, and nested objects and arrays as JSON text. It is
+// used to infer a schema for the file, along with ReadJSONLColumnNames.
+func NewUntypedJSONLReader(nbf *types.NomsBinFormat, r io.ReadCloser, colNames []string) (*JSONLReader, error) {
+	if len(colNames) == 0 {
+		r.Close()
+		return nil, errors.New("no columns were found in the JSONL file")
+	}
+
+	_, sch := untyped.NewUntypedSchema(colNames...)
+	return &JSONLReader{closer: r, bRd: newJSONLBufReader(r), sch: sch, nbf: nbf, untyped: true}, nil
+}
+
+func newJSONLBufReader(r io.Reader) *bufio.Reader {
+	textReader := transform.NewReader(r, unicode.BOMOverride(unicode.UTF8.NewDecoder()))
+	return bufio.NewReaderSize(textReader, ReadBufSize)
+}
+
+// ReadJSONLColumnNames reads all the rows in |r| and returns the names of the keys found in them, in the order in
+// which they first appear.
+func ReadJSONLColumnNames(r io.Reader) ([]string, error) {
+	bRd := newJSONLBufReader(r)
+
+	var names []string
+	seen := make(map[string]struct{})
+	for lineNum := 1; ; lineNum++ {
+		line, done, err := iohelp.ReadLine(bRd)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.TrimSpace(line) != "" {
+			keys, err := objectKeys(line)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing line %d of JSONL file: %w", lineNum, err)
+			}
+			for _, key := range keys {
+				if _, ok := seen[key]; !ok {
+					seen[key] = struct{}{}
+					names = append(names, key)
+				}
+			}
+		}
+
+		if done {
+			return names, nil
+		}
+	}
+}
+
+// objectKeys returns the keys of the JSON object |line|, in order.
+func objectKeys(line string) ([]string, error) {
+	dec := json.NewDecoder(strings.NewReader(line))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, errJSONLNotObject
+	}
+
+	var keys []string
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, tok.(string))
+
+		var val json.RawMessage
+		if err = dec.Decode(&val); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+var errJSONLNotObject = errors.New("unexpected JSONL format, expected a JSON object on each line")
+
+// Close should release resources being held
+func (r *JSONLReader) Close(ctx context.Context) error {
+	if r.closer != nil {
+		err := r.closer.Close()
+		r.closer = nil
+
+		return err
+	}
+	return errors.New("already closed")
+}
+
+// GetSchema gets the schema of the rows that this reader will return
+func (r *JSONLReader) GetSchema() schema.Schema {
+	return r.sch
+}
+
+// VerifySchema checks that the incoming schema matches the schema from the existing table
+func (r *JSONLReader) VerifySchema(sch schema.Schema) (bool, error) {
+	if r.sampleRow == nil {
+		var err error
+		r.sampleRow, err = r.ReadSqlRow(context.Background())
+		return err == nil, nil
+	}
+	return true, nil
+}
+
+// ReadRow reads a row as a row.Row. It is only supported by untyped readers, which are used for schema inference.
+func (r *JSONLReader) ReadRow(ctx context.Context) (row.Row, error) {
+	if !r.untyped {
+		panic("deprecated")
+	}
+
+	sqlRow, err := r.ReadSqlRow(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allCols := r.sch.GetAllCols()
+	taggedVals := make(row.TaggedValues, allCols.Size())
+	for i, val := range sqlRow {
+		if val != nil {
+			taggedVals[allCols.GetByIndex(i).Tag] = types.String(val.(string))
+		}
+	}
+
+	return row.New(r.nbf, r.sch, taggedVals)
+}
+
+func (r *JSONLReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
+	if r.sampleRow != nil {
+		ret := r.sampleRow
+		r.sampleRow = nil
+		return ret, nil
+	}
+
+	for !r.isDone {
+		line, done, err := iohelp.ReadLine(r.bRd)
+		if err != nil {
+			return nil, err
+		}
+		r.isDone = done
+		r.lineNum++
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var rowMap map[string]json.RawMessage
+		if err = json.Unmarshal([]byte(line), &rowMap); err != nil || rowMap == nil {
+			return nil, table.NewBadRow(nil, fmt.Sprintf("error parsing line %d of JSONL file: %s", r.lineNum, jsonlErrMsg(err)))
+		}
+
+		var sqlRow sql.Row
+		if r.untyped {
+			sqlRow, err = convToUntypedSqlRow(r.sch, rowMap)
+		} else {
+			sqlRow, err = convRawToSqlRow(r.sch, rowMap)
+		}
+		if err != nil {
+			return nil, table.NewBadRow(nil, fmt.Sprintf("error on line %d of JSONL file: %s", r.lineNum, err.Error()))
+		}
+
+		return sqlRow, nil
+	}
+
+	return nil, io.EOF
+}
+
+func jsonlErrMsg(err error) string {
+	if err == nil {
+		return errJSONLNotObject.Error()
+	}
+	return err.Error()
+}
+
+// convRawToSqlRow converts the values in |rowMap| to their Go representations and then to a row of |sch|.
+func convRawToSqlRow(sch schema.Schema, rowMap map[string]json.RawMessage) (sql.Row, error) {
+	vals := make(map[string]interface{}, len(rowMap))
+	for k, raw := range rowMap {
+		v, err := decodeRawValue(raw)
+		if err != nil {
+			return nil, err
+		}
+		vals[k] = v
+	}
+
+	return convToSqlRow(sch, vals)
+}
+
+// decodeRawValue decodes a single JSON value. Numbers that are integers are decoded to int64 or uint64, so that they
+// don't lose precision, and other numbers are returned as their text, which converts exactly to decimal columns.
+func decodeRawValue(raw json.RawMessage) (interface{}, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	switch raw[0] {
+	case '"', '{', '[', 't', 'f', 'n':
+		var v interface{}
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	default:
+		numStr := string(raw)
+		if i, err := strconv.ParseInt(numStr, 10, 64); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(numStr, 10, 64); err == nil {
+			return u, nil
+		}
+		return numStr, nil
+	}
+}
+
+// convToUntypedSqlRow returns a row of the untyped schema |sch| holding the values in |rowMap| as strings.
+func convToUntypedSqlRow(sch schema.Schema, rowMap map[string]json.RawMessage) (sql.Row, error) {
+	allCols := sch.GetAllCols()
+
+	ret := make(sql.Row, allCols.Size())
+	for k, raw := range rowMap {
+		col, ok := allCols.GetByName(k)
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in schema", k)
+		}
+
+		raw = bytes.TrimSpace(raw)
+		var v interface{}
+		switch {
+		case len(raw) == 0 || string(raw) == "null":
+			v = nil
+		case raw[0] == '"':
+			var str string
+			if err := json.Unmarshal(raw, &str); err != nil {
+				return nil, err
+			}
+			v = str
+		case raw[0] == '{' || raw[0] == '[':
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, raw); err != nil {
+				return nil, err
+			}
+			v = buf.String()
+		default:
+			v = string(raw)
+		}
+
+		ret[allCols.TagToIdx[col.Tag]] = v
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func jsonlTestSchema(t *testing.T) schema.Schema {
+	decimalType, err := typeinfo.FromSqlType(gmstypes.MustCreateDecimalType(20, 2))
+	require.NoError(t, err)
+
+	return schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.Column{Name: "id", Tag: 0, Kind: types.IntKind, IsPartOfPK: true, TypeInfo: typeinfo.Int64Type},
+		schema.Column{Name: "name", Tag: 1, Kind: types.StringKind, TypeInfo: typeinfo.StringDefaultType},
+		schema.Column{Name: "amount", Tag: 2, Kind: types.DecimalKind, TypeInfo: decimalType},
+	))
+}
+
+func TestJSONLReader(t *testing.T) {
+	ctx := context.Background()
+	input := "{\"id\": 9007199254740993, \"name\": \"bill\", \"amount\": 12345678901234.56}\n" +
+		"\n" +
+		"{\"id\": 2, \"name\": null}\r\n" +
+		"{\"amount\": 1, \"id\": 3}"
+
+	rd, err := NewJSONLReader(io.NopCloser(strings.NewReader(input)), jsonlTestSchema(t))
+	require.NoError(t, err)
+
+	var rows []sql.Row
+	for {
+		r, err := rd.ReadSqlRow(ctx)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+	require.NoError(t, rd.Close(ctx))
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, int64(9007199254740993), rows[0][0])
+	assert.Equal(t, "bill", rows[0][1])
+	assert.True(t, decimal.RequireFromString("12345678901234.56").Equal(rows[0][2].(decimal.Decimal)))
+	assert.Equal(t, sql.Row{int64(2), nil, nil}, rows[1])
+	assert.Equal(t, int64(3), rows[2][0])
+	assert.Nil(t, rows[2][1])
+}
+
+func TestJSONLReaderBadLine(t *testing.T) {
+	ctx := context.Background()
+	input := "{\"id\": 1}\n[1, 2]\n{\"id\": 3, \"missing\": 1}\n{\"id\": 4}\n"
+
+	rd, err := NewJSONLReader(io.NopCloser(strings.NewReader(input)), jsonlTestSchema(t))
+	require.NoError(t, err)
+
+	_, err = rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+
+	_, err = rd.ReadSqlRow(ctx)
+	require.Error(t, err)
+	assert.True(t, table.IsBadRow(err))
+	assert.Contains(t, err.Error(), "line 2")
+
+	_, err = rd.ReadSqlRow(ctx)
+	require.Error(t, err)
+	assert.True(t, table.IsBadRow(err))
+	assert.Contains(t, err.Error(), "column missing not found")
+
+	r, err := rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), r[0])
+
+	_, err = rd.ReadSqlRow(ctx)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestUntypedJSONLReader(t *testing.T) {
+	ctx := context.Background()
+	input := "{\"id\": 1, \"name\": \"bill\"}\n{\"id\": 2, \"tags\": [\"a\", \"b\"], \"active\": true, \"name\": null}\n"
+
+	names, err := ReadJSONLColumnNames(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "name", "tags", "active"}, names)
+
+	rd, err := NewUntypedJSONLReader(types.Format_Default, io.NopCloser(strings.NewReader(input)), names)
+	require.NoError(t, err)
+
+	r, err := rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{"1", "bill", nil, nil}, r)
+
+	r, err = rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{"2", nil, `["a","b"]`, "true"}, r)
+
+	_, err = rd.ReadRow(ctx)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestJSONLWriter(t *testing.T) {
+	ctx := context.Background()
+	sch := jsonlTestSchema(t)
+
+	var buf bytes.Buffer
+	wr, err := NewJSONLWriter(iohelp.NopWrCloser(&buf), sch)
+	require.NoError(t, err)
+	require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{int64(1), "bill", decimal.RequireFromString("1.50")}))
+	require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{int64(2), nil, nil}))
+	require.NoError(t, wr.Close(ctx))
+
+	assert.Equal(t, "{\"amount\":\"1.50\",\"id\":1,\"name\":\"bill\"}\n{\"id\":2}\n", buf.String())
+
+	rd, err := NewJSONLReader(io.NopCloser(&buf), sch)
+	require.NoError(t, err)
+	r, err := rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), r[0])
+	assert.True(t, decimal.RequireFromString("1.5").Equal(r[2].(decimal.Decimal)))
+}
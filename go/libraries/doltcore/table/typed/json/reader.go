@@ -123,11 +123,13 @@ func (r *JSONReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
 		return nil, fmt.Errorf("unexpected JSON format received, expected format: { \"rows\": [ json_row_objects... ] } ")
 	}
 
-	return r.convToSqlRow(mapVal)
+	return convToSqlRow(r.sch, mapVal)
 }
 
-func (r *JSONReader) convToSqlRow(rowMap map[string]interface{}) (sql.Row, error) {
-	allCols := r.sch.GetAllCols()
+// convToSqlRow converts |rowMap|, a JSON object keyed by column name, to a row of |sch|. Columns missing from the
+// object are NULL.
+func convToSqlRow(sch schema.Schema, rowMap map[string]interface{}) (sql.Row, error) {
+	allCols := sch.GetAllCols()
 
 	ret := make(sql.Row, allCols.Size())
 	for k, v := range rowMap {
@@ -16,6 +16,7 @@ package parquet
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -25,7 +26,6 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/xitongsys/parquet-go-source/local"
-	"github.com/xitongsys/parquet-go/common"
 	"github.com/xitongsys/parquet-go/reader"
 	"github.com/xitongsys/parquet-go/source"
 
@@ -36,7 +36,13 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
-// ParquetReader implements TableReader.  It reads parquet files and returns rows.
+const (
+	// readBatchSize is the number of rows read from each column of the file at once.
+	readBatchSize = 64 * 1024
+)
+
+// ParquetReader implements TableReader.  It reads parquet files and returns rows. Only the columns of the file that
+// are in the reader's schema are read, and they are read in batches of rows, so the file is never held in memory.
 type ParquetReader struct {
 	fileReader     source.ParquetFile
 	pReader        *reader.ParquetReader
@@ -44,8 +50,13 @@ type ParquetReader struct {
 	vrw            types.ValueReadWriter
 	numRow         int
 	rowReadCounter int
-	fileData       map[string][]interface{}
-	columnName     []string
+
+	// cols are the columns of the file that are read, in the order of the columns of |sch|
+	cols     []fileColumn
+	colTypes []columnType
+	// batch holds the values of the current batch of rows for each of |cols|
+	batch    [][]interface{}
+	batchIdx int
 }
 
 var _ table.SqlTableReader = (*ParquetReader)(nil)
@@ -60,41 +71,91 @@ func OpenParquetReader(vrw types.ValueReadWriter, path string, sch schema.Schema
 	return NewParquetReader(vrw, fr, sch)
 }
 
-// NewParquetReader creates a ParquetReader from a given fileReader.
-// The ParquetFileInfo should describe the parquet file being read.
+// OpenParquetReaderWithInferredSchema opens a reader at a given path within local filesystem, whose schema is
+// inferred from the columns of the file. See InferColumns.
+func OpenParquetReaderWithInferredSchema(vrw types.ValueReadWriter, path string) (*ParquetReader, error) {
+	cols, err := InferColumnsFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sch, err := schema.SchemaFromCols(cols)
+	if err != nil {
+		return nil, err
+	}
+
+	return OpenParquetReader(vrw, path, sch)
+}
+
+// NewParquetReader creates a ParquetReader from a given fileReader. The columns of |sche| that are not in the file
+// are left out of the reader's schema.
 func NewParquetReader(vrw types.ValueReadWriter, fr source.ParquetFile, sche schema.Schema) (*ParquetReader, error) {
 	pr, err := reader.NewParquetColumnReader(fr, 4)
 	if err != nil {
 		return nil, err
 	}
 
-	columns := sche.GetAllCols().GetColumns()
-	num := pr.GetNumRows()
-
-	// TODO : need to solve for getting single row data in readRow (storing all columns data in memory right now)
-	data := make(map[string][]interface{})
-	var colName []string
-	for _, col := range columns {
-		colData, _, _, cErr := pr.ReadColumnByPath(common.ReformPathStr(fmt.Sprintf("parquet_go_root.%s", col.Name)), num)
-		if cErr != nil {
-			return nil, fmt.Errorf("cannot read column: %s", cErr.Error())
-		}
-		data[col.Name] = colData
-		colName = append(colName, col.Name)
+	cols, colTypes, sch, err := projectColumns(pr, sche)
+	if err != nil {
+		pr.ReadStop()
+		return nil, err
 	}
 
 	return &ParquetReader{
-		fileReader:     fr,
-		pReader:        pr,
-		sch:            sche,
-		vrw:            vrw,
-		numRow:         int(num),
-		rowReadCounter: 0,
-		fileData:       data,
-		columnName:     colName,
+		fileReader: fr,
+		pReader:    pr,
+		sch:        sch,
+		vrw:        vrw,
+		numRow:     int(pr.GetNumRows()),
+		cols:       cols,
+		colTypes:   colTypes,
 	}, nil
 }
 
+// projectColumns returns the columns of the file read by |pr| that are in |sch|, their types, and the schema of the
+// rows read from them.
+func projectColumns(pr *reader.ParquetReader, sch schema.Schema) ([]fileColumn, []columnType, schema.Schema, error) {
+	fileCols := make(map[string]fileColumn)
+	for _, fc := range fileColumns(pr.SchemaHandler) {
+		fileCols[fc.name] = fc
+	}
+
+	var cols []fileColumn
+	var colTypes []columnType
+	var schCols []schema.Column
+	for _, col := range sch.GetAllCols().GetColumns() {
+		fc, ok := fileCols[col.Name]
+		if !ok {
+			continue
+		}
+		if fc.elem == nil {
+			return nil, nil, nil, fmt.Errorf("cannot read column: %s has a nested type, which is not supported", col.Name)
+		}
+
+		ct, err := parseColumnType(fc.elem)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("cannot read column: %s: %w", col.Name, err)
+		}
+
+		cols = append(cols, fc)
+		colTypes = append(colTypes, ct)
+		schCols = append(schCols, col)
+	}
+
+	if len(cols) == 0 {
+		return nil, nil, nil, errors.New("none of the columns of the parquet file are in the schema")
+	}
+
+	if len(schCols) == sch.GetAllCols().Size() {
+		return cols, colTypes, sch, nil
+	}
+
+	projected, err := schema.SchemaFromCols(schema.NewColCollection(schCols...))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return cols, colTypes, projected, nil
+}
+
 func (pr *ParquetReader) ReadRow(ctx context.Context) (row.Row, error) {
 	panic("deprecated")
 }
@@ -133,34 +194,69 @@ func (pr *ParquetReader) ReadSqlRow(ctx context.Context) (sql.Row, error) {
 		return nil, io.EOF
 	}
 
+	if pr.batch == nil || pr.batchIdx >= len(pr.batch[0]) {
+		if err := pr.readBatch(); err != nil {
+			return nil, err
+		}
+	}
+
 	allCols := pr.sch.GetAllCols()
 	row := make(sql.Row, allCols.Size())
-	allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
-		val := pr.fileData[col.Name][pr.rowReadCounter]
-		if val != nil {
+	for i, col := range allCols.GetColumns() {
+		val := pr.batch[i][pr.batchIdx]
+		if val == nil {
+			continue
+		}
+
+		val, err := pr.colTypes[i].convert(val)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read column: %s: %w", col.Name, err)
+		}
+
+		// datetime and time values are written by dolt as integers without a logical type
+		if n, ok := val.(int64); ok {
 			switch col.TypeInfo.GetTypeIdentifier() {
 			case typeinfo.DatetimeTypeIdentifier:
-				val = time.UnixMicro(val.(int64))
+				val = time.UnixMicro(n).UTC()
 			case typeinfo.TimeTypeIdentifier:
-				val = gmstypes.Timespan(time.Duration(val.(int64)).Microseconds())
+				val = gmstypes.Timespan(time.Duration(n).Microseconds())
 			}
 		}
 
-		if col.Kind == types.DecimalKind {
-			prec, scale := col.TypeInfo.ToSqlType().(gmstypes.DecimalType_).Precision(), col.TypeInfo.ToSqlType().(gmstypes.DecimalType_).Scale()
-			val = DecimalByteArrayToString([]byte(val.(string)), int(prec), int(scale))
-		}
-
-		row[allCols.TagToIdx[tag]] = val
-
-		return false, nil
-	})
+		row[i] = val
+	}
 
+	pr.batchIdx++
 	pr.rowReadCounter++
 
 	return row, nil
 }
 
+// readBatch reads the next batch of rows from each of the columns being read.
+func (pr *ParquetReader) readBatch() error {
+	num := pr.numRow - pr.rowReadCounter
+	if num > readBatchSize {
+		num = readBatchSize
+	}
+
+	if pr.batch == nil {
+		pr.batch = make([][]interface{}, len(pr.cols))
+	}
+	for i, fc := range pr.cols {
+		vals, _, _, err := pr.pReader.ReadColumnByPath(fc.path, int64(num))
+		if err != nil {
+			return fmt.Errorf("cannot read column: %s: %w", fc.name, err)
+		}
+		if len(vals) != num {
+			return fmt.Errorf("cannot read column: %s: expected %d values but read %d", fc.name, num, len(vals))
+		}
+		pr.batch[i] = vals
+	}
+	pr.batchIdx = 0
+
+	return nil
+}
+
 func (pr *ParquetReader) GetSchema() schema.Schema {
 	return pr.sch
 }
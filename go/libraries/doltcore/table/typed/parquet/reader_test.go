@@ -0,0 +1,153 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"context"
+	"io"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+type typedRow struct {
+	ID      int64   `parquet:"name=id, type=INT64"`
+	Small   int32   `parquet:"name=small, type=INT32, convertedtype=INT_16"`
+	Count   int32   `parquet:"name=count, type=INT32, convertedtype=UINT_32"`
+	Price   int64   `parquet:"name=price, type=INT64, convertedtype=DECIMAL, scale=2, precision=10"`
+	Day     int32   `parquet:"name=day, type=INT32, convertedtype=DATE"`
+	Created int64   `parquet:"name=created, type=INT64, convertedtype=TIMESTAMP_MILLIS"`
+	Name    *string `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Ratio   float64 `parquet:"name=ratio, type=DOUBLE"`
+	Active  bool    `parquet:"name=active, type=BOOLEAN"`
+}
+
+func writeTypedRows(t *testing.T, n int) string {
+	p := path.Join(t.TempDir(), "typed.parquet")
+	fw, err := local.NewLocalFileWriter(p)
+	require.NoError(t, err)
+	pw, err := writer.NewParquetWriter(fw, new(typedRow), 4)
+	require.NoError(t, err)
+
+	created := time.Date(2024, 3, 5, 13, 14, 15, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		r := typedRow{
+			ID:      int64(i),
+			Small:   int32(-i % 100),
+			Count:   -1,
+			Price:   int64(i*100 + 5),
+			Day:     int32(created.Unix() / (24 * 60 * 60)),
+			Created: created.UnixMilli(),
+			Ratio:   0.5,
+			Active:  i%2 == 0,
+		}
+		if i%3 != 0 {
+			name := "row"
+			r.Name = &name
+		}
+		require.NoError(t, pw.Write(r))
+	}
+	require.NoError(t, pw.WriteStop())
+	require.NoError(t, fw.Close())
+
+	return p
+}
+
+func TestInferColumns(t *testing.T) {
+	cols, err := InferColumnsFromFile(writeTypedRows(t, 1))
+	require.NoError(t, err)
+
+	var names, sqlTypes []string
+	var notNull []bool
+	for _, col := range cols.GetColumns() {
+		names = append(names, col.Name)
+		sqlTypes = append(sqlTypes, col.TypeInfo.ToSqlType().String())
+		notNull = append(notNull, !col.IsNullable())
+		assert.False(t, col.IsPartOfPK)
+	}
+
+	assert.Equal(t, []string{"id", "small", "count", "price", "day", "created", "name", "ratio", "active"}, names)
+	assert.Equal(t, []string{
+		"bigint",
+		"smallint",
+		"int unsigned",
+		"decimal(10,2)",
+		"date",
+		"datetime(6)",
+		typeinfo.StringDefaultType.ToSqlType().String(),
+		"double",
+		"tinyint(1)",
+	}, sqlTypes)
+	assert.Equal(t, []bool{true, true, true, true, true, true, false, true, true}, notNull)
+}
+
+func TestParquetReaderInferredSchema(t *testing.T) {
+	ctx := context.Background()
+	numRows := readBatchSize + 10
+	rd, err := OpenParquetReaderWithInferredSchema(nil, writeTypedRows(t, numRows))
+	require.NoError(t, err)
+	defer rd.Close(ctx)
+
+	var rows []sql.Row
+	for {
+		r, err := rd.ReadSqlRow(ctx)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, r)
+	}
+	require.Len(t, rows, numRows)
+
+	created := time.Date(2024, 3, 5, 13, 14, 15, 0, time.UTC)
+	day := time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, sql.Row{int64(0), int32(0), uint64(4294967295), "0.05", day, created, nil, 0.5, true}, rows[0])
+	last := numRows - 1
+	assert.Equal(t, sql.Row{int64(last), int32(-last % 100), uint64(4294967295), "65545.05", day, created, "row", 0.5, false}, rows[last])
+}
+
+func TestParquetReaderProjection(t *testing.T) {
+	ctx := context.Background()
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.Column{Name: "id", Tag: 0, Kind: types.IntKind, IsPartOfPK: true, TypeInfo: typeinfo.Int64Type},
+		schema.Column{Name: "missing", Tag: 1, Kind: types.StringKind, TypeInfo: typeinfo.StringDefaultType},
+		schema.Column{Name: "name", Tag: 2, Kind: types.StringKind, TypeInfo: typeinfo.StringDefaultType},
+	))
+
+	rd, err := OpenParquetReader(nil, writeTypedRows(t, 2), sch)
+	require.NoError(t, err)
+	defer rd.Close(ctx)
+
+	assert.Equal(t, []string{"id", "name"}, rd.GetSchema().GetAllCols().GetColumnNames())
+
+	r, err := rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{int64(0), nil}, r)
+	r, err = rd.ReadSqlRow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sql.Row{int64(1), "row"}, r)
+	_, err = rd.ReadSqlRow(ctx)
+	assert.Equal(t, io.EOF, err)
+}
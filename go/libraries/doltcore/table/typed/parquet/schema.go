@@ -0,0 +1,427 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"fmt"
+	"time"
+
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/common"
+	pq "github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	pqschema "github.com/xitongsys/parquet-go/schema"
+	pqtypes "github.com/xitongsys/parquet-go/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+)
+
+// fileColumn is a top level column of a parquet file.
+type fileColumn struct {
+	// name is the name of the column in the file
+	name string
+	// path is the internal path of the column, used to read its values
+	path string
+	elem *pq.SchemaElement
+}
+
+// fileColumns returns the top level columns of the parquet file described by |sh|, in file order. Nested columns,
+// such as lists, maps and structs, are returned with a nil |elem|, as their values can't be read into a table.
+func fileColumns(sh *pqschema.SchemaHandler) []fileColumn {
+	var cols []fileColumn
+	for i := 1; i < len(sh.SchemaElements); i++ {
+		elem := sh.SchemaElements[i]
+		path := sh.IndexMap[int32(i)]
+		if len(common.StrToPath(path)) != 2 {
+			continue
+		}
+
+		col := fileColumn{name: sh.Infos[i].ExName, path: path}
+		if elem.GetNumChildren() == 0 && elem.GetRepetitionType() != pq.FieldRepetitionType_REPEATED {
+			col.elem = elem
+		}
+		cols = append(cols, col)
+	}
+	return cols
+}
+
+// InferColumnsFromFile returns the columns of the parquet file at |path|. See InferColumns.
+func InferColumnsFromFile(path string) (*schema.ColCollection, error) {
+	fr, err := local.NewLocalFileReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fr.Close()
+
+	pr, err := reader.NewParquetColumnReader(fr, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer pr.ReadStop()
+
+	return InferColumns(pr.SchemaHandler)
+}
+
+// InferColumns returns a column for each top level column of the parquet file described by |sh|, with a type derived
+// from the column's physical and logical types. Required columns are given a NOT NULL constraint. Columns are tagged
+// in file order, starting at schema.ReservedTagMin, and none of them are part of the primary key. Files with nested
+// columns are not supported.
+func InferColumns(sh *pqschema.SchemaHandler) (*schema.ColCollection, error) {
+	var cols []schema.Column
+	for i, fc := range fileColumns(sh) {
+		if fc.elem == nil {
+			return nil, fmt.Errorf("column '%s' of the parquet file has a nested type, which is not supported", fc.name)
+		}
+
+		ct, err := parseColumnType(fc.elem)
+		if err != nil {
+			return nil, fmt.Errorf("column '%s': %w", fc.name, err)
+		}
+		ti, err := ct.typeInfo()
+		if err != nil {
+			return nil, fmt.Errorf("column '%s': %w", fc.name, err)
+		}
+
+		var constraints []schema.ColConstraint
+		if fc.elem.GetRepetitionType() == pq.FieldRepetitionType_REQUIRED {
+			constraints = append(constraints, schema.NotNullConstraint{})
+		}
+
+		col, err := schema.NewColumnWithTypeInfo(fc.name, schema.ReservedTagMin+uint64(i), ti, false, "", false, "", constraints...)
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+
+	return schema.NewColCollection(cols...), nil
+}
+
+// valueKind is the kind of value held by a parquet column, derived from its physical and logical types.
+type valueKind int
+
+const (
+	boolKind valueKind = iota
+	intKind
+	uintKind
+	float32Kind
+	float64Kind
+	decimalKind
+	dateKind
+	timeKind
+	timestampKind
+	int96TimestampKind
+	stringKind
+	jsonKind
+	uuidKind
+	binaryKind
+)
+
+// timeUnit is the unit of the integers stored in time and timestamp columns.
+type timeUnit int
+
+const (
+	millis timeUnit = iota
+	micros
+	nanos
+)
+
+// columnType describes how the values of a parquet column are interpreted.
+type columnType struct {
+	physical pq.Type
+	kind     valueKind
+	// bitWidth is the width of integer columns
+	bitWidth int
+	unit     timeUnit
+	// precision and scale are set for decimal columns
+	precision int
+	scale     int
+	// length is the length of fixed length byte array columns
+	length int
+}
+
+// parseColumnType returns the type of the column described by |elem|. The logical type of the column takes precedence
+// over its converted type, which older writers use instead.
+func parseColumnType(elem *pq.SchemaElement) (columnType, error) {
+	ct := columnType{physical: elem.GetType(), length: int(elem.GetTypeLength())}
+
+	if lt := elem.LogicalType; lt != nil {
+		switch {
+		case lt.STRING != nil, lt.ENUM != nil:
+			ct.kind = stringKind
+		case lt.JSON != nil:
+			ct.kind = jsonKind
+		case lt.UUID != nil:
+			ct.kind = uuidKind
+		case lt.DECIMAL != nil:
+			ct.kind, ct.precision, ct.scale = decimalKind, int(lt.DECIMAL.Precision), int(lt.DECIMAL.Scale)
+		case lt.DATE != nil:
+			ct.kind = dateKind
+		case lt.TIME != nil:
+			ct.kind, ct.unit = timeKind, unitOf(lt.TIME.Unit)
+		case lt.TIMESTAMP != nil:
+			ct.kind, ct.unit = timestampKind, unitOf(lt.TIMESTAMP.Unit)
+		case lt.INTEGER != nil:
+			ct.kind, ct.bitWidth = intKind, int(lt.INTEGER.BitWidth)
+			if !lt.INTEGER.IsSigned {
+				ct.kind = uintKind
+			}
+		default:
+			return parseConvertedType(elem, ct)
+		}
+		return ct, ct.validate()
+	}
+
+	return parseConvertedType(elem, ct)
+}
+
+func parseConvertedType(elem *pq.SchemaElement, ct columnType) (columnType, error) {
+	if elem.ConvertedType == nil {
+		return physicalColumnType(ct)
+	}
+
+	switch elem.GetConvertedType() {
+	case pq.ConvertedType_UTF8, pq.ConvertedType_ENUM:
+		ct.kind = stringKind
+	case pq.ConvertedType_JSON:
+		ct.kind = jsonKind
+	case pq.ConvertedType_DECIMAL:
+		ct.kind, ct.precision, ct.scale = decimalKind, int(elem.GetPrecision()), int(elem.GetScale())
+	case pq.ConvertedType_DATE:
+		ct.kind = dateKind
+	case pq.ConvertedType_TIME_MILLIS:
+		ct.kind, ct.unit = timeKind, millis
+	case pq.ConvertedType_TIME_MICROS:
+		ct.kind, ct.unit = timeKind, micros
+	case pq.ConvertedType_TIMESTAMP_MILLIS:
+		ct.kind, ct.unit = timestampKind, millis
+	case pq.ConvertedType_TIMESTAMP_MICROS:
+		ct.kind, ct.unit = timestampKind, micros
+	case pq.ConvertedType_INT_8:
+		ct.kind, ct.bitWidth = intKind, 8
+	case pq.ConvertedType_INT_16:
+		ct.kind, ct.bitWidth = intKind, 16
+	case pq.ConvertedType_INT_32:
+		ct.kind, ct.bitWidth = intKind, 32
+	case pq.ConvertedType_INT_64:
+		ct.kind, ct.bitWidth = intKind, 64
+	case pq.ConvertedType_UINT_8:
+		ct.kind, ct.bitWidth = uintKind, 8
+	case pq.ConvertedType_UINT_16:
+		ct.kind, ct.bitWidth = uintKind, 16
+	case pq.ConvertedType_UINT_32:
+		ct.kind, ct.bitWidth = uintKind, 32
+	case pq.ConvertedType_UINT_64:
+		ct.kind, ct.bitWidth = uintKind, 64
+	case pq.ConvertedType_BSON:
+		ct.kind = binaryKind
+	default:
+		return physicalColumnType(ct)
+	}
+	return ct, ct.validate()
+}
+
+// physicalColumnType returns the type of a column that has no logical or converted type.
+func physicalColumnType(ct columnType) (columnType, error) {
+	switch ct.physical {
+	case pq.Type_BOOLEAN:
+		ct.kind = boolKind
+	case pq.Type_INT32:
+		ct.kind, ct.bitWidth = intKind, 32
+	case pq.Type_INT64:
+		ct.kind, ct.bitWidth = intKind, 64
+	case pq.Type_INT96:
+		ct.kind = int96TimestampKind
+	case pq.Type_FLOAT:
+		ct.kind = float32Kind
+	case pq.Type_DOUBLE:
+		ct.kind = float64Kind
+	case pq.Type_BYTE_ARRAY, pq.Type_FIXED_LEN_BYTE_ARRAY:
+		ct.kind = binaryKind
+	default:
+		return ct, fmt.Errorf("unsupported parquet type %s", ct.physical)
+	}
+	return ct, nil
+}
+
+// validate checks that the logical type of the column can annotate its physical type.
+func (ct columnType) validate() error {
+	ok := true
+	switch ct.kind {
+	case stringKind, jsonKind:
+		ok = ct.physical == pq.Type_BYTE_ARRAY || ct.physical == pq.Type_FIXED_LEN_BYTE_ARRAY
+	case uuidKind:
+		ok = ct.physical == pq.Type_FIXED_LEN_BYTE_ARRAY && ct.length == 16
+	case decimalKind:
+		ok = ct.physical != pq.Type_BOOLEAN && ct.physical != pq.Type_FLOAT && ct.physical != pq.Type_DOUBLE && ct.physical != pq.Type_INT96
+	case dateKind:
+		ok = ct.physical == pq.Type_INT32
+	case timeKind:
+		ok = (ct.physical == pq.Type_INT32 && ct.unit == millis) || (ct.physical == pq.Type_INT64 && ct.unit != millis)
+	case timestampKind:
+		ok = ct.physical == pq.Type_INT64
+	case intKind, uintKind:
+		ok = ct.physical == pq.Type_INT32 || ct.physical == pq.Type_INT64
+	}
+
+	if !ok {
+		return fmt.Errorf("invalid logical type for parquet type %s", ct.physical)
+	}
+	return nil
+}
+
+func unitOf(u *pq.TimeUnit) timeUnit {
+	switch {
+	case u == nil, u.MICROS != nil:
+		return micros
+	case u.MILLIS != nil:
+		return millis
+	default:
+		return nanos
+	}
+}
+
+// typeInfo returns the type of the table column inferred for the parquet column.
+func (ct columnType) typeInfo() (typeinfo.TypeInfo, error) {
+	switch ct.kind {
+	case boolKind:
+		return typeinfo.FromSqlType(gmstypes.Boolean)
+	case intKind:
+		switch ct.bitWidth {
+		case 8:
+			return typeinfo.Int8Type, nil
+		case 16:
+			return typeinfo.Int16Type, nil
+		case 32:
+			return typeinfo.Int32Type, nil
+		default:
+			return typeinfo.Int64Type, nil
+		}
+	case uintKind:
+		switch ct.bitWidth {
+		case 8:
+			return typeinfo.Uint8Type, nil
+		case 16:
+			return typeinfo.Uint16Type, nil
+		case 32:
+			return typeinfo.Uint32Type, nil
+		default:
+			return typeinfo.Uint64Type, nil
+		}
+	case float32Kind:
+		return typeinfo.Float32Type, nil
+	case float64Kind:
+		return typeinfo.Float64Type, nil
+	case decimalKind:
+		decType, err := gmstypes.CreateDecimalType(uint8(ct.precision), uint8(ct.scale))
+		if err != nil {
+			return nil, err
+		}
+		return typeinfo.FromSqlType(decType)
+	case dateKind:
+		return typeinfo.DateType, nil
+	case timeKind:
+		return typeinfo.TimeType, nil
+	case timestampKind, int96TimestampKind:
+		return typeinfo.DatetimeType, nil
+	case stringKind:
+		return typeinfo.StringDefaultType, nil
+	case jsonKind:
+		return typeinfo.JSONType, nil
+	case uuidKind:
+		return typeinfo.UuidType, nil
+	default:
+		if ct.physical == pq.Type_FIXED_LEN_BYTE_ARRAY && ct.length > 0 && ct.length <= 255 {
+			return typeinfo.FromSqlType(gmstypes.MustCreateBinary(sqltypes.Binary, int64(ct.length)))
+		}
+		return typeinfo.BlobType, nil
+	}
+}
+
+// convert converts |v|, a non-NULL value read from the parquet column, to the value of the sql type of the column.
+// Values of integer columns without a logical type are returned as they are read.
+func (ct columnType) convert(v interface{}) (interface{}, error) {
+	switch ct.kind {
+	case uintKind:
+		switch i := v.(type) {
+		case int32:
+			return uint64(uint32(i)), nil
+		case int64:
+			return uint64(i), nil
+		}
+	case decimalKind:
+		switch d := v.(type) {
+		case int32:
+			return decimal.New(int64(d), -int32(ct.scale)).String(), nil
+		case int64:
+			return decimal.New(d, -int32(ct.scale)).String(), nil
+		case string:
+			if len(d) == 0 {
+				return "0", nil
+			}
+			return DecimalByteArrayToString([]byte(d), ct.precision, ct.scale), nil
+		}
+	case dateKind:
+		if days, ok := v.(int32); ok {
+			return time.Unix(int64(days)*24*60*60, 0).UTC(), nil
+		}
+	case timeKind:
+		switch t := v.(type) {
+		case int32:
+			return gmstypes.Timespan(int64(t) * 1000), nil
+		case int64:
+			if ct.unit == nanos {
+				return gmstypes.Timespan(t / 1000), nil
+			}
+			return gmstypes.Timespan(t), nil
+		}
+	case timestampKind:
+		if t, ok := v.(int64); ok {
+			switch ct.unit {
+			case millis:
+				return time.UnixMilli(t).UTC(), nil
+			case nanos:
+				return time.Unix(0, t).UTC(), nil
+			default:
+				return time.UnixMicro(t).UTC(), nil
+			}
+		}
+	case int96TimestampKind:
+		if s, ok := v.(string); ok && len(s) == 12 {
+			return pqtypes.INT96ToTime(s).UTC(), nil
+		}
+	case uuidKind:
+		if s, ok := v.(string); ok {
+			u, err := uuid.FromBytes([]byte(s))
+			if err != nil {
+				return nil, err
+			}
+			return u.String(), nil
+		}
+	case binaryKind:
+		if s, ok := v.(string); ok {
+			return []byte(s), nil
+		}
+	default:
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("unexpected value of type %T for a parquet %s column", v, ct.physical)
+}
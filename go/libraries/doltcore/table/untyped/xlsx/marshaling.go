@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/tealeg/xlsx"
@@ -115,8 +116,7 @@ func getXlsxRows(data *xlsx.File, tblName string) ([][][]string, error) {
 			for i := 0; i < len(sheet.Rows); i++ {
 				var rowVals []string
 				for j := 0; j < len(sheet.Rows[i].Cells); j++ {
-
-					rowVals = append(rowVals, sheet.Rows[i].Cells[j].Value)
+					rowVals = append(rowVals, cellValue(sheet.Rows[i].Cells[j], data.Date1904))
 				}
 				rows = append(rows, rowVals)
 			}
@@ -127,3 +127,21 @@ func getXlsxRows(data *xlsx.File, tblName string) ([][][]string, error) {
 	}
 	return nil, ErrTableNameMatchSheetName
 }
+
+// cellValue returns the value of |cell| as a string. Cells holding dates are stored by Excel as numbers, so they are
+// converted back to the date or datetime they represent.
+func cellValue(cell *xlsx.Cell, date1904 bool) string {
+	if cell.Type() != xlsx.CellTypeNumeric || !cell.IsTime() {
+		return cell.Value
+	}
+
+	t, err := cell.GetTime(date1904)
+	if err != nil {
+		return cell.Value
+	}
+	t = t.Round(time.Second)
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xlsx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+	"github.com/tealeg/xlsx"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+)
+
+const (
+	// maxSheetNameLen is the maximum number of characters Excel allows in a sheet name.
+	maxSheetNameLen = 31
+
+	// maxSheetRows is the maximum number of rows Excel allows in a sheet, including the header row.
+	maxSheetRows = 1048576
+
+	dateFormat     = "yyyy-mm-dd"
+	datetimeFormat = "yyyy-mm-dd hh:mm:ss"
+)
+
+// ErrTooManyRows is returned when a table has more rows than fit in a single Excel sheet.
+var ErrTooManyRows = fmt.Errorf("xlsx sheets are limited to %d rows", maxSheetRows-1)
+
+// XLSXWorkbook is an xlsx file that is being written. Each table written to the workbook gets its own sheet. Excel
+// files can't be written incrementally, so the workbook is kept in memory and written out when it is closed.
+type XLSXWorkbook struct {
+	file   *xlsx.File
+	closer io.WriteCloser
+}
+
+// NewXLSXWorkbook creates a new, empty workbook that is written to |wr| when it is closed.
+func NewXLSXWorkbook(wr io.WriteCloser) *XLSXWorkbook {
+	return &XLSXWorkbook{file: xlsx.NewFile(), closer: wr}
+}
+
+// NewSheetWriter adds a sheet for the table |tableName| to the workbook, and returns a writer for its rows. The
+// sheet name is derived from |tableName|, and made unique within the workbook.
+func (wb *XLSXWorkbook) NewSheetWriter(tableName string, outSch schema.Schema) (*XLSXWriter, error) {
+	sheet, err := wb.file.AddSheet(wb.uniqueSheetName(tableName))
+	if err != nil {
+		return nil, err
+	}
+
+	cols := outSch.GetAllCols().GetColumns()
+	colTypes := make([]sql.Type, len(cols))
+	header := sheet.AddRow()
+	for i, col := range cols {
+		header.AddCell().SetString(col.Name)
+		colTypes[i] = col.TypeInfo.ToSqlType()
+	}
+
+	return &XLSXWriter{sheet: sheet, colTypes: colTypes, rowCount: 1}, nil
+}
+
+// Close writes the workbook to its destination, and closes it.
+func (wb *XLSXWorkbook) Close() error {
+	if wb.closer == nil {
+		return errors.New("Already closed.")
+	}
+
+	err := wb.file.Write(wb.closer)
+	errCl := wb.closer.Close()
+	wb.closer = nil
+	if err != nil {
+		return err
+	}
+	return errCl
+}
+
+// uniqueSheetName returns a sheet name for |tableName| that Excel accepts and that isn't used yet in the workbook.
+// Characters Excel doesn't allow in sheet names are replaced, and long names are truncated.
+func (wb *XLSXWorkbook) uniqueSheetName(tableName string) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '\\', '/', '?', '*', '[', ']':
+			return '_'
+		}
+		return r
+	}, tableName)
+	if name == "" {
+		name = "Sheet"
+	}
+
+	candidate := truncateRunes(name, maxSheetNameLen)
+	for i := 2; ; i++ {
+		if _, exists := wb.file.Sheet[candidate]; !exists {
+			return candidate
+		}
+		suffix := "~" + strconv.Itoa(i)
+		candidate = truncateRunes(name, maxSheetNameLen-len(suffix)) + suffix
+	}
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// XLSXWriter implements TableWriter. It writes rows to a sheet of an xlsx workbook, with a header row holding the
+// column names. NULL values are written as empty cells.
+type XLSXWriter struct {
+	sheet    *xlsx.Sheet
+	colTypes []sql.Type
+	rowCount int
+
+	// workbook is set when the writer owns the workbook it writes to, and the workbook is written out when the writer
+	// is closed.
+	workbook *XLSXWorkbook
+	closed   bool
+}
+
+var _ table.SqlRowWriter = (*XLSXWriter)(nil)
+
+// NewXLSXWriter writes rows to a new workbook with a single sheet, based on the Schema and XLSXFileInfo provided.
+// The workbook is written to |wr| when the writer is closed.
+func NewXLSXWriter(wr io.WriteCloser, outSch schema.Schema, info *XLSXFileInfo) (*XLSXWriter, error) {
+	wb := NewXLSXWorkbook(wr)
+	xlsxw, err := wb.NewSheetWriter(info.SheetName, outSch)
+	if err != nil {
+		wr.Close()
+		return nil, err
+	}
+
+	xlsxw.workbook = wb
+	return xlsxw, nil
+}
+
+// WriteSqlRow adds |r| to the sheet as a new row.
+func (xlsxw *XLSXWriter) WriteSqlRow(ctx context.Context, r sql.Row) error {
+	if xlsxw.rowCount >= maxSheetRows {
+		return ErrTooManyRows
+	}
+
+	row := xlsxw.sheet.AddRow()
+	for i, val := range r {
+		cell := row.AddCell()
+		if val == nil {
+			continue
+		}
+		if err := setCellValue(cell, xlsxw.colTypes[i], val); err != nil {
+			return err
+		}
+	}
+
+	xlsxw.rowCount++
+	return nil
+}
+
+// Close finishes the sheet. If the writer owns its workbook, the workbook is written out.
+func (xlsxw *XLSXWriter) Close(ctx context.Context) error {
+	if xlsxw.closed {
+		return errors.New("Already closed.")
+	}
+	xlsxw.closed = true
+
+	if xlsxw.workbook != nil {
+		return xlsxw.workbook.Close()
+	}
+	return nil
+}
+
+// setCellValue sets |cell| to |val|, a value of the SQL type |colType|. Numbers, dates and datetimes are written as
+// Excel numbers, so that they can be used in formulas, and every other type is written as a string.
+func setCellValue(cell *xlsx.Cell, colType sql.Type, val interface{}) error {
+	switch v := val.(type) {
+	case int8:
+		cell.SetInt64(int64(v))
+	case int16:
+		cell.SetInt64(int64(v))
+	case int32:
+		cell.SetInt64(int64(v))
+	case int64:
+		cell.SetInt64(v)
+	case uint8:
+		cell.SetInt64(int64(v))
+	case uint16:
+		cell.SetInt64(int64(v))
+	case uint32:
+		cell.SetInt64(int64(v))
+	case uint64:
+		if v > math.MaxInt64 {
+			cell.SetString(strconv.FormatUint(v, 10))
+		} else {
+			cell.SetInt64(int64(v))
+		}
+	case float32:
+		cell.SetFloat(float64(v))
+	case float64:
+		cell.SetFloat(v)
+	case decimal.Decimal:
+		setDecimalCell(cell, colType, v)
+	case time.Time:
+		setTimeCell(cell, colType, v)
+	default:
+		str, err := sqlutil.SqlColToStr(colType, val)
+		if err != nil {
+			return err
+		}
+		cell.SetString(str)
+	}
+
+	return nil
+}
+
+// setDecimalCell writes a decimal as a number, displayed with the scale of its column. The stored value keeps every
+// digit of the decimal, even though Excel computes with floating point numbers.
+func setDecimalCell(cell *xlsx.Cell, colType sql.Type, v decimal.Decimal) {
+	format := "0"
+	if decType, ok := colType.(sql.DecimalType); ok && decType.Scale() > 0 {
+		format = "0." + strings.Repeat("0", int(decType.Scale()))
+	}
+
+	f, _ := v.Float64()
+	cell.SetFloatWithFormat(f, format)
+	cell.Value = v.String()
+}
+
+// setTimeCell writes a date, datetime or timestamp as an Excel date, formatted according to its type.
+func setTimeCell(cell *xlsx.Cell, colType sql.Type, v time.Time) {
+	format := datetimeFormat
+	if types.IsDateType(colType) {
+		format = dateFormat
+	}
+	cell.SetDateTimeWithFormat(xlsx.TimeToExcelTime(v.UTC(), false), format)
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xlsx
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tealeg/xlsx"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func testSchema(t *testing.T) schema.Schema {
+	decimalType, err := typeinfo.FromSqlType(gmstypes.MustCreateDecimalType(10, 2))
+	require.NoError(t, err)
+
+	return schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.Column{Name: "id", Tag: 0, Kind: types.IntKind, IsPartOfPK: true, TypeInfo: typeinfo.Int64Type},
+		schema.Column{Name: "name", Tag: 1, Kind: types.StringKind, TypeInfo: typeinfo.StringDefaultType},
+		schema.Column{Name: "price", Tag: 2, Kind: types.DecimalKind, TypeInfo: decimalType},
+		schema.Column{Name: "ratio", Tag: 3, Kind: types.FloatKind, TypeInfo: typeinfo.Float64Type},
+		schema.Column{Name: "day", Tag: 4, Kind: types.TimestampKind, TypeInfo: typeinfo.DateType},
+		schema.Column{Name: "created", Tag: 5, Kind: types.TimestampKind, TypeInfo: typeinfo.DatetimeType},
+	))
+}
+
+func TestXLSXWriter(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2024, 3, 5, 13, 14, 15, 0, time.UTC)
+	rows := []sql.Row{
+		{int64(1), "apple", decimal.RequireFromString("1234567.89"), 0.5, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), created},
+		{int64(2), nil, nil, nil, nil, nil},
+	}
+
+	var buf bytes.Buffer
+	wr, err := NewXLSXWriter(iohelp.NopWrCloser(&buf), testSchema(t), NewXLSXInfo("fruit"))
+	require.NoError(t, err)
+	for _, r := range rows {
+		require.NoError(t, wr.WriteSqlRow(ctx, r))
+	}
+	require.NoError(t, wr.Close(ctx))
+	assert.Error(t, wr.Close(ctx))
+
+	file, err := xlsx.OpenBinary(buf.Bytes())
+	require.NoError(t, err)
+	require.Len(t, file.Sheets, 1)
+	sheet := file.Sheets[0]
+	assert.Equal(t, "fruit", sheet.Name)
+	require.Len(t, sheet.Rows, 3)
+
+	var header []string
+	for _, cell := range sheet.Rows[0].Cells {
+		header = append(header, cell.Value)
+	}
+	assert.Equal(t, []string{"id", "name", "price", "ratio", "day", "created"}, header)
+
+	cells := sheet.Rows[1].Cells
+	assert.Equal(t, xlsx.CellTypeNumeric, cells[0].Type())
+	assert.Equal(t, "1", cells[0].Value)
+	assert.Equal(t, xlsx.CellTypeString, cells[1].Type())
+	assert.Equal(t, "apple", cells[1].Value)
+	assert.Equal(t, xlsx.CellTypeNumeric, cells[2].Type())
+	assert.Equal(t, "1234567.89", cells[2].Value)
+	assert.Equal(t, "0.00", cells[2].GetNumberFormat())
+	assert.Equal(t, "0.5", cells[3].Value)
+	assert.True(t, cells[4].IsTime())
+	day, err := cells[4].GetTime(false)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-05", day.Format("2006-01-02"))
+	assert.True(t, cells[5].IsTime())
+	createdVal, err := cells[5].GetTime(false)
+	require.NoError(t, err)
+	assert.True(t, created.Equal(createdVal.Round(time.Second)))
+
+	for _, cell := range sheet.Rows[2].Cells[1:] {
+		assert.Equal(t, "", cell.Value)
+	}
+}
+
+func TestXLSXWorkbook(t *testing.T) {
+	ctx := context.Background()
+	sch := testSchema(t)
+
+	var buf bytes.Buffer
+	wb := NewXLSXWorkbook(iohelp.NopWrCloser(&buf))
+	names := []string{"first", "a/b:c", strings.Repeat("x", 40), strings.Repeat("x", 35)}
+	for i, name := range names {
+		wr, err := wb.NewSheetWriter(name, sch)
+		require.NoError(t, err)
+		require.NoError(t, wr.WriteSqlRow(ctx, sql.Row{int64(i), name, nil, nil, nil, nil}))
+		require.NoError(t, wr.Close(ctx))
+	}
+	require.NoError(t, wb.Close())
+
+	file, err := xlsx.OpenBinary(buf.Bytes())
+	require.NoError(t, err)
+	var sheetNames []string
+	for _, sheet := range file.Sheets {
+		sheetNames = append(sheetNames, sheet.Name)
+		assert.Len(t, sheet.Rows, 2)
+	}
+	assert.Equal(t, []string{"first", "a_b_c", strings.Repeat("x", 31), strings.Repeat("x", 29) + "~2"}, sheetNames)
+}
+
+func TestXLSXWriterRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	wr, err := NewXLSXWriter(iohelp.NopWrCloser(&buf), testSchema(t), NewXLSXInfo("fruit"))
+	require.NoError(t, err)
+	row := sql.Row{int64(1), "apple", decimal.RequireFromString("1.50"), 0.25, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 5, 13, 14, 15, 0, time.UTC)}
+	require.NoError(t, wr.WriteSqlRow(ctx, row))
+	require.NoError(t, wr.Close(ctx))
+
+	rows, err := getXlsxRowsFromBinary(buf.Bytes(), "fruit")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, [][]string{
+		{"id", "name", "price", "ratio", "day", "created"},
+		{"1", "apple", "1.5", "0.25", "2024-03-05", "2024-03-05 13:14:15"},
+	}, rows[0])
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	azureUploadBlockSize   = 8 * 1024 * 1024
+	azureUploadConcurrency = 4
+
+	// azureSourceSASExpiry is how long the SAS urls used to copy the sources of a Concatenate are valid for
+	azureSourceSASExpiry = time.Hour
+)
+
+// AzureBlobstore provides an Azure Blob Storage implementation of the Blobstore interface. Blobs are block blobs,
+// versions are their ETags, and Concatenate commits a block list with one block per source.
+type AzureBlobstore struct {
+	container     *container.Client
+	containerName string
+	prefix        string
+}
+
+var _ Blobstore = &AzureBlobstore{}
+
+// NewAzureBlobstore creates a new instance of an AzureBlobstore
+func NewAzureBlobstore(client *container.Client, containerName, prefix string) *AzureBlobstore {
+	return &AzureBlobstore{client, containerName, normalizePrefix(prefix)}
+}
+
+func (bs *AzureBlobstore) Path() string {
+	return path.Join(bs.containerName, bs.prefix)
+}
+
+// Exists returns true if a blob exists for the given key, and false if it does not.
+func (bs *AzureBlobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := bs.blobClient(key).GetProperties(ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Get retrieves an io.reader for the portion of a blob specified by br along with
+// its version
+func (bs *AzureBlobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	client := bs.blobClient(key)
+	opts := &blob.DownloadStreamOptions{}
+	if br.offset < 0 {
+		// ranges relative to the end of the blob are not supported, so the size is needed to make them absolute
+		props, err := client.GetProperties(ctx, nil)
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, "", bs.notFound(key)
+		} else if err != nil {
+			return nil, "", err
+		}
+		br = br.positiveRange(*props.ContentLength)
+		opts.AccessConditions = &blob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfMatch: props.ETag},
+		}
+	}
+	opts.Range = blob.HTTPRange{Offset: br.offset, Count: br.length}
+
+	resp, err := client.DownloadStream(ctx, opts)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return nil, "", bs.notFound(key)
+	} else if err != nil {
+		return nil, "", err
+	}
+	return resp.Body, string(*resp.ETag), nil
+}
+
+// Put sets the blob and the version for a key
+func (bs *AzureBlobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	return bs.upload(ctx, key, reader, nil)
+}
+
+// CheckAndPut will check the current version of a blob against an expectedVersion, and if the
+// versions match it will update the data and version associated with the key
+func (bs *AzureBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	cond := &blob.ModifiedAccessConditions{IfNoneMatch: to.Ptr(azcore.ETagAny)}
+	if expectedVersion != "" {
+		cond = &blob.ModifiedAccessConditions{IfMatch: to.Ptr(azcore.ETag(expectedVersion))}
+	}
+
+	ver, err := bs.upload(ctx, key, reader, &blob.AccessConditions{ModifiedAccessConditions: cond})
+	if bloberror.HasCode(err, bloberror.ConditionNotMet, bloberror.BlobAlreadyExists) {
+		return "", CheckAndPutError{key, expectedVersion, "unknown (Not supported in Azure implementation)"}
+	}
+	return ver, err
+}
+
+// Concatenate creates the blob |key| by committing a block list with one block per source. Sources are copied into
+// their blocks server side when the client can sign urls for them, and downloaded and re-uploaded otherwise.
+func (bs *AzureBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	dst := bs.blobClient(key)
+	blockIDs := make([]string, len(sources))
+	for i := range sources {
+		blockIDs[i] = azureBlockID(i)
+	}
+
+	eg, ectx := errgroup.WithContext(ctx)
+	eg.SetLimit(azureUploadConcurrency)
+	for i := range sources {
+		idx := i
+		eg.Go(func() error {
+			return bs.stageSource(ectx, dst, blockIDs[idx], sources[idx])
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return "", err
+	}
+
+	resp, err := dst.CommitBlockList(ctx, blockIDs, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(*resp.ETag), nil
+}
+
+func (bs *AzureBlobstore) stageSource(ctx context.Context, dst *blockblob.Client, blockID, key string) error {
+	src := bs.blobClient(key)
+	if srcURL, err := src.GetSASURL(sas.BlobPermissions{Read: true}, time.Now().Add(azureSourceSASExpiry), nil); err == nil {
+		_, err = dst.StageBlockFromURL(ctx, blockID, srcURL, nil)
+		if bloberror.HasCode(err, bloberror.CannotVerifyCopySource, bloberror.BlobNotFound) {
+			return bs.notFound(key)
+		}
+		return err
+	}
+
+	// without a shared key the source can't be authorized for a server side copy
+	rc, _, err := bs.Get(ctx, key, AllRange)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	_, err = dst.StageBlock(ctx, blockID, streaming.NopCloser(bytes.NewReader(data)), nil)
+	return err
+}
+
+func (bs *AzureBlobstore) upload(ctx context.Context, key string, reader io.Reader, cond *blob.AccessConditions) (string, error) {
+	resp, err := bs.blobClient(key).UploadStream(ctx, reader, &blockblob.UploadStreamOptions{
+		BlockSize:        azureUploadBlockSize,
+		Concurrency:      azureUploadConcurrency,
+		AccessConditions: cond,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(*resp.ETag), nil
+}
+
+func (bs *AzureBlobstore) blobClient(key string) *blockblob.Client {
+	return bs.container.NewBlockBlobClient(path.Join(bs.prefix, key))
+}
+
+func (bs *AzureBlobstore) notFound(key string) NotFound {
+	return NotFound{"az://" + path.Join(bs.containerName, bs.prefix, key)}
+}
+
+// azureBlockID returns the id of the |i|th block of a block list. Every id of a blob must have the same length.
+func azureBlockID(i int) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%010d", i)))
+}
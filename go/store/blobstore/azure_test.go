@@ -0,0 +1,290 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeAzureAccount = "devstoreaccount1"
+
+// fakeAzureServer implements the subset of the Azure Blob Storage REST API used by AzureBlobstore: getting blobs and
+// their properties, putting blobs, staging blocks from a request body or a source url, and committing block lists.
+// Writes honor the If-Match and If-None-Match conditions of their request.
+type fakeAzureServer struct {
+	mu     sync.Mutex
+	blobs  map[string]fakeAzureBlob
+	staged map[string]map[string][]byte
+	copies int
+}
+
+type fakeAzureBlob struct {
+	data []byte
+	etag string
+}
+
+func newFakeAzureServer() *fakeAzureServer {
+	return &fakeAzureServer{
+		blobs:  make(map[string]fakeAzureBlob),
+		staged: make(map[string]map[string][]byte),
+	}
+}
+
+func writeFakeAzureError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.WriteHeader(status)
+}
+
+func (s *fakeAzureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.URL.Path
+	switch {
+	case r.Method == http.MethodPut && r.URL.Query().Get("comp") == "block":
+		s.stageBlock(w, r, key)
+	case r.Method == http.MethodPut && r.URL.Query().Get("comp") == "blocklist":
+		s.commitBlockList(w, r, key)
+	case r.Method == http.MethodPut && r.URL.Query().Get("comp") == "":
+		s.putBlob(w, r, key)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		s.getBlob(w, r, key)
+	default:
+		writeFakeAzureError(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+	}
+}
+
+func (s *fakeAzureServer) stageBlock(w http.ResponseWriter, r *http.Request, key string) {
+	var data []byte
+	if src := r.Header.Get("x-ms-copy-source"); src != "" {
+		srcURL, err := url.Parse(src)
+		if err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidHeaderValue")
+			return
+		}
+		blob, ok := s.blobs[srcURL.Path]
+		if !ok {
+			writeFakeAzureError(w, http.StatusNotFound, "CannotVerifyCopySource")
+			return
+		}
+		data = blob.data
+		s.copies++
+	} else {
+		var err error
+		data, err = io.ReadAll(r.Body)
+		if err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidInput")
+			return
+		}
+	}
+
+	if s.staged[key] == nil {
+		s.staged[key] = make(map[string][]byte)
+	}
+	s.staged[key][r.URL.Query().Get("blockid")] = data
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *fakeAzureServer) commitBlockList(w http.ResponseWriter, r *http.Request, key string) {
+	var list struct {
+		Latest []string `xml:"Latest"`
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&list); err != nil {
+		writeFakeAzureError(w, http.StatusBadRequest, "InvalidXmlDocument")
+		return
+	}
+
+	var buf bytes.Buffer
+	for _, id := range list.Latest {
+		block, ok := s.staged[key][id]
+		if !ok {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidBlockList")
+			return
+		}
+		buf.Write(block)
+	}
+	if s.write(w, r, key, buf.Bytes()) {
+		delete(s.staged, key)
+	}
+}
+
+func (s *fakeAzureServer) putBlob(w http.ResponseWriter, r *http.Request, key string) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeFakeAzureError(w, http.StatusBadRequest, "InvalidInput")
+		return
+	}
+	s.write(w, r, key, data)
+}
+
+// write sets the contents of the blob |key| if the conditions of |r| are met, and returns whether they were.
+func (s *fakeAzureServer) write(w http.ResponseWriter, r *http.Request, key string, data []byte) bool {
+	existing, exists := s.blobs[key]
+	if match := r.Header.Get("If-Match"); match != "" && (!exists || existing.etag != match) {
+		writeFakeAzureError(w, http.StatusPreconditionFailed, "ConditionNotMet")
+		return false
+	}
+	if r.Header.Get("If-None-Match") == "*" && exists {
+		writeFakeAzureError(w, http.StatusConflict, "BlobAlreadyExists")
+		return false
+	}
+
+	etag := `"` + uuid.New().String() + `"`
+	s.blobs[key] = fakeAzureBlob{data: data, etag: etag}
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusCreated)
+	return true
+}
+
+func (s *fakeAzureServer) getBlob(w http.ResponseWriter, r *http.Request, key string) {
+	blob, ok := s.blobs[key]
+	if !ok {
+		writeFakeAzureError(w, http.StatusNotFound, "BlobNotFound")
+		return
+	}
+	if match := r.Header.Get("If-Match"); match != "" && match != blob.etag {
+		writeFakeAzureError(w, http.StatusPreconditionFailed, "ConditionNotMet")
+		return
+	}
+
+	data := blob.data
+	status := http.StatusOK
+	if rng := r.Header.Get("x-ms-range"); rng != "" {
+		br, err := parseFakeRange(rng)
+		if err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidRange")
+			return
+		}
+		br = br.positiveRange(int64(len(data)))
+		data = data[br.offset : br.offset+br.length]
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", br.offset, br.offset+br.length-1, len(blob.data)))
+		status = http.StatusPartialContent
+	}
+
+	w.Header().Set("ETag", blob.etag)
+	w.Header().Set("x-ms-blob-type", "BlockBlob")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(status)
+	if r.Method == http.MethodGet {
+		w.Write(data)
+	}
+}
+
+func fakeAzureCredential() *container.SharedKeyCredential {
+	cred, err := container.NewSharedKeyCredential(fakeAzureAccount, base64.StdEncoding.EncodeToString([]byte("fake-account-key")))
+	if err != nil {
+		panic(err)
+	}
+	return cred
+}
+
+func newFakeAzureContainer(t require.TestingT, srv *httptest.Server, sharedKey bool) *container.Client {
+	containerURL := srv.URL + "/" + fakeAzureAccount + "/dolt"
+	if !sharedKey {
+		client, err := container.NewClientWithNoCredential(containerURL, nil)
+		require.NoError(t, err)
+		return client
+	}
+	client, err := container.NewClientWithSharedKeyCredential(containerURL, fakeAzureCredential(), nil)
+	require.NoError(t, err)
+	return client
+}
+
+func TestAzureConcatenate(t *testing.T) {
+	ctx := context.Background()
+	for _, sharedKey := range []bool{true, false} {
+		t.Run(fmt.Sprintf("shared key %t", sharedKey), func(t *testing.T) {
+			fake := newFakeAzureServer()
+			srv := httptest.NewServer(fake)
+			defer srv.Close()
+			bs := NewAzureBlobstore(newFakeAzureContainer(t, srv, sharedKey), "dolt", "/db")
+
+			var keys []string
+			var expected []byte
+			for i := 0; i < 12; i++ {
+				data := randBytes(100 + i)
+				key := uuid.New().String()
+				_, err := bs.Put(ctx, key, int64(len(data)), bytes.NewReader(data))
+				require.NoError(t, err)
+				keys = append(keys, key)
+				expected = append(expected, data...)
+			}
+
+			ver, err := bs.Concatenate(ctx, "composite", keys)
+			require.NoError(t, err)
+			if sharedKey {
+				// sources are copied server side from signed urls
+				assert.Equal(t, len(keys), fake.copies)
+			} else {
+				assert.Equal(t, 0, fake.copies)
+			}
+
+			rc, actualVer, err := bs.Get(ctx, "composite", AllRange)
+			require.NoError(t, err)
+			defer rc.Close()
+			actual, err := io.ReadAll(rc)
+			require.NoError(t, err)
+			assert.Equal(t, ver, actualVer)
+			assert.Equal(t, expected, actual)
+
+			_, err = bs.Concatenate(ctx, "missing", []string{keys[0], "does-not-exist"})
+			assert.True(t, IsNotFoundError(err))
+		})
+	}
+}
+
+func TestAzureCheckAndPutConditions(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(newFakeAzureServer())
+	defer srv.Close()
+	bs := NewAzureBlobstore(newFakeAzureContainer(t, srv, false), "dolt", "")
+
+	ver, err := CheckAndPutBytes(ctx, bs, "", "manifest", []byte("one"))
+	require.NoError(t, err)
+
+	// the blob exists, so it can't be created again
+	_, err = CheckAndPutBytes(ctx, bs, "", "manifest", []byte("two"))
+	assert.True(t, IsCheckAndPutError(err))
+
+	_, err = CheckAndPutBytes(ctx, bs, `"stale"`, "manifest", []byte("two"))
+	assert.True(t, IsCheckAndPutError(err))
+
+	newVer, err := CheckAndPutBytes(ctx, bs, ver, "manifest", []byte("two"))
+	require.NoError(t, err)
+	assert.NotEqual(t, ver, newVer)
+
+	ok, err := bs.Exists(ctx, "manifest")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = bs.Exists(ctx, "does-not-exist")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -20,8 +20,10 @@ import (
 	"encoding/binary"
 	"fmt"
 	"hash/maphash"
+	"io"
 	"log"
 	"math/rand"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"runtime"
@@ -29,6 +31,7 @@ import (
 	"testing"
 
 	"cloud.google.com/go/storage"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	"github.com/google/uuid"
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/objectstorage"
@@ -113,10 +116,22 @@ func appendLocalTest(tests []BlobstoreTest) []BlobstoreTest {
 	return append(tests, BlobstoreTest{"local", NewLocalBlobstore(dir), 10, 20})
 }
 
+func appendAzureTest(tests []BlobstoreTest) []BlobstoreTest {
+	srv := httptest.NewServer(newFakeAzureServer())
+	client, err := container.NewClientWithSharedKeyCredential(srv.URL+"/"+fakeAzureAccount+"/dolt", fakeAzureCredential(), nil)
+	if err != nil {
+		panic("Could not create AzureBlobstore")
+	}
+
+	return append(tests, BlobstoreTest{"azure", NewAzureBlobstore(client, "dolt", uuid.New().String()+"/"), 10, 20})
+}
+
 func newBlobStoreTests() []BlobstoreTest {
 	var tests []BlobstoreTest
 	tests = append(tests, BlobstoreTest{"inmem", NewInMemoryBlobstore(""), 10, 20})
+	tests = append(tests, BlobstoreTest{"s3", NewS3Blobstore(newFakeS3(), "bucket", uuid.New().String()+"/"), 10, 20})
 	tests = appendLocalTest(tests)
+	tests = appendAzureTest(tests)
 	tests = appendGCSTest(tests)
 	tests = appendOCITest(tests)
 
@@ -460,7 +475,7 @@ func testConcatenate(t *testing.T, bs Blobstore, cnt int) {
 		assert.NoError(t, err)
 
 		act := make([]byte, length)
-		n, err := rdr.Read(act)
+		n, err := io.ReadFull(rdr, act)
 		assert.NoError(t, err)
 		assert.Equal(t, int(length), n)
 		assert.Equal(t, blobs[i].data, act)
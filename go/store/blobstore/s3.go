@@ -0,0 +1,336 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// s3MinPartSize is the smallest part S3 accepts in a multipart upload, for every part but the last
+const s3MinPartSize = 5 * 1024 * 1024
+
+// S3Blobstore provides an S3 implementation of the Blobstore interface. Versions are object ETags, and CheckAndPut
+// relies on conditional writes (If-Match / If-None-Match), so no external lock table is needed. It works against
+// any S3-compatible object store which supports conditional writes.
+type S3Blobstore struct {
+	s3         s3iface.S3API
+	bucketName string
+	prefix     string
+}
+
+var _ Blobstore = &S3Blobstore{}
+
+// NewS3Blobstore creates a new instance of an S3Blobstore
+func NewS3Blobstore(s3 s3iface.S3API, bucketName, prefix string) *S3Blobstore {
+	return &S3Blobstore{s3, bucketName, normalizePrefix(prefix)}
+}
+
+func (bs *S3Blobstore) Path() string {
+	return path.Join(bs.bucketName, bs.prefix)
+}
+
+// Exists returns true if a blob exists for the given key, and false if it does not.
+func (bs *S3Blobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := bs.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(bs.absKey(key)),
+	})
+	if isS3NotFoundErr(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Get retrieves an io.reader for the portion of a blob specified by br along with
+// its version
+func (bs *S3Blobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	absKey := bs.absKey(key)
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(absKey),
+	}
+	if !br.isAllRange() {
+		input.Range = aws.String(s3RangeHeader(br))
+	}
+
+	out, err := bs.s3.GetObjectWithContext(ctx, input)
+	if isS3NotFoundErr(err) {
+		return nil, "", NotFound{"s3://" + path.Join(bs.bucketName, absKey)}
+	} else if err != nil {
+		return nil, "", err
+	}
+
+	var rc io.ReadCloser = out.Body
+	if br.offset < 0 && br.length > 0 && br.length < -br.offset {
+		// a suffix range reads to the end of the blob, so trim it to the requested length
+		rc = limitReadCloser{io.LimitReader(out.Body, br.length), out.Body}
+	}
+	return rc, aws.StringValue(out.ETag), nil
+}
+
+// Put sets the blob and the version for a key. Blobs larger than a part are streamed to S3 as a multipart upload, so
+// that they aren't buffered in memory, and can be larger than a single PUT allows.
+func (bs *S3Blobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	if totalSize <= s3MinPartSize {
+		return bs.put(ctx, key, reader)
+	}
+
+	// S3 allows at most s3manager.MaxUploadParts parts, so large blobs need larger parts
+	partSize := int64(s3MinPartSize)
+	if n := (totalSize + s3manager.MaxUploadParts - 1) / s3manager.MaxUploadParts; n > partSize {
+		partSize = n
+	}
+	uploader := s3manager.NewUploaderWithClient(bs.s3, func(u *s3manager.Uploader) {
+		u.PartSize = partSize
+	})
+	absKey := bs.absKey(key)
+	_, err := uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(absKey),
+		Body:   reader,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// the uploader doesn't return the ETag of the object it completes
+	out, err := bs.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(absKey),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(out.ETag), nil
+}
+
+// CheckAndPut will check the current version of a blob against an expectedVersion, and if the
+// versions match it will update the data and version associated with the key
+func (bs *S3Blobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	cond := map[string]string{"If-None-Match": "*"}
+	if expectedVersion != "" {
+		cond = map[string]string{"If-Match": expectedVersion}
+	}
+
+	ver, err := bs.put(ctx, key, reader, request.WithSetRequestHeaders(cond))
+	if isS3PrecondFailedErr(err) {
+		return "", CheckAndPutError{key, expectedVersion, "unknown (Not supported in S3 implementation)"}
+	}
+	return ver, err
+}
+
+// Concatenate creates the blob |key| from the contents of |sources|. Small sources are downloaded and re-uploaded,
+// sources large enough to be a part of a multipart upload are copied server side.
+func (bs *S3Blobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	sizes := make([]int64, len(sources))
+	var total int64
+	for i, src := range sources {
+		out, err := bs.s3.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bs.bucketName),
+			Key:    aws.String(bs.absKey(src)),
+		})
+		if isS3NotFoundErr(err) {
+			return "", NotFound{"s3://" + path.Join(bs.bucketName, bs.absKey(src))}
+		} else if err != nil {
+			return "", err
+		}
+		sizes[i] = aws.Int64Value(out.ContentLength)
+		total += sizes[i]
+	}
+
+	if total < s3MinPartSize {
+		var buf bytes.Buffer
+		for _, src := range sources {
+			if err := bs.readInto(ctx, &buf, src); err != nil {
+				return "", err
+			}
+		}
+		return bs.put(ctx, key, bytes.NewReader(buf.Bytes()))
+	}
+
+	absKey := bs.absKey(key)
+	mpu, err := bs.s3.CreateMultipartUploadWithContext(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(absKey),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	parts, err := bs.uploadParts(ctx, absKey, mpu.UploadId, sources, sizes)
+	if err != nil {
+		// best effort, the upload is garbage collected by the bucket's lifecycle rules otherwise
+		_, _ = bs.s3.AbortMultipartUploadWithContext(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bs.bucketName),
+			Key:      aws.String(absKey),
+			UploadId: mpu.UploadId,
+		})
+		return "", err
+	}
+
+	out, err := bs.s3.CompleteMultipartUploadWithContext(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bs.bucketName),
+		Key:             aws.String(absKey),
+		UploadId:        mpu.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(out.ETag), nil
+}
+
+// uploadParts uploads |sources| as the parts of the multipart upload |uploadID|. A source is copied server side when
+// it can be a part of its own, otherwise it is buffered along with its neighbours until they make a part together.
+func (bs *S3Blobstore) uploadParts(ctx context.Context, absKey string, uploadID *string, sources []string, sizes []int64) ([]*s3.CompletedPart, error) {
+	var parts []*s3.CompletedPart
+	var pending bytes.Buffer
+
+	flush := func() error {
+		partNum := aws.Int64(int64(len(parts) + 1))
+		out, err := bs.s3.UploadPartWithContext(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(bs.bucketName),
+			Key:        aws.String(absKey),
+			UploadId:   uploadID,
+			PartNumber: partNum,
+			Body:       bytes.NewReader(pending.Bytes()),
+		})
+		if err != nil {
+			return err
+		}
+		parts = append(parts, &s3.CompletedPart{ETag: out.ETag, PartNumber: partNum})
+		pending.Reset()
+		return nil
+	}
+
+	for i, src := range sources {
+		if pending.Len() == 0 && sizes[i] >= s3MinPartSize {
+			partNum := aws.Int64(int64(len(parts) + 1))
+			out, err := bs.s3.UploadPartCopyWithContext(ctx, &s3.UploadPartCopyInput{
+				Bucket:     aws.String(bs.bucketName),
+				Key:        aws.String(absKey),
+				UploadId:   uploadID,
+				PartNumber: partNum,
+				CopySource: aws.String(url.PathEscape(bs.bucketName + "/" + bs.absKey(src))),
+			})
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, &s3.CompletedPart{ETag: out.CopyPartResult.ETag, PartNumber: partNum})
+			continue
+		}
+
+		if err := bs.readInto(ctx, &pending, src); err != nil {
+			return nil, err
+		}
+		if pending.Len() >= s3MinPartSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if pending.Len() > 0 {
+		if err := flush(); err != nil {
+			return nil, err
+		}
+	}
+	return parts, nil
+}
+
+func (bs *S3Blobstore) readInto(ctx context.Context, buf *bytes.Buffer, key string) error {
+	rc, _, err := bs.Get(ctx, key, AllRange)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = buf.ReadFrom(rc)
+	return err
+}
+
+// put writes |reader| with a single PutObject. It is used for small blobs, and for the conditional writes of
+// CheckAndPut, which S3 doesn't support for multipart uploads.
+func (bs *S3Blobstore) put(ctx context.Context, key string, reader io.Reader, opts ...request.Option) (string, error) {
+	// the SDK needs to seek the body to sign and retry requests
+	body, ok := reader.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return "", err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	out, err := bs.s3.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bs.bucketName),
+		Key:    aws.String(bs.absKey(key)),
+		Body:   body,
+	}, opts...)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(out.ETag), nil
+}
+
+func (bs *S3Blobstore) absKey(key string) string {
+	return path.Join(bs.prefix, key)
+}
+
+type limitReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// s3RangeHeader returns the HTTP Range header for |br|. Negative offsets are sent as suffix ranges.
+func s3RangeHeader(br BlobRange) string {
+	if br.offset < 0 {
+		return fmt.Sprintf("bytes=%d", br.offset)
+	} else if br.length == 0 {
+		return fmt.Sprintf("bytes=%d-", br.offset)
+	}
+	return fmt.Sprintf("bytes=%d-%d", br.offset, br.offset+br.length-1)
+}
+
+func isS3NotFoundErr(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == s3.ErrCodeNoSuchKey || awsErr.Code() == "NotFound"
+	}
+	return false
+}
+
+// isS3PrecondFailedErr returns true if |err| is the failure of a conditional write. Concurrent conditional writes to
+// the same key can also fail with a conflict.
+func isS3PrecondFailedErr(err error) bool {
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		return reqErr.StatusCode() == http.StatusPreconditionFailed || reqErr.StatusCode() == http.StatusConflict
+	}
+	return false
+}
@@ -0,0 +1,295 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is an s3iface.S3API which stores its objects in an InMemoryBlobstore, and honors the conditional write
+// headers sent with PutObject.
+type fakeS3 struct {
+	s3iface.S3API
+
+	objects *InMemoryBlobstore
+	mu      sync.Mutex
+	uploads map[string]map[int64][]byte
+	copies  int
+	puts    int
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		objects: NewInMemoryBlobstore(""),
+		uploads: make(map[string]map[int64][]byte),
+	}
+}
+
+func fakeS3Error(code string, status int) error {
+	return awserr.NewRequestFailure(awserr.New(code, code, nil), status, uuid.New().String())
+}
+
+func etag(ver string) *string {
+	return aws.String(`"` + ver + `"`)
+}
+
+func (m *fakeS3) HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error) {
+	rc, ver, err := m.objects.Get(ctx, *input.Key, AllRange)
+	if IsNotFoundError(err) {
+		return nil, fakeS3Error("NotFound", http.StatusNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	return &s3.HeadObjectOutput{ContentLength: aws.Int64(int64(len(data))), ETag: etag(ver)}, nil
+}
+
+func (m *fakeS3) GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error) {
+	br := AllRange
+	if input.Range != nil {
+		var err error
+		br, err = parseFakeRange(*input.Range)
+		if err != nil {
+			return nil, err
+		}
+	}
+	rc, ver, err := m.objects.Get(ctx, *input.Key, br)
+	if IsNotFoundError(err) {
+		return nil, fakeS3Error(s3.ErrCodeNoSuchKey, http.StatusNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return &s3.GetObjectOutput{Body: rc, ETag: etag(ver)}, nil
+}
+
+// GetObjectRequest is used by s3manager.Uploader to presign the location of the objects it uploads.
+func (m *fakeS3) GetObjectRequest(input *s3.GetObjectInput) (*request.Request, *s3.GetObjectOutput) {
+	out := &s3.GetObjectOutput{}
+	var handlers request.Handlers
+	handlers.Send.PushBack(func(r *request.Request) {
+		res, err := m.GetObjectWithContext(r.Context(), input)
+		r.Error = err
+		if res != nil {
+			*(r.Data.(*s3.GetObjectOutput)) = *res
+		}
+	})
+	return request.New(aws.Config{}, metadata.ClientInfo{}, handlers, nil, &request.Operation{
+		Name:       "GetObject",
+		HTTPMethod: "GET",
+		HTTPPath:   "/{Bucket}/{Key+}",
+	}, input, out), out
+}
+
+func (m *fakeS3) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	req := &request.Request{HTTPRequest: &http.Request{Header: http.Header{}}}
+	req.ApplyOptions(opts...)
+
+	m.mu.Lock()
+	m.puts++
+	m.mu.Unlock()
+
+	var ver string
+	var err error
+	if match := req.HTTPRequest.Header.Get("If-Match"); match != "" {
+		ver, err = m.objects.CheckAndPut(ctx, strings.Trim(match, `"`), *input.Key, 0, input.Body)
+	} else if req.HTTPRequest.Header.Get("If-None-Match") == "*" {
+		ver, err = m.objects.CheckAndPut(ctx, "", *input.Key, 0, input.Body)
+	} else {
+		ver, err = m.objects.Put(ctx, *input.Key, 0, input.Body)
+	}
+	if IsCheckAndPutError(err) {
+		return nil, fakeS3Error("PreconditionFailed", http.StatusPreconditionFailed)
+	} else if err != nil {
+		return nil, err
+	}
+	return &s3.PutObjectOutput{ETag: etag(ver)}, nil
+}
+
+func (m *fakeS3) CreateMultipartUploadWithContext(ctx aws.Context, input *s3.CreateMultipartUploadInput, opts ...request.Option) (*s3.CreateMultipartUploadOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	uploadID := uuid.New().String()
+	m.uploads[uploadID] = make(map[int64][]byte)
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String(uploadID)}, nil
+}
+
+func (m *fakeS3) UploadPartWithContext(ctx aws.Context, input *s3.UploadPartInput, opts ...request.Option) (*s3.UploadPartOutput, error) {
+	data, err := io.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &s3.UploadPartOutput{ETag: m.addPart(*input.UploadId, *input.PartNumber, data)}, nil
+}
+
+func (m *fakeS3) UploadPartCopyWithContext(ctx aws.Context, input *s3.UploadPartCopyInput, opts ...request.Option) (*s3.UploadPartCopyOutput, error) {
+	src, err := url.PathUnescape(*input.CopySource)
+	if err != nil {
+		return nil, err
+	}
+	_, key, ok := strings.Cut(src, "/")
+	if !ok {
+		return nil, fmt.Errorf("malformed CopySource %s", src)
+	}
+	rc, _, err := m.objects.Get(ctx, key, AllRange)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.copies++
+	m.mu.Unlock()
+	return &s3.UploadPartCopyOutput{CopyPartResult: &s3.CopyPartResult{ETag: m.addPart(*input.UploadId, *input.PartNumber, data)}}, nil
+}
+
+func (m *fakeS3) CompleteMultipartUploadWithContext(ctx aws.Context, input *s3.CompleteMultipartUploadInput, opts ...request.Option) (*s3.CompleteMultipartUploadOutput, error) {
+	m.mu.Lock()
+	parts := m.uploads[*input.UploadId]
+	delete(m.uploads, *input.UploadId)
+	m.mu.Unlock()
+
+	var buf bytes.Buffer
+	for i, p := range input.MultipartUpload.Parts {
+		if *p.PartNumber != int64(i+1) {
+			return nil, fakeS3Error("InvalidPartOrder", http.StatusBadRequest)
+		}
+		data := parts[*p.PartNumber]
+		if i < len(input.MultipartUpload.Parts)-1 && len(data) < s3MinPartSize {
+			return nil, fakeS3Error("EntityTooSmall", http.StatusBadRequest)
+		}
+		buf.Write(data)
+	}
+	ver, err := m.objects.Put(ctx, *input.Key, int64(buf.Len()), &buf)
+	if err != nil {
+		return nil, err
+	}
+	return &s3.CompleteMultipartUploadOutput{ETag: etag(ver)}, nil
+}
+
+func (m *fakeS3) AbortMultipartUploadWithContext(ctx aws.Context, input *s3.AbortMultipartUploadInput, opts ...request.Option) (*s3.AbortMultipartUploadOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.uploads, *input.UploadId)
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func (m *fakeS3) addPart(uploadID string, partNum int64, data []byte) *string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.uploads[uploadID][partNum] = data
+	return etag(uuid.New().String())
+}
+
+func parseFakeRange(hdr string) (BlobRange, error) {
+	start, end, _ := strings.Cut(strings.TrimPrefix(hdr, "bytes="), "-")
+	if start == "" {
+		n, err := strconv.ParseInt(end, 10, 64)
+		return NewBlobRange(-n, 0), err
+	}
+	offset, err := strconv.ParseInt(start, 10, 64)
+	if err != nil || end == "" {
+		return NewBlobRange(offset, 0), err
+	}
+	last, err := strconv.ParseInt(end, 10, 64)
+	return NewBlobRange(offset, last-offset+1), err
+}
+
+func TestS3RangeHeader(t *testing.T) {
+	assert.Equal(t, "bytes=10-", s3RangeHeader(NewBlobRange(10, 0)))
+	assert.Equal(t, "bytes=10-19", s3RangeHeader(NewBlobRange(10, 10)))
+	assert.Equal(t, "bytes=-10", s3RangeHeader(NewBlobRange(-10, 0)))
+	assert.Equal(t, "bytes=-10", s3RangeHeader(NewBlobRange(-10, 5)))
+}
+
+func TestS3ConcatenateLargeSources(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3()
+	bs := NewS3Blobstore(fake, "bucket", "/db")
+
+	// small sources are buffered into a part, large ones are copied unless a part is already being buffered
+	sizes := []int{1024, s3MinPartSize, s3MinPartSize + 1, 10, s3MinPartSize, 10}
+	var keys []string
+	var expected []byte
+	for _, sz := range sizes {
+		data := randBytes(sz)
+		key := uuid.New().String()
+		_, err := bs.Put(ctx, key, int64(sz), bytes.NewReader(data))
+		require.NoError(t, err)
+		keys = append(keys, key)
+		expected = append(expected, data...)
+	}
+
+	ver, err := bs.Concatenate(ctx, "composite", keys)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.copies)
+
+	rc, actualVer, err := bs.Get(ctx, "composite", AllRange)
+	require.NoError(t, err)
+	defer rc.Close()
+	actual, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, ver, actualVer)
+	assert.Equal(t, expected, actual)
+
+	_, err = bs.Concatenate(ctx, "missing", []string{keys[0], "does-not-exist"})
+	assert.True(t, IsNotFoundError(err))
+}
+
+func TestS3PutLargeStream(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeS3()
+	bs := NewS3Blobstore(fake, "bucket", "/db")
+
+	// a table file is written from a reader that can't seek, and is uploaded in parts rather than buffered
+	data := randBytes(2*s3MinPartSize + 10)
+	ver, err := bs.Put(ctx, "table", int64(len(data)), io.LimitReader(bytes.NewReader(data), int64(len(data))))
+	require.NoError(t, err)
+	assert.Equal(t, 0, fake.puts)
+
+	rc, actualVer, err := bs.Get(ctx, "table", AllRange)
+	require.NoError(t, err)
+	defer rc.Close()
+	actual, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, ver, actualVer)
+	assert.Equal(t, data, actual)
+}
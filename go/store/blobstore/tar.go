@@ -0,0 +1,117 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrReadOnly is returned when writing to a read only Blobstore
+var ErrReadOnly = errors.New("blobstore is read only")
+
+type tarEntry struct {
+	offset int64
+	size   int64
+}
+
+// TarBlobstore is a read only Blobstore implementation backed by an uncompressed tar archive. Each regular file in the
+// archive is a blob keyed by its name.
+type TarBlobstore struct {
+	path    string
+	entries map[string]tarEntry
+}
+
+var _ Blobstore = &TarBlobstore{}
+
+// NewTarBlobstore indexes the tar archive at |path| and returns a TarBlobstore which reads its files
+func NewTarBlobstore(path string) (*TarBlobstore, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries := make(map[string]tarEntry)
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read tar archive %s: %w", path, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// the reader is positioned at the beginning of the file's contents after reading its header
+		offset, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		entries[hdr.Name] = tarEntry{offset: offset, size: hdr.Size}
+	}
+
+	return &TarBlobstore{path: path, entries: entries}, nil
+}
+
+func (bs *TarBlobstore) Path() string {
+	return bs.path
+}
+
+// Exists returns true if the archive contains a file named |key|
+func (bs *TarBlobstore) Exists(ctx context.Context, key string) (bool, error) {
+	_, ok := bs.entries[key]
+	return ok, nil
+}
+
+// Get retrieves an io.reader for the portion of the file named |key| specified by |br|. Files in the archive never
+// change, so the version returned is always empty.
+func (bs *TarBlobstore) Get(ctx context.Context, key string, br BlobRange) (io.ReadCloser, string, error) {
+	e, ok := bs.entries[key]
+	if !ok {
+		return nil, "", NotFound{key}
+	}
+
+	f, err := os.Open(bs.path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	br = br.positiveRange(e.size)
+	return tarEntryReader{io.NewSectionReader(f, e.offset+br.offset, br.length), f}, "", nil
+}
+
+type tarEntryReader struct {
+	*io.SectionReader
+	io.Closer
+}
+
+func (bs *TarBlobstore) Put(ctx context.Context, key string, totalSize int64, reader io.Reader) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (bs *TarBlobstore) CheckAndPut(ctx context.Context, expectedVersion, key string, totalSize int64, reader io.Reader) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (bs *TarBlobstore) Concatenate(ctx context.Context, key string, sources []string) (string, error) {
+	return "", ErrReadOnly
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blobstore
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestTar(t *testing.T, files map[string][]byte, order []string) string {
+	path := filepath.Join(t.TempDir(), "test.tar")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755}))
+	for _, name := range order {
+		data := files[name]
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(data))}))
+		_, err = tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return path
+}
+
+func TestTarBlobstore(t *testing.T) {
+	ctx := context.Background()
+	maxValue := int64(16 * 1024)
+	files := map[string][]byte{
+		"first": []byte("the first file"),
+		key:     rangeData(0, maxValue),
+		"empty": {},
+		"last":  bytes.Repeat([]byte{0xff}, 1000),
+	}
+	bs, err := NewTarBlobstore(writeTestTar(t, files, []string{"first", key, "empty", "last"}))
+	require.NoError(t, err)
+
+	for name, data := range files {
+		ok, err := bs.Exists(ctx, name)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		retrieved, _, err := GetBytes(ctx, bs, name, AllRange)
+		require.NoError(t, err)
+		assert.Equal(t, data, retrieved)
+	}
+
+	testGetRange(t, bs, NewBlobRange(0, 2048), rangeData(0, 1024))
+	testGetRange(t, bs, NewBlobRange(2*1024, 2*1024), rangeData(1024, 2048))
+	testGetRange(t, bs, NewBlobRange(-2*1024, 0), rangeData(maxValue-1024, maxValue))
+	testGetRange(t, bs, NewBlobRange(-2*1024, 512), rangeData(maxValue-1024, maxValue-768))
+
+	ok, err := bs.Exists(ctx, "dir/")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, _, err = bs.Get(ctx, "missing", AllRange)
+	assert.True(t, IsNotFoundError(err))
+
+	_, err = PutBytes(ctx, bs, "new", []byte("data"))
+	assert.ErrorIs(t, err, ErrReadOnly)
+	_, err = bs.CheckAndPut(ctx, "", "new", 4, bytes.NewReader([]byte("data")))
+	assert.ErrorIs(t, err, ErrReadOnly)
+	_, err = bs.Concatenate(ctx, "new", []string{"first", "last"})
+	assert.ErrorIs(t, err, ErrReadOnly)
+}
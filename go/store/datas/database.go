@@ -193,8 +193,9 @@ type GarbageCollector interface {
 	types.ValueReadWriter
 
 	// GC traverses the database starting at the Root and removes
-	// all unreferenced data from persistent storage.
-	GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepointF func() error) error
+	// all unreferenced data from persistent storage. Data kept by
+	// |safepoint|, if it is non-nil, is not removed.
+	GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepoint types.GCSafepointController) error
 }
 
 // CanUsePuller returns true if a datas.Puller can be used to pull data from one Database into another.  Not all
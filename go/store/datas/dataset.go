@@ -159,6 +159,7 @@ type WorkingSetHead struct {
 	StagedAddr  *hash.Hash
 	MergeState  *MergeState
 	RebaseState *RebaseState
+	BisectState *BisectState
 }
 
 type RebaseState struct {
@@ -169,6 +170,7 @@ type RebaseState struct {
 	emptyCommitHandling        uint8
 	lastAttemptedStep          float32
 	rebasingStarted            bool
+	conflictStrategy           uint8
 }
 
 func (rs *RebaseState) PreRebaseWorkingAddr() hash.Hash {
@@ -206,6 +208,29 @@ func (rs *RebaseState) EmptyCommitHandling(_ context.Context) uint8 {
 	return rs.emptyCommitHandling
 }
 
+func (rs *RebaseState) ConflictStrategy(_ context.Context) uint8 {
+	return rs.conflictStrategy
+}
+
+type BisectState struct {
+	badCommitAddr      *hash.Hash
+	goodCommitAddrs    []hash.Hash
+	skippedCommitAddrs []hash.Hash
+}
+
+// BadCommitAddr returns the address of the commit marked as bad, or nil if no commit has been marked as bad yet.
+func (bs *BisectState) BadCommitAddr() *hash.Hash {
+	return bs.badCommitAddr
+}
+
+func (bs *BisectState) GoodCommitAddrs() []hash.Hash {
+	return bs.goodCommitAddrs
+}
+
+func (bs *BisectState) SkippedCommitAddrs() []hash.Hash {
+	return bs.skippedCommitAddrs
+}
+
 type MergeState struct {
 	preMergeWorkingAddr *hash.Hash
 	fromCommitAddr      *hash.Hash
@@ -458,6 +483,24 @@ func (h serialWorkingSetHead) HeadWorkingSet() (*WorkingSetHead, error) {
 			rebaseState.EmptyCommitHandling(),
 			rebaseState.LastAttemptedStep(),
 			rebaseState.RebasingStarted(),
+			rebaseState.ConflictStrategy(),
+		)
+	}
+
+	bisectState, err := h.msg.TryBisectState(nil)
+	if err != nil {
+		return nil, err
+	}
+	if bisectState != nil {
+		var badCommitAddr *hash.Hash
+		if bisectState.BadCommitAddrLength() != 0 {
+			badCommitAddr = new(hash.Hash)
+			*badCommitAddr = hash.New(bisectState.BadCommitAddrBytes())
+		}
+		ret.BisectState = NewBisectState(
+			badCommitAddr,
+			hashesFromBytes(bisectState.GoodCommitAddrsBytes()),
+			hashesFromBytes(bisectState.SkippedCommitAddrsBytes()),
 		)
 	}
 
@@ -21,6 +21,8 @@ import (
 	"io"
 
 	"github.com/cenkalti/backoff/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 
@@ -55,7 +57,13 @@ func Clone(ctx context.Context, srcCS, sinkCS chunks.ChunkStore, eventCh chan<-
 		return fmt.Errorf("%w: sink db is not a Table File Store", ErrCloneUnsupported)
 	}
 
-	return clone(ctx, srcTS, sinkTS, sinkCS, eventCh)
+	ctx, span := tracer.Start(ctx, "pull.Clone", trace.WithAttributes(attribute.Int64("size", int64(size))))
+	defer span.End()
+	err = clone(ctx, srcTS, sinkTS, sinkCS, eventCh)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
 }
 
 type CloneTableFileEvent int
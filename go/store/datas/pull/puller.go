@@ -28,6 +28,9 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
@@ -36,6 +39,8 @@ import (
 	"github.com/dolthub/dolt/go/store/nbs"
 )
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/store/datas/pull")
+
 // ErrDBUpToDate is the error code returned from NewPuller in the event that there is no work to do.
 var ErrDBUpToDate = errors.New("the database does not need to be pulled as it's already up to date")
 
@@ -292,7 +297,18 @@ func (s *stats) read() Stats {
 }
 
 // Pull executes the sync operation
-func (p *Puller) Pull(ctx context.Context) error {
+func (p *Puller) Pull(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "pull.Pull", trace.WithAttributes(attribute.Int("num_hashes", len(p.hashes))))
+	defer func() {
+		span.SetAttributes(
+			attribute.Int64("fetched_chunks", int64(atomic.LoadUint64(&p.stats.fetchedSourceChunks))),
+			attribute.Int64("fetched_bytes", int64(atomic.LoadUint64(&p.stats.fetchedSourceBytes))))
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
+
 	if p.statsCh != nil {
 		c := emitStats(p.stats, p.statsCh)
 		defer c()
@@ -318,7 +334,9 @@ func (p *Puller) Pull(ctx context.Context) error {
 			}
 
 			atomic.AddUint64(&p.stats.totalSourceChunks, uint64(len(toFetch)))
-			err = p.rd.Get(ctx, toFetch)
+			getCtx, span := tracer.Start(ctx, "pull.GetChunks", trace.WithAttributes(attribute.Int("num_hashes", len(toFetch))))
+			err = p.rd.Get(getCtx, toFetch)
+			span.End()
 			if err != nil {
 				return err
 			}
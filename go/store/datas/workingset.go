@@ -87,6 +87,7 @@ type WorkingSetSpec struct {
 	StagedRoot  types.Ref
 	MergeState  *MergeState
 	RebaseState *RebaseState
+	BisectState *BisectState
 }
 
 // newWorkingSet creates a new working set object.
@@ -112,10 +113,11 @@ func newWorkingSet(ctx context.Context, db *database, workingSetSpec WorkingSetS
 	stagedRef := workingSetSpec.StagedRoot
 	mergeState := workingSetSpec.MergeState
 	rebaseState := workingSetSpec.RebaseState
+	bisectState := workingSetSpec.BisectState
 
 	if db.Format().UsesFlatbuffers() {
 		stagedAddr := stagedRef.TargetHash()
-		data := workingset_flatbuffer(workingRef.TargetHash(), &stagedAddr, mergeState, rebaseState, meta)
+		data := workingset_flatbuffer(workingRef.TargetHash(), &stagedAddr, mergeState, rebaseState, bisectState, meta)
 
 		r, err := db.WriteValue(ctx, types.SerialMessage(data))
 		if err != nil {
@@ -163,10 +165,10 @@ func newWorkingSet(ctx context.Context, db *database, workingSetSpec WorkingSetS
 }
 
 // workingset_flatbuffer creates a flatbuffer message for working set metadata.
-func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *MergeState, rebaseState *RebaseState, meta *WorkingSetMeta) serial.Message {
+func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *MergeState, rebaseState *RebaseState, bisectState *BisectState, meta *WorkingSetMeta) serial.Message {
 	builder := flatbuffers.NewBuilder(1024)
 	workingoff := builder.CreateByteVector(working[:])
-	var stagedOff, mergeStateOff, rebaseStateOffset flatbuffers.UOffsetT
+	var stagedOff, mergeStateOff, rebaseStateOffset, bisectStateOffset flatbuffers.UOffsetT
 	if staged != nil {
 		stagedOff = builder.CreateByteVector((*staged)[:])
 	}
@@ -196,9 +198,26 @@ func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *Mer
 		serial.RebaseStateAddEmptyCommitHandling(builder, rebaseState.emptyCommitHandling)
 		serial.RebaseStateAddLastAttemptedStep(builder, rebaseState.lastAttemptedStep)
 		serial.RebaseStateAddRebasingStarted(builder, rebaseState.rebasingStarted)
+		serial.RebaseStateAddConflictStrategy(builder, rebaseState.conflictStrategy)
 		rebaseStateOffset = serial.RebaseStateEnd(builder)
 	}
 
+	if bisectState != nil {
+		var badAddrOffset flatbuffers.UOffsetT
+		if bisectState.badCommitAddr != nil {
+			badAddrOffset = builder.CreateByteVector((*bisectState.badCommitAddr)[:])
+		}
+		goodAddrsOffset := builder.CreateByteVector(hashesToBytes(bisectState.goodCommitAddrs))
+		skippedAddrsOffset := builder.CreateByteVector(hashesToBytes(bisectState.skippedCommitAddrs))
+		serial.BisectStateStart(builder)
+		if badAddrOffset != 0 {
+			serial.BisectStateAddBadCommitAddr(builder, badAddrOffset)
+		}
+		serial.BisectStateAddGoodCommitAddrs(builder, goodAddrsOffset)
+		serial.BisectStateAddSkippedCommitAddrs(builder, skippedAddrsOffset)
+		bisectStateOffset = serial.BisectStateEnd(builder)
+	}
+
 	var nameOff, emailOff, descOff flatbuffers.UOffsetT
 	if meta != nil {
 		nameOff = builder.CreateString(meta.Name)
@@ -217,6 +236,9 @@ func workingset_flatbuffer(working hash.Hash, staged *hash.Hash, mergeState *Mer
 	if rebaseStateOffset != 0 {
 		serial.WorkingSetAddRebaseState(builder, rebaseStateOffset)
 	}
+	if bisectStateOffset != 0 {
+		serial.WorkingSetAddBisectState(builder, bisectStateOffset)
+	}
 
 	if meta != nil {
 		serial.WorkingSetAddName(builder, nameOff)
@@ -264,7 +286,7 @@ func NewMergeState(
 	}
 }
 
-func NewRebaseState(preRebaseWorkingRoot hash.Hash, commitAddr hash.Hash, branch string, commitBecomesEmptyHandling uint8, emptyCommitHandling uint8, lastAttemptedStep float32, rebasingStarted bool) *RebaseState {
+func NewRebaseState(preRebaseWorkingRoot hash.Hash, commitAddr hash.Hash, branch string, commitBecomesEmptyHandling uint8, emptyCommitHandling uint8, lastAttemptedStep float32, rebasingStarted bool, conflictStrategy uint8) *RebaseState {
 	return &RebaseState{
 		preRebaseWorkingAddr:       &preRebaseWorkingRoot,
 		ontoCommitAddr:             &commitAddr,
@@ -273,7 +295,34 @@ func NewRebaseState(preRebaseWorkingRoot hash.Hash, commitAddr hash.Hash, branch
 		emptyCommitHandling:        emptyCommitHandling,
 		lastAttemptedStep:          lastAttemptedStep,
 		rebasingStarted:            rebasingStarted,
+		conflictStrategy:           conflictStrategy,
+	}
+}
+
+func NewBisectState(badCommitAddr *hash.Hash, goodCommitAddrs []hash.Hash, skippedCommitAddrs []hash.Hash) *BisectState {
+	return &BisectState{
+		badCommitAddr:      badCommitAddr,
+		goodCommitAddrs:    goodCommitAddrs,
+		skippedCommitAddrs: skippedCommitAddrs,
+	}
+}
+
+// hashesToBytes concatenates |hashes| into a single byte slice for serialization.
+func hashesToBytes(hashes []hash.Hash) []byte {
+	bs := make([]byte, 0, len(hashes)*hash.ByteLen)
+	for _, h := range hashes {
+		bs = append(bs, h[:]...)
+	}
+	return bs
+}
+
+// hashesFromBytes splits a byte slice created by hashesToBytes back into hashes.
+func hashesFromBytes(bs []byte) []hash.Hash {
+	hashes := make([]hash.Hash, len(bs)/hash.ByteLen)
+	for i := range hashes {
+		hashes[i] = hash.New(bs[i*hash.ByteLen : (i+1)*hash.ByteLen])
 	}
+	return hashes
 }
 
 func IsWorkingSet(v types.Value) (bool, error) {
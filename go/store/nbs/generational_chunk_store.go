@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -35,8 +36,16 @@ type GenerationalNBS struct {
 	oldGen   *NomsBlockStore
 	newGen   *NomsBlockStore
 	ghostGen *GhostBlockStore
+
+	lazyFetcher atomic.Pointer[LazyFetcher]
+	lazyMu      sync.Mutex
+	lazyFetches atomic.Int64
 }
 
+// LazyFetcher fetches |hashes|, which were left out of a partial clone or fetch, from a remote into |sink|. Lazy
+// chunks are missing from |sink|, rather than being ghosts.
+type LazyFetcher func(ctx context.Context, sink chunks.ChunkStore, hashes hash.HashSet) error
+
 var ErrGhostChunkRequested = errors.New("requested chunk which is expected to be a ghost chunk")
 
 func (gcs *GenerationalNBS) PersistGhostHashes(ctx context.Context, refs hash.HashSet) error {
@@ -50,6 +59,72 @@ func (gcs *GenerationalNBS) GhostGen() chunks.ChunkStore {
 	return gcs.ghostGen
 }
 
+// SetLazyFetcher sets the function used to fetch lazy chunks recorded in the ghost store when they are first read. See
+// GhostBlockStore.AddLazyHashes.
+func (gcs *GenerationalNBS) SetLazyFetcher(fetch LazyFetcher) {
+	gcs.lazyFetcher.Store(&fetch)
+}
+
+// fetchLazyChunks fetches the lazy chunks among |hashes|, along with the rest of their groups. It returns true if
+// chunks which were missing from the new gen may now be found there.
+func (gcs *GenerationalNBS) fetchLazyChunks(ctx context.Context, hashes hash.HashSet) (bool, error) {
+	fetch := gcs.lazyFetcher.Load()
+	if gcs.ghostGen == nil || fetch == nil {
+		return false, nil
+	}
+	if gcs.newGen.gcIsInProgress() || gcs.oldGen.gcIsInProgress() {
+		// fetching into a store being collected would block on the collection. Until it is done, lazy chunks are
+		// read as ghosts, which the collection skips.
+		return false, nil
+	}
+	if gcs.ghostGen.LazyHashes(hashes).Size() == 0 {
+		// the chunks may have been fetched since the new gen was checked for them
+		return gcs.lazyFetches.Load() > 0, nil
+	}
+
+	gcs.lazyMu.Lock()
+	defer gcs.lazyMu.Unlock()
+
+	// some chunks may have been fetched while waiting for the lock
+	absent, err := gcs.newGen.HasMany(ctx, hashes)
+	if err != nil {
+		return false, err
+	}
+	fetched := absent.Size() < hashes.Size()
+
+	toFetch := gcs.ghostGen.LazyHashes(absent)
+	if toFetch.Size() == 0 {
+		return fetched, nil
+	}
+
+	err = (*fetch)(ctx, lazyFetchSink{gcs}, toFetch)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch lazy chunks: %w", err)
+	}
+	gcs.ghostGen.lazy.remove(toFetch)
+	gcs.lazyFetches.Add(1)
+
+	return true, gcs.ghostGen.lazy.persist()
+}
+
+// lazyFetchSink is the store lazy chunks are fetched into. It does not have the chunks in the ghost store.
+type lazyFetchSink struct {
+	*GenerationalNBS
+}
+
+func (s lazyFetchSink) Has(ctx context.Context, h hash.Hash) (bool, error) {
+	absent, err := s.HasMany(ctx, hash.NewHashSet(h))
+	return absent.Size() == 0, err
+}
+
+func (s lazyFetchSink) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
+	absent, err := s.newGen.HasMany(ctx, hashes)
+	if err != nil || absent.Size() == 0 {
+		return absent, err
+	}
+	return s.oldGen.HasMany(ctx, absent)
+}
+
 func NewGenerationalCS(oldGen, newGen *NomsBlockStore, ghostGen *GhostBlockStore) *GenerationalNBS {
 	if oldGen.Version() != "" && oldGen.Version() != newGen.Version() {
 		panic("oldgen and newgen chunkstore versions vary")
@@ -85,6 +160,19 @@ func (gcs *GenerationalNBS) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 		return chunks.EmptyChunk, err
 	}
 
+	if c.IsEmpty() {
+		fetched, err := gcs.fetchLazyChunks(ctx, hash.NewHashSet(h))
+		if err != nil {
+			return chunks.EmptyChunk, err
+		}
+		if fetched {
+			c, err = gcs.newGen.Get(ctx, h)
+			if err != nil {
+				return chunks.EmptyChunk, err
+			}
+		}
+	}
+
 	if c.IsEmpty() && gcs.ghostGen != nil {
 		c, err = gcs.ghostGen.Get(ctx, h)
 		if err != nil {
@@ -116,7 +204,7 @@ func (gcs *GenerationalNBS) GetMany(ctx context.Context, hashes hash.HashSet, fo
 		return nil
 	}
 
-	err = gcs.newGen.GetMany(ctx, notFound, func(ctx context.Context, chunk *chunks.Chunk) {
+	newGenFound := func(ctx context.Context, chunk *chunks.Chunk) {
 		func() {
 			mu.Lock()
 			defer mu.Unlock()
@@ -124,7 +212,8 @@ func (gcs *GenerationalNBS) GetMany(ctx context.Context, hashes hash.HashSet, fo
 		}()
 
 		found(ctx, chunk)
-	})
+	}
+	err = gcs.newGen.GetMany(ctx, notFound.Copy(), newGenFound)
 	if err != nil {
 		return err
 	}
@@ -132,6 +221,20 @@ func (gcs *GenerationalNBS) GetMany(ctx context.Context, hashes hash.HashSet, fo
 		return nil
 	}
 
+	fetched, err := gcs.fetchLazyChunks(ctx, notFound)
+	if err != nil {
+		return err
+	}
+	if fetched {
+		err = gcs.newGen.GetMany(ctx, notFound.Copy(), newGenFound)
+		if err != nil {
+			return err
+		}
+		if len(notFound) == 0 {
+			return nil
+		}
+	}
+
 	// Last ditch effort to see if the requested objects are commits we've decided to ignore. Note the function spec
 	// considers non-present chunks to be silently ignored, so we don't need to return an error here
 	if gcs.ghostGen == nil {
@@ -162,14 +265,15 @@ func (gcs *GenerationalNBS) GetManyCompressed(ctx context.Context, hashes hash.H
 	}
 
 	notFound := notInOldGen.Copy()
-	err = gcs.newGen.GetManyCompressed(ctx, notInOldGen, func(ctx context.Context, chunk CompressedChunk) {
+	newGenFound := func(ctx context.Context, chunk CompressedChunk) {
 		func() {
 			mu.Lock()
 			defer mu.Unlock()
 			delete(notFound, chunk.Hash())
 		}()
 		found(ctx, chunk)
-	})
+	}
+	err = gcs.newGen.GetManyCompressed(ctx, notInOldGen, newGenFound)
 	if err != nil {
 		return err
 	}
@@ -178,6 +282,20 @@ func (gcs *GenerationalNBS) GetManyCompressed(ctx context.Context, hashes hash.H
 		return nil
 	}
 
+	fetched, err := gcs.fetchLazyChunks(ctx, notFound)
+	if err != nil {
+		return err
+	}
+	if fetched {
+		err = gcs.newGen.GetManyCompressed(ctx, notFound.Copy(), newGenFound)
+		if err != nil {
+			return err
+		}
+		if len(notFound) == 0 {
+			return nil
+		}
+	}
+
 	// We are definitely missing some chunks. Check if any are ghost chunks, mainly to give a better error message.
 	if gcs.ghostGen != nil {
 		// If any of the hashes are in the ghost store.
@@ -171,3 +171,73 @@ func TestGenerationalCS(t *testing.T) {
 	putChunks(t, ctx, chnks, cs, inNew, 15, 16, 17, 18, 19)
 	requireChunks(t, ctx, chnks, cs, inOld, inNew)
 }
+
+func TestGenerationalCSLazyChunks(t *testing.T) {
+	ctx := context.Background()
+	oldGen, _, _ := makeTestLocalStore(t, 64)
+	newGen, _, _ := makeTestLocalStore(t, 64)
+	src, _, _ := makeTestLocalStore(t, 64)
+	chnks := genChunks(t, 4, 1000)
+	for _, c := range chnks {
+		require.NoError(t, src.Put(ctx, c, noopGetAddrs))
+	}
+
+	ghostDir := t.TempDir()
+	ghosts, err := NewGhostBlockStore(ghostDir)
+	require.NoError(t, err)
+	err = ghosts.AddLazyHashes(ctx, []hash.HashSet{
+		hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash()),
+		hash.NewHashSet(chnks[2].Hash()),
+	})
+	require.NoError(t, err)
+
+	cs := NewGenerationalCS(oldGen, newGen, ghosts)
+
+	// without a fetcher, lazy chunks are ghosts
+	c, err := cs.Get(ctx, chnks[0].Hash())
+	require.NoError(t, err)
+	require.True(t, c.IsGhost())
+	absent, err := cs.HasMany(ctx, hash.NewHashSet(chnks[0].Hash(), chnks[2].Hash(), chnks[3].Hash()))
+	require.NoError(t, err)
+	require.Equal(t, hash.NewHashSet(chnks[3].Hash()), absent)
+
+	var fetched []hash.HashSet
+	cs.SetLazyFetcher(func(ctx context.Context, sink chunks.ChunkStore, hashes hash.HashSet) error {
+		fetched = append(fetched, hashes)
+		for h := range hashes {
+			c, err := src.Get(ctx, h)
+			if err != nil {
+				return err
+			}
+			if err = sink.Put(ctx, c, noopGetAddrs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// reading a lazy chunk fetches its whole group
+	c, err = cs.Get(ctx, chnks[0].Hash())
+	require.NoError(t, err)
+	require.Equal(t, chnks[0].Data(), c.Data())
+	received := foundHashes{}
+	err = cs.GetMany(ctx, hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash()), received.found)
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+	require.Equal(t, []hash.HashSet{hash.NewHashSet(chnks[0].Hash(), chnks[1].Hash())}, fetched)
+
+	// fetched groups are no longer lazy
+	reloaded, err := NewGhostBlockStore(ghostDir)
+	require.NoError(t, err)
+	require.Equal(t, hash.NewHashSet(chnks[2].Hash()), reloaded.LazyHashes(hashesForChunks(chnks, map[int]bool{0: true, 1: true, 2: true})))
+
+	received = foundHashes{}
+	err = cs.GetMany(ctx, hash.NewHashSet(chnks[2].Hash(), chnks[3].Hash()), received.found)
+	require.NoError(t, err)
+	require.Equal(t, hash.NewHashSet(chnks[2].Hash()), hash.HashSet(received))
+	require.Len(t, fetched, 2)
+
+	reloaded, err = NewGhostBlockStore(ghostDir)
+	require.NoError(t, err)
+	require.Empty(t, reloaded.LazyHashes(hashesForChunks(chnks, map[int]bool{0: true, 1: true, 2: true})))
+}
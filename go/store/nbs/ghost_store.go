@@ -22,6 +22,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -30,6 +32,7 @@ import (
 type GhostBlockStore struct {
 	skippedRefs      *hash.HashSet
 	ghostObjectsFile string
+	lazy             *lazyRefs
 }
 
 // We use the Has, HasMany, Get, GetMany, and PersistGhostHashes methods from the ChunkStore interface. All other methods are not supported.
@@ -39,7 +42,15 @@ var _ chunks.ChunkStore = &GhostBlockStore{}
 // where we will create a text file called ghostObjects.txt. This file will contain the hashes of the ghost objects. Creation
 // and use of this file is constrained to this instance. If there is no ghostObjects.txt file, then the GhostBlockStore will
 // be empty - never returning any values from the Has, HasMany, Get, or GetMany methods.
+//
+// The same directory holds lazyObjects.txt, which lists the chunks left out of a partial clone or fetch. See
+// AddLazyHashes.
 func NewGhostBlockStore(nomsPath string) (*GhostBlockStore, error) {
+	lazy, err := loadLazyRefs(filepath.Join(nomsPath, "lazyObjects.txt"))
+	if err != nil {
+		return nil, err
+	}
+
 	ghostPath := filepath.Join(nomsPath, "ghostObjects.txt")
 	f, err := os.Open(ghostPath)
 	if err != nil {
@@ -47,11 +58,13 @@ func NewGhostBlockStore(nomsPath string) (*GhostBlockStore, error) {
 			return &GhostBlockStore{
 				skippedRefs:      &hash.HashSet{},
 				ghostObjectsFile: ghostPath,
+				lazy:             lazy,
 			}, nil
 		}
 		// Other error, permission denied, etc, we want to hear about.
 		return nil, err
 	}
+	defer f.Close()
 	scanner := bufio.NewScanner(f)
 	skiplist := &hash.HashSet{}
 	for scanner.Scan() {
@@ -66,13 +79,14 @@ func NewGhostBlockStore(nomsPath string) (*GhostBlockStore, error) {
 	return &GhostBlockStore{
 		skippedRefs:      skiplist,
 		ghostObjectsFile: ghostPath,
+		lazy:             lazy,
 	}, nil
 }
 
 // Get returns a ghost chunk if the hash is in the ghostObjectsFile. Otherwise, it returns an empty chunk. Chunks returned
 // by this code will always be ghost chunks, ie chunk.IsGhost() will always return true.
 func (g GhostBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
-	if g.skippedRefs.Has(h) {
+	if g.skippedRefs.Has(h) || g.lazy.has(h) {
 		return *chunks.NewGhostChunk(h), nil
 	}
 	return chunks.EmptyChunk, nil
@@ -80,7 +94,7 @@ func (g GhostBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, er
 
 func (g GhostBlockStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
 	for h := range hashes {
-		if g.skippedRefs.Has(h) {
+		if g.skippedRefs.Has(h) || g.lazy.has(h) {
 			found(ctx, chunks.NewGhostChunk(h))
 		}
 	}
@@ -111,8 +125,22 @@ func (g *GhostBlockStore) PersistGhostHashes(ctx context.Context, hashes hash.Ha
 	return nil
 }
 
+// AddLazyHashes records |groups| of chunks which are missing from the store, but which can be fetched from a remote
+// when they are first read, such as the data of tables left out of a partial clone. Lazy chunks are ghost chunks until
+// they are fetched, and the chunks of a group are fetched together. Chunks which are already lazy are left in the
+// group they were first recorded in.
+func (g *GhostBlockStore) AddLazyHashes(ctx context.Context, groups []hash.HashSet) error {
+	g.lazy.add(groups)
+	return g.lazy.persist()
+}
+
+// LazyHashes returns the chunks of every lazy group which any of |hashes| belongs to.
+func (g *GhostBlockStore) LazyHashes(hashes hash.HashSet) hash.HashSet {
+	return g.lazy.groupsOf(hashes)
+}
+
 func (g GhostBlockStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
-	if g.skippedRefs.Has(h) {
+	if g.skippedRefs.Has(h) || g.lazy.has(h) {
 		return true, nil
 	}
 	return false, nil
@@ -125,7 +153,7 @@ func (g GhostBlockStore) HasMany(ctx context.Context, hashes hash.HashSet) (abse
 func (g GhostBlockStore) hasMany(hashes hash.HashSet) (absent hash.HashSet, err error) {
 	absent = hash.HashSet{}
 	for h := range hashes {
-		if !g.skippedRefs.Has(h) {
+		if !g.skippedRefs.Has(h) && !g.lazy.has(h) {
 			absent.Insert(h)
 		}
 	}
@@ -167,3 +195,147 @@ func (g GhostBlockStore) StatsSummary() string {
 func (g GhostBlockStore) Close() error {
 	panic("GhostBlockStore does not support Close")
 }
+
+// lazyRefs are the chunks left out of a partial clone or fetch. They are persisted in a text file holding one group of
+// chunks, which are fetched together, per line.
+type lazyRefs struct {
+	path    string
+	mu      sync.RWMutex
+	groupOf map[hash.Hash]int
+	groups  map[int]hash.HashSet
+	nextID  int
+}
+
+func loadLazyRefs(path string) (*lazyRefs, error) {
+	l := &lazyRefs{
+		path:    path,
+		groupOf: make(map[hash.Hash]int),
+		groups:  make(map[int]hash.HashSet),
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var groups []hash.HashSet
+	scanner := bufio.NewScanner(f)
+	// a group holds the children of a table's primary index root, so lines can be long
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		group := hash.HashSet{}
+		for _, h := range strings.Fields(scanner.Text()) {
+			if !hash.IsValid(h) {
+				return nil, fmt.Errorf("invalid hash %s in %s", h, filepath.Base(path))
+			}
+			group.Insert(hash.Parse(h))
+		}
+		groups = append(groups, group)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	l.add(groups)
+	return l, nil
+}
+
+func (l *lazyRefs) has(h hash.Hash) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.groupOf[h]
+	return ok
+}
+
+func (l *lazyRefs) add(groups []hash.HashSet) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, group := range groups {
+		members := hash.HashSet{}
+		for h := range group {
+			if _, ok := l.groupOf[h]; !ok {
+				l.groupOf[h] = l.nextID
+				members.Insert(h)
+			}
+		}
+		if members.Size() > 0 {
+			l.groups[l.nextID] = members
+			l.nextID++
+		}
+	}
+}
+
+// groupsOf returns the chunks of every group which any of |hashes| belongs to
+func (l *lazyRefs) groupsOf(hashes hash.HashSet) hash.HashSet {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	ret := hash.HashSet{}
+	for h := range hashes {
+		if id, ok := l.groupOf[h]; ok && !ret.Has(h) {
+			ret.InsertAll(l.groups[id])
+		}
+	}
+	return ret
+}
+
+func (l *lazyRefs) remove(hashes hash.HashSet) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for h := range hashes {
+		id, ok := l.groupOf[h]
+		if !ok {
+			continue
+		}
+		delete(l.groupOf, h)
+		l.groups[id].Remove(h)
+		if l.groups[id].Size() == 0 {
+			delete(l.groups, id)
+		}
+	}
+}
+
+// persist writes the lazy chunks to a temporary file, which then replaces the lazy chunks file
+func (l *lazyRefs) persist() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.groups) == 0 {
+		err := os.Remove(l.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	tmp := l.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, group := range l.groups {
+		sep := ""
+		for h := range group {
+			if _, err = w.WriteString(sep + h.String()); err != nil {
+				f.Close()
+				return err
+			}
+			sep = " "
+		}
+		if err = w.WriteByte('\n'); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path)
+}
@@ -124,6 +124,12 @@ func TestReadRecordRanges(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, data[h], ch2)
 	}
+
+	// every request is marked as found, so that other chunk sources don't report its range again
+	assert.Len(t, ranges, len(data))
+	for _, get := range gets {
+		assert.True(t, get.found)
+	}
 }
 
 func randBuf(n int) (b []byte) {
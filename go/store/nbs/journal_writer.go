@@ -25,6 +25,8 @@ import (
 	"path/filepath"
 	"runtime/trace"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dolthub/swiss"
 	"github.com/sirupsen/logrus"
@@ -455,6 +457,14 @@ func (wr *journalWriter) writeCompressedChunk(ctx context.Context, cc Compressed
 	return nil
 }
 
+var journalSyncs, journalSyncNanos atomic.Uint64
+
+// JournalSyncStats returns the number of times the chunk journals of this process have been synced to disk, and
+// the total time spent syncing them.
+func JournalSyncStats() (syncs uint64, total time.Duration) {
+	return journalSyncs.Load(), time.Duration(journalSyncNanos.Load())
+}
+
 // commitRootHash commits |root| to the journal and syncs the file to disk.
 func (wr *journalWriter) commitRootHash(ctx context.Context, root hash.Hash) error {
 	wr.lock.Lock()
@@ -477,7 +487,10 @@ func (wr *journalWriter) commitRootHashUnlocked(ctx context.Context, root hash.H
 	func() {
 		defer trace.StartRegion(ctx, "sync").End()
 
+		start := time.Now()
 		err = wr.journal.Sync()
+		journalSyncNanos.Add(uint64(time.Since(start)))
+		journalSyncs.Add(1)
 	}()
 	if err != nil {
 		return err
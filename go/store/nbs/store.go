@@ -882,7 +882,7 @@ func (nbs *NomsBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 
 func (nbs *NomsBlockStore) GetMany(ctx context.Context, hashes hash.HashSet, found func(context.Context, *chunks.Chunk)) error {
 	ctx, span := tracer.Start(ctx, "nbs.GetMany", trace.WithAttributes(attribute.Int("num_hashes", len(hashes))))
-	span.End()
+	defer span.End()
 	return nbs.getManyWithFunc(ctx, hashes, func(ctx context.Context, cr chunkReader, eg *errgroup.Group, reqs []getRecord, stats *Stats) (bool, error) {
 		return cr.getMany(ctx, eg, reqs, found, nbs.stats)
 	})
@@ -1450,6 +1450,43 @@ func (nbs *NomsBlockStore) Size(ctx context.Context) (uint64, error) {
 	return size, nil
 }
 
+// JournalSize returns the size, in bytes, of the chunk journal of the store, or 0 if the store has no journal.
+func (nbs *NomsBlockStore) JournalSize() uint64 {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+
+	for _, cs := range nbs.tables.upstream {
+		if _, ok := cs.(journalChunkSource); ok {
+			return cs.currentSize()
+		}
+	}
+	for _, cs := range nbs.tables.novel {
+		if _, ok := cs.(journalChunkSource); ok {
+			return cs.currentSize()
+		}
+	}
+	return 0
+}
+
+// TableFileCount returns the number of table files in the store, not counting the chunk journal.
+func (nbs *NomsBlockStore) TableFileCount() int {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+
+	count := 0
+	for _, cs := range nbs.tables.upstream {
+		if _, ok := cs.(journalChunkSource); !ok {
+			count++
+		}
+	}
+	for _, cs := range nbs.tables.novel {
+		if _, ok := cs.(journalChunkSource); !ok {
+			count++
+		}
+	}
+	return count
+}
+
 func (nbs *NomsBlockStore) chunkSourcesByAddr() (map[hash.Hash]chunkSource, error) {
 	css := make(map[hash.Hash]chunkSource, len(nbs.tables.upstream)+len(nbs.tables.novel))
 	for _, cs := range nbs.tables.upstream {
@@ -1569,6 +1606,13 @@ func (nbs *NomsBlockStore) EndGC() {
 	nbs.cond.Broadcast()
 }
 
+// gcIsInProgress returns whether this store is between a BeginGC and an EndGC call
+func (nbs *NomsBlockStore) gcIsInProgress() bool {
+	nbs.mu.RLock()
+	defer nbs.mu.RUnlock()
+	return nbs.gcInProgress
+}
+
 func (nbs *NomsBlockStore) MarkAndSweepChunks(ctx context.Context, hashes <-chan []hash.Hash, dest chunks.ChunkStore) error {
 	ops := nbs.SupportedOperations()
 	if !ops.CanGC || !ops.CanPrune {
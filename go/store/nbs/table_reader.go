@@ -29,6 +29,8 @@ import (
 	"sort"
 
 	"github.com/golang/snappy"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/store/chunks"
@@ -336,7 +338,12 @@ func (tr tableReader) readAtOffsetsWithCB(
 	readLength := rb.End() - rb.Start()
 	buff := make([]byte, readLength)
 
-	n, err := tr.r.ReadAtWithStats(ctx, buff, int64(rb.Start()), stats)
+	readCtx, span := tracer.Start(ctx, "nbs.tableReader.readAtOffsets", trace.WithAttributes(
+		attribute.Int64("offset", int64(rb.Start())),
+		attribute.Int64("length", int64(readLength)),
+		attribute.Int("num_chunks", len(rb))))
+	n, err := tr.r.ReadAtWithStats(readCtx, buff, int64(rb.Start()), stats)
+	span.End()
 	if err != nil {
 		return err
 	}
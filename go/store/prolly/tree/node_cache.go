@@ -26,6 +26,12 @@ const (
 	stripeMask byte = 0b00011111
 )
 
+// NodeCacheStats returns the number of lookups in the shared node cache which found the node in the
+// cache, |hits|, and which did not, |misses|.
+func NodeCacheStats() (hits, misses uint64) {
+	return sharedCache.stats()
+}
+
 func newChunkCache(maxSize int) (c nodeCache) {
 	sz := maxSize / numStripes
 	for i := range c.stripes {
@@ -43,6 +49,16 @@ func (c nodeCache) get(addr hash.Hash) (Node, bool) {
 	return s.get(addr)
 }
 
+// stats sums the hit and miss counts of the stripes of |c|.
+func (c nodeCache) stats() (hits, misses uint64) {
+	for _, s := range c.stripes {
+		h, m := s.stats()
+		hits += h
+		misses += m
+	}
+	return
+}
+
 func (c nodeCache) insert(addr hash.Hash, node Node) {
 	s := c.stripes[addr[0]&stripeMask]
 	s.insert(addr, node)
@@ -63,6 +79,8 @@ type stripe struct {
 	sz     int
 	maxSz  int
 	rev    int
+	hits   uint64
+	misses uint64
 }
 
 func newStripe(maxSize int) *stripe {
@@ -73,6 +91,8 @@ func newStripe(maxSize int) *stripe {
 		0,
 		maxSize,
 		0,
+		0,
+		0,
 	}
 }
 
@@ -104,12 +124,20 @@ func (s *stripe) get(h hash.Hash) (Node, bool) {
 	defer s.mu.Unlock()
 	if e, ok := s.chunks[h]; ok {
 		s.moveToFront(e)
+		s.hits++
 		return e.n, true
 	} else {
+		s.misses++
 		return Node{}, false
 	}
 }
 
+func (s *stripe) stats() (hits, misses uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits, s.misses
+}
+
 func (s *stripe) insert(addr hash.Hash, node Node) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -577,6 +577,24 @@ func (sm SerialMessage) WalkAddrs(nbf *NomsBinFormat, cb func(addr hash.Hash) er
 				return err
 			}
 		}
+		bisectState, err := msg.TryBisectState(nil)
+		if err != nil {
+			return err
+		}
+		if bisectState != nil {
+			if bisectState.BadCommitAddrLength() != 0 {
+				if err = cb(hash.New(bisectState.BadCommitAddrBytes())); err != nil {
+					return err
+				}
+			}
+			for _, addrs := range [][]byte{bisectState.GoodCommitAddrsBytes(), bisectState.SkippedCommitAddrsBytes()} {
+				for i := 0; i < len(addrs)/hash.ByteLen; i++ {
+					if err = cb(hash.New(addrs[i*hash.ByteLen : (i+1)*hash.ByteLen])); err != nil {
+						return err
+					}
+				}
+			}
+		}
 	case serial.RootValueFileID:
 		var msg serial.RootValue
 		err := serial.InitRootValueRoot(&msg, []byte(sm), serial.MessagePrefixSz)
@@ -563,8 +563,22 @@ func makeBatches(hss []hash.HashSet, count int) [][]hash.Hash {
 	return res
 }
 
-// GC traverses the ValueStore from the root and removes unreferenced chunks from the ChunkStore
-func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepointF func() error) error {
+// GCSafepointController is used by GC to coordinate with the users of a ValueStore which hold references to chunks
+// that aren't reachable from the roots given to GC, such as the uncommitted changes of a SQL session.
+type GCSafepointController interface {
+	// EstablishPreFinalizeSafepoint is called after GC has walked the chunks reachable from the roots it was given,
+	// and before it finalizes the set of chunks it keeps. When it returns, every chunk that is referenced by the
+	// users of the store must have been written to the store, or passed to |keep|, and the users must not take
+	// new references to chunks until the GC is over. |keep| must not be called after this returns.
+	EstablishPreFinalizeSafepoint(ctx context.Context, keep func(hash.Hash) bool) error
+	// EstablishPostFinalizeSafepoint is called after GC has finalized the set of chunks it keeps, and before
+	// the chunks which aren't kept are removed. Returning an error aborts the GC.
+	EstablishPostFinalizeSafepoint(ctx context.Context) error
+}
+
+// GC traverses the ValueStore from the root and removes unreferenced chunks from the ChunkStore. If |safepoint| is
+// non-nil, the chunks it keeps are kept along with the chunks reachable from the root and the given refs.
+func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashSet, safepoint GCSafepointController) error {
 	lvs.versOnce.Do(lvs.expectVersion)
 
 	lvs.transitionToOldGenGC()
@@ -608,7 +622,7 @@ func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashS
 			return err
 		}
 
-		err = lvs.gc(ctx, newGenRefs, oldGen.HasMany, newGen, newGen, safepointF, lvs.transitionToFinalizingGC)
+		err = lvs.gc(ctx, newGenRefs, oldGen.HasMany, newGen, newGen, safepoint, lvs.transitionToFinalizingGC)
 		newGen.EndGC()
 		if err != nil {
 			return err
@@ -638,7 +652,7 @@ func (lvs *ValueStore) GC(ctx context.Context, oldGenRefs, newGenRefs hash.HashS
 
 		newGenRefs.Insert(root)
 
-		err = lvs.gc(ctx, newGenRefs, unfilteredHashFunc, collector, collector, safepointF, lvs.transitionToFinalizingGC)
+		err = lvs.gc(ctx, newGenRefs, unfilteredHashFunc, collector, collector, safepoint, lvs.transitionToFinalizingGC)
 		collector.EndGC()
 		if err != nil {
 			return err
@@ -662,7 +676,7 @@ func (lvs *ValueStore) gc(ctx context.Context,
 	toVisit hash.HashSet,
 	hashFilter HashFilterFunc,
 	src, dest chunks.ChunkStoreGarbageCollector,
-	safepointF func() error,
+	safepoint GCSafepointController,
 	finalize func() hash.HashSet) error {
 	keepChunks := make(chan []hash.Hash, gcBuffSize)
 
@@ -689,7 +703,7 @@ func (lvs *ValueStore) gc(ctx context.Context,
 	eg.Go(func() error {
 		defer walker.Close()
 
-		err := lvs.gcProcessRefs(ctx, toVisit, keepHashes, walker, hashFilter, safepointF, finalize)
+		err := lvs.gcProcessRefs(ctx, toVisit, keepHashes, walker, hashFilter, safepoint, finalize)
 		if err != nil {
 			return err
 		}
@@ -712,7 +726,7 @@ func (lvs *ValueStore) gc(ctx context.Context,
 func (lvs *ValueStore) gcProcessRefs(ctx context.Context,
 	initialToVisit hash.HashSet, keepHashes func(hs []hash.Hash) error,
 	walker *parallelRefWalker, hashFilter HashFilterFunc,
-	safepointF func() error,
+	safepoint GCSafepointController,
 	finalize func() hash.HashSet) error {
 	visited := make(hash.HashSet)
 
@@ -781,6 +795,15 @@ func (lvs *ValueStore) gcProcessRefs(ctx context.Context,
 	// We can accumulate hashes which which are already visited. We prune
 	// those here.
 
+	// The chunks kept by the safepoint are added to NewGenToVisit, so
+	// that they are walked along with the chunks written during the GC.
+	if safepoint != nil {
+		err = safepoint.EstablishPreFinalizeSafepoint(ctx, lvs.gcAddChunk)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Before we call finalize(), we can process the current set of
 	// NewGenToVisit. NewGen -> Finalize is going to block writes until
 	// we are done, so its best to keep it as small as possible.
@@ -819,8 +842,8 @@ func (lvs *ValueStore) gcProcessRefs(ctx context.Context,
 		return err
 	}
 
-	if safepointF != nil {
-		return safepointF()
+	if safepoint != nil {
+		return safepoint.EstablishPostFinalizeSafepoint(ctx)
 	}
 	return nil
 }
@@ -23,6 +23,7 @@ package types
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -209,6 +210,65 @@ func TestGC(t *testing.T) {
 	assert.Nil(v2)
 }
 
+type testGCSafepoint struct {
+	keep          hash.HashSet
+	preFinalized  bool
+	postFinalized bool
+}
+
+func (sp *testGCSafepoint) EstablishPreFinalizeSafepoint(ctx context.Context, keep func(hash.Hash) bool) error {
+	sp.preFinalized = true
+	for h := range sp.keep {
+		if keep(h) {
+			return errors.New("keep called after finalize")
+		}
+	}
+	return nil
+}
+
+func (sp *testGCSafepoint) EstablishPostFinalizeSafepoint(ctx context.Context) error {
+	sp.postFinalized = true
+	return nil
+}
+
+func TestGCSafepointKeepsChunks(t *testing.T) {
+	ctx := context.Background()
+	vs := newTestValueStore()
+	vs.skipWriteCaching = true
+	r1 := mustRef(vs.WriteValue(ctx, String("committed")))
+	r2 := mustRef(vs.WriteValue(ctx, String("held by a session")))
+	r3 := mustRef(vs.WriteValue(ctx, String("unreferenced")))
+	h1 := mustRef(vs.WriteValue(ctx, mustSet(NewSet(ctx, vs, r1)))).TargetHash()
+	h2 := mustRef(vs.WriteValue(ctx, mustSet(NewSet(ctx, vs, r2)))).TargetHash()
+	h3 := mustRef(vs.WriteValue(ctx, mustSet(NewSet(ctx, vs, r3)))).TargetHash()
+
+	rt, err := vs.Root(ctx)
+	require.NoError(t, err)
+	ok, err := vs.Commit(ctx, h1, rt)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	sp := &testGCSafepoint{keep: hash.NewHashSet(h2)}
+	err = vs.GC(ctx, hash.HashSet{}, hash.HashSet{}, sp)
+	require.NoError(t, err)
+	assert.True(t, sp.preFinalized)
+	assert.True(t, sp.postFinalized)
+
+	v, err := vs.ReadValue(ctx, h1)
+	require.NoError(t, err)
+	assert.NotNil(t, v)
+	// The chunks referenced by kept chunks are kept too.
+	v, err = vs.ReadValue(ctx, h2)
+	require.NoError(t, err)
+	assert.NotNil(t, v)
+	v, err = vs.ReadValue(ctx, r2.TargetHash())
+	require.NoError(t, err)
+	assert.NotNil(t, v)
+	v, err = vs.ReadValue(ctx, h3)
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
 type badVersionStore struct {
 	chunks.ChunkStore
 }
@@ -0,0 +1,62 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE table t (pk int primary key, c int);"
+    dolt commit -Am "c1"
+    dolt sql -q "INSERT INTO t VALUES (1, 1);"
+    dolt commit -am "c2"
+    dolt sql -q "INSERT INTO t VALUES (2, -1);"
+    dolt commit -am "c3"
+    dolt sql -q "INSERT INTO t VALUES (3, 3);"
+    dolt commit -am "c4"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "bisect: no bisect in progress errors" {
+    run dolt bisect good
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no bisect in progress" ]] || false
+}
+
+@test "bisect: mark commits manually" {
+    run dolt bisect start
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "waiting for both good and bad commits" ]] || false
+
+    dolt bisect bad HEAD
+    run dolt bisect good HEAD~3
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Bisecting: 1 revision(s) left to test" ]] || false
+    [[ "$output" =~ "c3" ]] || false
+
+    run dolt bisect bad
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c2" ]] || false
+
+    run dolt bisect good
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "is the first bad commit" ]] || false
+    [[ "$output" =~ "c3" ]] || false
+
+    # bisecting never changes the working set
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt bisect reset
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Bisect reset" ]] || false
+}
+
+@test "bisect: run a predicate query" {
+    dolt bisect start HEAD HEAD~3
+    run dolt bisect run "select count(*) > 0 from t where c < 0"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "is the first bad commit" ]] || false
+    [[ "${lines[-1]}" =~ "c3" ]] || false
+}
@@ -21,8 +21,8 @@ setup_test_user() {
 @test "branch-control: fresh database. branch control tables exist" {
       run dolt sql -r csv -q "select * from dolt_branch_control"
       [ $status -eq 0 ]
-      [ ${lines[0]} = "database,branch,user,host,permissions" ]
-      [ ${lines[1]} = "%,%,%,%,write" ]
+      [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+      [ ${lines[1]} = "%,%,%,%,%,%,write" ]
 
       dolt sql -q "select * from dolt_branch_namespace_control"
 
@@ -32,6 +32,8 @@ setup_test_user() {
       [[ $output =~ "branch" ]] || false
       [[ $output =~ "user" ]] || false
       [[ $output =~ "host" ]] || false
+      [[ $output =~ "table" ]] || false
+      [[ $output =~ "column" ]] || false
       [[ $output =~ "permissions" ]] || false
 
       run dolt sql -q "describe dolt_branch_namespace_control"
@@ -47,26 +49,26 @@ setup_test_user() {
 
     run dolt sql --result-format csv -q "select * from dolt_branch_control"
     [ $status -eq 0 ]
-    [ ${lines[0]} = "database,branch,user,host,permissions" ]
-    [ ${lines[1]} = "%,%,%,%,write" ]
+    [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+    [ ${lines[1]} = "%,%,%,%,%,%,write" ]
 
     dolt sql -q "select * from dolt_branch_namespace_control"
 }
 
 @test "branch-control: modify dolt_branch_control from dolt sql then make sure changes are reflected" {
     setup_test_user
-    dolt sql -q "insert into dolt_branch_control values ('test-db','test-branch', 'test', '%', 'write')"
+    dolt sql -q "insert into dolt_branch_control values ('test-db', 'test-branch', 'test', '%', '%', '%', 'write')"
 
     run dolt sql -r csv -q "select * from dolt_branch_control"
     [ $status -eq 0 ]
-    [ ${lines[0]} = "database,branch,user,host,permissions" ]
-    [ ${lines[1]} = "test-db,test-branch,test,%,write" ]
+    [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+    [ ${lines[1]} = "test-db,test-branch,test,%,%,%,write" ]
 
     start_sql_server
     run dolt sql --result-format csv -q "select * from dolt_branch_control"
     [ $status -eq 0 ]
-    [ ${lines[0]} = "database,branch,user,host,permissions" ]
-    [ ${lines[1]} = "test-db,test-branch,test,%,write" ]
+    [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+    [ ${lines[1]} = "test-db,test-branch,test,%,%,%,write" ]
 }
 
 @test "branch-control: default user root works as expected" {
@@ -79,8 +81,8 @@ setup_test_user() {
     sleep 5 # not using python wait so this works on windows
 
     run dolt sql --result-format csv -q "select * from dolt_branch_control"
-    [ ${lines[0]} = "database,branch,user,host,permissions" ]
-    [ ${lines[1]} = "%,%,%,%,write" ]
+    [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+    [ ${lines[1]} = "%,%,%,%,%,%,write" ]
 
     dolt sql -q "delete from dolt_branch_control where user='%'"
      
@@ -92,7 +94,7 @@ setup_test_user() {
 @test "branch-control: test basic branch write permissions" {
     setup_test_user
 
-    dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'test-branch', 'test', '%', 'write')"
+    dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'test-branch', 'test', '%', '%', '%', 'write')"
     dolt branch test-branch
     
     start_sql_server
@@ -120,7 +122,7 @@ setup_test_user() {
     dolt sql -q "create user test2"
     dolt sql -q "grant all on *.* to test2"
 
-    dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'test-branch', 'test', '%', 'admin')"
+    dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'test-branch', 'test', '%', '%', '%', 'admin')"
     dolt branch test-branch
 
     start_sql_server
@@ -134,21 +136,21 @@ setup_test_user() {
     dolt -u test sql -q "call dolt_checkout('test-branch'); create table t (c1 int)"
 
     # Admin can make other users
-    dolt -u test sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'test-branch', 'test2', '%', 'write')"
+    dolt -u test sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'test-branch', 'test2', '%', '%', '%', 'write')"
     run dolt -u test sql --result-format csv -q "select * from dolt_branch_control"
     [ $status -eq 0 ]
-    [ ${lines[0]} = "database,branch,user,host,permissions" ]
-    [ ${lines[1]} = "dolt-repo-$$,test-branch,test,%,admin" ]
-    [ ${lines[2]} = "dolt-repo-$$,test-branch,root,localhost,admin" ]
-    [ ${lines[3]} = "dolt-repo-$$,test-branch,test2,%,write" ]
+    [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+    [ ${lines[1]} = "dolt-repo-$$,test-branch,test,%,%,%,admin" ]
+    [ ${lines[2]} = "dolt-repo-$$,test-branch,root,localhost,%,%,admin" ]
+    [ ${lines[3]} = "dolt-repo-$$,test-branch,test2,%,%,%,write" ]
 
     # test2 can see all branch permissions
     run dolt -u test2 sql --result-format csv -q "select * from dolt_branch_control"
     [ $status -eq 0 ]
-    [ ${lines[0]} = "database,branch,user,host,permissions" ]
-    [ ${lines[1]} = "dolt-repo-$$,test-branch,test,%,admin" ]
-    [ ${lines[2]} = "dolt-repo-$$,test-branch,root,localhost,admin" ]
-    [ ${lines[3]} = "dolt-repo-$$,test-branch,test2,%,write" ]
+    [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+    [ ${lines[1]} = "dolt-repo-$$,test-branch,test,%,%,%,admin" ]
+    [ ${lines[2]} = "dolt-repo-$$,test-branch,root,localhost,%,%,admin" ]
+    [ ${lines[3]} = "dolt-repo-$$,test-branch,test2,%,%,%,write" ]
 
     # test2 now has write permissions on test-branch
     dolt -u test2 sql -q "call dolt_checkout('test-branch'); insert into t values(0)"
@@ -158,8 +160,8 @@ setup_test_user() {
 
     run dolt -u test sql --result-format csv -q "select * from dolt_branch_control"
     [ $status -eq 0 ]
-    [ ${lines[0]} = "database,branch,user,host,permissions" ]
-    [ ${lines[1]} = "dolt-repo-$$,test-branch,test,%,admin" ]
+    [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+    [ ${lines[1]} = "dolt-repo-$$,test-branch,test,%,%,%,admin" ]
 
     # test2 cannot write to branch
     run dolt -u test2 sql -q "call dolt_checkout('test-branch'); insert into t values(1)"
@@ -170,7 +172,7 @@ setup_test_user() {
 @test "branch-control: creating a branch grants admin permissions" {
     setup_test_user
 
-    dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'main', 'test', '%', 'write')"
+    dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'main', 'test', '%', '%', '%', 'write')"
 
     start_sql_server
 
@@ -178,9 +180,9 @@ setup_test_user() {
 
     run dolt -u test sql --result-format csv -q "select * from dolt_branch_control"
     [ $status -eq 0 ]
-    [ ${lines[0]} = "database,branch,user,host,permissions" ]
-    [ ${lines[1]} = "dolt-repo-$$,main,test,%,write" ]
-    [ ${lines[2]} = "dolt-repo-$$,test-branch,test,%,admin" ]
+    [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+    [ ${lines[1]} = "dolt-repo-$$,main,test,%,%,%,write" ]
+    [ ${lines[2]} = "dolt-repo-$$,test-branch,test,%,%,%,admin" ]
 }
 
 @test "branch-control: test branch namespace control" {
@@ -190,7 +192,7 @@ setup_test_user() {
     dolt sql -q "grant all on *.* to test2"
 
     dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'test-
-branch', 'test', '%', 'admin')"
+branch', 'test', '%', '%', '%', 'admin')"
     dolt sql -q "insert into dolt_branch_namespace_control values ('dolt-repo-$$', 'test-%', 'test2', '%')"
 
     start_sql_server
@@ -236,10 +238,10 @@ branch', 'test', '%', 'admin')"
   setup_test_user
   dolt sql -q "create user admin"
   dolt sql -q "grant all on *.* to admin"
-  dolt sql -q "insert into dolt_branch_control values ('%', '%', 'admin', '%', 'admin')"
+  dolt sql -q "insert into dolt_branch_control values ('%', '%', 'admin', '%', '%', '%', 'admin')"
 
-  dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'test-branch', 'test', '%', 'read')"
-  dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', '%', 'test', '%', 'write')"
+  dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'test-branch', 'test', '%', '%', '%', 'read')"
+  dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', '%', 'test', '%', '%', '%', 'write')"
   dolt branch test-branch
 
   start_sql_server
@@ -258,28 +260,28 @@ branch', 'test', '%', 'admin')"
 @test "branch-control: repeat deletion does not cause a nil panic" {
   dolt sql <<SQL
 DELETE FROM dolt_branch_control;
-INSERT INTO dolt_branch_control VALUES ("dolt","s1","ab","%","admin");
-INSERT INTO dolt_branch_control VALUES ("dolt","s2","ab","%","admin");
-INSERT INTO dolt_branch_control VALUES ("%","%","%","%","write");
+INSERT INTO dolt_branch_control VALUES ("dolt","s1","ab","%","%","%","admin");
+INSERT INTO dolt_branch_control VALUES ("dolt","s2","ab","%","%","%","admin");
+INSERT INTO dolt_branch_control VALUES ("%","%","%","%","%","%","write");
 DELETE FROM dolt_branch_control;
-INSERT INTO dolt_branch_control VALUES ("dolt","s1","ab","%","admin");
-INSERT INTO dolt_branch_control VALUES ("dolt","s2","ab","%","admin");
-INSERT INTO dolt_branch_control VALUES ("%","%","%","%","write");
+INSERT INTO dolt_branch_control VALUES ("dolt","s1","ab","%","%","%","admin");
+INSERT INTO dolt_branch_control VALUES ("dolt","s2","ab","%","%","%","admin");
+INSERT INTO dolt_branch_control VALUES ("%","%","%","%","%","%","write");
 DELETE FROM dolt_branch_control;
-INSERT INTO dolt_branch_control VALUES ("dolt","s1","ab","%","admin");
-INSERT INTO dolt_branch_control VALUES ("dolt","s2","ab","%","admin");
-INSERT INTO dolt_branch_control VALUES ("%","%","%","%","write");
+INSERT INTO dolt_branch_control VALUES ("dolt","s1","ab","%","%","%","admin");
+INSERT INTO dolt_branch_control VALUES ("dolt","s2","ab","%","%","%","admin");
+INSERT INTO dolt_branch_control VALUES ("%","%","%","%","%","%","write");
 DELETE FROM dolt_branch_control;
-INSERT INTO dolt_branch_control VALUES ("dolt","s1","ab","%","admin");
-INSERT INTO dolt_branch_control VALUES ("dolt","s2","ab","%","admin");
-INSERT INTO dolt_branch_control VALUES ("%","%","%","%","write");
+INSERT INTO dolt_branch_control VALUES ("dolt","s1","ab","%","%","%","admin");
+INSERT INTO dolt_branch_control VALUES ("dolt","s2","ab","%","%","%","admin");
+INSERT INTO dolt_branch_control VALUES ("%","%","%","%","%","%","write");
 SQL
   run dolt sql -q "SELECT * FROM dolt_branch_control ORDER BY 1,2,3" -r=csv
   [ $status -eq 0 ]
-  [ ${lines[0]} = "database,branch,user,host,permissions" ]
-  [ ${lines[1]} = "%,%,%,%,write" ]
-  [ ${lines[2]} = "dolt,s1,ab,%,admin" ]
-  [ ${lines[3]} = "dolt,s2,ab,%,admin" ]
+  [ ${lines[0]} = "database,branch,user,host,table,column,permissions" ]
+  [ ${lines[1]} = "%,%,%,%,%,%,write" ]
+  [ ${lines[2]} = "dolt,s1,ab,%,%,%,admin" ]
+  [ ${lines[3]} = "dolt,s2,ab,%,%,%,admin" ]
 
   # Related to the above issue, multiple deletions would report matches even when they should have all been deleted
   run dolt sql -q "DELETE FROM dolt_branch_control;"
@@ -295,3 +297,65 @@ SQL
   [ $status -eq 0 ]
   [[ $output =~ "0 rows affected" ]] || false
 }
+
+@test "branch-control: table and column scoped permissions" {
+    dolt sql -q "create table orders_1 (pk int primary key)"
+    dolt sql -q "create table customers (pk int primary key, name varchar(20), ssn varchar(11))"
+    dolt sql -q "insert into customers values (1, 'alice', '123-45-6789')"
+    dolt commit -Am "create tables"
+    dolt branch release1
+    setup_test_user
+
+    dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'main', 'test', '%', 'orders_%', '%', 'write')"
+    dolt sql -q "insert into dolt_branch_control values ('dolt-repo-$$', 'release%', '%', '%', '%', 'ssn', '')"
+
+    start_sql_server
+
+    dolt -u test sql -q "insert into orders_1 values (1)"
+
+    run dolt -u test sql -q "insert into customers values (2, 'bob', '987-65-4321')"
+    [ $status -ne 0 ]
+    [[ $output =~ "does not have the correct permissions on table \`customers\`" ]] || false
+
+    run dolt -u test sql -r csv -q "call dolt_checkout('release1'); select pk, name from customers"
+    [ $status -eq 0 ]
+    [[ $output =~ "1,alice" ]] || false
+
+    run dolt -u test sql -q "call dolt_checkout('release1'); select * from customers"
+    [ $status -ne 0 ]
+    [[ $output =~ "does not have the correct permissions on column \`ssn\`" ]] || false
+}
+
+@test "branch-control: branch protection rules" {
+    dolt sql -q "create table t (pk int primary key)"
+    dolt commit -Am "create table"
+    dolt branch feature
+    dolt sql -q "insert into t values (1)"
+    dolt commit -am "insert"
+    dolt sql -q "insert into dolt_branch_protection values ('dolt-repo-$$', 'main', true)"
+
+    run dolt sql -r csv -q "select * from dolt_branch_protection"
+    [ $status -eq 0 ]
+    [[ $output =~ "dolt-repo-$$,main,1" ]] || false
+
+    start_sql_server
+
+    run dolt sql -q "call dolt_reset('--hard', 'HEAD~1')"
+    [ $status -ne 0 ]
+    [[ $output =~ "branch \`main\` is protected, which does not allow rewriting its history" ]] || false
+
+    run dolt sql -q "call dolt_branch('-D', 'main')"
+    [ $status -ne 0 ]
+    [[ $output =~ "branch \`main\` is protected, which does not allow deleting it" ]] || false
+
+    run dolt sql -q "insert into t values (2); call dolt_commit('-am', 'direct')"
+    [ $status -ne 0 ]
+    [[ $output =~ "branch \`main\` is protected, which does not allow committing to it directly" ]] || false
+
+    dolt sql -q "call dolt_checkout('feature'); insert into t values (3); call dolt_commit('-am', 'feature')"
+    dolt sql -q "call dolt_merge('feature', '--no-ff', '-m', 'merge feature')"
+
+    run dolt sql -r csv -q "select message from dolt_log limit 1"
+    [ $status -eq 0 ]
+    [[ $output =~ "merge feature" ]] || false
+}
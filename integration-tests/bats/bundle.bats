@@ -0,0 +1,109 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+
+    dolt sql -q "CREATE TABLE test (pk BIGINT PRIMARY KEY, c1 BIGINT);"
+    dolt sql -q "INSERT INTO test VALUES (0, 0);"
+    dolt add test
+    dolt commit -m "test commit"
+    dolt tag v1
+    dolt branch feature
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "bundle: clone a bundle" {
+    dolt bundle create db.bundle main feature v1
+    [ -f db.bundle ]
+
+    cd dolt-repo-clones
+    dolt clone ../db.bundle
+    cd db
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remotes/origin/main" ]] || false
+    [[ "$output" =~ "remotes/origin/feature" ]] || false
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+    run dolt sql -q "select c1 from test where pk = 0" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+    run dolt remote -v
+    [[ "$output" =~ "bundle://" ]] || false
+}
+
+@test "bundle: fetch an incremental bundle" {
+    dolt bundle create full.bundle main
+    cd dolt-repo-clones
+    dolt clone ../full.bundle test-repo
+    cd ../
+
+    dolt sql -q "INSERT INTO test VALUES (1, 1);"
+    dolt commit -am "second commit"
+    dolt bundle create inc.bundle main --basis v1
+    [ $(stat -c %s inc.bundle 2>/dev/null || stat -f %z inc.bundle) -lt $(stat -c %s full.bundle 2>/dev/null || stat -f %z full.bundle) ]
+
+    cd dolt-repo-clones/test-repo
+    dolt fetch ../../inc.bundle
+    run dolt branch -a
+    [[ "$output" =~ "remotes/inc/main" ]] || false
+    dolt merge inc/main
+    run dolt sql -q "select count(*) from test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    # a bundle added as a remote is fetched like any other remote
+    dolt remote add incremental ../../inc.bundle
+    dolt fetch incremental
+    run dolt branch -a
+    [[ "$output" =~ "remotes/incremental/main" ]] || false
+}
+
+@test "bundle: bundle prerequisites must be present" {
+    dolt sql -q "INSERT INTO test VALUES (1, 1);"
+    dolt commit -am "second commit"
+    dolt bundle create inc.bundle main --basis v1
+
+    cd dolt-repo-clones
+    run dolt clone ../inc.bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "bundle requires commits which are not in this database" ]] || false
+    [ ! -d inc ]
+
+    mkdir other && cd other
+    dolt init
+    run dolt fetch ../../inc.bundle
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "bundle requires commits which are not in this database" ]] || false
+}
+
+@test "bundle: bundles are read only" {
+    dolt bundle create db.bundle main
+    dolt remote add bundle db.bundle
+    run dolt push bundle main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "read only" ]] || false
+}
+
+@test "bundle: create errors" {
+    run dolt bundle create db.bundle
+    [ "$status" -ne 0 ]
+
+    run dolt bundle create db.bundle not-a-branch
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "'not-a-branch' is not a branch or tag" ]] || false
+    [ ! -f db.bundle ]
+
+    run dolt bundle create db.bundle main --basis not-a-commit
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid basis" ]] || false
+}
@@ -761,6 +761,117 @@ SQL
     [ ! -f dumps/warehouse.json ]
 }
 
+@test "dump: JSONL type - compare tables in database with tables imported from corresponding files" {
+    create_tables
+
+    dolt add .
+    dolt commit -m "create tables"
+
+    dolt branch new_branch
+
+    insert_data_into_tables
+
+    dolt add .
+    dolt commit -m "insert to tables"
+
+    run dolt dump -r jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    check_for_files "jsonl"
+
+    run head -n 1 doltdump/warehouse.jsonl
+    [ "$output" = '{"warehouse_id":1,"warehouse_name":"UPS"}' ]
+
+    run dolt dump -r jsonl
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    dolt checkout new_branch
+
+    import_tables "jsonl"
+    dolt add .
+    dolt commit --allow-empty -m "create tables from doltdump"
+
+    run dolt diff --stat main new_branch
+    [ "$status" -eq 0 ]
+    [[ "$output" = "" ]] || false
+}
+
+@test "dump: Arrow type - compare tables in database with tables imported from corresponding files" {
+    create_tables
+
+    dolt add .
+    dolt commit -m "create tables"
+
+    dolt branch new_branch
+
+    insert_data_into_tables
+
+    dolt add .
+    dolt commit -m "insert to tables"
+
+    run dolt dump -r arrow
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    check_for_files "arrow"
+
+    run dolt dump -r arrow
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already exists" ]] || false
+
+    dolt checkout new_branch
+
+    import_tables "arrow"
+    dolt add .
+    dolt commit --allow-empty -m "create tables from doltdump"
+
+    run dolt diff --stat main new_branch
+    [ "$status" -eq 0 ]
+    [[ "$output" = "" ]] || false
+}
+
+@test "dump: XLSX type - with multiple tables and check -f flag" {
+    dolt sql -q "CREATE TABLE new_table(pk int primary key);"
+    dolt sql -q "INSERT INTO new_table VALUES (1);"
+    dolt sql -q "CREATE TABLE warehouse(warehouse_id int primary key, warehouse_name longtext);"
+    dolt sql -q "INSERT into warehouse VALUES (1, 'UPS'), (2, 'TV'), (3, 'Table');"
+
+    run dolt dump -r xlsx
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    [ -f doltdump.xlsx ]
+
+    run dolt dump -r xlsx
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "doltdump.xlsx already exists" ]] || false
+
+    run dolt dump -f -r xlsx
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+
+    dolt sql -q "DELETE FROM warehouse;"
+    dolt table import -u warehouse doltdump.xlsx
+    run dolt sql -q "SELECT count(*) FROM warehouse;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "dump: XLSX type - with filename given" {
+    dolt sql -q "CREATE TABLE new_table(pk int primary key);"
+    run dolt dump -r xlsx --file-name dumpfile
+    [ "$status" -eq 0 ]
+    [ -f dumpfile.xlsx ]
+    [ ! -f doltdump.xlsx ]
+}
+
+@test "dump: XLSX type - with directory name given" {
+    dolt sql -q "CREATE TABLE new_table(pk int primary key);"
+    run dolt dump -r xlsx -d dumps
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "directory is not supported for xlsx exports" ]] || false
+    [ ! -d dumps ]
+}
+
 @test "dump: dump with schema-only flag" {
     dolt sql -q "CREATE TABLE new_table(pk int primary key);"
     dolt sql -q "INSERT INTO new_table VALUES (1), (2);"
@@ -479,6 +479,88 @@ SQL
     [[ "$output" =~ "5235.66789" ]] || false
 }
 
+@test "export-tables: round trip typed columns to and from arrow files and streams" {
+    dolt sql -q "CREATE TABLE t (pk int primary key, d DECIMAL(9,5), dt DATETIME(6), j JSON, tm TIME, s VARCHAR(20));"
+    dolt sql -q "INSERT INTO t VALUES (1, 1234.56789, '2024-01-02 03:04:05.678901', '{\"a\": [1, 2]}', '-12:34:56', 'row1'), (2, NULL, NULL, NULL, NULL, NULL);"
+
+    run dolt table export t t.arrow
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    [ -f t.arrow ]
+
+    run dolt table export t --file-type arrow
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Cannot export this format to stdout" ]] || false
+
+    dolt table export t --file-type arrows > t.arrows
+
+    dolt table import -c --pk pk t2 t.arrow
+    run dolt sql -q "SELECT count(*) FROM (SELECT * FROM t EXCEPT SELECT * FROM t2) x" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+
+    run dolt sql -q "SHOW CREATE TABLE t2"
+    [[ "$output" =~ '`d` decimal(9,5)' ]] || false
+    [[ "$output" =~ '`dt` datetime(6)' ]] || false
+    [[ "$output" =~ '`j` json' ]] || false
+
+    dolt table import -c --pk pk --file-type arrows t3 < t.arrows
+    run dolt sql -q "SELECT count(*) FROM (SELECT * FROM t EXCEPT SELECT * FROM t3) x" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+
+    dolt sql -q "DELETE FROM t"
+    dolt table import -u t t.arrows
+    run dolt sql -q "SELECT pk, d, j FROM t ORDER BY pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = '1,1234.56789,"{""a"":[1,2]}"' ]
+    [ "${lines[2]}" = "2,," ]
+}
+
+@test "export-tables: table export and import of jsonl files and streams" {
+    dolt sql -q "insert into test_int values (0, 1, 2, 3, 4, 5), (1, NULL, 2, 3, 4, 5)"
+
+    run dolt table export test_int export.jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    run cat export.jsonl
+    [ "${#lines[@]}" -eq 2 ]
+    [ "${lines[0]}" = '{"c1":1,"c2":2,"c3":3,"c4":4,"c5":5,"pk":0}' ]
+    [ "${lines[1]}" = '{"c2":2,"c3":3,"c4":4,"c5":5,"pk":1}' ]
+
+    run dolt table export test_int --file-type jsonl
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '{"c1":1,"c2":2,"c3":3,"c4":4,"c5":5,"pk":0}' ]] || false
+
+    dolt sql -q "delete from test_int"
+    cat export.jsonl | dolt table import -u test_int --file-type ndjson
+    run dolt sql -q "select * from test_int order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0,1,2,3,4,5" ]
+    [ "${lines[2]}" = "1,,2,3,4,5" ]
+
+    run dolt table import -c test_int2 export.jsonl
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Please specify schema file for .jsonl tables." ]] || false
+}
+
+@test "export-tables: round trip dates, decimals and nulls to and from xlsx" {
+    dolt sql -q "CREATE TABLE t (pk int primary key, d DECIMAL(9,5), dt DATE, ts DATETIME);"
+    dolt sql -q "INSERT INTO t VALUES (1, 1234.56789, '2024-01-02', '2024-01-02 03:04:05'), (2, NULL, NULL, NULL);"
+
+    run dolt table export t t.xlsx
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully exported data." ]] || false
+    [ -f t.xlsx ]
+
+    dolt sql -q "delete from t where true"
+    dolt table import -u t t.xlsx
+    run dolt sql -q "SELECT * FROM t order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,1234.56789,2024-01-02,2024-01-02 03:04:05" ]] || false
+    [[ "$output" =~ "2,,," ]] || false
+}
+
 @test "export-tables: table export to sql with null values in different sql types" {
     dolt sql <<SQL
 CREATE TABLE s (stringVal VARCHAR(6));
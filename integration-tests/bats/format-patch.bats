@@ -0,0 +1,166 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql <<SQL
+CREATE TABLE test(pk BIGINT PRIMARY KEY, v varchar(20));
+CREATE TABLE keyless(a int, b varchar(10));
+INSERT INTO test VALUES (1, 'a'), (2, 'b');
+INSERT INTO keyless VALUES (1, 'x'), (1, 'x');
+SQL
+    dolt add .
+    dolt commit -am "Created tables"
+    dolt checkout -b branch1
+    dolt sql -q "UPDATE test SET v = 'it''s a' WHERE pk = 1; DELETE FROM test WHERE pk = 2; INSERT INTO test VALUES (3, 'c')"
+    dolt sql -q "DELETE FROM keyless LIMIT 1; INSERT INTO keyless VALUES (2, 'y')"
+    dolt commit -am $'Changed rows\n\nwith a longer description'
+    dolt sql -q "ALTER TABLE test ADD COLUMN w int; CREATE TABLE other (pk int PRIMARY KEY, t bigint, FOREIGN KEY (t) REFERENCES test(pk))"
+    dolt sql -q "INSERT INTO test VALUES (4, 'd', 4); INSERT INTO other VALUES (1, 4)"
+    dolt add .
+    dolt commit -m "Added column and table"
+    dolt checkout main
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "format-patch: writes a patch file for each commit" {
+    run dolt format-patch main..branch1 -o patches
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "patches/0001-changed-rows.patch" ]] || false
+    [[ "$output" =~ "patches/0002-added-column-and-table.patch" ]] || false
+
+    run cat patches/0001-changed-rows.patch
+    [[ "$output" =~ "Author: Bats Tests <bats@email.fake>" ]] || false
+    [[ "$output" =~ "    with a longer description" ]] || false
+    [[ "$output" =~ "diff --dolt a/test b/test" ]] || false
+    [[ "$output" =~ "-(1,'a')" ]] || false
+    [[ "$output" =~ "+(1,'it\'s a')" ]] || false
+    [[ "$output" =~ "-(2,'b')" ]] || false
+    [[ "$output" =~ "+(3,'c')" ]] || false
+
+    run cat patches/0002-added-column-and-table.patch
+    [[ "$output" =~ "--- /dev/null" ]] || false
+    [[ "$output" =~ "+++ b/other" ]] || false
+    [[ "$output" =~ "CREATE TABLE \`other\`" ]] || false
+    [[ "$output" =~ "ADD \`w\` int" ]] || false
+    [[ "$output" =~ "+(4,'d',4)" ]] || false
+}
+
+@test "format-patch: --stdout and -n" {
+    run dolt format-patch -n 1 --stdout branch1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Added column and table" ]] || false
+    [[ ! "$output" =~ "Changed rows" ]] || false
+
+    run dolt format-patch --stdout main..branch1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Added column and table" ]] || false
+    [[ "$output" =~ "Changed rows" ]] || false
+
+    run dolt format-patch main..branch1 -n 1
+    [ "$status" -eq 1 ]
+}
+
+@test "format-patch: am applies patches as commits" {
+    dolt format-patch main..branch1 -o patches
+
+    run dolt am patches/0001-changed-rows.patch patches/0002-added-column-and-table.patch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applying: Changed rows" ]] || false
+    [[ "$output" =~ "Applying: Added column and table" ]] || false
+
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+
+    run dolt log -n 2
+    [[ "$output" =~ "Added column and table" ]] || false
+    [[ "$output" =~ "with a longer description" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_diff_stat('branch1', 'main')" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+}
+
+@test "format-patch: am applies patches to another database" {
+    dolt format-patch main..branch1 --stdout > ../all.patch
+    mkdir ../other-db
+    cp -r .dolt ../other-db/
+    cd ../other-db
+    dolt branch -D branch1
+
+    run dolt am < ../all.patch
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [[ "$output" =~ "1,it's a," ]] || false
+    [[ ! "$output" =~ "2,b" ]] || false
+    [[ "$output" =~ "3,c," ]] || false
+    [[ "$output" =~ "4,d,4" ]] || false
+
+    run dolt sql -q "SELECT a, b, count(*) FROM keyless GROUP BY a, b ORDER BY a" -r csv
+    [[ "$output" =~ "1,x,1" ]] || false
+    [[ "$output" =~ "2,y,1" ]] || false
+
+    run dolt sql -q "SELECT * FROM other" -r csv
+    [[ "$output" =~ "1,4" ]] || false
+}
+
+@test "format-patch: am requires a clean working set" {
+    dolt format-patch main..branch1 -o patches
+    dolt sql -q "INSERT INTO test VALUES (10, 'z')"
+
+    run dolt am patches/0001-changed-rows.patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "local changes" ]] || false
+}
+
+@test "format-patch: am reports conflicts" {
+    dolt format-patch main..branch1 -o patches
+    dolt sql -q "UPDATE test SET v = 'mine' WHERE pk = 1"
+    dolt commit -am "Changed row 1"
+
+    run dolt am patches/0001-changed-rows.patch patches/0002-added-column-and-table.patch
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "CONFLICT: test" ]] || false
+    [[ ! "$output" =~ "Applying: Added column and table" ]] || false
+
+    run dolt conflicts cat test
+    [[ "$output" =~ "mine" ]] || false
+    [[ "$output" =~ "it's a" ]] || false
+
+    dolt merge --abort
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [[ "$output" =~ "1,mine" ]] || false
+    [[ "$output" =~ "2,b" ]] || false
+
+    dolt am patches/0001-changed-rows.patch || true
+    dolt conflicts resolve --theirs test
+    dolt add .
+    dolt commit -m "Changed rows"
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [[ "$output" =~ "1,it's a" ]] || false
+    [[ ! "$output" =~ "2,b" ]] || false
+
+    run dolt am patches/0002-added-column-and-table.patch
+    [ "$status" -eq 0 ]
+}
+
+@test "format-patch: apply changes the working set" {
+    dolt format-patch main..branch1 -o patches
+
+    run dolt apply patches/0001-changed-rows.patch
+    [ "$status" -eq 0 ]
+
+    run dolt status
+    [[ "$output" =~ "Changes not staged for commit" ]] || false
+    [[ "$output" =~ "modified:         test" ]] || false
+
+    run dolt sql -q "SELECT * FROM test ORDER BY pk" -r csv
+    [[ "$output" =~ "1,it's a" ]] || false
+    [[ "$output" =~ "3,c" ]] || false
+}
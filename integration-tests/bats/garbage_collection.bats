@@ -85,6 +85,56 @@ CALL dolt_gc();
 SQL
 }
 
+@test "garbage_collection: session is usable after GC in sql script" {
+    dolt sql <<SQL
+CREATE TABLE t (pk int primary key, val text);
+INSERT INTO t VALUES (1, 'one');
+CALL dolt_commit('-Am', 'new table with one row');
+SQL
+    run dolt sql -r csv <<SQL
+CREATE TEMPORARY TABLE tmp (pk int primary key);
+INSERT INTO tmp VALUES (1), (2);
+INSERT INTO t VALUES (2, 'two');
+START TRANSACTION;
+INSERT INTO t VALUES (3, 'three');
+CALL dolt_gc();
+SELECT count(*) AS tmp_count FROM tmp;
+COMMIT;
+SELECT group_concat(val ORDER BY pk) AS vals FROM t;
+CALL dolt_commit('-am', 'added rows');
+SQL
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "tmp_count" ]] || false
+    [[ "$output" =~ "one,two,three" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+    run dolt status
+    [[ "$output" =~ "working tree clean" ]] || false
+}
+
+@test "garbage_collection: dolt_gc_status reports the last GC" {
+    dolt sql <<SQL
+CREATE TABLE t (pk int primary key);
+INSERT INTO t VALUES (1);
+CALL dolt_commit('-Am', 'new table');
+SQL
+    run dolt sql -r csv -q "SELECT running, phase, runs FROM dolt_gc_status"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "false,,0" ]] || false
+
+    run dolt sql -r csv <<SQL
+CALL dolt_gc();
+SELECT running, phase, kind, reason, error, runs, journal_size FROM dolt_gc_status;
+CALL dolt_gc('--shallow');
+SELECT running, phase, kind, reason, error, runs FROM dolt_gc_status;
+SQL
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "false,done,full,dolt_gc,,1,0" ]] || false
+    [[ "$output" =~ "false,done,shallow,dolt_gc,,2" ]] || false
+}
+
 @test "garbage_collection: blob types work after GC" {
     dolt sql -q "create table t(pk int primary key, val text)"
     dolt sql -q "insert into t values (1, 'one'), (2, 'two');"
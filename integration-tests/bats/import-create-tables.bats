@@ -916,3 +916,43 @@ DELIM
     [ "$status" -eq 1 ]
     [[ "$output" =~ "parameters all-text and schema are mutually exclusive" ]] || false
 }
+
+@test "import-create-tables: create table from parquet file infers schema" {
+    dolt sql -q "CREATE TABLE source (id BIGINT PRIMARY KEY, d DECIMAL(10,2), dt DATETIME, s VARCHAR(20), f DOUBLE, u BIGINT UNSIGNED, INDEX s_idx (s));"
+    dolt sql -q "INSERT INTO source VALUES (3,'1.50','2024-01-02 03:04:05','c',1.5,18446744073709551615),(1,'-2.25','1999-12-31 23:59:59',NULL,2.5,0),(2,NULL,NULL,'b',NULL,NULL);"
+    dolt table export source source.parquet
+
+    run dolt table import -c --pk=id test source.parquet
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 3, Additions: 3, Modifications: 0, Had No Effect: 0" ]] || false
+    [[ "$output" =~ "Import completed successfully." ]] || false
+
+    run dolt sql -q "describe test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "id,bigint,NO,PRI" ]] || false
+    [[ "$output" =~ "d,\"decimal(10,2)\",YES" ]] || false
+    [[ "$output" =~ "dt,datetime(6),YES" ]] || false
+    [[ "$output" =~ "u,bigint unsigned,YES" ]] || false
+
+    run dolt sql -q "select * from source except select * from test" -r csv
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt sql -q "select id from test where s = 'b'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "import-create-tables: create table from parquet file with duplicate primary keys fails" {
+    dolt sql -q "CREATE TABLE source (id BIGINT PRIMARY KEY, v INT);"
+    dolt sql -q "INSERT INTO source VALUES (1,1),(2,1),(3,2);"
+    dolt table export source source.parquet
+
+    run dolt table import -c --pk=v test source.parquet
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "duplicate primary key" ]] || false
+
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "test" ]] || false
+}
@@ -1360,3 +1360,30 @@ SQL
     run dolt merge b1
     log_status_eq 0
 }
+
+@test "merge: dolt_merge_rules resolve conflicting cell changes" {
+    dolt sql <<SQL
+insert into test1 values (1, 10, 10);
+insert into dolt_merge_rules values ('test1', 'c1', 'sum'), ('test1', 'c2', 'max');
+call dolt_commit('-Am', 'add merge rules');
+call dolt_branch('other');
+update test1 set c1 = c1 + 5, c2 = 15;
+call dolt_commit('-am', 'main changes');
+call dolt_checkout('other');
+update test1 set c1 = c1 + 3, c2 = 12;
+call dolt_commit('-am', 'other changes');
+SQL
+
+    run dolt merge other
+    log_status_eq 0
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "select * from test1" -r csv
+    log_status_eq 0
+    [[ "$output" =~ "1,18,15" ]] || false
+
+    run dolt sql -q "select * from dolt_merge_rules" -r csv
+    log_status_eq 0
+    [[ "$output" =~ "test1,c1,sum" ]] || false
+    [[ "$output" =~ "test1,c2,max" ]] || false
+}
@@ -0,0 +1,132 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+
+    dolt sql <<SQL
+CREATE TABLE big (pk INT PRIMARY KEY, v VARCHAR(200), INDEX (v));
+CREATE TABLE small (pk INT PRIMARY KEY, c INT);
+CREATE TABLE digits (n INT PRIMARY KEY);
+INSERT INTO digits VALUES (0),(1),(2),(3),(4),(5),(6),(7),(8),(9);
+INSERT INTO big SELECT a.n*1000 + b.n*100 + c.n*10 + d.n, CONCAT('value-', a.n, b.n, c.n, d.n, REPEAT('x', 100))
+  FROM digits a, digits b, digits c, digits d;
+INSERT INTO small VALUES (1, 1), (2, 2);
+SQL
+    dolt add -A
+    dolt commit -m "initial data"
+    dolt remote add origin file://remote
+    dolt push origin main
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "partial-clone: clone with --exclude-tables reads excluded tables on demand" {
+    cd dolt-repo-clones
+    dolt clone --exclude-tables=big file://../remote partial
+    dolt clone file://../remote full
+    cd partial
+    [ -f .dolt/noms/lazyObjects.txt ]
+    [ $(du -sk .dolt/noms | cut -f1) -lt $(du -sk ../full/.dolt/noms | cut -f1) ]
+
+    run dolt remote -v
+    [[ "$output" =~ "exclude-tables" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM small" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM big" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "10000" ]] || false
+
+    run dolt sql -q "SELECT pk FROM big WHERE v LIKE 'value-0077%'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "77" ]] || false
+}
+
+@test "partial-clone: clone with --tables" {
+    cd dolt-repo-clones
+    dolt clone --tables=small file://../remote partial
+    cd partial
+    [ -f .dolt/noms/lazyObjects.txt ]
+
+    run dolt sql -q "SELECT count(*) FROM digits" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "10" ]] || false
+    run dolt diff HEAD~1 HEAD --stat
+    [ "$status" -eq 0 ]
+}
+
+@test "partial-clone: pull uses the filter the remote was cloned with" {
+    cd dolt-repo-clones
+    dolt clone --exclude-tables=big file://../remote partial
+    cd ..
+
+    dolt sql -q "UPDATE big SET v = 'changed' WHERE pk < 5000; INSERT INTO small VALUES (3, 3)"
+    dolt commit -am "second commit"
+    dolt push origin main
+
+    cd dolt-repo-clones/partial
+    dolt pull
+    run dolt sql -q "SELECT count(*) FROM small" -r csv
+    [[ "$output" =~ "3" ]] || false
+    run dolt sql -q "SELECT count(*) FROM big WHERE v = 'changed'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "5000" ]] || false
+}
+
+@test "partial-clone: fetch with --exclude-tables" {
+    cd dolt-repo-clones
+    dolt clone file://../remote full
+    cd ..
+
+    dolt sql -q "INSERT INTO big VALUES (20000, 'new')"
+    dolt commit -am "second commit"
+    dolt push origin main
+
+    cd dolt-repo-clones/full
+    dolt fetch --exclude-tables=big
+    [ -f .dolt/noms/lazyObjects.txt ]
+    run dolt sql -q "SELECT v FROM big AS OF 'origin/main' WHERE pk = 20000" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "new" ]] || false
+
+    # the filter only applies to the fetch it was given to
+    run dolt remote -v
+    [[ ! "$output" =~ "exclude-tables" ]] || false
+}
+
+@test "partial-clone: dolt_clone with --exclude-tables" {
+    cd dolt-repo-clones
+    dolt sql -q "call dolt_clone('--exclude-tables=big', 'file://../remote', 'partial')"
+    cd partial
+    [ -f .dolt/noms/lazyObjects.txt ]
+    run dolt sql -q "SELECT count(*) FROM big" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "10000" ]] || false
+}
+
+@test "partial-clone: dolt gc works" {
+    cd dolt-repo-clones
+    dolt clone --exclude-tables=big file://../remote partial
+    cd partial
+    dolt gc
+    run dolt sql -q "SELECT count(*) FROM big" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "10000" ]] || false
+}
+
+@test "partial-clone: --tables and --exclude-tables cannot be used together" {
+    cd dolt-repo-clones
+    run dolt clone --tables=small --exclude-tables=big file://../remote partial
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot be used together" ]] || false
+    [ ! -d partial ]
+}
@@ -42,10 +42,68 @@ setupCustomEditorScript() {
     [[ "$output" =~ "no rebase in progress" ]] || false
 }
 
-@test "rebase: -i flag required" {
-    run dolt rebase b1
+@test "rebase: non-interactive rebase" {
+    dolt checkout b1
+    run dolt rebase main
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased and updated refs/heads/b1" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "b1 commit 1" ]] || false
+    [[ "${lines[1]}" =~ "main commit 2" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* b1" ]] || false
+    [[ ! "$output" =~ "dolt_rebase_b1" ]] || false
+}
+
+@test "rebase: non-interactive rebase with conflict strategy" {
+    dolt checkout b1
+    dolt sql -q "INSERT INTO t1 VALUES (1,2);"
+    dolt commit -am "b1 commit 2"
+
+    run dolt rebase --strategy=mine main
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "only 'ours' or 'theirs' are allowed" ]] || false
+
+    run dolt rebase --strategy=theirs main
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased and updated refs/heads/b1" ]] || false
+
+    run dolt sql -q "select c from t1 where pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "2" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "b1 commit 2" ]] || false
+    [[ "${lines[2]}" =~ "main commit 2" ]] || false
+}
+
+@test "rebase: non-interactive rebase stops for data conflicts" {
+    dolt checkout b1
+    dolt sql -q "INSERT INTO t1 VALUES (1,2);"
+    dolt commit -am "b1 commit 2"
+
+    run dolt rebase main
     [ "$status" -eq 1 ]
-    [[ "$output" =~ "non-interactive rebases not currently supported" ]] || false
+    [[ "$output" =~ "data conflict detected while rebasing commit" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* dolt_rebase_b1" ]] || false
+
+    dolt conflicts resolve --ours t1
+    dolt add t1
+    run dolt rebase --continue
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased and updated refs/heads/b1" ]] || false
+
+    run dolt sql -q "select c from t1 where pk = 1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "1" ]] || false
 }
 
 @test "rebase: bad args" {
@@ -268,6 +326,68 @@ setupCustomEditorScript() {
     [[ "$output" =~ "main commit 2" ]] || false
 }
 
+@test "rebase: edit, break, and exec actions" {
+    setupCustomEditorScript "editPlan.txt"
+
+    dolt checkout b1
+    run dolt show head
+    [ "$status" -eq 0 ]
+    COMMIT1=${lines[0]:12:32}
+
+    dolt sql -q "insert into t2 values (1);"
+    dolt commit -am "b1 commit 2"
+    run dolt show head
+    [ "$status" -eq 0 ]
+    COMMIT2=${lines[0]:12:32}
+
+    touch editPlan.txt
+    echo "edit $COMMIT1 b1 commit 1" >> editPlan.txt
+    echo "exec insert into t2 values (100)" >> editPlan.txt
+    echo "break" >> editPlan.txt
+    echo "pick $COMMIT2 b1 commit 2" >> editPlan.txt
+
+    run dolt rebase -i main
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Stopped at $COMMIT1 (b1 commit 1)" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* dolt_rebase_b1" ]] || false
+
+    dolt sql -q "insert into t2 values (0);"
+    dolt commit -a --amend -m "b1 commit 1, amended"
+
+    run dolt rebase --continue
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "(exec insert into t2 values (100)); the statement left uncommitted changes" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* dolt_rebase_b1" ]] || false
+
+    dolt commit -am "exec commit"
+
+    run dolt rebase --continue
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "(break)" ]] || false
+
+    run dolt rebase --continue
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Successfully rebased and updated refs/heads/b1" ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "b1 commit 2" ]] || false
+    [[ "${lines[1]}" =~ "exec commit" ]] || false
+    [[ "${lines[2]}" =~ "b1 commit 1, amended" ]] || false
+    [[ "${lines[3]}" =~ "main commit 2" ]] || false
+
+    run dolt sql -q "select * from t2" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+    [[ "$output" =~ "100" ]] || false
+}
+
 @test "rebase: non-standard plan changes" {
     setupCustomEditorScript "nonStandardPlan.txt"
 
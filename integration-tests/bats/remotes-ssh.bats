@@ -0,0 +1,82 @@
+#!/usr/bin/env bats
+
+# ssh remotes are exercised with a fake ssh command, which runs the remote command on the local host
+
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    skiponwindows "the fake ssh command is a shell script"
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+
+    cat > "$BATS_TMPDIR/dolt-repo-$$/fake-ssh" <<'EOF'
+#!/bin/sh
+# fake-ssh [-p port] [user@]host command
+while [ $# -gt 1 ]; do shift; done
+exec sh -c "$1"
+EOF
+    chmod +x "$BATS_TMPDIR/dolt-repo-$$/fake-ssh"
+    export DOLT_SSH="$BATS_TMPDIR/dolt-repo-$$/fake-ssh"
+    export DOLT_SSH_EXEC_PATH="$(which dolt)"
+
+    dolt sql -q "CREATE TABLE test (pk BIGINT PRIMARY KEY, c1 BIGINT);"
+    dolt add test
+    dolt commit -m "test commit"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "remotes-ssh: push, pull, and clone an ssh remote" {
+    dolt remote add origin "ssh://user@localhost:2222$BATS_TMPDIR/dolt-repo-$$/remotedir"
+    dolt push --set-upstream origin main
+    [ -d remotedir ]
+
+    cd dolt-repo-clones
+    dolt clone "ssh://localhost$BATS_TMPDIR/dolt-repo-$$/remotedir" test-repo
+    cd test-repo
+    run dolt sql -q "select count(*) from test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    dolt sql -q "insert into test values (0, 1)"
+    dolt commit -am "put row"
+    dolt push origin main
+    run dolt branch --list main -v
+    main_state1=$output
+
+    cd ../..
+    dolt pull
+    run dolt branch --list main -v
+    [[ "$output" = "$main_state1" ]] || false
+}
+
+@test "remotes-ssh: clone and push to a dolt repository over ssh" {
+    cd dolt-repo-clones
+    dolt clone "ssh://localhost$BATS_TMPDIR/dolt-repo-$$" test-repo
+    cd test-repo
+    dolt checkout -b feature
+    dolt sql -q "insert into test values (0, 1)"
+    dolt commit -am "put row"
+    dolt push origin feature
+
+    cd ../..
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature" ]] || false
+    run dolt sql -q "select c1 from test as of 'feature'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "remotes-ssh: errors from ssh are reported" {
+    export DOLT_SSH_EXEC_PATH="/does/not/exist/dolt"
+    cd dolt-repo-clones
+    run dolt clone "ssh://localhost$BATS_TMPDIR/dolt-repo-$$" test-repo
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "/does/not/exist/dolt" ]] || false
+}
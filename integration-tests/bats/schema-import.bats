@@ -101,6 +101,36 @@ teardown() {
     [[ "$output" =~ "\`j\` json" ]] || false
 }
 
+@test "schema-import: import jsonl file" {
+    cat <<DELIM > people.jsonl
+{"pk": 1, "name": "bill", "score": 1.5, "tags": {"a": 1}, "born": "2020-01-02"}
+
+{"pk": 2, "name": null, "score": 3, "active": true}
+DELIM
+
+    run dolt schema import --dry-run -c --pks=pk test people.jsonl
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "test" ]] || false
+    [[ "$output" =~ "\`pk\` int" ]] || false
+    [[ "$output" =~ "\`name\` varchar(1023)" ]] || false
+    [[ "$output" =~ "\`score\` float" ]] || false
+    [[ "$output" =~ "\`tags\` json" ]] || false
+    [[ "$output" =~ "\`born\` date" ]] || false
+    [[ "$output" =~ "\`active\` tinyint" ]] || false
+
+    cp people.jsonl people.data
+    run dolt schema import -c --pks=pk --file-type ndjson test people.data
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Created table successfully." ]] || false
+
+    run dolt table import -u test people.jsonl
+    [ "$status" -eq 0 ]
+    run dolt sql -q "select pk, name, active from test order by pk" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "1,bill," ]] || false
+    [[ "${lines[2]}" = "2,,1" ]] || false
+}
+
 @test "schema-import: import long text" {
     run dolt schema import --dry-run -c --pks=pk test 1pklongtext.csv
     [ "$status" -eq 0 ]
@@ -410,3 +440,32 @@ CSV
     [[ "$output" =~ "name" ]] || false
     [[ "$output" =~ "invalid schema" ]] || false
 }
+
+@test "schema-import: create from parquet file" {
+    dolt sql -q "CREATE TABLE source (id BIGINT PRIMARY KEY, name VARCHAR(20), price DECIMAL(8,2), created DATETIME);"
+    dolt sql -q "INSERT INTO source VALUES (1,'a','1.25','2024-01-02 03:04:05');"
+    dolt table export source source.parquet
+
+    run dolt schema import -c --pks=id test source.parquet
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CREATE TABLE \`test\`" ]] || false
+    [[ "$output" =~ "\`id\` bigint NOT NULL" ]] || false
+    [[ "$output" =~ "\`price\` decimal(8,2)" ]] || false
+    [[ "$output" =~ "\`created\` datetime(6)" ]] || false
+    [[ "$output" =~ "PRIMARY KEY (\`id\`)" ]] || false
+}
+
+@test "schema-import: create from arrow file" {
+    dolt sql -q "CREATE TABLE source (id BIGINT PRIMARY KEY, name VARCHAR(20), price DECIMAL(8,2), created DATETIME, doc JSON);"
+    dolt sql -q "INSERT INTO source VALUES (1,'a','1.25','2024-01-02 03:04:05','{}');"
+    dolt table export source source.arrow
+
+    run dolt schema import -c --pks=id test source.arrow
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CREATE TABLE \`test\`" ]] || false
+    [[ "$output" =~ "\`id\` bigint NOT NULL" ]] || false
+    [[ "$output" =~ "\`price\` decimal(8,2)" ]] || false
+    [[ "$output" =~ "\`created\` datetime(6)" ]] || false
+    [[ "$output" =~ "\`doc\` json" ]] || false
+    [[ "$output" =~ "PRIMARY KEY (\`id\`)" ]] || false
+}
@@ -498,6 +498,101 @@ call dolt_commit('-am', 'add one val');"
     ! [[ "$output" =~ "zeek" ]] || false
 }
 
+@test "sql-server-remotesrv: force push to a protected branch is rejected by the server" {
+    mkdir remote
+    cd remote
+    dolt init
+    dolt sql -q 'create table names (name varchar(10) primary key);'
+    dolt sql -q 'insert into names (name) values ("abe"), ("betsy"), ("calvin");'
+    dolt add names
+    dolt commit -m 'initial names.'
+    dolt sql -q "insert into dolt_branch_protection values ('remote', 'main', false)"
+
+    APIPORT=$( definePORT )
+    export DOLT_REMOTE_PASSWORD="rootpass"
+    export SQL_USER="root"
+    start_sql_server_with_args -u "$SQL_USER" -p "$DOLT_REMOTE_PASSWORD" --remotesapi-port $APIPORT
+
+    cd ../
+    dolt clone http://localhost:$APIPORT/remote cloned_db -u root
+
+    cd remote
+    dolt sql -q 'insert into names (name) values ("zeek");'
+    dolt commit -a -m 'add Zeek.'
+
+    # The clone has no protection rules of its own, so only the server can reject the push
+    cd ../cloned_db
+    dolt sql -q 'insert into names values ("dave");'
+    dolt commit -am 'add dave'
+
+    run dolt push origin --force --user $SQL_USER main:main
+    [[ "$status" -ne 0 ]] || false
+    [[ "$output" =~ "does not allow force pushing to it" ]] || false
+
+    cd ../remote
+    run dolt sql -q 'select * from names;'
+    ! [[ "$output" =~ "dave" ]] || false
+    [[ "$output" =~ "zeek" ]] || false
+
+    # Fast-forward pushes are still allowed
+    cd ../cloned_db
+    dolt reset --hard HEAD~1
+    dolt pull --user $SQL_USER origin main
+    dolt sql -q 'insert into names values ("dave");'
+    dolt commit -am 'add dave'
+    dolt push --user $SQL_USER origin main:main
+
+    cd ../remote
+    run dolt sql -q 'select * from names;'
+    [[ "$output" =~ "dave" ]] || false
+    [[ "$output" =~ "zeek" ]] || false
+}
+
+@test "sql-server-remotesrv: push that removes a failing test is rejected" {
+    mkdir remote
+    cd remote
+    dolt init
+    dolt sql -q 'create table orders (id int primary key, total int);'
+    dolt sql -q 'insert into orders values (1, 10);'
+    dolt sql -q "insert into dolt_tests values ('no negative totals', 'orders', 'select * from orders where total < 0', 'expected_rows', '==', '0');"
+    dolt add .
+    dolt commit -m 'initial orders.'
+    dolt sql -q "set @@persist.dolt_pre_merge_test_groups = 'orders'"
+
+    APIPORT=$( definePORT )
+    export DOLT_REMOTE_PASSWORD="rootpass"
+    export SQL_USER="root"
+    start_sql_server_with_args -u "$SQL_USER" -p "$DOLT_REMOTE_PASSWORD" --remotesapi-port $APIPORT
+
+    cd ../
+    dolt clone http://localhost:$APIPORT/remote cloned_db -u root
+
+    # The tests come from the server's branch, so deleting them doesn't get the push through
+    cd cloned_db
+    dolt sql -q 'insert into orders values (2, -5);'
+    dolt sql -q 'delete from dolt_tests;'
+    dolt commit -am 'negative total'
+
+    run dolt push --user $SQL_USER origin main:main
+    [[ "$status" -ne 0 ]] || false
+    [[ "$output" =~ "push to branch 'main' rejected, as the following tests in dolt_tests failed" ]] || false
+    [[ "$output" =~ "no negative totals" ]] || false
+
+    cd ../remote
+    run dolt sql -q 'select * from orders;'
+    ! [[ "$output" =~ "-5" ]] || false
+
+    cd ../cloned_db
+    dolt reset --hard HEAD~1
+    dolt sql -q 'insert into orders values (2, 5);'
+    dolt commit -am 'positive total'
+    dolt push --user $SQL_USER origin main:main
+
+    cd ../remote
+    run dolt sql -r csv -q 'select * from orders where id = 2;'
+    [[ "$output" =~ "2,5" ]] || false
+}
+
 @test "sql-server-remotesrv: push to remoteapi port as non-super user rejected" {
     mkdir remote
     cd remote
@@ -17,7 +17,6 @@ package main
 import (
 	"context"
 	"database/sql"
-	sqldriver "database/sql/driver"
 	"fmt"
 	"strings"
 	"testing"
@@ -111,12 +110,7 @@ func (gct gcTest) doGC(t *testing.T, ctx context.Context, db *sql.DB) error {
 		t.Logf("err in Conn for dolt_gc: %v", err)
 		return nil
 	}
-	defer func() {
-		// After calling dolt_gc, the connection is bad. Remove it from the connection pool.
-		conn.Raw(func(_ any) error {
-			return sqldriver.ErrBadConn
-		})
-	}()
+	defer conn.Close()
 	b := time.Now()
 	_, err = conn.ExecContext(ctx, "call dolt_gc()")
 	if err != nil {
@@ -223,11 +217,70 @@ func (gct gcTest) run(t *testing.T) {
 
 	require.NoError(t, eg.Wait())
 
-	// Recreate the connection pool here, since idle connections in the
-	// connection pool may be stale.
-	db.Close()
-	db, err = server.DB(driver.Connection{User: "root"})
+	gct.finalize(t, context.Background(), db)
+}
+
+// TestGCKeepsSessionState asserts that connections survive a dolt_gc() run by
+// another connection, along with their uncommitted changes and transactions.
+func TestGCKeepsSessionState(t *testing.T) {
+	u, err := driver.NewDoltUser()
 	require.NoError(t, err)
+	t.Cleanup(func() {
+		u.Cleanup()
+	})
 
-	gct.finalize(t, context.Background(), db)
+	rs, err := u.MakeRepoStore()
+	require.NoError(t, err)
+
+	repo, err := rs.MakeRepo("gc_session_state_test")
+	require.NoError(t, err)
+
+	server := MakeServer(t, repo, &driver.Server{})
+	server.DBName = "gc_session_state_test"
+
+	db, err := server.DB(driver.Connection{User: "root"})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.ExecContext(ctx, "create table vals (id int primary key, val int)")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "call dolt_commit('-Am', 'create vals table')")
+	require.NoError(t, err)
+
+	// Leave some uncommitted changes in the working set, and some
+	// in an open transaction, which is only held in the session.
+	_, err = conn.ExecContext(ctx, "insert into vals values (1, 1)")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "create temporary table tmp (id int primary key)")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "start transaction")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "insert into vals values (2, 2)")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "insert into tmp values (1), (2)")
+	require.NoError(t, err)
+
+	gcConn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer gcConn.Close()
+	_, err = gcConn.ExecContext(ctx, "call dolt_gc()")
+	require.NoError(t, err)
+
+	var cnt int
+	require.NoError(t, conn.QueryRowContext(ctx, "select count(*) from tmp").Scan(&cnt))
+	require.Equal(t, 2, cnt)
+	_, err = conn.ExecContext(ctx, "commit")
+	require.NoError(t, err)
+	require.NoError(t, conn.QueryRowContext(ctx, "select sum(val) from vals").Scan(&cnt))
+	require.Equal(t, 3, cnt)
+
+	// Both connections are still usable.
+	_, err = gcConn.ExecContext(ctx, "call dolt_commit('-am', 'insert vals')")
+	require.NoError(t, err)
+	require.NoError(t, gcConn.QueryRowContext(ctx, "select count(*) from dolt_log").Scan(&cnt))
+	require.Equal(t, 3, cnt)
 }
@@ -21,7 +21,6 @@ import (
 	"strconv"
 	"strings"
 	"testing"
-	sqldriver "database/sql/driver"
 
 	"github.com/stretchr/testify/require"
 
@@ -73,12 +72,8 @@ func TestGCConjoinsOldgen(t *testing.T) {
 		}
 		func() {
 			conn, err := db.Conn(ctx)
-			defer func() {
-				// After calling dolt_gc, the connection is bad. Remove it from the connection pool.
-				conn.Raw(func(_ any) error {
-					return sqldriver.ErrBadConn
-				})
-			}()
+			require.NoError(t, err)
+			defer conn.Close()
 
 			_, err = conn.ExecContext(ctx, "insert into vals values " + strings.Join(vals, ","))
 			require.NoError(t, err)